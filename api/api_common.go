@@ -39,6 +39,17 @@ type Common interface {
 	// node
 	LogAlerts(ctx context.Context) ([]alerting.Alert, error) //perm:admin
 
+	// LogStream streams the log entries of the node at or above the given level, of the given
+	// subsystems, or all subsystems when none are given. Entries are only produced for levels
+	// enabled on the subsystems, see LogSetLevel. Entries are dropped when the client doesn't
+	// keep up with the stream.
+	LogStream(ctx context.Context, level string, subsystems []string) (<-chan LogEntry, error) //perm:admin
+
+	// RPCStats returns the number of calls and their cumulative duration for each RPC method, per
+	// client connection or API token, as selected by the API.RPCStats config option. Returns no
+	// statistics when recording is disabled.
+	RPCStats(ctx context.Context) ([]RPCClientStats, error) //perm:admin
+
 	// MethodGroup: Common
 
 	// Version provides information about API provider
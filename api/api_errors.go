@@ -26,6 +26,9 @@ const (
 	EF3NotReady
 	EExecutionReverted
 	ENullRound
+	ESectorNotAssigned
+	EEthTxRejected
+	EIndexInconsistent
 )
 
 var (
@@ -60,6 +63,11 @@ var (
 	_ jsonrpc.RPCErrorCodec = (*ErrExecutionReverted)(nil)
 	_ error                 = (*ErrNullRound)(nil)
 	_ jsonrpc.RPCErrorCodec = (*ErrNullRound)(nil)
+	_ error                 = (*ErrSectorNotAssigned)(nil)
+	_ error                 = (*ErrEthTxRejected)(nil)
+	_ jsonrpc.RPCErrorCodec = (*ErrEthTxRejected)(nil)
+	_ error                 = (*ErrIndexInconsistent)(nil)
+	_ jsonrpc.RPCErrorCodec = (*ErrIndexInconsistent)(nil)
 )
 
 func init() {
@@ -74,6 +82,9 @@ func init() {
 	RPCErrors.Register(EF3NotReady, new(*errF3NotReady))
 	RPCErrors.Register(EExecutionReverted, new(*ErrExecutionReverted))
 	RPCErrors.Register(ENullRound, new(*ErrNullRound))
+	RPCErrors.Register(ESectorNotAssigned, new(*ErrSectorNotAssigned))
+	RPCErrors.Register(EEthTxRejected, new(*ErrEthTxRejected))
+	RPCErrors.Register(EIndexInconsistent, new(*ErrIndexInconsistent))
 }
 
 func ErrorIsIn(err error, errorTypes []error) bool {
@@ -96,6 +107,12 @@ type ErrActorNotFound struct{}
 
 func (ErrActorNotFound) Error() string { return "actor not found" }
 
+// ErrSectorNotAssigned signals that a sector is not assigned to a deadline yet, because it is
+// only pre-committed.
+type ErrSectorNotAssigned struct{}
+
+func (ErrSectorNotAssigned) Error() string { return "sector is not assigned to a deadline" }
+
 type errF3Disabled struct{}
 
 func (errF3Disabled) Error() string { return "f3 is disabled" }
@@ -213,3 +230,100 @@ func (e *ErrNullRound) Is(target error) bool {
 	_, ok := target.(*ErrNullRound)
 	return ok
 }
+
+// ErrEthTxRejected signals that an Ethereum transaction was rejected before being added to the
+// message pool. The message starts with the reason Ethereum clients report for the same rejection,
+// e.g. "nonce too low", which wallets match on.
+type ErrEthTxRejected struct {
+	Message string
+}
+
+func NewErrEthTxRejected(reason string, err error) *ErrEthTxRejected {
+	msg := reason
+	if err != nil {
+		msg = fmt.Sprintf("%s: %s", reason, err)
+	}
+	return &ErrEthTxRejected{Message: msg}
+}
+
+func (e *ErrEthTxRejected) Error() string { return e.Message }
+
+func (e *ErrEthTxRejected) FromJSONRPCError(jerr jsonrpc.JSONRPCError) error {
+	if jerr.Code != EEthTxRejected {
+		return fmt.Errorf("unexpected error code: %d", jerr.Code)
+	}
+	e.Message = jerr.Message
+	return nil
+}
+
+func (e *ErrEthTxRejected) ToJSONRPCError() (jsonrpc.JSONRPCError, error) {
+	return jsonrpc.JSONRPCError{
+		Code:    EEthTxRejected,
+		Message: e.Message,
+	}, nil
+}
+
+// IndexInconsistency is the kind of problem ChainValidateIndex found with the chain index entries
+// of an epoch.
+type IndexInconsistency string
+
+const (
+	// IndexMissing means the index lacks the tipset of the epoch, or some of its messages or events.
+	IndexMissing IndexInconsistency = "missing"
+	// IndexExtra means the index holds more messages or events for the epoch than the chain.
+	IndexExtra IndexInconsistency = "extra"
+	// IndexCorrupt means the index entries of the epoch contradict the chain in any other way.
+	IndexCorrupt IndexInconsistency = "corrupt"
+)
+
+// ErrIndexInconsistent signals that the chain index entries of an epoch don't match the chain.
+type ErrIndexInconsistent struct {
+	Epoch   abi.ChainEpoch
+	Kind    IndexInconsistency
+	Message string
+}
+
+func NewErrIndexInconsistent(epoch abi.ChainEpoch, kind IndexInconsistency, format string, args ...interface{}) *ErrIndexInconsistent {
+	return &ErrIndexInconsistent{
+		Epoch:   epoch,
+		Kind:    kind,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (e *ErrIndexInconsistent) Error() string { return e.Message }
+
+func (e *ErrIndexInconsistent) FromJSONRPCError(jerr jsonrpc.JSONRPCError) error {
+	if jerr.Code != EIndexInconsistent {
+		return fmt.Errorf("unexpected error code: %d", jerr.Code)
+	}
+
+	data, ok := jerr.Data.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("expected object data in index inconsistent error, got %T", jerr.Data)
+	}
+	epoch, ok := data["epoch"].(float64)
+	if !ok {
+		return fmt.Errorf("expected number epoch in index inconsistent error, got %T", data["epoch"])
+	}
+	kind, ok := data["kind"].(string)
+	if !ok {
+		return fmt.Errorf("expected string kind in index inconsistent error, got %T", data["kind"])
+	}
+
+	e.Epoch = abi.ChainEpoch(epoch)
+	e.Kind = IndexInconsistency(kind)
+	e.Message = jerr.Message
+	return nil
+}
+
+func (e *ErrIndexInconsistent) ToJSONRPCError() (jsonrpc.JSONRPCError, error) {
+	return jsonrpc.JSONRPCError{
+		Code:    EIndexInconsistent,
+		Message: e.Message,
+		Data: map[string]interface{}{
+			"epoch": e.Epoch,
+			"kind":  e.Kind,
+		},
+	}, nil
+}
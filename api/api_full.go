@@ -20,6 +20,7 @@ import (
 	"github.com/filecoin-project/go-state-types/builtin/v8/paych"
 	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/dline"
+	"github.com/filecoin-project/go-state-types/exitcode"
 	abinetwork "github.com/filecoin-project/go-state-types/network"
 
 	apitypes "github.com/filecoin-project/lotus/api/types"
@@ -146,6 +147,13 @@ type FullNode interface {
 	// will be returned.
 	ChainGetTipSetAfterHeight(context.Context, abi.ChainEpoch, types.TipSetKey) (*types.TipSet, error) //perm:read
 
+	// ChainGetBaseFeeHistory returns the base fee of the tipsets from fromHeight to toHeight,
+	// sampled every step epochs, read from the block headers. When a sampled height is a null
+	// round, the base fee of the closest tipset before it is returned, so the actual heights of
+	// the sampled tipsets are returned with the base fees. toHeight is capped at the current
+	// head, and at most 2880 samples can be requested at once.
+	ChainGetBaseFeeHistory(ctx context.Context, fromHeight, toHeight, step abi.ChainEpoch) ([]BaseFeeSample, error) //perm:read
+
 	// ChainReadObj reads ipld nodes referenced by the specified CID from chain
 	// blockstore and returns raw bytes.
 	ChainReadObj(context.Context, cid.Cid) ([]byte, error) //perm:read
@@ -200,6 +208,21 @@ type FullNode interface {
 	// If oldmsgskip is set, messages from before the requested roots are also not included.
 	ChainExport(ctx context.Context, nroots abi.ChainEpoch, oldmsgskip bool, tsk types.TipSetKey) (<-chan []byte, error) //perm:read
 
+	// ChainExportActorState returns a stream of bytes with a CAR dump of the state of a single
+	// actor in the parent state of the given tipset. The CAR is rooted at the state root, and
+	// contains the blocks on the path from the state root to the actor, and all of the actor's
+	// state, so that the actor can be loaded from the state tree as usual. Other actors, the
+	// history of the actor and its code are not included.
+	ChainExportActorState(ctx context.Context, addr address.Address, tsk types.TipSetKey) (<-chan []byte, error) //perm:read
+
+	// ChainExportHeaders returns a stream of bytes with a CAR dump of the block headers and
+	// messages of the chain from the given tipset back to the tail height, without any state
+	// trees or message receipts. The chain structure and the messages can be reconstructed from
+	// it, but not the state at any height. When the tail is 0 the CAR includes the genesis block
+	// and the genesis state and can be imported, with the state at other heights having to be
+	// recomputed from the genesis state.
+	ChainExportHeaders(ctx context.Context, tail abi.ChainEpoch, tsk types.TipSetKey) (<-chan []byte, error) //perm:read
+
 	// ChainExportRangeInternal triggers the export of a chain
 	// CAR-snapshot directly to disk. It is similar to ChainExport,
 	// except, depending on options, the snapshot can include receipts,
@@ -244,6 +267,13 @@ type FullNode interface {
 	// GasEstimateMessageGas estimates gas values for unset message gas fields
 	GasEstimateMessageGas(context.Context, *types.Message, *MessageSendSpec, types.TipSetKey) (*types.Message, error) //perm:read
 
+	// GasBaseFeeForecast estimates the base fee for each of the next epochs after
+	// the current head, by extrapolating the trend of block fullness over recent
+	// epochs. The first entry is exact, the following ones are an estimate for
+	// scheduling non-urgent messages in cheap windows. Actual base fees depend on
+	// the future demand for block space and can differ significantly.
+	GasBaseFeeForecast(ctx context.Context, epochs int) ([]BaseFeeForecast, error) //perm:read
+
 	// MethodGroup: Sync
 	// The Sync method group contains methods for interacting with and
 	// observing the lotus sync service.
@@ -260,8 +290,14 @@ type FullNode interface {
 	SyncIncomingBlocks(ctx context.Context) (<-chan *types.BlockHeader, error) //perm:read
 
 	// SyncCheckpoint marks a blocks as checkpointed, meaning that it won't ever fork away from it.
+	// The checkpoint must be the current head or one of its ancestors; it replaces any previous
+	// checkpoint and is persisted across restarts.
 	SyncCheckpoint(ctx context.Context, tsk types.TipSetKey) error //perm:admin
 
+	// SyncCheckpointClear removes the current checkpoint, if any, allowing the node to fork away
+	// from the previously checkpointed tipset again.
+	SyncCheckpointClear(ctx context.Context) error //perm:admin
+
 	// SyncMarkBad marks a blocks as bad, meaning that it won't ever by synced.
 	// Use with extreme caution.
 	SyncMarkBad(ctx context.Context, bcid cid.Cid) error //perm:admin
@@ -286,6 +322,20 @@ type FullNode interface {
 	// MpoolPending returns pending mempool messages.
 	MpoolPending(context.Context, types.TipSetKey) ([]*types.SignedMessage, error) //perm:read
 
+	// MpoolPendingTo returns the pending mempool messages sent to the given address, ordered by
+	// sender and nonce. Messages addressing the recipient by its ID or by its robust address are
+	// both included. The messages are taken from a single snapshot of the mempool.
+	MpoolPendingTo(ctx context.Context, addr address.Address) ([]*types.SignedMessage, error) //perm:read
+
+	// MpoolMessageInclusionEstimate estimates the probability of a pending message being included
+	// within the next few epochs. The estimate compares the effective gas premium of the message,
+	// and of the pending messages of its sender it depends on, with the other pending messages and
+	// with the premiums and gas usage of recently included messages. It assumes that blocks of an
+	// epoch mostly include the same messages, that block producers order messages by premium, and
+	// that higher premium messages keep arriving at the recent rate. The status is "unknown" when
+	// the message isn't pending or there are no recent blocks to estimate from.
+	MpoolMessageInclusionEstimate(ctx context.Context, msgCid cid.Cid) (*MpoolInclusionEstimate, error) //perm:read
+
 	// MpoolSelect returns a list of pending messages for inclusion in the next block
 	MpoolSelect(context.Context, types.TipSetKey, float64) ([]*types.SignedMessage, error) //perm:read
 
@@ -322,7 +372,16 @@ type FullNode interface {
 	// MpoolGetNonce gets next nonce for the specified sender.
 	// Note that this method may not be atomic. Use MpoolPushMessage instead.
 	MpoolGetNonce(context.Context, address.Address) (uint64, error) //perm:read
-	MpoolSub(context.Context) (<-chan MpoolUpdate, error)           //perm:read
+	// MpoolGetNonces gets the next nonce of each of the specified senders in one
+	// call, accounting for their pending messages. Senders without an actor on
+	// chain have a next nonce of 0.
+	// Note that this method may not be atomic. Use MpoolPushMessage instead.
+	MpoolGetNonces(context.Context, []address.Address) ([]uint64, error) //perm:read
+	MpoolSub(context.Context) (<-chan MpoolUpdate, error)                //perm:read
+	// MpoolWatch streams the messages entering the mempool which match the filter, before they
+	// are included in a block. Messages are dropped when they aren't read fast enough, the
+	// mempool is never held up by a slow watcher.
+	MpoolWatch(ctx context.Context, filter MpoolFilter) (<-chan *types.SignedMessage, error) //perm:read
 
 	// MpoolClear clears pending messages from the mpool.
 	// If clearLocal is true, ALL messages will be cleared.
@@ -338,6 +397,12 @@ type FullNode interface {
 
 	MinerGetBaseInfo(context.Context, address.Address, abi.ChainEpoch, types.TipSetKey) (*MiningBaseInfo, error) //perm:read
 	MinerCreateBlock(context.Context, *BlockTemplate) (*types.BlockMsg, error)                                   //perm:write
+	// MinerWinEligibility returns whether the miner is eligible to win blocks at
+	// the given epoch on top of the current chain, and its share of network power
+	// in the power table used for the election. Unlike MinerGetBaseInfo it
+	// doesn't need beacon entries or compute the winning PoSt sector set, so it
+	// is cheap to call from dashboards showing the expected win rate.
+	MinerWinEligibility(ctx context.Context, maddr address.Address, epoch abi.ChainEpoch) (*WinEligibility, error) //perm:read
 
 	// // UX ?
 
@@ -404,10 +469,21 @@ type FullNode interface {
 	// different signature, but with all other parameters matching (source/destination,
 	// nonce, params, etc.)
 	StateReplay(context.Context, types.TipSetKey, cid.Cid) (*InvocResult, error) //perm:read
+	// StateCallTree replays the given message in the tipset it was executed in, like
+	// StateReplay, and returns its execution as a tree of the message and its internal sends.
+	// Each node of the tree reports the gas charged in the call itself, and in the call including
+	// all of its subcalls. The total gas of the root node is normally equal to the gas used by the
+	// message on chain, but the trace of a message that ran out of gas or aborted may be incomplete;
+	// such trees are still returned, with GasMismatch set.
+	StateCallTree(ctx context.Context, msg cid.Cid) (*CallTree, error) //perm:read
 	// StateGetActor returns the indicated actor's nonce and balance.
 	StateGetActor(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.Actor, error) //perm:read
 	// StateReadState returns the indicated actor's state.
 	StateReadState(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*ActorState, error) //perm:read
+	// StateReadStateWithOpts returns the indicated actor's state like StateReadState, limited to the
+	// field selected by opts.Path and the nesting depth of opts.Depth, to reduce the size of the
+	// response for actors with large states. Zero options return the full state.
+	StateReadStateWithOpts(ctx context.Context, actor address.Address, opts ReadStateOpts, tsk types.TipSetKey) (*ActorState, error) //perm:read
 	// StateListMessages looks back and returns all messages with a matching to or from address, stopping at the given height.
 	StateListMessages(ctx context.Context, match *MessageMatch, tsk types.TipSetKey, toht abi.ChainEpoch) ([]cid.Cid, error) //perm:read
 	// StateDecodeParams attempts to decode the provided params, based on the recipient actor address and method number.
@@ -456,6 +532,9 @@ type FullNode interface {
 	StateMinerInitialPledgeForSector(ctx context.Context, sectorDuration abi.ChainEpoch, sectorSize abi.SectorSize, verifiedSize uint64, tsk types.TipSetKey) (types.BigInt, error) //perm:read
 	// StateMinerAvailableBalance returns the portion of a miner's balance that can be withdrawn or spent
 	StateMinerAvailableBalance(context.Context, address.Address, types.TipSetKey) (types.BigInt, error) //perm:read
+	// StateMinerVestingSchedule returns the locked rewards of a miner which are still vesting, with
+	// the epochs they unlock at.
+	StateMinerVestingSchedule(context.Context, address.Address, types.TipSetKey) (*MinerVestingSchedule, error) //perm:read
 	// StateMinerSectorAllocated checks if a sector number is marked as allocated.
 	StateMinerSectorAllocated(context.Context, address.Address, abi.SectorNumber, types.TipSetKey) (bool, error) //perm:read
 	// StateSectorPreCommitInfo returns the PreCommit info for the specified miner's sector.
@@ -471,8 +550,13 @@ type FullNode interface {
 	StateSectorGetInfo(context.Context, address.Address, abi.SectorNumber, types.TipSetKey) (*miner.SectorOnChainInfo, error) //perm:read
 	// StateSectorExpiration returns epoch at which given sector will expire
 	StateSectorExpiration(context.Context, address.Address, abi.SectorNumber, types.TipSetKey) (*miner.SectorExpiration, error) //perm:read
-	// StateSectorPartition finds deadline/partition with the specified sector
+	// StateSectorPartition finds deadline/partition with the specified sector. Returns an
+	// ErrSectorNotAssigned error if the sector is pre-committed, but not yet assigned to a deadline.
 	StateSectorPartition(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tok types.TipSetKey) (*miner.SectorLocation, error) //perm:read
+	// StateMinerSectorDeadlineMap returns the live sectors of every partition of every deadline of the
+	// miner, computed in a single pass over the miner deadlines. Sectors are returned as bitfields, which
+	// stay compact for miners with millions of sectors, ordered by deadline and partition.
+	StateMinerSectorDeadlineMap(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]SectorDeadlinePartition, error) //perm:read
 	// StateSearchMsg looks back up to limit epochs in the chain for a message, and returns its receipt and the tipset where it was executed
 	//
 	// NOTE: If a replacing message is found on chain, this method will return
@@ -490,6 +574,13 @@ type FullNode interface {
 	// different signature, but with all other parameters matching (source/destination,
 	// nonce, params, etc.)
 	StateSearchMsg(ctx context.Context, from types.TipSetKey, msg cid.Cid, limit abi.ChainEpoch, allowReplaced bool) (*MsgLookup, error) //perm:read
+	// StateSearchMsgInRange searches for a message executed in a tipset with height between
+	// minHeight and maxHeight (inclusive), and returns its receipt and the tipset where it was
+	// executed. Unlike StateSearchMsg, the chain is never walked back below minHeight, and null is
+	// returned when the message isn't found in the range.
+	//
+	// See StateSearchMsg for the semantics of allowReplaced.
+	StateSearchMsgInRange(ctx context.Context, msg cid.Cid, minHeight, maxHeight abi.ChainEpoch, allowReplaced bool) (*MsgLookup, error) //perm:read
 	// StateWaitMsg looks back up to limit epochs in the chain for a message.
 	// If not found, it blocks until the message arrives on chain, and gets to the
 	// indicated confidence depth.
@@ -513,6 +604,15 @@ type FullNode interface {
 	StateListMiners(context.Context, types.TipSetKey) ([]address.Address, error) //perm:read
 	// StateListActors returns the addresses of every actor in the state
 	StateListActors(context.Context, types.TipSetKey) ([]address.Address, error) //perm:read
+	// StateListActorsByType streams the addresses of every actor in the state whose code is the
+	// builtin actor of the given type. The type is a builtin actor name as used in the actors
+	// manifest, e.g. "storageminer", "paymentchannel", "multisig", "account" or "evm"; "miner",
+	// "paych" and "msig" are accepted as aliases.
+	// This walks the whole state tree and is therefore expensive. The channel is closed once all
+	// actors have been visited. When the state can't be fully read, a last entry with the Error
+	// field set is sent before closing the channel, so a channel closed without an error entry
+	// means the list is complete.
+	StateListActorsByType(ctx context.Context, actorType string, tsk types.TipSetKey) (<-chan ActorListEntry, error) //perm:admin
 	// StateMarketBalance looks up the Escrow and Locked balances of the given address in the Storage Market
 	StateMarketBalance(context.Context, address.Address, types.TipSetKey) (MarketBalance, error) //perm:read
 	// StateMarketParticipants returns the Escrow and Locked balances of every participant in the Storage Market
@@ -608,6 +708,11 @@ type FullNode interface {
 	// StateVMCirculatingSupplyInternal returns an approximation of the circulating supply of Filecoin at the given tipset.
 	// This is the value reported by the runtime interface to actors code.
 	StateVMCirculatingSupplyInternal(context.Context, types.TipSetKey) (CirculatingSupply, error) //perm:read
+	// StateTotalBurned returns the total amount of FIL burned by the messages of the chain below the
+	// given tipset. This is the balance of the burnt funds actor (f099), which receives burned fees
+	// and penalties, in the parent state of the tipset, so it doesn't include the messages of the
+	// tipset itself.
+	StateTotalBurned(context.Context, types.TipSetKey) (abi.TokenAmount, error) //perm:read
 	// StateNetworkVersion returns the network version at the given tipset
 	StateNetworkVersion(context.Context, types.TipSetKey) (apitypes.NetworkVersion, error) //perm:read
 	// StateActorCodeCIDs returns the CIDs of all the builtin actors for the given network version
@@ -1055,6 +1160,13 @@ type MinerSectors struct {
 	Faulty uint64
 }
 
+// ActorListEntry is an actor address streamed by StateListActorsByType, or the error which ended
+// the listing early, in which case Address is undefined.
+type ActorListEntry struct {
+	Address address.Address
+	Error   string `json:",omitempty"`
+}
+
 type MsgLookup struct {
 	Message   cid.Cid // Can be different than requested, in case it was replaced, but only gas values changed
 	Receipt   types.MessageReceipt
@@ -1063,6 +1175,12 @@ type MsgLookup struct {
 	Height    abi.ChainEpoch
 }
 
+// BaseFeeForecast is the estimated base fee paid by messages included at an epoch.
+type BaseFeeForecast struct {
+	Epoch   abi.ChainEpoch
+	BaseFee abi.TokenAmount
+}
+
 type MsgGasCost struct {
 	Message            cid.Cid // Can be different than requested, in case it was replaced, but only gas values changed
 	GasUsed            abi.TokenAmount
@@ -1086,6 +1204,18 @@ type Message struct {
 	Message *types.Message
 }
 
+// ReadStateOpts limit the actor state returned by StateReadStateWithOpts.
+type ReadStateOpts struct {
+	// Path selects a field of the state, with the names of nested fields as in the JSON encoding
+	// of the state separated by dots, e.g. "Info" or "ProofValidationBatch". The whole state is
+	// returned when empty.
+	Path string `json:",omitempty"`
+	// Depth limits the nesting of returned objects and arrays, deeper ones are replaced by a short
+	// description like "<array of 12>". CIDs and other scalar values are always returned in full.
+	// Unlimited when 0, 1 returns the top-level fields only.
+	Depth int `json:",omitempty"`
+}
+
 type ActorState struct {
 	Balance types.BigInt
 	Code    cid.Cid
@@ -1168,6 +1298,18 @@ type VoucherCreateResult struct {
 	Shortfall types.BigInt
 }
 
+// MinerVestingSchedule is the vesting schedule of the locked rewards of a miner.
+type MinerVestingSchedule struct {
+	// Vesting is the total amount of locked rewards.
+	Vesting abi.TokenAmount
+	// Vested is the part of Vesting whose unlock epoch has passed at the tipset, it is unlocked
+	// with the next withdrawal or cron run of the miner.
+	Vested abi.TokenAmount
+	// Schedule is the amount unlocking at each epoch, in ascending epoch order. The layout of the
+	// schedule in the miner actor state depends on the actors version, this is the same for all.
+	Schedule []miner.VestingFund
+}
+
 type MinerPower struct {
 	MinerPower  power.Claim
 	TotalPower  power.Claim
@@ -1238,6 +1380,45 @@ type InvocResult struct {
 	Duration       time.Duration
 }
 
+// CallTree is the execution of a message as a tree of internal sends, see StateCallTree.
+type CallTree struct {
+	// MsgCid is the CID of the executed message, which differs from the requested CID if the
+	// message was gas-repriced on chain.
+	MsgCid   cid.Cid
+	ExitCode exitcode.ExitCode
+	// GasUsed is the gas used by the message, as recorded in its receipt.
+	GasUsed int64
+	// TraceGasUsed is the sum of the gas charges recorded in the execution trace, equal to
+	// Root.TotalGasUsed.
+	TraceGasUsed int64
+	// GasMismatch is set if TraceGasUsed differs from GasUsed, which means that the execution
+	// trace is incomplete and the per-call gas in the tree doesn't add up to the gas used.
+	GasMismatch bool
+	Root        CallTreeNode
+}
+
+// CallTreeNode is a single call in a CallTree.
+type CallTreeNode struct {
+	From  address.Address
+	To    address.Address
+	Value abi.TokenAmount
+	// Actor is the name of the invoked actor code, e.g. "fil/16/evm"; empty if no actor was invoked.
+	Actor  string `json:",omitempty"`
+	Method abi.MethodNum
+	// MethodName is the name of the invoked method, if known.
+	MethodName string `json:",omitempty"`
+	GasLimit   uint64
+	ReadOnly   bool
+	ExitCode   exitcode.ExitCode
+
+	// GasUsed is the gas charged in this call, excluding its subcalls.
+	GasUsed int64
+	// TotalGasUsed is the gas charged in this call, including all of its subcalls.
+	TotalGasUsed int64
+
+	Subcalls []CallTreeNode `json:",omitempty"`
+}
+
 type IpldObject struct {
 	Cid cid.Cid
 	Obj interface{}
@@ -1307,6 +1488,43 @@ type MpoolUpdate struct {
 	Message *types.SignedMessage
 }
 
+// MpoolFilter selects the messages streamed by MpoolWatch. A message matches when it matches
+// every set field, and a field matches when it contains the value of the message.
+type MpoolFilter struct {
+	From    []address.Address `json:",omitempty"`
+	To      []address.Address `json:",omitempty"`
+	Methods []abi.MethodNum   `json:",omitempty"`
+}
+
+const (
+	InclusionEstimated = "estimated"
+	InclusionUnknown   = "unknown"
+)
+
+type MpoolInclusionEstimate struct {
+	// Status is InclusionEstimated, or InclusionUnknown with the Reason when there isn't enough
+	// data to estimate
+	Status string
+	Reason string
+
+	// Probability of inclusion within Epochs
+	Probability float64
+	Epochs      abi.ChainEpoch
+	// ExpectedEpochs is the number of non-empty epochs the message is expected to be included
+	// within, -1 when higher premium messages are expected to keep filling the blocks
+	ExpectedEpochs int64
+
+	BaseFee abi.TokenAmount
+	// EffectivePremium is the gas limit weighted premium of the message and the pending messages
+	// of its sender with lower nonces, capped at the fee cap above the base fee
+	EffectivePremium abi.TokenAmount
+	// GasAhead is the gas limit of the other pending messages paying a higher effective premium
+	GasAhead int64
+	// RecentFullness is the average gas limit of the messages included in recent tipsets, over the
+	// block gas limit
+	RecentFullness float64
+}
+
 type ComputeStateOutput struct {
 	Root  cid.Cid
 	Trace []*InvocResult
@@ -1337,6 +1555,30 @@ type MiningBaseInfo struct {
 	EligibleForMining bool
 }
 
+type WinEligibility struct {
+	Epoch abi.ChainEpoch
+	// BaseTipSet is the tipset blocks at Epoch are mined on
+	BaseTipSet types.TipSetKey
+	// LookbackHeight is the height of the state the power and sectors are taken from
+	LookbackHeight abi.ChainEpoch
+
+	// Eligible is set when the miner meets the consensus minimum power, has a
+	// non-zero power claim, no fee debt and no active consensus fault.
+	Eligible bool
+	// ActiveSectors is the number of sectors the miner can be challenged on
+	ActiveSectors uint64
+
+	MinerPower   abi.StoragePower
+	NetworkPower abi.StoragePower
+	// PowerFraction is the share of the miner in network quality-adjusted power,
+	// 0 when the miner isn't eligible.
+	PowerFraction float64
+	// ExpectedWinsPerEpoch and ExpectedWinsPerDay are the expected number of
+	// blocks won by the miner, given the expected number of blocks per epoch.
+	ExpectedWinsPerEpoch float64
+	ExpectedWinsPerDay   float64
+}
+
 type BlockTemplate struct {
 	Miner            address.Address
 	Parents          types.TipSetKey
@@ -1354,6 +1596,12 @@ type HeadChange struct {
 	Val  *types.TipSet
 }
 
+// BaseFeeSample is the base fee of the messages included in the tipset at a height
+type BaseFeeSample struct {
+	Height  abi.ChainEpoch
+	BaseFee abi.TokenAmount
+}
+
 type MsigProposeResponse int
 
 const (
@@ -1374,6 +1622,13 @@ type Partition struct {
 	ActiveSectors     bitfield.BitField
 }
 
+// SectorDeadlinePartition are the live sectors assigned to a partition of a miner deadline.
+type SectorDeadlinePartition struct {
+	Deadline  uint64
+	Partition uint64
+	Sectors   bitfield.BitField
+}
+
 type Fault struct {
 	Miner address.Address
 	Epoch abi.ChainEpoch
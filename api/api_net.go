@@ -36,6 +36,11 @@ type Net interface {
 	NetAgentVersion(ctx context.Context, p peer.ID) (string, error)           //perm:read
 	NetPeerInfo(context.Context, peer.ID) (*ExtendedPeerInfo, error)          //perm:read
 
+	// NetPeerProtocols returns the libp2p protocols the peer supports, as learned with the identify
+	// protocol. Peers which weren't identified yet are connected to first, finding their addresses
+	// through the DHT when needed.
+	NetPeerProtocols(ctx context.Context, p peer.ID) ([]protocol.ID, error) //perm:read
+
 	// NetBandwidthStats returns statistics about the nodes total bandwidth
 	// usage and current rate across all peers and protocols.
 	NetBandwidthStats(ctx context.Context) (metrics.Stats, error) //perm:read
@@ -57,6 +62,10 @@ type Net interface {
 	NetProtectRemove(ctx context.Context, acl []peer.ID) error //perm:admin
 	NetProtectList(ctx context.Context) ([]peer.ID, error)     //perm:read
 
+	// NetSubnetConns returns the number of open connections per /24 IPv4 and /64 IPv6
+	// subnet, along with the connection limit which applies to each subnet.
+	NetSubnetConns(ctx context.Context) ([]NetSubnetConns, error) //perm:read
+
 	// ResourceManager API
 	NetStat(ctx context.Context, scope string) (NetStat, error)          //perm:read
 	NetLimit(ctx context.Context, scope string) (NetLimit, error)        //perm:read
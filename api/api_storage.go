@@ -59,6 +59,15 @@ type StorageMiner interface {
 
 	ComputeWindowPoSt(ctx context.Context, dlIdx uint64, tsk types.TipSetKey) ([]miner.SubmitWindowedPoStParams, error) //perm:admin
 
+	// AckWindowPoStFaults acknowledges the faulty sectors of a deadline whose WindowPoSt submission
+	// was halted because they exceeded the Proving.HaltSubmissionFaulty* thresholds, and submits its
+	// proofs.
+	AckWindowPoStFaults(ctx context.Context, dlIdx uint64) error //perm:admin
+
+	// ProvingResourceForecast estimates the memory and compute time needed to prove each upcoming
+	// deadline with sectors, based on the durations of the recently computed WindowPoSt proofs.
+	ProvingResourceForecast(ctx context.Context) (*ProvingForecast, error) //perm:read
+
 	ComputeDataCid(ctx context.Context, pieceSize abi.UnpaddedPieceSize, pieceData storiface.Data) (abi.PieceInfo, error) //perm:admin
 
 	// Temp api for testing
@@ -67,6 +76,12 @@ type StorageMiner interface {
 	// Get the status of a given sector by ID
 	SectorsStatus(ctx context.Context, sid abi.SectorNumber, showOnChainInfo bool) (SectorInfo, error) //perm:read
 
+	// SectorsStatusStream returns a channel which receives an event every time a sector transitions
+	// between sealing states. When filter is set, only sectors with numbers in the given range are
+	// reported. Events are dropped for subscribers which don't keep up. The channel is closed when
+	// the request context is cancelled.
+	SectorsStatusStream(ctx context.Context, filter *SectorNumberRange) (<-chan SectorStateChange, error) //perm:read
+
 	// Add piece to an open sector. If no sectors with enough space are open,
 	// either a new sector will be created, or this call will block until more
 	// sectors can be created.
@@ -80,11 +95,30 @@ type StorageMiner interface {
 	// Get summary info of sectors
 	SectorsSummary(ctx context.Context) (map[SectorState]int, error) //perm:read
 
+	// SectorsSummaryByHealth groups the sectors of the miner by health, from the partitions of
+	// the miner at the chain head and the local sealing state. Each sector is in one group:
+	//   - Healthy: live in a partition and not faulty, including sectors not proven yet
+	//   - Faulty: declared or detected as faulty and not declared as recovering
+	//   - Recovering: faulty and declared as recovering
+	//   - Terminated: in a partition but no longer live (terminated or expired, not yet
+	//     compacted), or in a local terminating state, or proving locally but no longer in any
+	//     partition (expired and compacted)
+	//   - Sealing: in the local sealing pipeline and not in any partition yet, including sectors
+	//     in failed sealing states
+	// The on-chain state takes precedence, so a sector being snap-upgraded is grouped by its
+	// on-chain health. Sectors being removed or removed locally but not in any partition aren't
+	// grouped.
+	SectorsSummaryByHealth(ctx context.Context) (*SectorHealthSummary, error) //perm:read
+
 	// List sectors in particular states
 	SectorsListInStates(context.Context, []SectorState) ([]abi.SectorNumber, error) //perm:read
 
 	SectorsRefs(context.Context) (map[string][]SealedRef, error) //perm:read
 
+	// PieceLocations returns the locations of the piece in all sectors tracked by the sealing
+	// pipeline, with whether an unsealed copy of the piece is available in local or remote storage.
+	PieceLocations(ctx context.Context, pieceCid cid.Cid) ([]PieceLocation, error) //perm:read
+
 	// SectorStartSealing can be called on sectors in Empty or WaitDeals states
 	// to trigger sealing early
 	SectorStartSealing(context.Context, abi.SectorNumber) error //perm:write
@@ -99,6 +133,13 @@ type StorageMiner interface {
 	// SectorGetExpectedSealDuration gets the expected time for a sector to seal
 	SectorGetExpectedSealDuration(context.Context) (time.Duration, error) //perm:read
 	SectorsUpdate(context.Context, abi.SectorNumber, SectorState) error   //perm:admin
+	// SectorRetryStep moves the sector back to the state which re-runs the named sealing step,
+	// refusing transitions which are unsafe given the sector state and its on-chain status
+	SectorRetryStep(ctx context.Context, id abi.SectorNumber, step string) error //perm:admin
+	// SectorRecoverFailed moves a sector in the FailedUnrecoverable state back into the sealing pipeline,
+	// returning the state sealing resumes from. Sectors which are committed on chain, have expired deals
+	// or a missing sealed replica are refused.
+	SectorRecoverFailed(ctx context.Context, id abi.SectorNumber) (SectorState, error) //perm:admin
 	// SectorRemove removes the sector from storage. It doesn't terminate it on-chain, which can
 	// be done with SectorTerminate. Removing and not terminating live sectors will cause additional penalties.
 	SectorRemove(context.Context, abi.SectorNumber) error                           //perm:admin
@@ -174,6 +215,9 @@ type StorageMiner interface {
 	SealingAbort(ctx context.Context, call storiface.CallID) error           //perm:admin
 	// SealingRemoveRequest removes a request from sealing pipeline
 	SealingRemoveRequest(ctx context.Context, schedId uuid.UUID) error //perm:admin
+	// SealingSchedReleaseLeaked frees the worker resources reserved by the scheduler for tasks
+	// which are no longer tracked on the worker, and returns the released reservations
+	SealingSchedReleaseLeaked(ctx context.Context) ([]storiface.SchedReservation, error) //perm:admin
 
 	// paths.SectorIndex
 	StorageAttach(context.Context, storiface.StorageInfo, fsutil.FsStat) error                                                         //perm:admin
@@ -209,6 +253,11 @@ type StorageMiner interface {
 	StorageDetachLocal(ctx context.Context, path string) error                           //perm:admin
 	StorageRedeclareLocal(ctx context.Context, id *storiface.ID, dropMissing bool) error //perm:admin
 
+	// StorageRemoveUnsealed removes the unsealed copy of a sector from all storage paths, leaving
+	// other sector files in place. The caller is responsible for ensuring that the unsealed data is
+	// no longer needed, i.e. that the sector is neither being sealed nor serving retrievals.
+	StorageRemoveUnsealed(ctx context.Context, sector abi.SectorID) error //perm:admin
+
 	MarketListDeals(ctx context.Context) ([]*MarketDeal, error) //perm:read
 
 	// RuntimeSubsystems returns the subsystems that are enabled
@@ -303,6 +352,21 @@ type SectorInfo struct {
 	Early abi.ChainEpoch
 }
 
+// SectorHealthSummary is the result of SectorsSummaryByHealth.
+type SectorHealthSummary struct {
+	Healthy    SectorHealthGroup
+	Faulty     SectorHealthGroup
+	Recovering SectorHealthGroup
+	Terminated SectorHealthGroup
+	Sealing    SectorHealthGroup
+}
+
+type SectorHealthGroup struct {
+	Count int
+	// Samples are the lowest sector numbers in the group, up to 10
+	Samples []abi.SectorNumber
+}
+
 type SealedRef struct {
 	SectorID abi.SectorNumber
 	Offset   abi.PaddedPieceSize
@@ -313,6 +377,16 @@ type SealedRefs struct {
 	Refs []SealedRef
 }
 
+type PieceLocation struct {
+	SectorNumber abi.SectorNumber
+	// Offset and Length are the padded offset and size of the piece in the sector
+	Offset abi.PaddedPieceSize
+	Length abi.PaddedPieceSize
+	State  SectorState
+	// HasUnsealed is set when the piece is available unsealed, and can be read without unsealing
+	HasUnsealed bool
+}
+
 type SealTicket struct {
 	Value abi.SealRandomness
 	Epoch abi.ChainEpoch
@@ -337,6 +411,24 @@ func (s *SectorState) String() string {
 	return string(*s)
 }
 
+// SectorStateChange describes a single sector state transition
+type SectorStateChange struct {
+	Number abi.SectorNumber
+	From   SectorState
+	To     SectorState
+	Time   time.Time
+}
+
+// SectorNumberRange is an inclusive range of sector numbers
+type SectorNumberRange struct {
+	From abi.SectorNumber
+	To   abi.SectorNumber
+}
+
+func (r *SectorNumberRange) Contains(n abi.SectorNumber) bool {
+	return n >= r.From && n <= r.To
+}
+
 type AddrUse int
 
 const (
@@ -366,6 +458,37 @@ type PendingDealInfo struct {
 	PublishPeriod      time.Duration
 }
 
+// ProvingForecast estimates the resources needed to compute the WindowPoSt proofs of the upcoming
+// deadlines
+type ProvingForecast struct {
+	// Samples is the number of recently computed proofs the compute times are estimated from
+	Samples int
+	// PartitionTime is the mean time it took to prove a partition in the recent proofs
+	PartitionTime time.Duration
+
+	Deadlines []DeadlineForecast
+}
+
+type DeadlineForecast struct {
+	Index uint64
+	Open  abi.ChainEpoch
+	Close abi.ChainEpoch
+
+	Partitions int
+	// Sectors is the number of sectors to prove, which are live and not faulty, or recovering
+	Sectors uint64
+	// Proofs is the number of proofs to compute, one per batch of partitions
+	Proofs int
+
+	// PeakMemory is the memory needed to compute a proof, per the WindowPoSt resource table
+	PeakMemory uint64
+	// ComputeTime is the estimated time to compute all proofs of the deadline one after another,
+	// zero when no proofs were measured yet
+	ComputeTime time.Duration
+	// AtRisk is set when ComputeTime exceeds the time the deadline is open for
+	AtRisk bool
+}
+
 type SectorOffset struct {
 	Sector abi.SectorNumber
 	Offset abi.PaddedPieceSize
@@ -208,6 +208,7 @@ func init() {
 			TotalOut: 12500,
 		},
 	})
+	addExample(protocol.ID("/fil/hello/1.0.0"))
 	addExample(map[protocol.ID]metrics.Stats{
 		"/fil/hello/1.0.0": {
 			RateIn:   100,
@@ -156,6 +156,36 @@ func (mr *MockFullNodeMockRecorder) ChainExport(arg0, arg1, arg2, arg3 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainExport", reflect.TypeOf((*MockFullNode)(nil).ChainExport), arg0, arg1, arg2, arg3)
 }
 
+// ChainExportActorState mocks base method.
+func (m *MockFullNode) ChainExportActorState(arg0 context.Context, arg1 address.Address, arg2 types.TipSetKey) (<-chan []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainExportActorState", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan []byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainExportActorState indicates an expected call of ChainExportActorState.
+func (mr *MockFullNodeMockRecorder) ChainExportActorState(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainExportActorState", reflect.TypeOf((*MockFullNode)(nil).ChainExportActorState), arg0, arg1, arg2)
+}
+
+// ChainExportHeaders mocks base method.
+func (m *MockFullNode) ChainExportHeaders(arg0 context.Context, arg1 abi.ChainEpoch, arg2 types.TipSetKey) (<-chan []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainExportHeaders", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan []byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainExportHeaders indicates an expected call of ChainExportHeaders.
+func (mr *MockFullNodeMockRecorder) ChainExportHeaders(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainExportHeaders", reflect.TypeOf((*MockFullNode)(nil).ChainExportHeaders), arg0, arg1, arg2)
+}
+
 // ChainExportRangeInternal mocks base method.
 func (m *MockFullNode) ChainExportRangeInternal(arg0 context.Context, arg1, arg2 types.TipSetKey, arg3 api.ChainExportConfig) error {
 	m.ctrl.T.Helper()
@@ -170,6 +200,21 @@ func (mr *MockFullNodeMockRecorder) ChainExportRangeInternal(arg0, arg1, arg2, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainExportRangeInternal", reflect.TypeOf((*MockFullNode)(nil).ChainExportRangeInternal), arg0, arg1, arg2, arg3)
 }
 
+// ChainGetBaseFeeHistory mocks base method.
+func (m *MockFullNode) ChainGetBaseFeeHistory(arg0 context.Context, arg1, arg2, arg3 abi.ChainEpoch) ([]api.BaseFeeSample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGetBaseFeeHistory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]api.BaseFeeSample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGetBaseFeeHistory indicates an expected call of ChainGetBaseFeeHistory.
+func (mr *MockFullNodeMockRecorder) ChainGetBaseFeeHistory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetBaseFeeHistory", reflect.TypeOf((*MockFullNode)(nil).ChainGetBaseFeeHistory), arg0, arg1, arg2, arg3)
+}
+
 // ChainGetBlock mocks base method.
 func (m *MockFullNode) ChainGetBlock(arg0 context.Context, arg1 cid.Cid) (*types.BlockHeader, error) {
 	m.ctrl.T.Helper()
@@ -1395,6 +1440,21 @@ func (mr *MockFullNodeMockRecorder) FilecoinAddressToEthAddress(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilecoinAddressToEthAddress", reflect.TypeOf((*MockFullNode)(nil).FilecoinAddressToEthAddress), arg0, arg1)
 }
 
+// GasBaseFeeForecast mocks base method.
+func (m *MockFullNode) GasBaseFeeForecast(arg0 context.Context, arg1 int) ([]api.BaseFeeForecast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GasBaseFeeForecast", arg0, arg1)
+	ret0, _ := ret[0].([]api.BaseFeeForecast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GasBaseFeeForecast indicates an expected call of GasBaseFeeForecast.
+func (mr *MockFullNodeMockRecorder) GasBaseFeeForecast(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasBaseFeeForecast", reflect.TypeOf((*MockFullNode)(nil).GasBaseFeeForecast), arg0, arg1)
+}
+
 // GasEstimateFeeCap mocks base method.
 func (m *MockFullNode) GasEstimateFeeCap(arg0 context.Context, arg1 *types.Message, arg2 int64, arg3 types.TipSetKey) (big.Int, error) {
 	m.ctrl.T.Helper()
@@ -1529,6 +1589,21 @@ func (mr *MockFullNodeMockRecorder) LogSetLevel(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogSetLevel", reflect.TypeOf((*MockFullNode)(nil).LogSetLevel), arg0, arg1, arg2)
 }
 
+// LogStream mocks base method.
+func (m *MockFullNode) LogStream(arg0 context.Context, arg1 string, arg2 []string) (<-chan api.LogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogStream", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan api.LogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogStream indicates an expected call of LogStream.
+func (mr *MockFullNodeMockRecorder) LogStream(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogStream", reflect.TypeOf((*MockFullNode)(nil).LogStream), arg0, arg1, arg2)
+}
+
 // MarketAddBalance mocks base method.
 func (m *MockFullNode) MarketAddBalance(arg0 context.Context, arg1, arg2 address.Address, arg3 big.Int) (cid.Cid, error) {
 	m.ctrl.T.Helper()
@@ -1633,6 +1708,21 @@ func (mr *MockFullNodeMockRecorder) MinerGetBaseInfo(arg0, arg1, arg2, arg3 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinerGetBaseInfo", reflect.TypeOf((*MockFullNode)(nil).MinerGetBaseInfo), arg0, arg1, arg2, arg3)
 }
 
+// MinerWinEligibility mocks base method.
+func (m *MockFullNode) MinerWinEligibility(arg0 context.Context, arg1 address.Address, arg2 abi.ChainEpoch) (*api.WinEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MinerWinEligibility", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*api.WinEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MinerWinEligibility indicates an expected call of MinerWinEligibility.
+func (mr *MockFullNodeMockRecorder) MinerWinEligibility(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinerWinEligibility", reflect.TypeOf((*MockFullNode)(nil).MinerWinEligibility), arg0, arg1, arg2)
+}
+
 // MpoolBatchPush mocks base method.
 func (m *MockFullNode) MpoolBatchPush(arg0 context.Context, arg1 []*types.SignedMessage) ([]cid.Cid, error) {
 	m.ctrl.T.Helper()
@@ -1767,6 +1857,36 @@ func (mr *MockFullNodeMockRecorder) MpoolGetNonce(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolGetNonce", reflect.TypeOf((*MockFullNode)(nil).MpoolGetNonce), arg0, arg1)
 }
 
+// MpoolGetNonces mocks base method.
+func (m *MockFullNode) MpoolGetNonces(arg0 context.Context, arg1 []address.Address) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolGetNonces", arg0, arg1)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolGetNonces indicates an expected call of MpoolGetNonces.
+func (mr *MockFullNodeMockRecorder) MpoolGetNonces(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolGetNonces", reflect.TypeOf((*MockFullNode)(nil).MpoolGetNonces), arg0, arg1)
+}
+
+// MpoolMessageInclusionEstimate mocks base method.
+func (m *MockFullNode) MpoolMessageInclusionEstimate(arg0 context.Context, arg1 cid.Cid) (*api.MpoolInclusionEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolMessageInclusionEstimate", arg0, arg1)
+	ret0, _ := ret[0].(*api.MpoolInclusionEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolMessageInclusionEstimate indicates an expected call of MpoolMessageInclusionEstimate.
+func (mr *MockFullNodeMockRecorder) MpoolMessageInclusionEstimate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolMessageInclusionEstimate", reflect.TypeOf((*MockFullNode)(nil).MpoolMessageInclusionEstimate), arg0, arg1)
+}
+
 // MpoolPending mocks base method.
 func (m *MockFullNode) MpoolPending(arg0 context.Context, arg1 types.TipSetKey) ([]*types.SignedMessage, error) {
 	m.ctrl.T.Helper()
@@ -1782,6 +1902,21 @@ func (mr *MockFullNodeMockRecorder) MpoolPending(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPending", reflect.TypeOf((*MockFullNode)(nil).MpoolPending), arg0, arg1)
 }
 
+// MpoolPendingTo mocks base method.
+func (m *MockFullNode) MpoolPendingTo(arg0 context.Context, arg1 address.Address) ([]*types.SignedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolPendingTo", arg0, arg1)
+	ret0, _ := ret[0].([]*types.SignedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolPendingTo indicates an expected call of MpoolPendingTo.
+func (mr *MockFullNodeMockRecorder) MpoolPendingTo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPendingTo", reflect.TypeOf((*MockFullNode)(nil).MpoolPendingTo), arg0, arg1)
+}
+
 // MpoolPush mocks base method.
 func (m *MockFullNode) MpoolPush(arg0 context.Context, arg1 *types.SignedMessage) (cid.Cid, error) {
 	m.ctrl.T.Helper()
@@ -1871,6 +2006,21 @@ func (mr *MockFullNodeMockRecorder) MpoolSub(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolSub", reflect.TypeOf((*MockFullNode)(nil).MpoolSub), arg0)
 }
 
+// MpoolWatch mocks base method.
+func (m *MockFullNode) MpoolWatch(arg0 context.Context, arg1 api.MpoolFilter) (<-chan *types.SignedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolWatch", arg0, arg1)
+	ret0, _ := ret[0].(<-chan *types.SignedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolWatch indicates an expected call of MpoolWatch.
+func (mr *MockFullNodeMockRecorder) MpoolWatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolWatch", reflect.TypeOf((*MockFullNode)(nil).MpoolWatch), arg0, arg1)
+}
+
 // MsigAddApprove mocks base method.
 func (m *MockFullNode) MsigAddApprove(arg0 context.Context, arg1, arg2 address.Address, arg3 uint64, arg4, arg5 address.Address, arg6 bool) (*api.MessagePrototype, error) {
 	m.ctrl.T.Helper()
@@ -2362,6 +2512,21 @@ func (mr *MockFullNodeMockRecorder) NetPeerInfo(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetPeerInfo", reflect.TypeOf((*MockFullNode)(nil).NetPeerInfo), arg0, arg1)
 }
 
+// NetPeerProtocols mocks base method.
+func (m *MockFullNode) NetPeerProtocols(arg0 context.Context, arg1 peer.ID) ([]protocol.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetPeerProtocols", arg0, arg1)
+	ret0, _ := ret[0].([]protocol.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetPeerProtocols indicates an expected call of NetPeerProtocols.
+func (mr *MockFullNodeMockRecorder) NetPeerProtocols(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetPeerProtocols", reflect.TypeOf((*MockFullNode)(nil).NetPeerProtocols), arg0, arg1)
+}
+
 // NetPeers mocks base method.
 func (m *MockFullNode) NetPeers(arg0 context.Context) ([]peer.AddrInfo, error) {
 	m.ctrl.T.Helper()
@@ -2479,6 +2644,21 @@ func (mr *MockFullNodeMockRecorder) NetStat(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetStat", reflect.TypeOf((*MockFullNode)(nil).NetStat), arg0, arg1)
 }
 
+// NetSubnetConns mocks base method.
+func (m *MockFullNode) NetSubnetConns(arg0 context.Context) ([]api.NetSubnetConns, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetSubnetConns", arg0)
+	ret0, _ := ret[0].([]api.NetSubnetConns)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetSubnetConns indicates an expected call of NetSubnetConns.
+func (mr *MockFullNodeMockRecorder) NetSubnetConns(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetSubnetConns", reflect.TypeOf((*MockFullNode)(nil).NetSubnetConns), arg0)
+}
+
 // NetVersion mocks base method.
 func (m *MockFullNode) NetVersion(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -2763,6 +2943,21 @@ func (mr *MockFullNodeMockRecorder) PaychVoucherSubmit(arg0, arg1, arg2, arg3, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaychVoucherSubmit", reflect.TypeOf((*MockFullNode)(nil).PaychVoucherSubmit), arg0, arg1, arg2, arg3, arg4)
 }
 
+// RPCStats mocks base method.
+func (m *MockFullNode) RPCStats(arg0 context.Context) ([]api.RPCClientStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RPCStats", arg0)
+	ret0, _ := ret[0].([]api.RPCClientStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RPCStats indicates an expected call of RPCStats.
+func (mr *MockFullNodeMockRecorder) RPCStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPCStats", reflect.TypeOf((*MockFullNode)(nil).RPCStats), arg0)
+}
+
 // Session mocks base method.
 func (m *MockFullNode) Session(arg0 context.Context) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -2882,6 +3077,21 @@ func (mr *MockFullNodeMockRecorder) StateCall(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateCall", reflect.TypeOf((*MockFullNode)(nil).StateCall), arg0, arg1, arg2)
 }
 
+// StateCallTree mocks base method.
+func (m *MockFullNode) StateCallTree(arg0 context.Context, arg1 cid.Cid) (*api.CallTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateCallTree", arg0, arg1)
+	ret0, _ := ret[0].(*api.CallTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateCallTree indicates an expected call of StateCallTree.
+func (mr *MockFullNodeMockRecorder) StateCallTree(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateCallTree", reflect.TypeOf((*MockFullNode)(nil).StateCallTree), arg0, arg1)
+}
+
 // StateChangedActors mocks base method.
 func (m *MockFullNode) StateChangedActors(arg0 context.Context, arg1, arg2 cid.Cid) (map[string]types.ActorV5, error) {
 	m.ctrl.T.Helper()
@@ -3227,6 +3437,21 @@ func (mr *MockFullNodeMockRecorder) StateListActors(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateListActors", reflect.TypeOf((*MockFullNode)(nil).StateListActors), arg0, arg1)
 }
 
+// StateListActorsByType mocks base method.
+func (m *MockFullNode) StateListActorsByType(arg0 context.Context, arg1 string, arg2 types.TipSetKey) (<-chan api.ActorListEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateListActorsByType", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan api.ActorListEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateListActorsByType indicates an expected call of StateListActorsByType.
+func (mr *MockFullNodeMockRecorder) StateListActorsByType(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateListActorsByType", reflect.TypeOf((*MockFullNode)(nil).StateListActorsByType), arg0, arg1, arg2)
+}
+
 // StateListMessages mocks base method.
 func (m *MockFullNode) StateListMessages(arg0 context.Context, arg1 *api.MessageMatch, arg2 types.TipSetKey, arg3 abi.ChainEpoch) ([]cid.Cid, error) {
 	m.ctrl.T.Helper()
@@ -3587,6 +3812,21 @@ func (mr *MockFullNodeMockRecorder) StateMinerSectorCount(arg0, arg1, arg2 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerSectorCount", reflect.TypeOf((*MockFullNode)(nil).StateMinerSectorCount), arg0, arg1, arg2)
 }
 
+// StateMinerSectorDeadlineMap mocks base method.
+func (m *MockFullNode) StateMinerSectorDeadlineMap(arg0 context.Context, arg1 address.Address, arg2 types.TipSetKey) ([]api.SectorDeadlinePartition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMinerSectorDeadlineMap", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]api.SectorDeadlinePartition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMinerSectorDeadlineMap indicates an expected call of StateMinerSectorDeadlineMap.
+func (mr *MockFullNodeMockRecorder) StateMinerSectorDeadlineMap(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerSectorDeadlineMap", reflect.TypeOf((*MockFullNode)(nil).StateMinerSectorDeadlineMap), arg0, arg1, arg2)
+}
+
 // StateMinerSectors mocks base method.
 func (m *MockFullNode) StateMinerSectors(arg0 context.Context, arg1 address.Address, arg2 *bitfield.BitField, arg3 types.TipSetKey) ([]*miner.SectorOnChainInfo, error) {
 	m.ctrl.T.Helper()
@@ -3602,6 +3842,21 @@ func (mr *MockFullNodeMockRecorder) StateMinerSectors(arg0, arg1, arg2, arg3 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerSectors", reflect.TypeOf((*MockFullNode)(nil).StateMinerSectors), arg0, arg1, arg2, arg3)
 }
 
+// StateMinerVestingSchedule mocks base method.
+func (m *MockFullNode) StateMinerVestingSchedule(arg0 context.Context, arg1 address.Address, arg2 types.TipSetKey) (*api.MinerVestingSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMinerVestingSchedule", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*api.MinerVestingSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMinerVestingSchedule indicates an expected call of StateMinerVestingSchedule.
+func (mr *MockFullNodeMockRecorder) StateMinerVestingSchedule(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerVestingSchedule", reflect.TypeOf((*MockFullNode)(nil).StateMinerVestingSchedule), arg0, arg1, arg2)
+}
+
 // StateNetworkName mocks base method.
 func (m *MockFullNode) StateNetworkName(arg0 context.Context) (dtypes.NetworkName, error) {
 	m.ctrl.T.Helper()
@@ -3647,6 +3902,21 @@ func (mr *MockFullNodeMockRecorder) StateReadState(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateReadState", reflect.TypeOf((*MockFullNode)(nil).StateReadState), arg0, arg1, arg2)
 }
 
+// StateReadStateWithOpts mocks base method.
+func (m *MockFullNode) StateReadStateWithOpts(arg0 context.Context, arg1 address.Address, arg2 api.ReadStateOpts, arg3 types.TipSetKey) (*api.ActorState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateReadStateWithOpts", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*api.ActorState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateReadStateWithOpts indicates an expected call of StateReadStateWithOpts.
+func (mr *MockFullNodeMockRecorder) StateReadStateWithOpts(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateReadStateWithOpts", reflect.TypeOf((*MockFullNode)(nil).StateReadStateWithOpts), arg0, arg1, arg2, arg3)
+}
+
 // StateReplay mocks base method.
 func (m *MockFullNode) StateReplay(arg0 context.Context, arg1 types.TipSetKey, arg2 cid.Cid) (*api.InvocResult, error) {
 	m.ctrl.T.Helper()
@@ -3677,6 +3947,21 @@ func (mr *MockFullNodeMockRecorder) StateSearchMsg(arg0, arg1, arg2, arg3, arg4
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSearchMsg", reflect.TypeOf((*MockFullNode)(nil).StateSearchMsg), arg0, arg1, arg2, arg3, arg4)
 }
 
+// StateSearchMsgInRange mocks base method.
+func (m *MockFullNode) StateSearchMsgInRange(arg0 context.Context, arg1 cid.Cid, arg2, arg3 abi.ChainEpoch, arg4 bool) (*api.MsgLookup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateSearchMsgInRange", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*api.MsgLookup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateSearchMsgInRange indicates an expected call of StateSearchMsgInRange.
+func (mr *MockFullNodeMockRecorder) StateSearchMsgInRange(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSearchMsgInRange", reflect.TypeOf((*MockFullNode)(nil).StateSearchMsgInRange), arg0, arg1, arg2, arg3, arg4)
+}
+
 // StateSectorExpiration mocks base method.
 func (m *MockFullNode) StateSectorExpiration(arg0 context.Context, arg1 address.Address, arg2 abi.SectorNumber, arg3 types.TipSetKey) (*miner1.SectorExpiration, error) {
 	m.ctrl.T.Helper()
@@ -3737,6 +4022,21 @@ func (mr *MockFullNodeMockRecorder) StateSectorPreCommitInfo(arg0, arg1, arg2, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSectorPreCommitInfo", reflect.TypeOf((*MockFullNode)(nil).StateSectorPreCommitInfo), arg0, arg1, arg2, arg3)
 }
 
+// StateTotalBurned mocks base method.
+func (m *MockFullNode) StateTotalBurned(arg0 context.Context, arg1 types.TipSetKey) (big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateTotalBurned", arg0, arg1)
+	ret0, _ := ret[0].(big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateTotalBurned indicates an expected call of StateTotalBurned.
+func (mr *MockFullNodeMockRecorder) StateTotalBurned(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateTotalBurned", reflect.TypeOf((*MockFullNode)(nil).StateTotalBurned), arg0, arg1)
+}
+
 // StateVMCirculatingSupplyInternal mocks base method.
 func (m *MockFullNode) StateVMCirculatingSupplyInternal(arg0 context.Context, arg1 types.TipSetKey) (api.CirculatingSupply, error) {
 	m.ctrl.T.Helper()
@@ -3856,6 +4156,20 @@ func (mr *MockFullNodeMockRecorder) SyncCheckpoint(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncCheckpoint", reflect.TypeOf((*MockFullNode)(nil).SyncCheckpoint), arg0, arg1)
 }
 
+// SyncCheckpointClear mocks base method.
+func (m *MockFullNode) SyncCheckpointClear(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncCheckpointClear", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncCheckpointClear indicates an expected call of SyncCheckpointClear.
+func (mr *MockFullNodeMockRecorder) SyncCheckpointClear(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncCheckpointClear", reflect.TypeOf((*MockFullNode)(nil).SyncCheckpointClear), arg0)
+}
+
 // SyncIncomingBlocks mocks base method.
 func (m *MockFullNode) SyncIncomingBlocks(arg0 context.Context) (<-chan *types.BlockHeader, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +82,10 @@ type CommonMethods struct {
 
 	LogSetLevel func(p0 context.Context, p1 string, p2 string) error `perm:"write"`
 
+	LogStream func(p0 context.Context, p1 string, p2 []string) (<-chan LogEntry, error) `perm:"admin"`
+
+	RPCStats func(p0 context.Context) ([]RPCClientStats, error) `perm:"admin"`
+
 	Session func(p0 context.Context) (uuid.UUID, error) `perm:"read"`
 
 	Shutdown func(p0 context.Context) error `perm:"admin"`
@@ -122,8 +126,14 @@ type FullNodeMethods struct {
 
 	ChainExport func(p0 context.Context, p1 abi.ChainEpoch, p2 bool, p3 types.TipSetKey) (<-chan []byte, error) `perm:"read"`
 
+	ChainExportActorState func(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (<-chan []byte, error) `perm:"read"`
+
+	ChainExportHeaders func(p0 context.Context, p1 abi.ChainEpoch, p2 types.TipSetKey) (<-chan []byte, error) `perm:"read"`
+
 	ChainExportRangeInternal func(p0 context.Context, p1 types.TipSetKey, p2 types.TipSetKey, p3 ChainExportConfig) error `perm:"admin"`
 
+	ChainGetBaseFeeHistory func(p0 context.Context, p1 abi.ChainEpoch, p2 abi.ChainEpoch, p3 abi.ChainEpoch) ([]BaseFeeSample, error) `perm:"read"`
+
 	ChainGetBlock func(p0 context.Context, p1 cid.Cid) (*types.BlockHeader, error) `perm:"read"`
 
 	ChainGetBlockMessages func(p0 context.Context, p1 cid.Cid) (*BlockMessages, error) `perm:"read"`
@@ -284,6 +294,8 @@ type FullNodeMethods struct {
 
 	FilecoinAddressToEthAddress func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthAddress, error) `perm:"read"`
 
+	GasBaseFeeForecast func(p0 context.Context, p1 int) ([]BaseFeeForecast, error) `perm:"read"`
+
 	GasEstimateFeeCap func(p0 context.Context, p1 *types.Message, p2 int64, p3 types.TipSetKey) (types.BigInt, error) `perm:"read"`
 
 	GasEstimateGasLimit func(p0 context.Context, p1 *types.Message, p2 types.TipSetKey) (int64, error) `perm:"read"`
@@ -308,6 +320,8 @@ type FullNodeMethods struct {
 
 	MinerGetBaseInfo func(p0 context.Context, p1 address.Address, p2 abi.ChainEpoch, p3 types.TipSetKey) (*MiningBaseInfo, error) `perm:"read"`
 
+	MinerWinEligibility func(p0 context.Context, p1 address.Address, p2 abi.ChainEpoch) (*WinEligibility, error) `perm:"read"`
+
 	MpoolBatchPush func(p0 context.Context, p1 []*types.SignedMessage) ([]cid.Cid, error) `perm:"write"`
 
 	MpoolBatchPushMessage func(p0 context.Context, p1 []*types.Message, p2 *MessageSendSpec) ([]*types.SignedMessage, error) `perm:"sign"`
@@ -326,8 +340,14 @@ type FullNodeMethods struct {
 
 	MpoolGetNonce func(p0 context.Context, p1 address.Address) (uint64, error) `perm:"read"`
 
+	MpoolGetNonces func(p0 context.Context, p1 []address.Address) ([]uint64, error) `perm:"read"`
+
+	MpoolMessageInclusionEstimate func(p0 context.Context, p1 cid.Cid) (*MpoolInclusionEstimate, error) `perm:"read"`
+
 	MpoolPending func(p0 context.Context, p1 types.TipSetKey) ([]*types.SignedMessage, error) `perm:"read"`
 
+	MpoolPendingTo func(p0 context.Context, p1 address.Address) ([]*types.SignedMessage, error) `perm:"read"`
+
 	MpoolPush func(p0 context.Context, p1 *types.SignedMessage) (cid.Cid, error) `perm:"write"`
 
 	MpoolPushMessage func(p0 context.Context, p1 *types.Message, p2 *MessageSendSpec) (*types.SignedMessage, error) `perm:"sign"`
@@ -340,6 +360,8 @@ type FullNodeMethods struct {
 
 	MpoolSub func(p0 context.Context) (<-chan MpoolUpdate, error) `perm:"read"`
 
+	MpoolWatch func(p0 context.Context, p1 MpoolFilter) (<-chan *types.SignedMessage, error) `perm:"read"`
+
 	MsigAddApprove func(p0 context.Context, p1 address.Address, p2 address.Address, p3 uint64, p4 address.Address, p5 address.Address, p6 bool) (*MessagePrototype, error) `perm:"sign"`
 
 	MsigAddCancel func(p0 context.Context, p1 address.Address, p2 address.Address, p3 uint64, p4 address.Address, p5 bool) (*MessagePrototype, error) `perm:"sign"`
@@ -424,6 +446,8 @@ type FullNodeMethods struct {
 
 	StateCall func(p0 context.Context, p1 *types.Message, p2 types.TipSetKey) (*InvocResult, error) `perm:"read"`
 
+	StateCallTree func(p0 context.Context, p1 cid.Cid) (*CallTree, error) `perm:"read"`
+
 	StateChangedActors func(p0 context.Context, p1 cid.Cid, p2 cid.Cid) (map[string]types.Actor, error) `perm:"read"`
 
 	StateCirculatingSupply func(p0 context.Context, p1 types.TipSetKey) (abi.TokenAmount, error) `perm:"read"`
@@ -470,6 +494,8 @@ type FullNodeMethods struct {
 
 	StateListActors func(p0 context.Context, p1 types.TipSetKey) ([]address.Address, error) `perm:"read"`
 
+	StateListActorsByType func(p0 context.Context, p1 string, p2 types.TipSetKey) (<-chan ActorListEntry, error) `perm:"admin"`
+
 	StateListMessages func(p0 context.Context, p1 *MessageMatch, p2 types.TipSetKey, p3 abi.ChainEpoch) ([]cid.Cid, error) `perm:"read"`
 
 	StateListMiners func(p0 context.Context, p1 types.TipSetKey) ([]address.Address, error) `perm:"read"`
@@ -518,18 +544,26 @@ type FullNodeMethods struct {
 
 	StateMinerSectorCount func(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (MinerSectors, error) `perm:"read"`
 
+	StateMinerSectorDeadlineMap func(p0 context.Context, p1 address.Address, p2 types.TipSetKey) ([]SectorDeadlinePartition, error) `perm:"read"`
+
 	StateMinerSectors func(p0 context.Context, p1 address.Address, p2 *bitfield.BitField, p3 types.TipSetKey) ([]*miner.SectorOnChainInfo, error) `perm:"read"`
 
+	StateMinerVestingSchedule func(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*MinerVestingSchedule, error) `perm:"read"`
+
 	StateNetworkName func(p0 context.Context) (dtypes.NetworkName, error) `perm:"read"`
 
 	StateNetworkVersion func(p0 context.Context, p1 types.TipSetKey) (apitypes.NetworkVersion, error) `perm:"read"`
 
 	StateReadState func(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*ActorState, error) `perm:"read"`
 
+	StateReadStateWithOpts func(p0 context.Context, p1 address.Address, p2 ReadStateOpts, p3 types.TipSetKey) (*ActorState, error) `perm:"read"`
+
 	StateReplay func(p0 context.Context, p1 types.TipSetKey, p2 cid.Cid) (*InvocResult, error) `perm:"read"`
 
 	StateSearchMsg func(p0 context.Context, p1 types.TipSetKey, p2 cid.Cid, p3 abi.ChainEpoch, p4 bool) (*MsgLookup, error) `perm:"read"`
 
+	StateSearchMsgInRange func(p0 context.Context, p1 cid.Cid, p2 abi.ChainEpoch, p3 abi.ChainEpoch, p4 bool) (*MsgLookup, error) `perm:"read"`
+
 	StateSectorExpiration func(p0 context.Context, p1 address.Address, p2 abi.SectorNumber, p3 types.TipSetKey) (*miner.SectorExpiration, error) `perm:"read"`
 
 	StateSectorGetInfo func(p0 context.Context, p1 address.Address, p2 abi.SectorNumber, p3 types.TipSetKey) (*miner.SectorOnChainInfo, error) `perm:"read"`
@@ -538,6 +572,8 @@ type FullNodeMethods struct {
 
 	StateSectorPreCommitInfo func(p0 context.Context, p1 address.Address, p2 abi.SectorNumber, p3 types.TipSetKey) (*miner.SectorPreCommitOnChainInfo, error) `perm:"read"`
 
+	StateTotalBurned func(p0 context.Context, p1 types.TipSetKey) (abi.TokenAmount, error) `perm:"read"`
+
 	StateVMCirculatingSupplyInternal func(p0 context.Context, p1 types.TipSetKey) (CirculatingSupply, error) `perm:"read"`
 
 	StateVerifiedClientStatus func(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*abi.StoragePower, error) `perm:"read"`
@@ -554,6 +590,8 @@ type FullNodeMethods struct {
 
 	SyncCheckpoint func(p0 context.Context, p1 types.TipSetKey) error `perm:"admin"`
 
+	SyncCheckpointClear func(p0 context.Context) error `perm:"admin"`
+
 	SyncIncomingBlocks func(p0 context.Context) (<-chan *types.BlockHeader, error) `perm:"read"`
 
 	SyncMarkBad func(p0 context.Context, p1 cid.Cid) error `perm:"admin"`
@@ -800,6 +838,8 @@ type GatewayMethods struct {
 
 	StateReadState func(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*ActorState, error) ``
 
+	StateReadStateWithOpts func(p0 context.Context, p1 address.Address, p2 ReadStateOpts, p3 types.TipSetKey) (*ActorState, error) ``
+
 	StateReplay func(p0 context.Context, p1 types.TipSetKey, p2 cid.Cid) (*InvocResult, error) ``
 
 	StateSearchMsg func(p0 context.Context, p1 types.TipSetKey, p2 cid.Cid, p3 abi.ChainEpoch, p4 bool) (*MsgLookup, error) ``
@@ -861,6 +901,8 @@ type NetMethods struct {
 
 	NetPeerInfo func(p0 context.Context, p1 peer.ID) (*ExtendedPeerInfo, error) `perm:"read"`
 
+	NetPeerProtocols func(p0 context.Context, p1 peer.ID) ([]protocol.ID, error) `perm:"read"`
+
 	NetPeers func(p0 context.Context) ([]peer.AddrInfo, error) `perm:"read"`
 
 	NetPing func(p0 context.Context, p1 peer.ID) (time.Duration, error) `perm:"read"`
@@ -876,6 +918,8 @@ type NetMethods struct {
 	NetSetLimit func(p0 context.Context, p1 string, p2 NetLimit) error `perm:"admin"`
 
 	NetStat func(p0 context.Context, p1 string) (NetStat, error) `perm:"read"`
+
+	NetSubnetConns func(p0 context.Context) ([]NetSubnetConns, error) `perm:"read"`
 }
 
 type NetStub struct {
@@ -899,6 +943,8 @@ type StorageMinerStruct struct {
 }
 
 type StorageMinerMethods struct {
+	AckWindowPoStFaults func(p0 context.Context, p1 uint64) error `perm:"admin"`
+
 	ActorAddress func(p0 context.Context) (address.Address, error) `perm:"read"`
 
 	ActorAddressConfig func(p0 context.Context) (AddressConfig, error) `perm:"read"`
@@ -923,8 +969,12 @@ type StorageMinerMethods struct {
 
 	MiningBase func(p0 context.Context) (*types.TipSet, error) `perm:"read"`
 
+	PieceLocations func(p0 context.Context, p1 cid.Cid) ([]PieceLocation, error) `perm:"read"`
+
 	PledgeSector func(p0 context.Context) (abi.SectorID, error) `perm:"write"`
 
+	ProvingResourceForecast func(p0 context.Context) (*ProvingForecast, error) `perm:"read"`
+
 	RecoverFault func(p0 context.Context, p1 []abi.SectorNumber) ([]cid.Cid, error) `perm:"admin"`
 
 	ReturnAddPiece func(p0 context.Context, p1 storiface.CallID, p2 abi.PieceInfo, p3 *storiface.CallError) error `perm:"admin"`
@@ -971,6 +1021,8 @@ type StorageMinerMethods struct {
 
 	SealingSchedDiag func(p0 context.Context, p1 bool) (interface{}, error) `perm:"admin"`
 
+	SealingSchedReleaseLeaked func(p0 context.Context) ([]storiface.SchedReservation, error) `perm:"admin"`
+
 	SectorAbortUpgrade func(p0 context.Context, p1 abi.SectorNumber) error `perm:"admin"`
 
 	SectorAddPieceToAny func(p0 context.Context, p1 abi.UnpaddedPieceSize, p2 storiface.Data, p3 piece.PieceDealInfo) (SectorOffset, error) `perm:"admin"`
@@ -1003,8 +1055,12 @@ type StorageMinerMethods struct {
 
 	SectorReceive func(p0 context.Context, p1 RemoteSectorMeta) error `perm:"admin"`
 
+	SectorRecoverFailed func(p0 context.Context, p1 abi.SectorNumber) (SectorState, error) `perm:"admin"`
+
 	SectorRemove func(p0 context.Context, p1 abi.SectorNumber) error `perm:"admin"`
 
+	SectorRetryStep func(p0 context.Context, p1 abi.SectorNumber, p2 string) error `perm:"admin"`
+
 	SectorSetExpectedSealDuration func(p0 context.Context, p1 time.Duration) error `perm:"write"`
 
 	SectorSetSealDelay func(p0 context.Context, p1 time.Duration) error `perm:"write"`
@@ -1027,8 +1083,12 @@ type StorageMinerMethods struct {
 
 	SectorsStatus func(p0 context.Context, p1 abi.SectorNumber, p2 bool) (SectorInfo, error) `perm:"read"`
 
+	SectorsStatusStream func(p0 context.Context, p1 *SectorNumberRange) (<-chan SectorStateChange, error) `perm:"read"`
+
 	SectorsSummary func(p0 context.Context) (map[SectorState]int, error) `perm:"read"`
 
+	SectorsSummaryByHealth func(p0 context.Context) (*SectorHealthSummary, error) `perm:"read"`
+
 	SectorsUnsealPiece func(p0 context.Context, p1 storiface.SectorRef, p2 storiface.UnpaddedByteIndex, p3 abi.UnpaddedPieceSize, p4 abi.SealRandomness, p5 *cid.Cid) error `perm:"admin"`
 
 	SectorsUpdate func(p0 context.Context, p1 abi.SectorNumber, p2 SectorState) error `perm:"admin"`
@@ -1063,6 +1123,8 @@ type StorageMinerMethods struct {
 
 	StorageRedeclareLocal func(p0 context.Context, p1 *storiface.ID, p2 bool) error `perm:"admin"`
 
+	StorageRemoveUnsealed func(p0 context.Context, p1 abi.SectorID) error `perm:"admin"`
+
 	StorageReportHealth func(p0 context.Context, p1 storiface.ID, p2 storiface.HealthReport) error `perm:"admin"`
 
 	StorageStat func(p0 context.Context, p1 storiface.ID) (fsutil.FsStat, error) `perm:"admin"`
@@ -1296,6 +1358,28 @@ func (s *CommonStub) LogSetLevel(p0 context.Context, p1 string, p2 string) error
 	return ErrNotSupported
 }
 
+func (s *CommonStruct) LogStream(p0 context.Context, p1 string, p2 []string) (<-chan LogEntry, error) {
+	if s.Internal.LogStream == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.LogStream(p0, p1, p2)
+}
+
+func (s *CommonStub) LogStream(p0 context.Context, p1 string, p2 []string) (<-chan LogEntry, error) {
+	return nil, ErrNotSupported
+}
+
+func (s *CommonStruct) RPCStats(p0 context.Context) ([]RPCClientStats, error) {
+	if s.Internal.RPCStats == nil {
+		return *new([]RPCClientStats), ErrNotSupported
+	}
+	return s.Internal.RPCStats(p0)
+}
+
+func (s *CommonStub) RPCStats(p0 context.Context) ([]RPCClientStats, error) {
+	return *new([]RPCClientStats), ErrNotSupported
+}
+
 func (s *CommonStruct) Session(p0 context.Context) (uuid.UUID, error) {
 	if s.Internal.Session == nil {
 		return *new(uuid.UUID), ErrNotSupported
@@ -1395,6 +1479,28 @@ func (s *FullNodeStub) ChainExport(p0 context.Context, p1 abi.ChainEpoch, p2 boo
 	return nil, ErrNotSupported
 }
 
+func (s *FullNodeStruct) ChainExportActorState(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (<-chan []byte, error) {
+	if s.Internal.ChainExportActorState == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.ChainExportActorState(p0, p1, p2)
+}
+
+func (s *FullNodeStub) ChainExportActorState(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (<-chan []byte, error) {
+	return nil, ErrNotSupported
+}
+
+func (s *FullNodeStruct) ChainExportHeaders(p0 context.Context, p1 abi.ChainEpoch, p2 types.TipSetKey) (<-chan []byte, error) {
+	if s.Internal.ChainExportHeaders == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.ChainExportHeaders(p0, p1, p2)
+}
+
+func (s *FullNodeStub) ChainExportHeaders(p0 context.Context, p1 abi.ChainEpoch, p2 types.TipSetKey) (<-chan []byte, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) ChainExportRangeInternal(p0 context.Context, p1 types.TipSetKey, p2 types.TipSetKey, p3 ChainExportConfig) error {
 	if s.Internal.ChainExportRangeInternal == nil {
 		return ErrNotSupported
@@ -1406,6 +1512,17 @@ func (s *FullNodeStub) ChainExportRangeInternal(p0 context.Context, p1 types.Tip
 	return ErrNotSupported
 }
 
+func (s *FullNodeStruct) ChainGetBaseFeeHistory(p0 context.Context, p1 abi.ChainEpoch, p2 abi.ChainEpoch, p3 abi.ChainEpoch) ([]BaseFeeSample, error) {
+	if s.Internal.ChainGetBaseFeeHistory == nil {
+		return *new([]BaseFeeSample), ErrNotSupported
+	}
+	return s.Internal.ChainGetBaseFeeHistory(p0, p1, p2, p3)
+}
+
+func (s *FullNodeStub) ChainGetBaseFeeHistory(p0 context.Context, p1 abi.ChainEpoch, p2 abi.ChainEpoch, p3 abi.ChainEpoch) ([]BaseFeeSample, error) {
+	return *new([]BaseFeeSample), ErrNotSupported
+}
+
 func (s *FullNodeStruct) ChainGetBlock(p0 context.Context, p1 cid.Cid) (*types.BlockHeader, error) {
 	if s.Internal.ChainGetBlock == nil {
 		return nil, ErrNotSupported
@@ -2286,6 +2403,17 @@ func (s *FullNodeStub) FilecoinAddressToEthAddress(p0 context.Context, p1 jsonrp
 	return *new(ethtypes.EthAddress), ErrNotSupported
 }
 
+func (s *FullNodeStruct) GasBaseFeeForecast(p0 context.Context, p1 int) ([]BaseFeeForecast, error) {
+	if s.Internal.GasBaseFeeForecast == nil {
+		return *new([]BaseFeeForecast), ErrNotSupported
+	}
+	return s.Internal.GasBaseFeeForecast(p0, p1)
+}
+
+func (s *FullNodeStub) GasBaseFeeForecast(p0 context.Context, p1 int) ([]BaseFeeForecast, error) {
+	return *new([]BaseFeeForecast), ErrNotSupported
+}
+
 func (s *FullNodeStruct) GasEstimateFeeCap(p0 context.Context, p1 *types.Message, p2 int64, p3 types.TipSetKey) (types.BigInt, error) {
 	if s.Internal.GasEstimateFeeCap == nil {
 		return *new(types.BigInt), ErrNotSupported
@@ -2418,6 +2546,17 @@ func (s *FullNodeStub) MinerGetBaseInfo(p0 context.Context, p1 address.Address,
 	return nil, ErrNotSupported
 }
 
+func (s *FullNodeStruct) MinerWinEligibility(p0 context.Context, p1 address.Address, p2 abi.ChainEpoch) (*WinEligibility, error) {
+	if s.Internal.MinerWinEligibility == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.MinerWinEligibility(p0, p1, p2)
+}
+
+func (s *FullNodeStub) MinerWinEligibility(p0 context.Context, p1 address.Address, p2 abi.ChainEpoch) (*WinEligibility, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) MpoolBatchPush(p0 context.Context, p1 []*types.SignedMessage) ([]cid.Cid, error) {
 	if s.Internal.MpoolBatchPush == nil {
 		return *new([]cid.Cid), ErrNotSupported
@@ -2517,6 +2656,28 @@ func (s *FullNodeStub) MpoolGetNonce(p0 context.Context, p1 address.Address) (ui
 	return 0, ErrNotSupported
 }
 
+func (s *FullNodeStruct) MpoolGetNonces(p0 context.Context, p1 []address.Address) ([]uint64, error) {
+	if s.Internal.MpoolGetNonces == nil {
+		return *new([]uint64), ErrNotSupported
+	}
+	return s.Internal.MpoolGetNonces(p0, p1)
+}
+
+func (s *FullNodeStub) MpoolGetNonces(p0 context.Context, p1 []address.Address) ([]uint64, error) {
+	return *new([]uint64), ErrNotSupported
+}
+
+func (s *FullNodeStruct) MpoolMessageInclusionEstimate(p0 context.Context, p1 cid.Cid) (*MpoolInclusionEstimate, error) {
+	if s.Internal.MpoolMessageInclusionEstimate == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.MpoolMessageInclusionEstimate(p0, p1)
+}
+
+func (s *FullNodeStub) MpoolMessageInclusionEstimate(p0 context.Context, p1 cid.Cid) (*MpoolInclusionEstimate, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) MpoolPending(p0 context.Context, p1 types.TipSetKey) ([]*types.SignedMessage, error) {
 	if s.Internal.MpoolPending == nil {
 		return *new([]*types.SignedMessage), ErrNotSupported
@@ -2528,6 +2689,17 @@ func (s *FullNodeStub) MpoolPending(p0 context.Context, p1 types.TipSetKey) ([]*
 	return *new([]*types.SignedMessage), ErrNotSupported
 }
 
+func (s *FullNodeStruct) MpoolPendingTo(p0 context.Context, p1 address.Address) ([]*types.SignedMessage, error) {
+	if s.Internal.MpoolPendingTo == nil {
+		return *new([]*types.SignedMessage), ErrNotSupported
+	}
+	return s.Internal.MpoolPendingTo(p0, p1)
+}
+
+func (s *FullNodeStub) MpoolPendingTo(p0 context.Context, p1 address.Address) ([]*types.SignedMessage, error) {
+	return *new([]*types.SignedMessage), ErrNotSupported
+}
+
 func (s *FullNodeStruct) MpoolPush(p0 context.Context, p1 *types.SignedMessage) (cid.Cid, error) {
 	if s.Internal.MpoolPush == nil {
 		return *new(cid.Cid), ErrNotSupported
@@ -2594,6 +2766,17 @@ func (s *FullNodeStub) MpoolSub(p0 context.Context) (<-chan MpoolUpdate, error)
 	return nil, ErrNotSupported
 }
 
+func (s *FullNodeStruct) MpoolWatch(p0 context.Context, p1 MpoolFilter) (<-chan *types.SignedMessage, error) {
+	if s.Internal.MpoolWatch == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.MpoolWatch(p0, p1)
+}
+
+func (s *FullNodeStub) MpoolWatch(p0 context.Context, p1 MpoolFilter) (<-chan *types.SignedMessage, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) MsigAddApprove(p0 context.Context, p1 address.Address, p2 address.Address, p3 uint64, p4 address.Address, p5 address.Address, p6 bool) (*MessagePrototype, error) {
 	if s.Internal.MsigAddApprove == nil {
 		return nil, ErrNotSupported
@@ -3056,6 +3239,17 @@ func (s *FullNodeStub) StateCall(p0 context.Context, p1 *types.Message, p2 types
 	return nil, ErrNotSupported
 }
 
+func (s *FullNodeStruct) StateCallTree(p0 context.Context, p1 cid.Cid) (*CallTree, error) {
+	if s.Internal.StateCallTree == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.StateCallTree(p0, p1)
+}
+
+func (s *FullNodeStub) StateCallTree(p0 context.Context, p1 cid.Cid) (*CallTree, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) StateChangedActors(p0 context.Context, p1 cid.Cid, p2 cid.Cid) (map[string]types.Actor, error) {
 	if s.Internal.StateChangedActors == nil {
 		return *new(map[string]types.Actor), ErrNotSupported
@@ -3309,6 +3503,17 @@ func (s *FullNodeStub) StateListActors(p0 context.Context, p1 types.TipSetKey) (
 	return *new([]address.Address), ErrNotSupported
 }
 
+func (s *FullNodeStruct) StateListActorsByType(p0 context.Context, p1 string, p2 types.TipSetKey) (<-chan ActorListEntry, error) {
+	if s.Internal.StateListActorsByType == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.StateListActorsByType(p0, p1, p2)
+}
+
+func (s *FullNodeStub) StateListActorsByType(p0 context.Context, p1 string, p2 types.TipSetKey) (<-chan ActorListEntry, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) StateListMessages(p0 context.Context, p1 *MessageMatch, p2 types.TipSetKey, p3 abi.ChainEpoch) ([]cid.Cid, error) {
 	if s.Internal.StateListMessages == nil {
 		return *new([]cid.Cid), ErrNotSupported
@@ -3573,6 +3778,17 @@ func (s *FullNodeStub) StateMinerSectorCount(p0 context.Context, p1 address.Addr
 	return *new(MinerSectors), ErrNotSupported
 }
 
+func (s *FullNodeStruct) StateMinerSectorDeadlineMap(p0 context.Context, p1 address.Address, p2 types.TipSetKey) ([]SectorDeadlinePartition, error) {
+	if s.Internal.StateMinerSectorDeadlineMap == nil {
+		return *new([]SectorDeadlinePartition), ErrNotSupported
+	}
+	return s.Internal.StateMinerSectorDeadlineMap(p0, p1, p2)
+}
+
+func (s *FullNodeStub) StateMinerSectorDeadlineMap(p0 context.Context, p1 address.Address, p2 types.TipSetKey) ([]SectorDeadlinePartition, error) {
+	return *new([]SectorDeadlinePartition), ErrNotSupported
+}
+
 func (s *FullNodeStruct) StateMinerSectors(p0 context.Context, p1 address.Address, p2 *bitfield.BitField, p3 types.TipSetKey) ([]*miner.SectorOnChainInfo, error) {
 	if s.Internal.StateMinerSectors == nil {
 		return *new([]*miner.SectorOnChainInfo), ErrNotSupported
@@ -3584,6 +3800,17 @@ func (s *FullNodeStub) StateMinerSectors(p0 context.Context, p1 address.Address,
 	return *new([]*miner.SectorOnChainInfo), ErrNotSupported
 }
 
+func (s *FullNodeStruct) StateMinerVestingSchedule(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*MinerVestingSchedule, error) {
+	if s.Internal.StateMinerVestingSchedule == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.StateMinerVestingSchedule(p0, p1, p2)
+}
+
+func (s *FullNodeStub) StateMinerVestingSchedule(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*MinerVestingSchedule, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) StateNetworkName(p0 context.Context) (dtypes.NetworkName, error) {
 	if s.Internal.StateNetworkName == nil {
 		return *new(dtypes.NetworkName), ErrNotSupported
@@ -3617,6 +3844,17 @@ func (s *FullNodeStub) StateReadState(p0 context.Context, p1 address.Address, p2
 	return nil, ErrNotSupported
 }
 
+func (s *FullNodeStruct) StateReadStateWithOpts(p0 context.Context, p1 address.Address, p2 ReadStateOpts, p3 types.TipSetKey) (*ActorState, error) {
+	if s.Internal.StateReadStateWithOpts == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.StateReadStateWithOpts(p0, p1, p2, p3)
+}
+
+func (s *FullNodeStub) StateReadStateWithOpts(p0 context.Context, p1 address.Address, p2 ReadStateOpts, p3 types.TipSetKey) (*ActorState, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) StateReplay(p0 context.Context, p1 types.TipSetKey, p2 cid.Cid) (*InvocResult, error) {
 	if s.Internal.StateReplay == nil {
 		return nil, ErrNotSupported
@@ -3639,6 +3877,17 @@ func (s *FullNodeStub) StateSearchMsg(p0 context.Context, p1 types.TipSetKey, p2
 	return nil, ErrNotSupported
 }
 
+func (s *FullNodeStruct) StateSearchMsgInRange(p0 context.Context, p1 cid.Cid, p2 abi.ChainEpoch, p3 abi.ChainEpoch, p4 bool) (*MsgLookup, error) {
+	if s.Internal.StateSearchMsgInRange == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.StateSearchMsgInRange(p0, p1, p2, p3, p4)
+}
+
+func (s *FullNodeStub) StateSearchMsgInRange(p0 context.Context, p1 cid.Cid, p2 abi.ChainEpoch, p3 abi.ChainEpoch, p4 bool) (*MsgLookup, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) StateSectorExpiration(p0 context.Context, p1 address.Address, p2 abi.SectorNumber, p3 types.TipSetKey) (*miner.SectorExpiration, error) {
 	if s.Internal.StateSectorExpiration == nil {
 		return nil, ErrNotSupported
@@ -3683,6 +3932,17 @@ func (s *FullNodeStub) StateSectorPreCommitInfo(p0 context.Context, p1 address.A
 	return nil, ErrNotSupported
 }
 
+func (s *FullNodeStruct) StateTotalBurned(p0 context.Context, p1 types.TipSetKey) (abi.TokenAmount, error) {
+	if s.Internal.StateTotalBurned == nil {
+		return *new(abi.TokenAmount), ErrNotSupported
+	}
+	return s.Internal.StateTotalBurned(p0, p1)
+}
+
+func (s *FullNodeStub) StateTotalBurned(p0 context.Context, p1 types.TipSetKey) (abi.TokenAmount, error) {
+	return *new(abi.TokenAmount), ErrNotSupported
+}
+
 func (s *FullNodeStruct) StateVMCirculatingSupplyInternal(p0 context.Context, p1 types.TipSetKey) (CirculatingSupply, error) {
 	if s.Internal.StateVMCirculatingSupplyInternal == nil {
 		return *new(CirculatingSupply), ErrNotSupported
@@ -3771,6 +4031,17 @@ func (s *FullNodeStub) SyncCheckpoint(p0 context.Context, p1 types.TipSetKey) er
 	return ErrNotSupported
 }
 
+func (s *FullNodeStruct) SyncCheckpointClear(p0 context.Context) error {
+	if s.Internal.SyncCheckpointClear == nil {
+		return ErrNotSupported
+	}
+	return s.Internal.SyncCheckpointClear(p0)
+}
+
+func (s *FullNodeStub) SyncCheckpointClear(p0 context.Context) error {
+	return ErrNotSupported
+}
+
 func (s *FullNodeStruct) SyncIncomingBlocks(p0 context.Context) (<-chan *types.BlockHeader, error) {
 	if s.Internal.SyncIncomingBlocks == nil {
 		return nil, ErrNotSupported
@@ -5058,6 +5329,17 @@ func (s *GatewayStub) StateReadState(p0 context.Context, p1 address.Address, p2
 	return nil, ErrNotSupported
 }
 
+func (s *GatewayStruct) StateReadStateWithOpts(p0 context.Context, p1 address.Address, p2 ReadStateOpts, p3 types.TipSetKey) (*ActorState, error) {
+	if s.Internal.StateReadStateWithOpts == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.StateReadStateWithOpts(p0, p1, p2, p3)
+}
+
+func (s *GatewayStub) StateReadStateWithOpts(p0 context.Context, p1 address.Address, p2 ReadStateOpts, p3 types.TipSetKey) (*ActorState, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *GatewayStruct) StateReplay(p0 context.Context, p1 types.TipSetKey, p2 cid.Cid) (*InvocResult, error) {
 	if s.Internal.StateReplay == nil {
 		return nil, ErrNotSupported
@@ -5344,6 +5626,17 @@ func (s *NetStub) NetPeerInfo(p0 context.Context, p1 peer.ID) (*ExtendedPeerInfo
 	return nil, ErrNotSupported
 }
 
+func (s *NetStruct) NetPeerProtocols(p0 context.Context, p1 peer.ID) ([]protocol.ID, error) {
+	if s.Internal.NetPeerProtocols == nil {
+		return *new([]protocol.ID), ErrNotSupported
+	}
+	return s.Internal.NetPeerProtocols(p0, p1)
+}
+
+func (s *NetStub) NetPeerProtocols(p0 context.Context, p1 peer.ID) ([]protocol.ID, error) {
+	return *new([]protocol.ID), ErrNotSupported
+}
+
 func (s *NetStruct) NetPeers(p0 context.Context) ([]peer.AddrInfo, error) {
 	if s.Internal.NetPeers == nil {
 		return *new([]peer.AddrInfo), ErrNotSupported
@@ -5432,6 +5725,17 @@ func (s *NetStub) NetStat(p0 context.Context, p1 string) (NetStat, error) {
 	return *new(NetStat), ErrNotSupported
 }
 
+func (s *NetStruct) NetSubnetConns(p0 context.Context) ([]NetSubnetConns, error) {
+	if s.Internal.NetSubnetConns == nil {
+		return *new([]NetSubnetConns), ErrNotSupported
+	}
+	return s.Internal.NetSubnetConns(p0)
+}
+
+func (s *NetStub) NetSubnetConns(p0 context.Context) ([]NetSubnetConns, error) {
+	return *new([]NetSubnetConns), ErrNotSupported
+}
+
 func (s *SignableStruct) Sign(p0 context.Context, p1 SignFunc) error {
 	if s.Internal.Sign == nil {
 		return ErrNotSupported
@@ -5443,6 +5747,17 @@ func (s *SignableStub) Sign(p0 context.Context, p1 SignFunc) error {
 	return ErrNotSupported
 }
 
+func (s *StorageMinerStruct) AckWindowPoStFaults(p0 context.Context, p1 uint64) error {
+	if s.Internal.AckWindowPoStFaults == nil {
+		return ErrNotSupported
+	}
+	return s.Internal.AckWindowPoStFaults(p0, p1)
+}
+
+func (s *StorageMinerStub) AckWindowPoStFaults(p0 context.Context, p1 uint64) error {
+	return ErrNotSupported
+}
+
 func (s *StorageMinerStruct) ActorAddress(p0 context.Context) (address.Address, error) {
 	if s.Internal.ActorAddress == nil {
 		return *new(address.Address), ErrNotSupported
@@ -5575,6 +5890,17 @@ func (s *StorageMinerStub) MiningBase(p0 context.Context) (*types.TipSet, error)
 	return nil, ErrNotSupported
 }
 
+func (s *StorageMinerStruct) PieceLocations(p0 context.Context, p1 cid.Cid) ([]PieceLocation, error) {
+	if s.Internal.PieceLocations == nil {
+		return *new([]PieceLocation), ErrNotSupported
+	}
+	return s.Internal.PieceLocations(p0, p1)
+}
+
+func (s *StorageMinerStub) PieceLocations(p0 context.Context, p1 cid.Cid) ([]PieceLocation, error) {
+	return *new([]PieceLocation), ErrNotSupported
+}
+
 func (s *StorageMinerStruct) PledgeSector(p0 context.Context) (abi.SectorID, error) {
 	if s.Internal.PledgeSector == nil {
 		return *new(abi.SectorID), ErrNotSupported
@@ -5586,6 +5912,17 @@ func (s *StorageMinerStub) PledgeSector(p0 context.Context) (abi.SectorID, error
 	return *new(abi.SectorID), ErrNotSupported
 }
 
+func (s *StorageMinerStruct) ProvingResourceForecast(p0 context.Context) (*ProvingForecast, error) {
+	if s.Internal.ProvingResourceForecast == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.ProvingResourceForecast(p0)
+}
+
+func (s *StorageMinerStub) ProvingResourceForecast(p0 context.Context) (*ProvingForecast, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *StorageMinerStruct) RecoverFault(p0 context.Context, p1 []abi.SectorNumber) ([]cid.Cid, error) {
 	if s.Internal.RecoverFault == nil {
 		return *new([]cid.Cid), ErrNotSupported
@@ -5839,6 +6176,17 @@ func (s *StorageMinerStub) SealingSchedDiag(p0 context.Context, p1 bool) (interf
 	return nil, ErrNotSupported
 }
 
+func (s *StorageMinerStruct) SealingSchedReleaseLeaked(p0 context.Context) ([]storiface.SchedReservation, error) {
+	if s.Internal.SealingSchedReleaseLeaked == nil {
+		return *new([]storiface.SchedReservation), ErrNotSupported
+	}
+	return s.Internal.SealingSchedReleaseLeaked(p0)
+}
+
+func (s *StorageMinerStub) SealingSchedReleaseLeaked(p0 context.Context) ([]storiface.SchedReservation, error) {
+	return *new([]storiface.SchedReservation), ErrNotSupported
+}
+
 func (s *StorageMinerStruct) SectorAbortUpgrade(p0 context.Context, p1 abi.SectorNumber) error {
 	if s.Internal.SectorAbortUpgrade == nil {
 		return ErrNotSupported
@@ -6015,6 +6363,17 @@ func (s *StorageMinerStub) SectorReceive(p0 context.Context, p1 RemoteSectorMeta
 	return ErrNotSupported
 }
 
+func (s *StorageMinerStruct) SectorRecoverFailed(p0 context.Context, p1 abi.SectorNumber) (SectorState, error) {
+	if s.Internal.SectorRecoverFailed == nil {
+		return *new(SectorState), ErrNotSupported
+	}
+	return s.Internal.SectorRecoverFailed(p0, p1)
+}
+
+func (s *StorageMinerStub) SectorRecoverFailed(p0 context.Context, p1 abi.SectorNumber) (SectorState, error) {
+	return *new(SectorState), ErrNotSupported
+}
+
 func (s *StorageMinerStruct) SectorRemove(p0 context.Context, p1 abi.SectorNumber) error {
 	if s.Internal.SectorRemove == nil {
 		return ErrNotSupported
@@ -6026,6 +6385,17 @@ func (s *StorageMinerStub) SectorRemove(p0 context.Context, p1 abi.SectorNumber)
 	return ErrNotSupported
 }
 
+func (s *StorageMinerStruct) SectorRetryStep(p0 context.Context, p1 abi.SectorNumber, p2 string) error {
+	if s.Internal.SectorRetryStep == nil {
+		return ErrNotSupported
+	}
+	return s.Internal.SectorRetryStep(p0, p1, p2)
+}
+
+func (s *StorageMinerStub) SectorRetryStep(p0 context.Context, p1 abi.SectorNumber, p2 string) error {
+	return ErrNotSupported
+}
+
 func (s *StorageMinerStruct) SectorSetExpectedSealDuration(p0 context.Context, p1 time.Duration) error {
 	if s.Internal.SectorSetExpectedSealDuration == nil {
 		return ErrNotSupported
@@ -6147,6 +6517,17 @@ func (s *StorageMinerStub) SectorsStatus(p0 context.Context, p1 abi.SectorNumber
 	return *new(SectorInfo), ErrNotSupported
 }
 
+func (s *StorageMinerStruct) SectorsStatusStream(p0 context.Context, p1 *SectorNumberRange) (<-chan SectorStateChange, error) {
+	if s.Internal.SectorsStatusStream == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.SectorsStatusStream(p0, p1)
+}
+
+func (s *StorageMinerStub) SectorsStatusStream(p0 context.Context, p1 *SectorNumberRange) (<-chan SectorStateChange, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *StorageMinerStruct) SectorsSummary(p0 context.Context) (map[SectorState]int, error) {
 	if s.Internal.SectorsSummary == nil {
 		return *new(map[SectorState]int), ErrNotSupported
@@ -6158,6 +6539,17 @@ func (s *StorageMinerStub) SectorsSummary(p0 context.Context) (map[SectorState]i
 	return *new(map[SectorState]int), ErrNotSupported
 }
 
+func (s *StorageMinerStruct) SectorsSummaryByHealth(p0 context.Context) (*SectorHealthSummary, error) {
+	if s.Internal.SectorsSummaryByHealth == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.SectorsSummaryByHealth(p0)
+}
+
+func (s *StorageMinerStub) SectorsSummaryByHealth(p0 context.Context) (*SectorHealthSummary, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *StorageMinerStruct) SectorsUnsealPiece(p0 context.Context, p1 storiface.SectorRef, p2 storiface.UnpaddedByteIndex, p3 abi.UnpaddedPieceSize, p4 abi.SealRandomness, p5 *cid.Cid) error {
 	if s.Internal.SectorsUnsealPiece == nil {
 		return ErrNotSupported
@@ -6345,6 +6737,17 @@ func (s *StorageMinerStub) StorageRedeclareLocal(p0 context.Context, p1 *storifa
 	return ErrNotSupported
 }
 
+func (s *StorageMinerStruct) StorageRemoveUnsealed(p0 context.Context, p1 abi.SectorID) error {
+	if s.Internal.StorageRemoveUnsealed == nil {
+		return ErrNotSupported
+	}
+	return s.Internal.StorageRemoveUnsealed(p0, p1)
+}
+
+func (s *StorageMinerStub) StorageRemoveUnsealed(p0 context.Context, p1 abi.SectorID) error {
+	return ErrNotSupported
+}
+
 func (s *StorageMinerStruct) StorageReportHealth(p0 context.Context, p1 storiface.ID, p2 storiface.HealthReport) error {
 	if s.Internal.StorageReportHealth == nil {
 		return ErrNotSupported
@@ -38,6 +38,10 @@ type MessageSendSpec struct {
 
 	// MaximizeFeeCap makes message FeeCap be based entirely on MaxFee
 	MaximizeFeeCap bool
+
+	// AutoBump marks a message sent automatically by the node or a miner using it, which the node
+	// fee bumper (Fees.AutoBump) replaces with a higher gas premium if it gets stuck in the mpool
+	AutoBump bool
 }
 
 type NetStat struct {
@@ -62,6 +66,14 @@ type NetBlockList struct {
 	IPSubnets []string
 }
 
+type NetSubnetConns struct {
+	// Subnet is the /24 IPv4 or /64 IPv6 subnet of the connections
+	Subnet string
+	Conns  int
+	// Limit is the maximum number of connections accepted from the subnet, 0 if unlimited
+	Limit int
+}
+
 type ExtendedPeerInfo struct {
 	ID          peer.ID
 	Agent       string
@@ -204,3 +216,31 @@ type ChainExportConfig struct {
 	IncludeReceipts   bool
 	IncludeStateRoots bool
 }
+
+// RPCClientStats are the RPC calls made by a client, returned by RPCStats.
+type RPCClientStats struct {
+	// Client is the remote address of the connection, prefixed with "conn:", or a hash of the API
+	// token, prefixed with "token:"
+	Client   string
+	LastCall time.Time
+	Methods  []RPCMethodStats
+}
+
+type RPCMethodStats struct {
+	Method string
+	Calls  uint64
+	Errors uint64
+	// Duration is the cumulative duration of the calls
+	Duration time.Duration
+}
+
+// LogEntry is a log entry streamed by LogStream.
+type LogEntry struct {
+	Time    time.Time
+	Level   string
+	Logger  string
+	Caller  string `json:",omitempty"`
+	Message string
+	// Fields holds the structured fields of the entry
+	Fields map[string]interface{} `json:",omitempty"`
+}
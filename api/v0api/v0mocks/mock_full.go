@@ -612,6 +612,21 @@ func (mr *MockFullNodeMockRecorder) LogSetLevel(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogSetLevel", reflect.TypeOf((*MockFullNode)(nil).LogSetLevel), arg0, arg1, arg2)
 }
 
+// LogStream mocks base method.
+func (m *MockFullNode) LogStream(arg0 context.Context, arg1 string, arg2 []string) (<-chan api.LogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogStream", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan api.LogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogStream indicates an expected call of LogStream.
+func (mr *MockFullNodeMockRecorder) LogStream(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogStream", reflect.TypeOf((*MockFullNode)(nil).LogStream), arg0, arg1, arg2)
+}
+
 // MarketAddBalance mocks base method.
 func (m *MockFullNode) MarketAddBalance(arg0 context.Context, arg1, arg2 address.Address, arg3 big.Int) (cid.Cid, error) {
 	m.ctrl.T.Helper()
@@ -1370,6 +1385,21 @@ func (mr *MockFullNodeMockRecorder) NetPeerInfo(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetPeerInfo", reflect.TypeOf((*MockFullNode)(nil).NetPeerInfo), arg0, arg1)
 }
 
+// NetPeerProtocols mocks base method.
+func (m *MockFullNode) NetPeerProtocols(arg0 context.Context, arg1 peer.ID) ([]protocol.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetPeerProtocols", arg0, arg1)
+	ret0, _ := ret[0].([]protocol.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetPeerProtocols indicates an expected call of NetPeerProtocols.
+func (mr *MockFullNodeMockRecorder) NetPeerProtocols(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetPeerProtocols", reflect.TypeOf((*MockFullNode)(nil).NetPeerProtocols), arg0, arg1)
+}
+
 // NetPeers mocks base method.
 func (m *MockFullNode) NetPeers(arg0 context.Context) ([]peer.AddrInfo, error) {
 	m.ctrl.T.Helper()
@@ -1487,6 +1517,21 @@ func (mr *MockFullNodeMockRecorder) NetStat(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetStat", reflect.TypeOf((*MockFullNode)(nil).NetStat), arg0, arg1)
 }
 
+// NetSubnetConns mocks base method.
+func (m *MockFullNode) NetSubnetConns(arg0 context.Context) ([]api.NetSubnetConns, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetSubnetConns", arg0)
+	ret0, _ := ret[0].([]api.NetSubnetConns)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetSubnetConns indicates an expected call of NetSubnetConns.
+func (mr *MockFullNodeMockRecorder) NetSubnetConns(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetSubnetConns", reflect.TypeOf((*MockFullNode)(nil).NetSubnetConns), arg0)
+}
+
 // PaychAllocateLane mocks base method.
 func (m *MockFullNode) PaychAllocateLane(arg0 context.Context, arg1 address.Address) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -1726,6 +1771,21 @@ func (mr *MockFullNodeMockRecorder) PaychVoucherSubmit(arg0, arg1, arg2, arg3, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaychVoucherSubmit", reflect.TypeOf((*MockFullNode)(nil).PaychVoucherSubmit), arg0, arg1, arg2, arg3, arg4)
 }
 
+// RPCStats mocks base method.
+func (m *MockFullNode) RPCStats(arg0 context.Context) ([]api.RPCClientStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RPCStats", arg0)
+	ret0, _ := ret[0].([]api.RPCClientStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RPCStats indicates an expected call of RPCStats.
+func (mr *MockFullNodeMockRecorder) RPCStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPCStats", reflect.TypeOf((*MockFullNode)(nil).RPCStats), arg0)
+}
+
 // Session mocks base method.
 func (m *MockFullNode) Session(arg0 context.Context) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -132,6 +132,17 @@ type Config struct {
 	// Moving GC will not occur when total moving size exceeds
 	// HotstoreMaxSpaceTarget - HotstoreMaxSpaceSafetyBuffer
 	HotstoreMaxSpaceSafetyBuffer uint64
+
+	// CompactionWindows restricts the start of compactions to the given daily windows of local
+	// time; a compaction which becomes due outside of them is deferred until the next window opens.
+	// A compaction started within a window may run past its end.
+	// If no windows are given, compaction may start at any time.
+	CompactionWindows []CompactionWindow
+
+	// LazyWarmup indicates whether to skip copying the state at the current head to the
+	// hotstore when a warmup is needed. Instead, the hotstore is considered warm right away,
+	// and cold objects are moved to the hotstore as they are accessed in hot views.
+	LazyWarmup bool
 }
 
 // ChainAccessor allows the Splitstore to access the chain. It will most likely
@@ -168,6 +179,11 @@ type SplitStore struct {
 	baseEpoch   abi.ChainEpoch // protected by compaction lock
 	pruneEpoch  abi.ChainEpoch // protected by compaction lock
 
+	// warmup progress, for reporting
+	warmupRunning atomic.Bool
+	warmupVisited atomic.Int64
+	warmupCopied  atomic.Int64
+
 	headChangeMx sync.Mutex
 
 	chain ChainAccessor
@@ -791,12 +807,19 @@ func (s *SplitStore) Start(chain ChainAccessor, us stmgr.UpgradeSchedule) error
 
 	log.Infow("starting splitstore", "baseEpoch", s.baseEpoch, "warmupEpoch", s.warmupEpoch.Load())
 
-	if warmup {
+	switch {
+	case warmup && s.cfg.LazyWarmup:
+		err = s.lazyWarmup(curTs)
+		if err != nil {
+			return xerrors.Errorf("error skipping warmup: %w", err)
+		}
+	case warmup:
 		err = s.warmup(curTs)
 		if err != nil {
 			return xerrors.Errorf("error starting warmup: %w", err)
 		}
 	}
+	s.recordWarm()
 
 	// spawn the reifier
 	go s.reifyOrchestrator()
@@ -146,9 +146,19 @@ func (s *SplitStore) Info() map[string]interface{} {
 	info := make(map[string]interface{})
 	info["base epoch"] = s.baseEpoch
 	info["warmup epoch"] = s.warmupEpoch.Load()
+	info["warmup mode"] = "eager"
+	if s.cfg.LazyWarmup {
+		info["warmup mode"] = "lazy"
+	}
+	info["warmup state"] = s.warmupState()
+	info["warmup visited"] = s.warmupVisited.Load()
+	info["warmup copied"] = s.warmupCopied.Load()
 	info["compactions"] = s.compactionIndex
 	info["prunes"] = s.pruneIndex
 	info["compacting"] = s.compacting == 1
+	if head := s.chain.GetHeaviestTipSet(); head != nil {
+		info["next compaction"] = s.nextCompaction(head.Height(), time.Now())
+	}
 
 	sizer, ok := s.hot.(bstore.BlockstoreSize)
 	if ok {
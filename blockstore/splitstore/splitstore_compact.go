@@ -142,7 +142,17 @@ func (s *SplitStore) HeadChange(_, apply []*types.TipSet) error {
 		return nil
 	}
 
+	now := time.Now()
+	stats.Record(s.ctx, metrics.SplitstoreNextCompaction.M(s.nextCompaction(epoch, now).Unix()))
+
 	if epoch-s.baseEpoch > CompactionThreshold {
+		if !s.compactionAllowed(now) {
+			// we are outside of the configured compaction windows, defer compaction
+			log.Debugw("deferring compaction until the next compaction window", "epoch", epoch, "baseEpoch", s.baseEpoch)
+			atomic.StoreInt32(&s.compacting, 0)
+			return nil
+		}
+
 		// it's time to compact -- prepare the transaction and go!
 		s.beginTxnProtect()
 		s.compactType = hot
@@ -0,0 +1,107 @@
+package splitstore
+
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/build/buildconstants"
+)
+
+// CompactionWindow is a daily window of local time during which compaction may start.
+// Start and End are offsets from midnight; a window with End before Start wraps around midnight.
+type CompactionWindow struct {
+	Start, End time.Duration
+}
+
+// ParseCompactionWindow parses a window in the "HH:MM-HH:MM" format, e.g. "22:00-06:00".
+func ParseCompactionWindow(s string) (CompactionWindow, error) {
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return CompactionWindow{}, xerrors.Errorf("invalid compaction window %q: expected HH:MM-HH:MM", s)
+	}
+
+	parse := func(v string) (time.Duration, error) {
+		t, err := time.Parse("15:04", strings.TrimSpace(v))
+		if err != nil {
+			return 0, xerrors.Errorf("invalid compaction window %q: %w", s, err)
+		}
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+	}
+
+	var w CompactionWindow
+	var err error
+	if w.Start, err = parse(from); err != nil {
+		return CompactionWindow{}, err
+	}
+	if w.End, err = parse(to); err != nil {
+		return CompactionWindow{}, err
+	}
+	if w.Start == w.End {
+		return CompactionWindow{}, xerrors.Errorf("invalid compaction window %q: window is empty", s)
+	}
+
+	return w, nil
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
+
+func (w CompactionWindow) contains(t time.Time) bool {
+	tod := sinceMidnight(t)
+	if w.Start < w.End {
+		return tod >= w.Start && tod < w.End
+	}
+	return tod >= w.Start || tod < w.End
+}
+
+// nextOpen returns the earliest time at or after t which falls within the window
+func (w CompactionWindow) nextOpen(t time.Time) time.Time {
+	if w.contains(t) {
+		return t
+	}
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Add(w.Start)
+	if start.Before(t) {
+		start = start.AddDate(0, 0, 1)
+	}
+	return start
+}
+
+// compactionAllowed returns true if compaction may start at time t; with no configured windows
+// compaction may start at any time.
+func (s *SplitStore) compactionAllowed(t time.Time) bool {
+	if len(s.cfg.CompactionWindows) == 0 {
+		return true
+	}
+	for _, w := range s.cfg.CompactionWindows {
+		if w.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextCompaction estimates when the next compaction will start, given the current epoch: the
+// time at which the compaction threshold is reached, deferred to the next compaction window.
+func (s *SplitStore) nextCompaction(epoch abi.ChainEpoch, now time.Time) time.Time {
+	due := now
+	if remaining := s.baseEpoch + CompactionThreshold + 1 - epoch; remaining > 0 {
+		due = now.Add(time.Duration(remaining) * time.Duration(buildconstants.BlockDelaySecs) * time.Second)
+	}
+
+	if len(s.cfg.CompactionWindows) == 0 {
+		return due
+	}
+
+	var next time.Time
+	for _, w := range s.cfg.CompactionWindows {
+		if t := w.nextOpen(due); next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	return next
+}
@@ -0,0 +1,56 @@
+package splitstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactionWindow(t *testing.T) {
+	_, err := ParseCompactionWindow("01:00")
+	require.Error(t, err)
+	_, err = ParseCompactionWindow("25:00-03:00")
+	require.Error(t, err)
+	_, err = ParseCompactionWindow("03:00-03:00")
+	require.Error(t, err)
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	w, err := ParseCompactionWindow("01:00-05:30")
+	require.NoError(t, err)
+	require.True(t, w.contains(at(1, 1, 0)))
+	require.True(t, w.contains(at(1, 5, 29)))
+	require.False(t, w.contains(at(1, 5, 30)))
+	require.False(t, w.contains(at(1, 0, 59)))
+	require.Equal(t, at(1, 3, 0), w.nextOpen(at(1, 3, 0)))
+	require.Equal(t, at(1, 1, 0), w.nextOpen(at(1, 0, 10)))
+	require.Equal(t, at(2, 1, 0), w.nextOpen(at(1, 12, 0)))
+
+	// wraps around midnight
+	w, err = ParseCompactionWindow("22:00 - 02:00")
+	require.NoError(t, err)
+	require.True(t, w.contains(at(1, 23, 0)))
+	require.True(t, w.contains(at(1, 1, 0)))
+	require.False(t, w.contains(at(1, 12, 0)))
+	require.Equal(t, at(1, 22, 0), w.nextOpen(at(1, 12, 0)))
+	require.Equal(t, at(1, 1, 0), w.nextOpen(at(1, 1, 0)))
+}
+
+func TestNextCompaction(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	s := &SplitStore{cfg: &Config{}, baseEpoch: 1000}
+	due := s.baseEpoch + CompactionThreshold + 1
+	require.Equal(t, now, s.nextCompaction(due, now))
+	require.True(t, s.nextCompaction(due-10, now).After(now))
+	require.True(t, s.compactionAllowed(now))
+
+	w, err := ParseCompactionWindow("01:00-05:00")
+	require.NoError(t, err)
+	s.cfg.CompactionWindows = []CompactionWindow{w}
+	require.False(t, s.compactionAllowed(now))
+	require.Equal(t, time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC), s.nextCompaction(due, now))
+}
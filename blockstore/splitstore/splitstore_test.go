@@ -590,6 +590,93 @@ func TestSplitStoreReification(t *testing.T) {
 	})
 }
 
+func testSplitStoreWarmup(t *testing.T, lazy bool) {
+	ctx := context.Background()
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+	hot := newMockStore()
+	cold := newMockStore()
+
+	// a chain of two tipsets, with everything in the coldstore
+	stateRoot := blocks.NewBlock([]byte("state"))
+	if err := cold.Put(ctx, stateRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	chain := &mockChain{t: t}
+	var curTs *types.TipSet
+	for i := 0; i < 2; i++ {
+		blk := mock.MkBlock(curTs, uint64(i), uint64(i))
+		blk.Messages = stateRoot.Cid()
+		blk.ParentMessageReceipts = stateRoot.Cid()
+		blk.ParentStateRoot = stateRoot.Cid()
+
+		sblk, err := blk.ToStorageBlock()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cold.Put(ctx, sblk); err != nil {
+			t.Fatal(err)
+		}
+
+		curTs = mock.TipSet(blk)
+		chain.push(curTs)
+	}
+
+	ss, err := Open(t.TempDir(), ds, hot, cold, &Config{MarkSetType: "map", LazyWarmup: lazy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ss.Close() //nolint
+
+	if err := ss.Start(chain, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for ss.warmupState() == "running" {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	info := ss.Info()
+	if info["warmup state"] != "done" {
+		t.Fatalf("expected warmup to be done, got %v", info["warmup state"])
+	}
+	if ss.warmupEpoch.Load() != int64(curTs.Height()) {
+		t.Fatalf("expected warmup epoch %d, got %d", curTs.Height(), ss.warmupEpoch.Load())
+	}
+
+	has, err := hot.Has(ctx, curTs.Blocks()[0].Cid())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if lazy {
+		if info["warmup mode"] != "lazy" {
+			t.Fatalf("expected lazy warmup mode, got %v", info["warmup mode"])
+		}
+		if has || info["warmup copied"] != int64(0) {
+			t.Fatal("expected lazy warmup to not copy objects to the hotstore")
+		}
+		return
+	}
+
+	if info["warmup mode"] != "eager" {
+		t.Fatalf("expected eager warmup mode, got %v", info["warmup mode"])
+	}
+	if !has {
+		t.Fatal("expected head to be copied to the hotstore")
+	}
+	if copied := info["warmup copied"].(int64); copied == 0 {
+		t.Fatal("expected eager warmup to copy objects to the hotstore")
+	}
+}
+
+func TestSplitStoreWarmup(t *testing.T) {
+	t.Log("test eager warmup")
+	testSplitStoreWarmup(t, false)
+	t.Log("test lazy warmup")
+	testSplitStoreWarmup(t, true)
+}
+
 type mockChain struct {
 	t testing.TB
 
@@ -8,12 +8,14 @@ import (
 	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
 	ipld "github.com/ipfs/go-ipld-format"
+	"go.opencensus.io/stats"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-state-types/abi"
 
 	"github.com/filecoin-project/lotus/chain/actors/policy"
 	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/metrics"
 )
 
 var (
@@ -29,8 +31,10 @@ func (s *SplitStore) warmup(curTs *types.TipSet) error {
 		return xerrors.Errorf("error locking compaction")
 	}
 	s.compactType = warmup
+	s.warmupRunning.Store(true)
 	go func() {
 		defer atomic.StoreInt32(&s.compacting, 0)
+		defer s.warmupRunning.Store(false)
 
 		log.Info("warming up hotstore")
 		start := time.Now()
@@ -40,6 +44,7 @@ func (s *SplitStore) warmup(curTs *types.TipSet) error {
 			log.Errorf("error warming up hotstore: %s", err)
 			return
 		}
+		s.recordWarm()
 
 		log.Infow("warm up done", "took", time.Since(start))
 	}()
@@ -47,6 +52,52 @@ func (s *SplitStore) warmup(curTs *types.TipSet) error {
 	return nil
 }
 
+// lazyWarmup marks the hotstore as warm without copying any objects to it; cold objects are
+// moved to the hotstore as they are accessed in hot views, i.e. during state computation.
+func (s *SplitStore) lazyWarmup(curTs *types.TipSet) error {
+	if curTs == nil {
+		// this can happen in some tests
+		return nil
+	}
+
+	log.Info("skipping hotstore warmup, objects will be moved to the hotstore as they are accessed")
+
+	epoch := curTs.Height()
+	err := s.ds.Put(s.ctx, warmupEpochKey, epochToBytes(epoch))
+	if err != nil {
+		return xerrors.Errorf("error saving warm up epoch: %w", err)
+	}
+	s.warmupEpoch.Store(int64(epoch))
+
+	// see doWarmup
+	err = s.ds.Put(s.ctx, compactionIndexKey, int64ToBytes(s.compactionIndex))
+	if err != nil {
+		return xerrors.Errorf("error saving compaction index: %w", err)
+	}
+
+	return nil
+}
+
+// warmupState describes the state of the hotstore warmup
+func (s *SplitStore) warmupState() string {
+	switch {
+	case s.warmupRunning.Load():
+		return "running"
+	case s.isWarm():
+		return "done"
+	default:
+		return "not warm"
+	}
+}
+
+func (s *SplitStore) recordWarm() {
+	var warm int64
+	if s.isWarm() {
+		warm = 1
+	}
+	stats.Record(s.ctx, metrics.SplitstoreWarm.M(warm))
+}
+
 // the actual warmup procedure; it walks the chain loading all state roots at the boundary
 // and headers all the way up to genesis.
 // objects are written in batches so as to minimize overhead.
@@ -62,6 +113,9 @@ func (s *SplitStore) doWarmup(curTs *types.TipSet) error {
 	xcount := new(int64)
 	missing := new(int64)
 
+	s.warmupVisited.Store(0)
+	s.warmupCopied.Store(0)
+
 	visitor, err := s.markSetEnv.New("warmup", 0)
 	if err != nil {
 		return xerrors.Errorf("error creating visitor: %w", err)
@@ -76,6 +130,7 @@ func (s *SplitStore) doWarmup(curTs *types.TipSet) error {
 			}
 
 			atomic.AddInt64(count, 1)
+			s.warmupVisited.Add(1)
 
 			has, err := s.hot.Has(s.ctx, c)
 			if err != nil {
@@ -106,6 +161,9 @@ func (s *SplitStore) doWarmup(curTs *types.TipSet) error {
 					return err
 				}
 				batchHot = batchHot[:0]
+
+				copied := s.warmupCopied.Add(batchSize)
+				stats.Record(s.ctx, metrics.SplitstoreWarmupCopied.M(copied))
 			}
 			mx.Unlock()
 
@@ -121,6 +179,9 @@ func (s *SplitStore) doWarmup(curTs *types.TipSet) error {
 		if err != nil {
 			return err
 		}
+
+		copied := s.warmupCopied.Add(int64(len(batchHot)))
+		stats.Record(s.ctx, metrics.SplitstoreWarmupCopied.M(copied))
 	}
 
 	log.Infow("warmup stats", "visited", *count, "warm", *xcount, "missing", *missing)
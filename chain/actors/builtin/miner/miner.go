@@ -161,6 +161,8 @@ type State interface {
 	AvailableBalance(abi.TokenAmount) (abi.TokenAmount, error)
 	// Funds that will vest by the given epoch.
 	VestedFunds(abi.ChainEpoch) (abi.TokenAmount, error)
+	// Funds still vesting, with the epochs they vest at in ascending order.
+	VestingSchedule() ([]VestingFund, error)
 	// Funds locked for various reasons.
 	LockedFunds() (LockedFunds, error)
 	FeeDebt() (abi.TokenAmount, error)
@@ -311,6 +313,7 @@ func WinningPoStProofTypeFromWindowPoStProofType(nver network.Version, proof abi
 
 type MinerInfo = minertypes.MinerInfo
 type BeneficiaryTerm = minertypes.BeneficiaryTerm
+type VestingFund = minertypes.VestingFund
 type PendingBeneficiaryChange = minertypes.PendingBeneficiaryChange
 type WorkerKeyChange = minertypes.WorkerKeyChange
 type SectorPreCommitOnChainInfo = minertypes.SectorPreCommitOnChainInfo
@@ -71,6 +71,19 @@ func (s *state10) VestedFunds(epoch abi.ChainEpoch) (abi.TokenAmount, error) {
 	return s.CheckVestedFunds(s.store, epoch)
 }
 
+func (s *state10) VestingSchedule() ([]VestingFund, error) {
+	funds, err := s.State.LoadVestingFunds(s.store)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]VestingFund, len(funds.Funds))
+	for i, f := range funds.Funds {
+		out[i] = VestingFund{Epoch: f.Epoch, Amount: f.Amount}
+	}
+	return out, nil
+}
+
 func (s *state10) LockedFunds() (LockedFunds, error) {
 	return LockedFunds{
 		VestingFunds:             s.State.LockedFunds,
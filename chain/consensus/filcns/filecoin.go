@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log/v2"
 	"go.opencensus.io/trace"
@@ -54,8 +55,14 @@ type FilecoinEC struct {
 	verifier proofs.Verifier
 
 	genesis *types.TipSet
+
+	// blocks which passed PreValidateBlock
+	preValidated *lru.Cache[cid.Cid, struct{}]
 }
 
+// preValidatedCacheSize is the number of pre-validated blocks remembered until they are validated.
+const preValidatedCacheSize = 4096
+
 // Blocks that are more than MaxHeightDrift epochs above
 // the theoretical max height based on systime are quickly rejected
 const MaxHeightDrift = 5
@@ -102,12 +109,15 @@ func NewFilecoinExpectedConsensus(sm *stmgr.StateManager, beacon beacon.Schedule
 		log.Warn("*********************************************************************************************")
 	}
 
+	preValidated, _ := lru.New[cid.Cid, struct{}](preValidatedCacheSize)
+
 	return &FilecoinEC{
-		store:    sm.ChainStore(),
-		beacon:   beacon,
-		sm:       sm,
-		verifier: verifier,
-		genesis:  genesis,
+		store:        sm.ChainStore(),
+		beacon:       beacon,
+		sm:           sm,
+		verifier:     verifier,
+		genesis:      genesis,
+		preValidated: preValidated,
 	}
 }
 
@@ -191,24 +201,6 @@ func (filec *FilecoinEC) ValidateBlock(ctx context.Context, b *types.FullBlock)
 			return xerrors.New("block's miner is ineligible to mine")
 		}
 
-		rBeacon := *prevBeacon
-		if len(h.BeaconEntries) != 0 {
-			rBeacon = h.BeaconEntries[len(h.BeaconEntries)-1]
-		}
-		buf := new(bytes.Buffer)
-		if err := h.Miner.MarshalCBOR(buf); err != nil {
-			return xerrors.Errorf("failed to marshal miner address to cbor: %w", err)
-		}
-
-		vrfBase, err := rand.DrawRandomnessFromBase(rBeacon.Data, crypto.DomainSeparationTag_ElectionProofProduction, h.Height, buf.Bytes())
-		if err != nil {
-			return xerrors.Errorf("could not draw randomness: %w", err)
-		}
-
-		if err := VerifyElectionPoStVRF(ctx, waddr, vrfBase, h.ElectionProof.VRFProof); err != nil {
-			return xerrors.Errorf("validating block election proof failed: %w", err)
-		}
-
 		slashed, err := stmgr.GetMinerSlashed(ctx, filec.sm, baseTs, h.Miner)
 		if err != nil {
 			return xerrors.Errorf("failed to check if block miner was slashed: %w", err)
@@ -231,6 +223,95 @@ func (filec *FilecoinEC) ValidateBlock(ctx context.Context, b *types.FullBlock)
 		return nil
 	})
 
+	// the checks of the block proofs are skipped when the block was pre-validated
+	var proofChecks []async.ErrorFuture
+	if filec.preValidated.Contains(b.Cid()) {
+		filec.preValidated.Remove(b.Cid())
+	} else {
+		proofChecks = filec.blockProofChecks(ctx, h, baseTs, *prevBeacon, lbst, waddr, winPoStNv)
+	}
+
+	commonChecks := consensus.CommonBlkChecks(ctx, filec.sm, filec.store, b, baseTs)
+	await := append([]async.ErrorFuture{
+		minerCheck,
+		winnerCheck,
+	}, proofChecks...)
+	await = append(await, commonChecks...)
+
+	return consensus.RunAsyncChecks(ctx, await)
+}
+
+// PreValidateBlock verifies the signature, election proof, ticket, beacon entries and winning PoSt
+// of the block. These only depend on the chain headers and the lookback state, not on the state of
+// the parent tipset. Blocks passing the checks are remembered so that ValidateBlock skips them.
+func (filec *FilecoinEC) PreValidateBlock(ctx context.Context, b *types.FullBlock) error {
+	if err := blockSanityChecks(b.Header); err != nil {
+		return xerrors.Errorf("incoming header failed basic sanity checks: %w", err)
+	}
+
+	h := b.Header
+
+	baseTs, err := filec.store.LoadTipSet(ctx, types.NewTipSetKey(h.Parents...))
+	if err != nil {
+		return xerrors.Errorf("load parent tipset failed (%s): %w", h.Parents, err)
+	}
+
+	// with more null rounds than the lookback the lookback state is the state of the parent
+	var lbr abi.ChainEpoch
+	if lb := policy.GetWinningPoStSectorSetLookback(filec.sm.GetNetworkVersion(ctx, h.Height)); h.Height > lb {
+		lbr = h.Height - lb
+	}
+	if lbr >= baseTs.Height() {
+		return xerrors.Errorf("lookback state of block at height %d depends on the parent tipset", h.Height)
+	}
+
+	_, lbst, err := stmgr.GetLookbackTipSetForRound(ctx, filec.sm, baseTs, h.Height)
+	if err != nil {
+		return xerrors.Errorf("failed to get lookback tipset for block: %w", err)
+	}
+
+	prevBeacon, err := filec.store.GetLatestBeaconEntry(ctx, baseTs)
+	if err != nil {
+		return xerrors.Errorf("failed to get latest beacon entry: %w", err)
+	}
+
+	waddr, err := stmgr.GetMinerWorkerRaw(ctx, filec.sm, lbst, h.Miner)
+	if err != nil {
+		return xerrors.Errorf("GetMinerWorkerRaw failed: %w", err)
+	}
+
+	winPoStNv := filec.sm.GetNetworkVersion(ctx, baseTs.Height())
+	if err := consensus.RunAsyncChecks(ctx, filec.blockProofChecks(ctx, h, baseTs, *prevBeacon, lbst, waddr, winPoStNv)); err != nil {
+		return err
+	}
+
+	filec.preValidated.Add(b.Cid(), struct{}{})
+	return nil
+}
+
+func (filec *FilecoinEC) blockProofChecks(ctx context.Context, h *types.BlockHeader, baseTs *types.TipSet,
+	prevBeacon types.BeaconEntry, lbst cid.Cid, waddr address.Address, winPoStNv network.Version) []async.ErrorFuture {
+	electionCheck := async.Err(func() error {
+		rBeacon := prevBeacon
+		if len(h.BeaconEntries) != 0 {
+			rBeacon = h.BeaconEntries[len(h.BeaconEntries)-1]
+		}
+		buf := new(bytes.Buffer)
+		if err := h.Miner.MarshalCBOR(buf); err != nil {
+			return xerrors.Errorf("failed to marshal miner address to cbor: %w", err)
+		}
+
+		vrfBase, err := rand.DrawRandomnessFromBase(rBeacon.Data, crypto.DomainSeparationTag_ElectionProofProduction, h.Height, buf.Bytes())
+		if err != nil {
+			return xerrors.Errorf("could not draw randomness: %w", err)
+		}
+
+		if err := VerifyElectionPoStVRF(ctx, waddr, vrfBase, h.ElectionProof.VRFProof); err != nil {
+			return xerrors.Errorf("validating block election proof failed: %w", err)
+		}
+		return nil
+	})
+
 	blockSigCheck := async.Err(func() error {
 		if err := verifyBlockSignature(ctx, h, waddr); err != nil {
 			return xerrors.Errorf("check block signature failed: %w", err)
@@ -245,7 +326,7 @@ func (filec *FilecoinEC) ValidateBlock(ctx context.Context, b *types.FullBlock)
 		}
 
 		nv := filec.sm.GetNetworkVersion(ctx, h.Height)
-		if err := beacon.ValidateBlockValues(filec.beacon, nv, h, baseTs.Height(), *prevBeacon); err != nil {
+		if err := beacon.ValidateBlockValues(filec.beacon, nv, h, baseTs.Height(), prevBeacon); err != nil {
 			return xerrors.Errorf("failed to validate blocks random beacon values: %w", err)
 		}
 		return nil
@@ -261,7 +342,7 @@ func (filec *FilecoinEC) ValidateBlock(ctx context.Context, b *types.FullBlock)
 			buf.Write(baseTs.MinTicket().VRFProof)
 		}
 
-		beaconBase := *prevBeacon
+		beaconBase := prevBeacon
 		if len(h.BeaconEntries) != 0 {
 			beaconBase = h.BeaconEntries[len(h.BeaconEntries)-1]
 		}
@@ -279,23 +360,19 @@ func (filec *FilecoinEC) ValidateBlock(ctx context.Context, b *types.FullBlock)
 	})
 
 	wproofCheck := async.Err(func() error {
-		if err := filec.VerifyWinningPoStProof(ctx, winPoStNv, h, *prevBeacon, lbst, waddr); err != nil {
+		if err := filec.VerifyWinningPoStProof(ctx, winPoStNv, h, prevBeacon, lbst, waddr); err != nil {
 			return xerrors.Errorf("invalid election post: %w", err)
 		}
 		return nil
 	})
 
-	commonChecks := consensus.CommonBlkChecks(ctx, filec.sm, filec.store, b, baseTs)
-	await := append([]async.ErrorFuture{
-		minerCheck,
-		tktsCheck,
+	return []async.ErrorFuture{
+		electionCheck,
 		blockSigCheck,
 		beaconValuesCheck,
+		tktsCheck,
 		wproofCheck,
-		winnerCheck,
-	}, commonChecks...)
-
-	return consensus.RunAsyncChecks(ctx, await)
+	}
 }
 
 func blockSanityChecks(h *types.BlockHeader) error {
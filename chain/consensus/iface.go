@@ -45,6 +45,16 @@ type Consensus interface {
 	CreateBlock(ctx context.Context, w api.Wallet, bt *api.BlockTemplate) (*types.FullBlock, error)
 }
 
+// BlockPreValidator is implemented by consensus implementations which can verify the parts of a
+// block that don't depend on the state of its parent tipset. The syncer uses it to verify upcoming
+// blocks in parallel while catching up, as the state of each tipset can only be computed in order.
+type BlockPreValidator interface {
+	// PreValidateBlock runs the checks of ValidateBlock which don't need the state of the parent
+	// tipset, and lets ValidateBlock skip them if they pass. A failure has no other effect, the
+	// block is rejected when ValidateBlock runs the checks again.
+	PreValidateBlock(ctx context.Context, b *types.FullBlock) error
+}
+
 // RewardFunc parametrizes the logic for rewards when a message is executed.
 //
 // Each consensus implementation can set their own reward function.
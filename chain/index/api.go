@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 
 	"github.com/ipfs/go-cid"
 	cbor "github.com/ipfs/go-ipld-cbor"
@@ -14,6 +15,7 @@ import (
 	amt4 "github.com/filecoin-project/go-amt-ipld/v4"
 	"github.com/filecoin-project/go-state-types/abi"
 
+	"github.com/filecoin-project/lotus/api"
 	bstore "github.com/filecoin-project/lotus/blockstore"
 	"github.com/filecoin-project/lotus/chain/types"
 )
@@ -95,10 +97,10 @@ func (si *SqliteIndexer) ChainValidateIndex(ctx context.Context, epoch abi.Chain
 		return si.backfillMissingTipset(ctx, expectedTs)
 
 	case revertedCount > 0 && nonRevertedCount == 0:
-		return nil, xerrors.Errorf("index corruption: height %d only has reverted tipsets", epoch)
+		return nil, api.NewErrIndexInconsistent(epoch, api.IndexCorrupt, "index corruption: height %d only has reverted tipsets", epoch)
 
 	case nonRevertedCount > 1:
-		return nil, xerrors.Errorf("index corruption: height %d has multiple non-reverted tipsets", epoch)
+		return nil, api.NewErrIndexInconsistent(epoch, api.IndexCorrupt, "index corruption: height %d has multiple non-reverted tipsets", epoch)
 	}
 
 	// fetch the non-reverted tipset at this epoch
@@ -117,7 +119,7 @@ func (si *SqliteIndexer) ChainValidateIndex(ctx context.Context, epoch abi.Chain
 		return nil, xerrors.Errorf("failed to get tipset key cid: %w", err)
 	}
 	if !indexedTsKeyCid.Equals(expectedTsKeyCid) {
-		return nil, xerrors.Errorf("index corruption: indexed tipset at height %d has key %s, but canonical chain has %s", epoch, indexedTsKeyCid, expectedTsKeyCid)
+		return nil, api.NewErrIndexInconsistent(epoch, api.IndexCorrupt, "index corruption: indexed tipset at height %d has key %s, but canonical chain has %s", epoch, indexedTsKeyCid, expectedTsKeyCid)
 	}
 
 	getAndVerifyIndexedData := func() (*indexedTipSetData, error) {
@@ -138,7 +140,7 @@ func (si *SqliteIndexer) ChainValidateIndex(ctx context.Context, epoch abi.Chain
 	var bf bool
 	if err != nil {
 		if !backfill {
-			return nil, xerrors.Errorf("failed to verify indexed data at height %d: %w", expectedTs.Height(), err)
+			return nil, wrapVerifyErr(err, "failed to verify indexed data at height %d", expectedTs.Height())
 		}
 
 		log.Warnf("failed to verify indexed data at height %d; err:%s; backfilling once and validating again", expectedTs.Height(), err)
@@ -148,7 +150,7 @@ func (si *SqliteIndexer) ChainValidateIndex(ctx context.Context, epoch abi.Chain
 
 		indexedData, err = getAndVerifyIndexedData()
 		if err != nil {
-			return nil, xerrors.Errorf("failed to verify indexed data at height %d after backfill: %w", expectedTs.Height(), err)
+			return nil, wrapVerifyErr(err, "failed to verify indexed data at height %d after backfill", expectedTs.Height())
 		}
 		bf = true
 	}
@@ -171,7 +173,7 @@ func (si *SqliteIndexer) validateIsNullRound(ctx context.Context, epoch abi.Chai
 		return nil, xerrors.Errorf("failed to check if null round exists at height %d: %w", epoch, err)
 	}
 	if !isNullRound {
-		return nil, xerrors.Errorf("index corruption: height %d should be a null round but is not", epoch)
+		return nil, api.NewErrIndexInconsistent(epoch, api.IndexCorrupt, "index corruption: height %d should be a null round but is not", epoch)
 	}
 
 	return &types.IndexValidation{
@@ -254,7 +256,7 @@ func (si *SqliteIndexer) verifyIndexedData(ctx context.Context, ts *types.TipSet
 		return xerrors.Errorf("failed to check if there are reverted events in tipset for height %d: %w", ts.Height(), err)
 	}
 	if hasRevertedEventsInTipset {
-		return xerrors.Errorf("index corruption: reverted events found for an executed tipset %s at height %d", tsKeyCid, ts.Height())
+		return api.NewErrIndexInconsistent(ts.Height(), api.IndexCorrupt, "index corruption: reverted events found for an executed tipset %s at height %d", tsKeyCid, ts.Height())
 	}
 
 	executedMsgs, err := si.executedMessagesLoaderFunc(ctx, si.cs, ts, executionTs)
@@ -274,16 +276,16 @@ func (si *SqliteIndexer) verifyIndexedData(ctx context.Context, ts *types.TipSet
 	}
 
 	if totalEventsCount != indexedData.nonRevertedEventCount {
-		return xerrors.Errorf("event count mismatch for height %d: chainstore has %d, index has %d", ts.Height(), totalEventsCount, indexedData.nonRevertedEventCount)
+		return api.NewErrIndexInconsistent(ts.Height(), countInconsistency(totalEventsCount, indexedData.nonRevertedEventCount), "event count mismatch for height %d: chainstore has %d, index has %d", ts.Height(), totalEventsCount, indexedData.nonRevertedEventCount)
 	}
 
 	totalExecutedMsgCount := uint64(len(executedMsgs))
 	if totalExecutedMsgCount != indexedData.nonRevertedMessageCount {
-		return xerrors.Errorf("message count mismatch for height %d: chainstore has %d, index has %d", ts.Height(), totalExecutedMsgCount, indexedData.nonRevertedMessageCount)
+		return api.NewErrIndexInconsistent(ts.Height(), countInconsistency(totalExecutedMsgCount, indexedData.nonRevertedMessageCount), "message count mismatch for height %d: chainstore has %d, index has %d", ts.Height(), totalExecutedMsgCount, indexedData.nonRevertedMessageCount)
 	}
 
 	if indexedData.nonRevertedEventEntriesCount != totalEventEntriesCount {
-		return xerrors.Errorf("event entries count mismatch for height %d: chainstore has %d, index has %d", ts.Height(), totalEventEntriesCount, indexedData.nonRevertedEventEntriesCount)
+		return api.NewErrIndexInconsistent(ts.Height(), countInconsistency(totalEventEntriesCount, indexedData.nonRevertedEventEntriesCount), "event entries count mismatch for height %d: chainstore has %d, index has %d", ts.Height(), totalEventEntriesCount, indexedData.nonRevertedEventEntriesCount)
 	}
 
 	// compare the events AMT root between the indexed events and the events in the chain state
@@ -299,16 +301,16 @@ func (si *SqliteIndexer) verifyIndexedData(ctx context.Context, ts *types.TipSet
 		}
 
 		if hasEvents && emsg.rct.EventsRoot == nil {
-			return xerrors.Errorf("index corruption: events found in index for message %s at height %d, but message receipt has no events root", emsg.msg.Cid(), ts.Height())
+			return api.NewErrIndexInconsistent(ts.Height(), api.IndexCorrupt, "index corruption: events found in index for message %s at height %d, but message receipt has no events root", emsg.msg.Cid(), ts.Height())
 		}
 
 		if !hasEvents && emsg.rct.EventsRoot != nil {
-			return xerrors.Errorf("index corruption: no events found in index for message %s at height %d, but message receipt has events root %s", emsg.msg.Cid(), ts.Height(), emsg.rct.EventsRoot)
+			return api.NewErrIndexInconsistent(ts.Height(), api.IndexCorrupt, "index corruption: no events found in index for message %s at height %d, but message receipt has events root %s", emsg.msg.Cid(), ts.Height(), emsg.rct.EventsRoot)
 		}
 
 		// Both index and receipt have events, compare the roots
 		if !indexedRoot.Equals(*emsg.rct.EventsRoot) {
-			return xerrors.Errorf("index corruption: events AMT root mismatch for message %s at height %d. Index root: %s, Receipt root: %s", emsg.msg.Cid(), ts.Height(), indexedRoot, emsg.rct.EventsRoot)
+			return api.NewErrIndexInconsistent(ts.Height(), api.IndexCorrupt, "index corruption: events AMT root mismatch for message %s at height %d. Index root: %s, Receipt root: %s", emsg.msg.Cid(), ts.Height(), indexedRoot, emsg.rct.EventsRoot)
 		}
 	}
 
@@ -366,7 +368,26 @@ func (si *SqliteIndexer) getNextTipset(ctx context.Context, ts *types.TipSet) (*
 }
 
 func makeBackfillRequiredErr(height abi.ChainEpoch) error {
-	return xerrors.Errorf("missing tipset at height %d in the chain index, set backfill flag to true to fix", height)
+	return api.NewErrIndexInconsistent(height, api.IndexMissing, "missing tipset at height %d in the chain index, set backfill flag to true to fix", height)
+}
+
+// countInconsistency tells whether the index is missing entries or has extra ones, given the
+// number of entries in the chain and in the index.
+func countInconsistency(chain, indexed uint64) api.IndexInconsistency {
+	if indexed < chain {
+		return api.IndexMissing
+	}
+	return api.IndexExtra
+}
+
+// wrapVerifyErr adds context to an error returned by verifyIndexedData. An index inconsistency
+// is kept an api.ErrIndexInconsistent, so that its kind is still known to callers over the RPC.
+func wrapVerifyErr(err error, format string, args ...interface{}) error {
+	var ie *api.ErrIndexInconsistent
+	if errors.As(err, &ie) {
+		return api.NewErrIndexInconsistent(ie.Epoch, ie.Kind, "%s: %s", fmt.Sprintf(format, args...), ie.Message)
+	}
+	return xerrors.Errorf(format+": %w", append(args, err)...)
 }
 
 // amtRootForEvents generates the events AMT root CID for a given message's events, and returns
@@ -12,6 +12,7 @@ import (
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 
+	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/chain/types"
 )
 
@@ -88,6 +89,11 @@ func TestValidateIsNullRoundSimple(t *testing.T) {
 	}
 }
 
+func TestCountInconsistency(t *testing.T) {
+	require.Equal(t, api.IndexMissing, countInconsistency(5, 3))
+	require.Equal(t, api.IndexExtra, countInconsistency(5, 7))
+}
+
 func TestFailureHeadHeight(t *testing.T) {
 	ctx := context.Background()
 	seed := time.Now().UnixNano()
@@ -153,6 +159,11 @@ func TestBackfillReturnsError(t *testing.T) {
 	_, err := si.ChainValidateIndex(ctx, missingEpoch, false)
 	require.Error(t, err)
 	require.ErrorContains(t, err, "missing tipset at height 50 in the chain index")
+
+	var ie *api.ErrIndexInconsistent
+	require.ErrorAs(t, err, &ie)
+	require.Equal(t, api.IndexMissing, ie.Kind)
+	require.Equal(t, missingEpoch, ie.Epoch)
 }
 
 func TestBackfillMissingEpoch(t *testing.T) {
@@ -223,6 +234,13 @@ func TestBackfillMissingEpoch(t *testing.T) {
 	require.ErrorContains(t, err, "events AMT root mismatch")
 	require.Nil(t, verificationResult)
 
+	// the verification failure is reported as a typed inconsistency, wrapped errors can't be told
+	// apart over the RPC
+	ie, ok := err.(*api.ErrIndexInconsistent)
+	require.True(t, ok, "unexpected error type %T", err)
+	require.Equal(t, api.IndexCorrupt, ie.Kind)
+	require.Equal(t, missingEpoch, ie.Epoch)
+
 	tsKeyCid, err := missingTs.Key().Cid()
 	require.NoError(t, err)
 
@@ -256,7 +256,7 @@ func (mp *MessagePool) checkMessages(ctx context.Context, msgs []*types.Message,
 			},
 		}
 
-		if len(bytes) > MaxMessageSize-128 { // 128 bytes to account for signature size
+		if len(bytes) > mp.maxMsgSize-128 { // 128 bytes to account for signature size
 			check.OK = false
 			check.Err = "message too big"
 		} else {
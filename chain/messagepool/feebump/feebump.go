@@ -0,0 +1,255 @@
+// Package feebump replaces pending messages which were not included in the chain for too long
+// with copies paying a higher gas premium.
+package feebump
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	logging "github.com/ipfs/go-log/v2"
+	"go.opencensus.io/stats"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/messagepool"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/metrics"
+)
+
+var log = logging.Logger("feebump")
+
+type BumpApi interface {
+	ChainHead(context.Context) (*types.TipSet, error)
+	MpoolPending(context.Context, types.TipSetKey) ([]*types.SignedMessage, error)
+	MpoolGetConfig(context.Context) (*types.MpoolConfig, error)
+	MpoolPush(context.Context, *types.SignedMessage) (cid.Cid, error)
+	GasEstimateMessageGas(context.Context, *types.Message, *api.MessageSendSpec, types.TipSetKey) (*types.Message, error)
+	WalletHas(context.Context, address.Address) (bool, error)
+	WalletSignMessage(context.Context, address.Address, *types.Message) (*types.SignedMessage, error)
+}
+
+type Config struct {
+	// StuckEpochs is the number of epochs a message has to be pending for before it is bumped
+	StuckEpochs abi.ChainEpoch
+	// MaxFee is the maximum fee, the fee cap times the gas limit, of a bumped message
+	MaxFee abi.TokenAmount
+	// BumpPushed enables bumping messages which weren't marked for automatic bumping, when their
+	// sender key is in the node wallet, e.g. messages sent with lotus send, or signed elsewhere and
+	// pushed with MpoolPush
+	BumpPushed bool
+
+	CheckInterval time.Duration
+}
+
+// Bumper periodically checks the message pool for messages sent automatically by the node, or the
+// miners using it, which are pending for more than the configured number of epochs, and replaces
+// them with messages paying a higher gas premium, so that they don't stall when the base fee rises
+// or the blocks are full.
+type Bumper struct {
+	api BumpApi
+	cfg Config
+	// marked returns whether the message with the given CID was pushed with
+	// MessageSendSpec.AutoBump set
+	marked func(cid.Cid) bool
+
+	lk sync.Mutex
+	// firstSeen is the height the pending messages were first seen at
+	firstSeen map[cid.Cid]abi.ChainEpoch
+	// bumped holds the replacements sent by the bumper
+	bumped map[cid.Cid]struct{}
+	// capped holds the messages which can't be bumped within the maximum fee
+	capped map[cid.Cid]struct{}
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewBumper(a BumpApi, marked func(cid.Cid) bool, cfg Config) (*Bumper, error) {
+	if cfg.StuckEpochs <= 0 {
+		return nil, xerrors.Errorf("stuck epochs must be positive")
+	}
+	if cfg.MaxFee.LessThanEqual(big.Zero()) {
+		return nil, xerrors.Errorf("max fee must be positive")
+	}
+	if cfg.CheckInterval <= 0 {
+		return nil, xerrors.Errorf("check interval must be positive")
+	}
+
+	return &Bumper{
+		api:    a,
+		cfg:    cfg,
+		marked: marked,
+
+		firstSeen: map[cid.Cid]abi.ChainEpoch{},
+		bumped:    map[cid.Cid]struct{}{},
+		capped:    map[cid.Cid]struct{}{},
+
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}, nil
+}
+
+func (b *Bumper) Start(context.Context) error {
+	go b.run()
+	return nil
+}
+
+func (b *Bumper) Stop(ctx context.Context) error {
+	close(b.stop)
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *Bumper) run() {
+	defer close(b.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-b.stop
+		cancel()
+	}()
+
+	ticker := time.NewTicker(b.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := b.Check(ctx); err != nil {
+			log.Errorw("checking for stuck messages", "error", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check bumps the gas premium of the eligible pending messages which were first seen more than
+// the configured number of epochs before the head.
+func (b *Bumper) Check(ctx context.Context) error {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+
+	head, err := b.api.ChainHead(ctx)
+	if err != nil {
+		return xerrors.Errorf("getting chain head: %w", err)
+	}
+	pending, err := b.api.MpoolPending(ctx, types.EmptyTSK)
+	if err != nil {
+		return xerrors.Errorf("getting pending messages: %w", err)
+	}
+	mcfg, err := b.api.MpoolGetConfig(ctx)
+	if err != nil {
+		return xerrors.Errorf("getting mpool config: %w", err)
+	}
+
+	// forget messages which were included or replaced
+	current := make(map[cid.Cid]struct{}, len(pending))
+	for _, m := range pending {
+		current[m.Cid()] = struct{}{}
+	}
+	for c := range b.firstSeen {
+		if _, ok := current[c]; !ok {
+			delete(b.firstSeen, c)
+			delete(b.bumped, c)
+			delete(b.capped, c)
+		}
+	}
+
+	inWallet := map[address.Address]bool{}
+	for _, m := range pending {
+		c := m.Cid()
+		seen, ok := b.firstSeen[c]
+		if !ok {
+			b.firstSeen[c] = head.Height()
+			continue
+		}
+		if head.Height()-seen < b.cfg.StuckEpochs {
+			continue
+		}
+		if _, ok := b.capped[c]; ok {
+			continue
+		}
+
+		_, replacement := b.bumped[c]
+		if !replacement && !b.marked(c) && !b.cfg.BumpPushed {
+			continue
+		}
+
+		from := m.Message.From
+		has, ok := inWallet[from]
+		if !ok {
+			has, err = b.api.WalletHas(ctx, from)
+			if err != nil {
+				return xerrors.Errorf("checking wallet for %s: %w", from, err)
+			}
+			inWallet[from] = has
+		}
+		if !has {
+			continue
+		}
+
+		if err := b.bump(ctx, m, head.Height()-seen, mcfg.ReplaceByFeeRatio, head.Height()); err != nil {
+			log.Errorw("bumping stuck message", "cid", c, "from", from, "nonce", m.Message.Nonce, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (b *Bumper) bump(ctx context.Context, sm *types.SignedMessage, stuck abi.ChainEpoch, rbfRatio types.Percent, height abi.ChainEpoch) error {
+	msg := sm.Message
+	minPremium := messagepool.ComputeRBF(msg.GasPremium, rbfRatio)
+
+	spec := &api.MessageSendSpec{MaxFee: b.cfg.MaxFee}
+	msg.GasFeeCap = abi.NewTokenAmount(0)
+	msg.GasPremium = abi.NewTokenAmount(0)
+	est, err := b.api.GasEstimateMessageGas(ctx, &msg, spec, types.EmptyTSK)
+	if err != nil {
+		return xerrors.Errorf("estimating gas: %w", err)
+	}
+
+	msg.GasPremium = big.Max(est.GasPremium, minPremium)
+	msg.GasFeeCap = big.Max(est.GasFeeCap, msg.GasPremium)
+	messagepool.CapGasFee(func() (abi.TokenAmount, error) {
+		return b.cfg.MaxFee, nil
+	}, &msg, spec)
+
+	if msg.GasPremium.LessThan(minPremium) {
+		log.Warnw("not bumping stuck message, the replacement would exceed the maximum fee",
+			"cid", sm.Cid(), "from", msg.From, "nonce", msg.Nonce, "premium", sm.Message.GasPremium,
+			"minPremium", minPremium, "maxFee", types.FIL(b.cfg.MaxFee))
+		b.capped[sm.Cid()] = struct{}{}
+		return nil
+	}
+
+	smsg, err := b.api.WalletSignMessage(ctx, msg.From, &msg)
+	if err != nil {
+		return xerrors.Errorf("signing replacement: %w", err)
+	}
+	c, err := b.api.MpoolPush(ctx, smsg)
+	if err != nil {
+		return xerrors.Errorf("pushing replacement: %w", err)
+	}
+
+	b.bumped[c] = struct{}{}
+	b.firstSeen[c] = height
+	stats.Record(ctx, metrics.MpoolAutoBumps.M(1))
+
+	log.Infow("bumped stuck message", "cid", sm.Cid(), "replacement", c, "from", msg.From, "nonce", msg.Nonce,
+		"stuckEpochs", stuck, "oldPremium", sm.Message.GasPremium, "premium", msg.GasPremium,
+		"oldFeeCap", sm.Message.GasFeeCap, "feeCap", msg.GasFeeCap)
+	return nil
+}
@@ -0,0 +1,146 @@
+package feebump
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+)
+
+type bumpApi struct {
+	height  abi.ChainEpoch
+	pending []*types.SignedMessage
+	wallet  map[address.Address]struct{}
+	pushed  []*types.SignedMessage
+}
+
+func (a *bumpApi) ChainHead(context.Context) (*types.TipSet, error) {
+	blk := mock.MkBlock(nil, 0, 0)
+	blk.Height = a.height
+	return mock.TipSet(blk), nil
+}
+
+func (a *bumpApi) MpoolPending(context.Context, types.TipSetKey) ([]*types.SignedMessage, error) {
+	return a.pending, nil
+}
+
+func (a *bumpApi) MpoolGetConfig(context.Context) (*types.MpoolConfig, error) {
+	return &types.MpoolConfig{ReplaceByFeeRatio: 125}, nil
+}
+
+func (a *bumpApi) MpoolPush(_ context.Context, sm *types.SignedMessage) (cid.Cid, error) {
+	// replace the message with the same sender and nonce
+	for i, m := range a.pending {
+		if m.Message.From == sm.Message.From && m.Message.Nonce == sm.Message.Nonce {
+			a.pending[i] = sm
+		}
+	}
+	a.pushed = append(a.pushed, sm)
+	return sm.Cid(), nil
+}
+
+func (a *bumpApi) GasEstimateMessageGas(_ context.Context, msg *types.Message, _ *api.MessageSendSpec, _ types.TipSetKey) (*types.Message, error) {
+	out := *msg
+	out.GasPremium = types.NewInt(100)
+	out.GasFeeCap = types.NewInt(1000)
+	return &out, nil
+}
+
+func (a *bumpApi) WalletHas(_ context.Context, addr address.Address) (bool, error) {
+	_, ok := a.wallet[addr]
+	return ok, nil
+}
+
+func (a *bumpApi) WalletSignMessage(_ context.Context, _ address.Address, msg *types.Message) (*types.SignedMessage, error) {
+	return &types.SignedMessage{Message: *msg, Signature: crypto.Signature{Type: crypto.SigTypeBLS}}, nil
+}
+
+func TestBumperCheck(t *testing.T) {
+	ctx := context.Background()
+	local, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	remote, err := address.NewIDAddress(1001)
+	require.NoError(t, err)
+
+	smsg := func(from address.Address, nonce uint64, premium, gasLimit int64) *types.SignedMessage {
+		return &types.SignedMessage{
+			Message: types.Message{
+				From:       from,
+				To:         remote,
+				Nonce:      nonce,
+				Value:      big.Zero(),
+				GasLimit:   gasLimit,
+				GasPremium: types.NewInt(uint64(premium)),
+				GasFeeCap:  types.NewInt(uint64(premium)),
+			},
+			Signature: crypto.Signature{Type: crypto.SigTypeBLS},
+		}
+	}
+
+	automatic := smsg(local, 0, 100, 1000)
+	sent := smsg(local, 1, 100, 1000)
+	expensive := smsg(local, 2, 100, 1000000)
+	foreign := smsg(remote, 0, 100, 1000)
+
+	a := &bumpApi{
+		height:  100,
+		pending: []*types.SignedMessage{automatic, sent, expensive, foreign},
+		wallet:  map[address.Address]struct{}{local: {}},
+	}
+	autoBump := map[cid.Cid]struct{}{automatic.Cid(): {}, expensive.Cid(): {}, foreign.Cid(): {}}
+	marked := func(c cid.Cid) bool {
+		_, ok := autoBump[c]
+		return ok
+	}
+
+	b, err := NewBumper(a, marked, Config{
+		StuckEpochs:   10,
+		MaxFee:        types.NewInt(1000000),
+		CheckInterval: time.Minute,
+	})
+	require.NoError(t, err)
+
+	// messages are first seen, then bumped once they are stuck
+	require.NoError(t, b.Check(ctx))
+	a.height = 109
+	require.NoError(t, b.Check(ctx))
+	require.Empty(t, a.pushed)
+
+	// only the marked message is bumped, the expensive one can't be bumped within the max
+	// fee and the sender of the foreign one isn't in the wallet
+	a.height = 110
+	require.NoError(t, b.Check(ctx))
+	require.Len(t, a.pushed, 1)
+	require.Equal(t, uint64(0), a.pushed[0].Message.Nonce)
+	require.Equal(t, types.NewInt(126), a.pushed[0].Message.GasPremium)
+	require.Equal(t, types.NewInt(1000), a.pushed[0].Message.GasFeeCap)
+
+	// replacements are bumped again once they are stuck
+	a.height = 115
+	require.NoError(t, b.Check(ctx))
+	require.Len(t, a.pushed, 1)
+	a.height = 120
+	require.NoError(t, b.Check(ctx))
+	require.Len(t, a.pushed, 2)
+	require.Equal(t, types.NewInt(158), a.pushed[1].Message.GasPremium)
+
+	// unmarked messages are only bumped when enabled
+	b.cfg.BumpPushed = true
+	require.NoError(t, b.Check(ctx))
+	require.Len(t, a.pushed, 3)
+	require.Equal(t, uint64(1), a.pushed[2].Message.Nonce)
+
+	_, err = NewBumper(a, marked, Config{StuckEpochs: 10, MaxFee: big.Zero(), CheckInterval: time.Minute})
+	require.Error(t, err)
+}
@@ -150,6 +150,10 @@ type MessagePool struct {
 	cfgLk sync.RWMutex
 	cfg   *types.MpoolConfig
 
+	// maxMsgSize is the size limit of messages accepted into the pool, MaxMessageSize unless
+	// raised for networks with larger messages
+	maxMsgSize int
+
 	api Provider
 
 	minGasPrice types.BigInt
@@ -373,6 +377,16 @@ func (ms *msgSet) toSlice() []*types.SignedMessage {
 }
 
 func New(ctx context.Context, api Provider, ds dtypes.MetadataDS, us stmgr.UpgradeSchedule, netName dtypes.NetworkName, j journal.Journal) (*MessagePool, error) {
+	return NewWithMaxMessageSize(ctx, api, ds, us, netName, j, MaxMessageSize)
+}
+
+// NewWithMaxMessageSize is like New, but accepts messages of up to maxMsgSize bytes instead of
+// MaxMessageSize, for networks with a raised messages topic message size limit.
+func NewWithMaxMessageSize(ctx context.Context, api Provider, ds dtypes.MetadataDS, us stmgr.UpgradeSchedule, netName dtypes.NetworkName, j journal.Journal, maxMsgSize int) (*MessagePool, error) {
+	if maxMsgSize < MaxMessageSize {
+		return nil, xerrors.Errorf("max message size %d is lower than the minimum %d", maxMsgSize, MaxMessageSize)
+	}
+
 	cache, _ := lru.New2Q[cid.Cid, crypto.Signature](buildconstants.BlsSignatureCacheSize)
 	verifcache, _ := lru.New2Q[string, struct{}](buildconstants.VerifSigCacheSize)
 	stateNonceCache, _ := lru.New[stateNonceCacheKey, uint64](32768) // 32k * ~200 bytes = 6MB
@@ -408,6 +422,7 @@ func New(ctx context.Context, api Provider, ds dtypes.MetadataDS, us stmgr.Upgra
 		api:             api,
 		netName:         netName,
 		cfg:             cfg,
+		maxMsgSize:      maxMsgSize,
 		evtTypes: [...]journal.EventType{
 			evtTypeMpoolAdd:    j.RegisterEventType("mpool", "add"),
 			evtTypeMpoolRemove: j.RegisterEventType("mpool", "remove"),
@@ -725,9 +740,14 @@ func (mp *MessagePool) Push(ctx context.Context, m *types.SignedMessage, publish
 	return m.Cid(), nil
 }
 
+// MaxMessageSize returns the size limit of messages accepted into the pool.
+func (mp *MessagePool) MaxMessageSize() int {
+	return mp.maxMsgSize
+}
+
 func (mp *MessagePool) checkMessage(ctx context.Context, m *types.SignedMessage) error {
 	// big messages are bad, anti DOS
-	if m.Size() > MaxMessageSize {
+	if m.Size() > mp.maxMsgSize {
 		return xerrors.Errorf("mpool message too large (%dB): %w", m.Size(), ErrMessageTooBig)
 	}
 
@@ -1042,6 +1062,29 @@ func (mp *MessagePool) GetNonce(ctx context.Context, addr address.Address, _ typ
 	return mp.getNonceLocked(ctx, addr, mp.curTs)
 }
 
+// GetNonces returns the next nonce of each of the addresses, accounting for their pending messages,
+// at the same tipset. Addresses without an actor on chain have a next nonce of 0.
+func (mp *MessagePool) GetNonces(ctx context.Context, addrs []address.Address) ([]uint64, error) {
+	mp.curTsLk.RLock()
+	defer mp.curTsLk.RUnlock()
+
+	mp.lk.RLock()
+	defer mp.lk.RUnlock()
+
+	out := make([]uint64, len(addrs))
+	for i, addr := range addrs {
+		n, err := mp.getNonceLocked(ctx, addr, mp.curTs)
+		if err != nil {
+			if errors.Is(err, types.ErrActorNotFound) {
+				continue
+			}
+			return nil, xerrors.Errorf("getting nonce of %s: %w", addr, err)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
 // GetActor should not be used. It is only here to satisfy interface mess caused by lite node handling
 func (mp *MessagePool) GetActor(_ context.Context, addr address.Address, _ types.TipSetKey) (*types.Actor, error) {
 	mp.curTsLk.RLock()
@@ -1269,6 +1312,47 @@ func (mp *MessagePool) pendingFor(ctx context.Context, a address.Address) []*typ
 	return mset.toSlice()
 }
 
+// PendingTo returns the pending messages sent to any of the given addresses, ordered by sender and
+// nonce, along with the tipset the pending messages are based on.
+func (mp *MessagePool) PendingTo(ctx context.Context, to ...address.Address) ([]*types.SignedMessage, *types.TipSet) {
+	mp.curTsLk.RLock()
+	defer mp.curTsLk.RUnlock()
+
+	mp.lk.RLock()
+	defer mp.lk.RUnlock()
+
+	match := make(map[address.Address]struct{}, len(to))
+	for _, a := range to {
+		match[a] = struct{}{}
+	}
+
+	type sentMsg struct {
+		from string // the sender key address, messages from one sender may use its ID or key address
+		msg  *types.SignedMessage
+	}
+	var sent []sentMsg
+	mp.forEachPending(func(a address.Address, mset *msgSet) {
+		for _, m := range mset.msgs {
+			if _, ok := match[m.Message.To]; ok {
+				sent = append(sent, sentMsg{from: a.String(), msg: m})
+			}
+		}
+	})
+
+	sort.Slice(sent, func(i, j int) bool {
+		if sent[i].from != sent[j].from {
+			return sent[i].from < sent[j].from
+		}
+		return sent[i].msg.Message.Nonce < sent[j].msg.Message.Nonce
+	})
+
+	out := make([]*types.SignedMessage, len(sent))
+	for i, s := range sent {
+		out[i] = s.msg
+	}
+	return out, mp.curTs
+}
+
 func (mp *MessagePool) HeadChange(ctx context.Context, revert []*types.TipSet, apply []*types.TipSet) error {
 	mp.curTsLk.Lock()
 	defer mp.curTsLk.Unlock()
@@ -1576,6 +1660,45 @@ func (mp *MessagePool) Updates(ctx context.Context) (<-chan api.MpoolUpdate, err
 	return out, nil
 }
 
+// Watch returns the messages added to the pool which match the filter. The filter is evaluated
+// for every added message and must be cheap. Messages are dropped when the channel isn't drained
+// fast enough, so that a slow watcher never holds up the pool.
+func (mp *MessagePool) Watch(ctx context.Context, match func(*types.SignedMessage) bool) <-chan *types.SignedMessage {
+	out := make(chan *types.SignedMessage, 20)
+	sub := mp.changes.Sub(localUpdates)
+
+	go func() {
+		defer mp.changes.Unsub(sub)
+		defer close(out)
+
+		var dropped int
+		for {
+			select {
+			case u := <-sub:
+				upd := u.(api.MpoolUpdate)
+				if upd.Type != api.MpoolAdd || !match(upd.Message) {
+					continue
+				}
+				select {
+				case out <- upd.Message:
+					if dropped > 0 {
+						log.Warnw("mpool watcher was too slow, dropped messages", "dropped", dropped)
+						dropped = 0
+					}
+				default:
+					dropped++
+				}
+			case <-ctx.Done():
+				return
+			case <-mp.closer:
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
 func (mp *MessagePool) loadLocal(ctx context.Context) error {
 	res, err := mp.localMsgs.Query(ctx, query.Query{})
 	if err != nil {
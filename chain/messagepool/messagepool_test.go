@@ -40,6 +40,8 @@ type testMpoolAPI struct {
 	bmsgs      map[cid.Cid][]*types.SignedMessage
 	statenonce map[address.Address]uint64
 	balance    map[address.Address]types.BigInt
+	// noActor are addresses without an actor on chain
+	noActor map[address.Address]struct{}
 
 	tipsets []*types.TipSet
 
@@ -122,6 +124,10 @@ func (tma *testMpoolAPI) PubSubPublish(string, []byte) error {
 }
 
 func (tma *testMpoolAPI) GetActorBefore(addr address.Address, ts *types.TipSet) (*types.Actor, error) {
+	if _, ok := tma.noActor[addr]; ok {
+		return nil, types.ErrActorNotFound
+	}
+
 	balance, ok := tma.balance[addr]
 	if !ok {
 		balance = types.NewInt(1000e6)
@@ -290,6 +296,85 @@ func TestMessagePool(t *testing.T) {
 	assertNonce(t, mp, sender, 2)
 }
 
+func TestGetNonces(t *testing.T) {
+	tma := newTestMpoolAPI()
+
+	w, err := wallet.NewWallet(wallet.NewMemKeyStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ds := datastore.NewMapDatastore()
+
+	mp, err := New(context.Background(), tma, ds, filcns.DefaultUpgradeSchedule(), "mptest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var senders []address.Address
+	for i := 0; i < 3; i++ {
+		a, err := w.WalletNew(context.Background(), types.KTSecp256k1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		senders = append(senders, a)
+	}
+	idle, pending, gap := senders[0], senders[1], senders[2]
+	unknown := mock.Address(1002)
+	tma.noActor = map[address.Address]struct{}{unknown: {}}
+	target := mock.Address(1001)
+
+	tma.setStateNonce(idle, 7)
+	tma.setStateNonce(pending, 3)
+	tma.setStateNonce(gap, 1)
+
+	assertNonces := func(addrs []address.Address, expected ...uint64) {
+		t.Helper()
+		n, err := mp.GetNonces(context.Background(), addrs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, expected, n)
+
+		// the batch agrees with the nonce of each address
+		for i, a := range addrs {
+			if _, ok := tma.noActor[a]; ok {
+				continue
+			}
+			assertNonce(t, mp, a, expected[i])
+		}
+	}
+
+	all := []address.Address{idle, pending, unknown, gap}
+	assertNonces(all, 7, 3, 0, 1)
+
+	// pending messages move the next nonce past them
+	for i := uint64(3); i < 6; i++ {
+		mustAdd(t, mp, mock.MkMessage(pending, target, i, w))
+	}
+	assertNonces(all, 7, 6, 0, 1)
+
+	// a pending message after a nonce gap doesn't fill the gap
+	mustAdd(t, mp, mock.MkMessage(gap, target, 2, w))
+	assertNonces(all, 7, 6, 0, 1)
+	mustAdd(t, mp, mock.MkMessage(gap, target, 1, w))
+	assertNonces(all, 7, 6, 0, 3)
+
+	// when pending messages are included on chain the next nonce doesn't change
+	a := tma.nextBlock()
+	tma.setBlockMessages(a, mock.MkMessage(pending, target, 3, w), mock.MkMessage(pending, target, 4, w))
+	tma.applyBlock(t, a)
+	assertNonces(all, 7, 6, 0, 3)
+
+	// duplicates and empty batches are fine
+	assertNonces([]address.Address{pending, pending}, 6, 6)
+	n, err := mp.GetNonces(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, n)
+}
+
 func TestCheckMessageBig(t *testing.T) {
 	tma := newTestMpoolAPI()
 
@@ -356,6 +441,57 @@ func TestCheckMessageBig(t *testing.T) {
 	}
 }
 
+func TestCheckMessageBigRaisedLimit(t *testing.T) {
+	tma := newTestMpoolAPI()
+
+	w, err := wallet.NewWallet(wallet.NewMemKeyStore())
+	assert.NoError(t, err)
+
+	from, err := w.WalletNew(context.Background(), types.KTSecp256k1)
+	assert.NoError(t, err)
+
+	tma.setBalance(from, 1000e9)
+
+	ds := datastore.NewMapDatastore()
+
+	_, err = NewWithMaxMessageSize(context.Background(), tma, ds, filcns.DefaultUpgradeSchedule(), "mptest", nil, MaxMessageSize-1)
+	assert.Error(t, err)
+
+	mp, err := NewWithMaxMessageSize(context.Background(), tma, ds, filcns.DefaultUpgradeSchedule(), "mptest", nil, 256<<10)
+	assert.NoError(t, err)
+	assert.Equal(t, 256<<10, mp.MaxMessageSize())
+
+	to := mock.Address(1001)
+
+	mkMsg := func(nonce uint64, size int) *types.SignedMessage {
+		msg := &types.Message{
+			To:         to,
+			From:       from,
+			Value:      types.NewInt(1),
+			Nonce:      nonce,
+			GasLimit:   1000000000,
+			GasFeeCap:  types.NewInt(100),
+			GasPremium: types.NewInt(1),
+			Params:     make([]byte, size),
+		}
+
+		sig, err := w.WalletSign(context.TODO(), from, msg.Cid().Bytes(), api.MsgMeta{})
+		if err != nil {
+			panic(err)
+		}
+		return &types.SignedMessage{
+			Message:   *msg,
+			Signature: *sig,
+		}
+	}
+
+	// messages over the default limit fit the raised one
+	mustAdd(t, mp, mkMsg(0, 128<<10))
+
+	err = mp.Add(context.TODO(), mkMsg(1, 256<<10))
+	assert.ErrorIs(t, err, ErrMessageTooBig)
+}
+
 func TestMessagePoolMessagesInEachBlock(t *testing.T) {
 	tma := newTestMpoolAPI()
 
@@ -1139,3 +1275,94 @@ func TestCapGasFee(t *testing.T) {
 		assert.Equal(t, msg.GasPremium.Int.Int64(), int64(100_000))
 	})
 }
+
+func TestPendingTo(t *testing.T) {
+	tma := newTestMpoolAPI()
+
+	w, err := wallet.NewWallet(wallet.NewMemKeyStore())
+	require.NoError(t, err)
+
+	mp, err := New(context.Background(), tma, datastore.NewMapDatastore(), filcns.DefaultUpgradeSchedule(), "mptest", nil)
+	require.NoError(t, err)
+
+	var senders []address.Address
+	for i := 0; i < 2; i++ {
+		s, err := w.WalletNew(context.Background(), types.KTSecp256k1)
+		require.NoError(t, err)
+		tma.setBalance(s, 1000e9)
+		senders = append(senders, s)
+	}
+	target, other := mock.Address(1001), mock.Address(1002)
+
+	// added out of order, and with a message to another recipient in between
+	mustAdd(t, mp, mock.MkMessage(senders[1], target, 0, w))
+	mustAdd(t, mp, mock.MkMessage(senders[0], target, 0, w))
+	mustAdd(t, mp, mock.MkMessage(senders[0], other, 1, w))
+	mustAdd(t, mp, mock.MkMessage(senders[0], target, 2, w))
+	mustAdd(t, mp, mock.MkMessage(senders[1], target, 1, w))
+
+	pending, _ := mp.PendingTo(context.Background(), target)
+	require.Len(t, pending, 4)
+
+	first, second := senders[0], senders[1]
+	if second.String() < first.String() {
+		first, second = second, first
+	}
+	var got []string
+	for _, m := range pending {
+		require.Equal(t, target, m.Message.To)
+		got = append(got, fmt.Sprintf("%s/%d", m.Message.From, m.Message.Nonce))
+	}
+	expNonces := map[address.Address][]uint64{senders[0]: {0, 2}, senders[1]: {0, 1}}
+	var exp []string
+	for _, s := range []address.Address{first, second} {
+		for _, n := range expNonces[s] {
+			exp = append(exp, fmt.Sprintf("%s/%d", s, n))
+		}
+	}
+	require.Equal(t, exp, got)
+
+	pending, _ = mp.PendingTo(context.Background(), other, mock.Address(1003))
+	require.Len(t, pending, 1)
+}
+
+func TestWatch(t *testing.T) {
+	tma := newTestMpoolAPI()
+
+	w, err := wallet.NewWallet(wallet.NewMemKeyStore())
+	require.NoError(t, err)
+
+	mp, err := New(context.Background(), tma, datastore.NewMapDatastore(), filcns.DefaultUpgradeSchedule(), "mptest", nil)
+	require.NoError(t, err)
+
+	sender, err := w.WalletNew(context.Background(), types.KTSecp256k1)
+	require.NoError(t, err)
+	tma.setBalance(sender, 1000e9)
+	target, other := mock.Address(1001), mock.Address(1002)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	watched := mp.Watch(ctx, func(sm *types.SignedMessage) bool {
+		return sm.Message.To == target
+	})
+
+	// nothing reads the watch channel, adding messages must not block
+	for i := uint64(0); i < 100; i++ {
+		to := target
+		if i%2 == 1 {
+			to = other
+		}
+		mustAdd(t, mp, mock.MkMessage(sender, to, i, w))
+	}
+
+	// the buffered messages are delivered, the rest were dropped
+	for i := uint64(0); i < 20; i++ {
+		sm := <-watched
+		require.Equal(t, target, sm.Message.To)
+		require.Equal(t, 2*i, sm.Message.Nonce)
+	}
+
+	cancel()
+	for range watched {
+		// drain until the watch is closed
+	}
+}
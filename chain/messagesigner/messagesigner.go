@@ -7,6 +7,8 @@ import (
 	"sync"
 
 	"github.com/google/uuid"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/namespace"
 	logging "github.com/ipfs/go-log/v2"
@@ -25,6 +27,10 @@ import (
 const dsKeyActorNonce = "ActorNextNonce"
 const dsKeyMsgUUIDSet = "MsgUuidSet"
 
+// autoBumpCacheSize is the number of recently signed messages marked for automatic fee bumping
+// the signer remembers
+const autoBumpCacheSize = 4096
+
 var log = logging.Logger("messagesigner")
 
 type MsgSigner interface {
@@ -42,14 +48,20 @@ type MessageSigner struct {
 	lk     sync.Mutex
 	mpool  messagepool.MpoolNonceAPI
 	ds     datastore.Batching
+
+	// autoBump holds the CIDs of recently signed and pushed messages marked with
+	// MessageSendSpec.AutoBump
+	autoBump *lru.Cache[cid.Cid, struct{}]
 }
 
 func NewMessageSigner(wallet api.Wallet, mpool messagepool.MpoolNonceAPI, ds dtypes.MetadataDS) *MessageSigner {
 	ds = namespace.Wrap(ds, datastore.NewKey("/message-signer/"))
+	autoBump, _ := lru.New[cid.Cid, struct{}](autoBumpCacheSize)
 	return &MessageSigner{
-		wallet: wallet,
-		mpool:  mpool,
-		ds:     ds,
+		wallet:   wallet,
+		mpool:    mpool,
+		ds:       ds,
+		autoBump: autoBump,
 	}
 }
 
@@ -101,10 +113,20 @@ func (ms *MessageSigner) SignMessage(ctx context.Context, msg *types.Message, sp
 	if err := ms.SaveNonce(ctx, msg.From, nonce); err != nil {
 		return nil, xerrors.Errorf("failed to save nonce: %w", err)
 	}
+	if spec != nil && spec.AutoBump {
+		ms.autoBump.Add(smsg.Cid(), struct{}{})
+	}
 
 	return smsg, nil
 }
 
+// AutoBumpMarked returns whether the signed message with the given CID was recently signed and
+// pushed by the signer with MessageSendSpec.AutoBump set. The marks are only kept in memory, so
+// they are lost when the node restarts.
+func (ms *MessageSigner) AutoBumpMarked(c cid.Cid) bool {
+	return ms.autoBump.Contains(c)
+}
+
 func (ms *MessageSigner) GetSignedMessage(ctx context.Context, uuid uuid.UUID) (*types.SignedMessage, error) {
 
 	key := datastore.KeyWithNamespaces([]string{dsKeyMsgUUIDSet, uuid.String()})
@@ -12,6 +12,7 @@ import (
 
 	"github.com/filecoin-project/go-address"
 
+	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/chain/messagepool"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/wallet"
@@ -201,3 +202,32 @@ func TestMessageSignerSignMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageSignerAutoBumpMarked(t *testing.T) {
+	ctx := context.Background()
+
+	w, _ := wallet.NewWallet(wallet.NewMemKeyStore())
+	from, err := w.WalletNew(ctx, types.KTSecp256k1)
+	require.NoError(t, err)
+	to, err := w.WalletNew(ctx, types.KTSecp256k1)
+	require.NoError(t, err)
+
+	ms := NewMessageSigner(w, newMockMpool(), ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	ok := func(*types.SignedMessage) error { return nil }
+
+	marked, err := ms.SignMessage(ctx, &types.Message{To: to, From: from}, &api.MessageSendSpec{AutoBump: true}, ok)
+	require.NoError(t, err)
+	unmarked, err := ms.SignMessage(ctx, &types.Message{To: to, From: from}, &api.MessageSendSpec{}, ok)
+	require.NoError(t, err)
+	noSpec, err := ms.SignMessage(ctx, &types.Message{To: to, From: from}, nil, ok)
+	require.NoError(t, err)
+	_, err = ms.SignMessage(ctx, &types.Message{To: to, From: from}, &api.MessageSendSpec{AutoBump: true}, func(*types.SignedMessage) error {
+		return xerrors.Errorf("push failed")
+	})
+	require.Error(t, err)
+
+	require.True(t, ms.AutoBumpMarked(marked.Cid()))
+	require.False(t, ms.AutoBumpMarked(unmarked.Cid()))
+	require.False(t, ms.AutoBumpMarked(noSpec.Cid()))
+	require.Equal(t, 1, ms.autoBump.Len())
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	stdbig "math/big"
 	"os"
 
 	"github.com/ipfs/go-cid"
@@ -17,6 +18,7 @@ import (
 	"github.com/filecoin-project/go-state-types/network"
 
 	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/build/buildconstants"
 	"github.com/filecoin-project/lotus/chain/actors/builtin"
 	"github.com/filecoin-project/lotus/chain/actors/builtin/market"
 	"github.com/filecoin-project/lotus/chain/actors/builtin/miner"
@@ -405,6 +407,72 @@ func MinerGetBaseInfo(ctx context.Context, sm *StateManager, bcs beacon.Schedule
 	}, nil
 }
 
+// MinerWinEligibility returns the eligibility of the miner to win blocks at the round on top of
+// the base tipset, from the same lookback state as MinerGetBaseInfo.
+func MinerWinEligibility(ctx context.Context, sm *StateManager, maddr address.Address, ts *types.TipSet, round abi.ChainEpoch) (*api.WinEligibility, error) {
+	lbts, lbst, err := GetLookbackTipSetForRound(ctx, sm, ts, round)
+	if err != nil {
+		return nil, xerrors.Errorf("getting lookback miner actor state: %w", err)
+	}
+
+	out := &api.WinEligibility{
+		Epoch:          round,
+		BaseTipSet:     ts.Key(),
+		LookbackHeight: lbts.Height(),
+		MinerPower:     big.Zero(),
+		NetworkPower:   big.Zero(),
+	}
+
+	act, err := sm.LoadActorRaw(ctx, maddr, lbst)
+	if errors.Is(err, types.ErrActorNotFound) {
+		// the miner was created after the lookback, it can't win yet
+		if _, err := sm.LoadActor(ctx, maddr, ts); err != nil {
+			return nil, xerrors.Errorf("loading miner in current state: %w", err)
+		}
+		return out, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load miner actor: %w", err)
+	}
+
+	mas, err := miner.Load(sm.cs.ActorStore(ctx), act)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load miner actor state: %w", err)
+	}
+	active, err := miner.AllPartSectors(mas, miner.Partition.ActiveSectors)
+	if err != nil {
+		return nil, xerrors.Errorf("get active sectors: %w", err)
+	}
+	if out.ActiveSectors, err = active.Count(); err != nil {
+		return nil, xerrors.Errorf("failed to count bits: %w", err)
+	}
+
+	mpow, tpow, _, err := GetPowerRaw(ctx, sm, lbst, maddr)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get power: %w", err)
+	}
+	out.MinerPower = mpow.QualityAdjPower
+	out.NetworkPower = tpow.QualityAdjPower
+
+	if out.Eligible, err = MinerEligibleToMine(ctx, sm, maddr, ts, lbts); err != nil {
+		return nil, xerrors.Errorf("determining miner eligibility: %w", err)
+	}
+
+	setWinExpectation(out)
+	return out, nil
+}
+
+// setWinExpectation sets the share of the network power and the expected number of wins of an
+// eligible miner with sectors to prove; they stay zero for other miners.
+func setWinExpectation(out *api.WinEligibility) {
+	if !out.Eligible || out.ActiveSectors == 0 || out.NetworkPower.IsZero() {
+		return
+	}
+	out.PowerFraction, _ = new(stdbig.Rat).SetFrac(out.MinerPower.Int, out.NetworkPower.Int).Float64()
+	out.ExpectedWinsPerEpoch = out.PowerFraction * float64(buildconstants.BlocksPerEpoch)
+	out.ExpectedWinsPerDay = out.ExpectedWinsPerEpoch * float64(builtin.EpochsInDay)
+}
+
 func minerHasMinPower(ctx context.Context, sm *StateManager, addr address.Address, ts *types.TipSet) (bool, error) {
 	pact, err := sm.LoadActor(ctx, power.Address, ts)
 	if err != nil {
@@ -457,30 +525,37 @@ func MinerEligibleToMine(ctx context.Context, sm *StateManager, addr address.Add
 		return false, err
 	}
 
-	// Non-empty power claim.
-	if claim, found, err := pstate.MinerPower(addr); err != nil {
+	claim, found, err := pstate.MinerPower(addr)
+	if err != nil {
 		return false, err
-	} else if !found {
+	}
+	debt, err := mstate.FeeDebt()
+	if err != nil {
 		return false, err
-	} else if claim.QualityAdjPower.LessThanEqual(big.Zero()) {
+	}
+	mInfo, err := mstate.Info()
+	if err != nil {
 		return false, err
 	}
 
-	// No fee debt.
-	if debt, err := mstate.FeeDebt(); err != nil {
-		return false, err
-	} else if !debt.IsZero() {
-		return false, err
+	return minerElectable(claim, found, debt, mInfo.ConsensusFaultElapsed, baseTs.Height()), nil
+}
+
+// minerElectable tells whether a miner meeting the consensus minimum power can be elected on top
+// of a tipset at the base height.
+func minerElectable(claim power.Claim, found bool, feeDebt abi.TokenAmount, consensusFaultElapsed, baseHeight abi.ChainEpoch) bool {
+	// Non-empty power claim.
+	if !found || claim.QualityAdjPower.LessThanEqual(big.Zero()) {
+		return false
 	}
 
-	// No active consensus faults.
-	if mInfo, err := mstate.Info(); err != nil {
-		return false, err
-	} else if baseTs.Height() <= mInfo.ConsensusFaultElapsed {
-		return false, nil
+	// No fee debt.
+	if !feeDebt.IsZero() {
+		return false
 	}
 
-	return true, nil
+	// No active consensus faults.
+	return baseHeight > consensusFaultElapsed
 }
 
 func (sm *StateManager) GetPaychState(ctx context.Context, addr address.Address, ts *types.TipSet) (*types.Actor, paych.State, error) {
@@ -0,0 +1,161 @@
+package stmgr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/build/buildconstants"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/power"
+)
+
+func TestWinEligibility(t *testing.T) {
+	const tib = 1 << 40
+	base := abi.ChainEpoch(1000)
+	blocksPerEpoch := float64(buildconstants.BlocksPerEpoch)
+
+	tests := []struct {
+		name string
+
+		hasMinPower           bool
+		claim                 *power.Claim // nil when the miner has no claim
+		feeDebt               abi.TokenAmount
+		consensusFaultElapsed abi.ChainEpoch
+		activeSectors         uint64
+		minerPower            abi.StoragePower
+		networkPower          abi.StoragePower
+
+		eligible      bool
+		powerFraction float64
+	}{
+		{
+			name:          "eligible",
+			hasMinPower:   true,
+			claim:         &power.Claim{QualityAdjPower: big.NewInt(10 * tib)},
+			activeSectors: 10,
+			minerPower:    big.NewInt(10 * tib),
+			networkPower:  big.NewInt(100 * tib),
+			eligible:      true,
+			powerFraction: 0.1,
+		},
+		{
+			name:          "eligible, whole network",
+			hasMinPower:   true,
+			claim:         &power.Claim{QualityAdjPower: big.NewInt(4 * tib)},
+			activeSectors: 1,
+			minerPower:    big.NewInt(4 * tib),
+			networkPower:  big.NewInt(4 * tib),
+			eligible:      true,
+			powerFraction: 1,
+		},
+		{
+			name:                  "consensus fault elapsed",
+			hasMinPower:           true,
+			claim:                 &power.Claim{QualityAdjPower: big.NewInt(tib)},
+			consensusFaultElapsed: base - 1,
+			activeSectors:         1,
+			minerPower:            big.NewInt(tib),
+			networkPower:          big.NewInt(4 * tib),
+			eligible:              true,
+			powerFraction:         0.25,
+		},
+		{
+			name:          "below minimum power",
+			hasMinPower:   false,
+			claim:         &power.Claim{QualityAdjPower: big.NewInt(tib)},
+			activeSectors: 1,
+			minerPower:    big.NewInt(tib),
+			networkPower:  big.NewInt(100 * tib),
+		},
+		{
+			name:                  "slashed, active consensus fault",
+			hasMinPower:           true,
+			claim:                 &power.Claim{QualityAdjPower: big.NewInt(10 * tib)},
+			consensusFaultElapsed: base,
+			activeSectors:         10,
+			minerPower:            big.NewInt(10 * tib),
+			networkPower:          big.NewInt(100 * tib),
+		},
+		{
+			name:          "fee debt",
+			hasMinPower:   true,
+			claim:         &power.Claim{QualityAdjPower: big.NewInt(10 * tib)},
+			feeDebt:       big.NewInt(1),
+			activeSectors: 10,
+			minerPower:    big.NewInt(10 * tib),
+			networkPower:  big.NewInt(100 * tib),
+		},
+		{
+			name:         "no power claim",
+			hasMinPower:  true,
+			minerPower:   big.Zero(),
+			networkPower: big.NewInt(100 * tib),
+		},
+		{
+			name:         "zero power claim",
+			hasMinPower:  true,
+			claim:        &power.Claim{QualityAdjPower: big.Zero()},
+			minerPower:   big.Zero(),
+			networkPower: big.NewInt(100 * tib),
+		},
+		{
+			// eligible for election, but without sectors to prove it can't win
+			name:          "no sectors",
+			hasMinPower:   true,
+			claim:         &power.Claim{QualityAdjPower: big.NewInt(10 * tib)},
+			activeSectors: 0,
+			minerPower:    big.NewInt(10 * tib),
+			networkPower:  big.NewInt(100 * tib),
+			eligible:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			feeDebt := tc.feeDebt
+			if feeDebt.Nil() {
+				feeDebt = big.Zero()
+			}
+			var claim power.Claim
+			if tc.claim != nil {
+				claim = *tc.claim
+			}
+
+			// as MinerEligibleToMine after network version 3
+			eligible := tc.hasMinPower && minerElectable(claim, tc.claim != nil, feeDebt, tc.consensusFaultElapsed, base)
+			require.Equal(t, tc.eligible, eligible)
+
+			out := &api.WinEligibility{
+				Eligible:      eligible,
+				ActiveSectors: tc.activeSectors,
+				MinerPower:    tc.minerPower,
+				NetworkPower:  tc.networkPower,
+			}
+			setWinExpectation(out)
+
+			require.InDelta(t, tc.powerFraction, out.PowerFraction, 1e-12)
+			require.InDelta(t, tc.powerFraction*blocksPerEpoch, out.ExpectedWinsPerEpoch, 1e-9)
+			require.InDelta(t, tc.powerFraction*blocksPerEpoch*builtin.EpochsInDay, out.ExpectedWinsPerDay, 1e-6)
+		})
+	}
+
+	// known values: 10% of the network power wins half a block per epoch with five blocks per
+	// epoch, which is 1440 blocks a day
+	if buildconstants.BlocksPerEpoch == 5 {
+		out := &api.WinEligibility{Eligible: true, ActiveSectors: 1, MinerPower: big.NewInt(10 * tib), NetworkPower: big.NewInt(100 * tib)}
+		setWinExpectation(out)
+		require.InDelta(t, 0.5, out.ExpectedWinsPerEpoch, 1e-12)
+		require.InDelta(t, 1440, out.ExpectedWinsPerDay, 1e-9)
+	}
+
+	// no network power, e.g. before any miner has proven its sectors
+	out := &api.WinEligibility{Eligible: true, ActiveSectors: 1, MinerPower: big.Zero(), NetworkPower: big.Zero()}
+	setWinExpectation(out)
+	require.Zero(t, out.PowerFraction)
+	require.Zero(t, out.ExpectedWinsPerDay)
+}
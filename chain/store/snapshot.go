@@ -12,20 +12,24 @@ import (
 
 	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
 	format "github.com/ipfs/go-ipld-format"
 	"github.com/ipld/go-car"
 	carutil "github.com/ipld/go-car/util"
 	carv2 "github.com/ipld/go-car/v2"
 	"github.com/multiformats/go-multicodec"
+	mh "github.com/multiformats/go-multihash"
 	cbg "github.com/whyrusleeping/cbor-gen"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/xerrors"
 
+	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 
 	bstore "github.com/filecoin-project/lotus/blockstore"
 	"github.com/filecoin-project/lotus/chain/actors/builtin"
 	"github.com/filecoin-project/lotus/chain/actors/policy"
+	"github.com/filecoin-project/lotus/chain/state"
 	"github.com/filecoin-project/lotus/chain/types"
 )
 
@@ -60,6 +64,93 @@ func (cs *ChainStore) Export(ctx context.Context, ts *types.TipSet, inclRecentRo
 	})
 }
 
+// ExportHeaders writes a CAR of the block headers and messages of the chain from the given tipset
+// back to the tail height, without state trees or receipts. The chain structure and the messages
+// can be reconstructed from it, but not the state at any height, nor the message receipts. When
+// the tail is genesis the CAR also includes the genesis state and can be imported, and the state
+// at other heights must be obtained by executing the chain from the genesis state.
+func (cs *ChainStore) ExportHeaders(ctx context.Context, ts *types.TipSet, tail abi.ChainEpoch, w io.Writer) error {
+	if tail < 0 || tail > ts.Height() {
+		return xerrors.Errorf("tail height %d out of range [0, %d]", tail, ts.Height())
+	}
+
+	h := &car.CarHeader{
+		Roots:   ts.Cids(),
+		Version: 1,
+	}
+	if err := car.WriteHeader(h, w); err != nil {
+		return xerrors.Errorf("failed to write car header: %s", err)
+	}
+
+	unionBs := cs.UnionStore()
+	write := func(c cid.Cid) error {
+		blk, err := unionBs.Get(ctx, c)
+		if err != nil {
+			return xerrors.Errorf("writing object to car, bs.Get: %w", err)
+		}
+		if err := carutil.LdWrite(w, c.Bytes(), blk.RawData()); err != nil {
+			return xerrors.Errorf("failed to write block to car output: %w", err)
+		}
+		return nil
+	}
+
+	seen := cid.NewSet()
+	for ts.Height() >= tail {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if ts.Height()%builtin.EpochsInDay == 0 {
+			log.Infow("export headers", "height", ts.Height())
+		}
+
+		for _, b := range ts.Blocks() {
+			if err := write(b.Cid()); err != nil {
+				return err
+			}
+
+			var out []cid.Cid
+			if seen.Visit(b.Messages) {
+				var err error
+				out, err = recurseLinks(ctx, cs.chainBlockstore, seen, b.Messages, []cid.Cid{b.Messages})
+				if err != nil {
+					return xerrors.Errorf("recursing messages failed: %w", err)
+				}
+			}
+			if ts.Height() == 0 {
+				// include the genesis block, and the genesis state to execute the chain from
+				out = append(out, b.Parents...)
+				if seen.Visit(b.ParentStateRoot) {
+					scids, err := recurseLinks(ctx, cs.stateBlockstore, seen, b.ParentStateRoot, []cid.Cid{b.ParentStateRoot})
+					if err != nil {
+						return xerrors.Errorf("recursing genesis state failed: %w", err)
+					}
+					out = append(out, scids...)
+				}
+			}
+
+			for _, c := range out {
+				if multicodec.Code(c.Prefix().MhType) == multicodec.Identity {
+					continue
+				}
+				if err := write(c); err != nil {
+					return err
+				}
+			}
+		}
+
+		if ts.Height() == 0 {
+			break
+		}
+		pts, err := cs.LoadTipSet(ctx, ts.Parents())
+		if err != nil {
+			return xerrors.Errorf("loading parent tipset of %s: %w", ts.Key(), err)
+		}
+		ts = pts
+	}
+
+	return nil
+}
+
 func (cs *ChainStore) Import(ctx context.Context, r io.Reader) (head *types.TipSet, genesis *types.BlockHeader, err error) {
 	// TODO: writing only to the state blockstore is incorrect.
 	//  At this time, both the state and chain blockstores are backed by the
@@ -784,3 +875,132 @@ func recurseLinks(ctx context.Context, bs bstore.Blockstore, walked *cid.Set, ro
 
 	return in, rerr
 }
+
+// ExportActorState writes a CAR of the state of a single actor in the parent state of the given
+// tipset. The CAR is rooted at the state root, and contains the blocks on the path from the state
+// root to the actor in the state tree, and all blocks reachable from the head of the actor's
+// state, so that the actor can be loaded from the state root like from a full state tree.
+// Other actors, the history of the actor and its code are not included.
+func (cs *ChainStore) ExportActorState(ctx context.Context, ts *types.TipSet, addr address.Address, w io.Writer) error {
+	root := ts.ParentState()
+	unionBs := cs.UnionStore()
+
+	resolveTree, err := state.LoadStateTree(cbor.NewCborStore(unionBs), root)
+	if err != nil {
+		return xerrors.Errorf("loading state tree: %w", err)
+	}
+	// resolve the address first, so that the path to the init actor state is not recorded
+	id, err := resolveTree.LookupIDAddress(addr)
+	if err != nil {
+		return xerrors.Errorf("resolving address %s: %w", addr, err)
+	}
+
+	// load the actor from a fresh state tree, recording the blocks on the path to it
+	rec := &recordingBlockstore{Blockstore: unionBs, seen: cid.NewSet()}
+	tree, err := state.LoadStateTree(cbor.NewCborStore(rec), root)
+	if err != nil {
+		return xerrors.Errorf("loading state tree: %w", err)
+	}
+	act, err := tree.GetActor(id)
+	if err != nil {
+		return xerrors.Errorf("loading actor %s: %w", addr, err)
+	}
+
+	h := &car.CarHeader{
+		Roots:   []cid.Cid{root},
+		Version: 1,
+	}
+	if err := car.WriteHeader(h, w); err != nil {
+		return xerrors.Errorf("failed to write car header: %s", err)
+	}
+
+	write := func(c cid.Cid) ([]byte, error) {
+		blk, err := unionBs.Get(ctx, c)
+		if err != nil {
+			return nil, xerrors.Errorf("writing object to car, bs.Get: %w", err)
+		}
+		if err := carutil.LdWrite(w, c.Bytes(), blk.RawData()); err != nil {
+			return nil, xerrors.Errorf("failed to write block to car output: %w", err)
+		}
+		return blk.RawData(), nil
+	}
+
+	for _, c := range rec.order {
+		if _, err := write(c); err != nil {
+			return err
+		}
+	}
+
+	walked := rec.seen
+	todo := []cid.Cid{act.Head}
+	walked.Add(act.Head)
+	for len(todo) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		c := todo[len(todo)-1]
+		todo = todo[:len(todo)-1]
+
+		if c.Prefix().MhType == mh.IDENTITY {
+			continue // inlined in the cid
+		}
+
+		data, err := write(c)
+		if err != nil {
+			return err
+		}
+
+		if multicodec.Code(c.Prefix().Codec) != multicodec.DagCbor {
+			continue
+		}
+		err = cbg.ScanForLinks(bytes.NewReader(data), func(l cid.Cid) {
+			if walked.Visit(l) {
+				todo = append(todo, l)
+			}
+		})
+		if err != nil {
+			return xerrors.Errorf("scanning for links failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// recordingBlockstore records the CIDs of the blocks read through it, in the order they are
+// first read.
+type recordingBlockstore struct {
+	bstore.Blockstore
+
+	lk    sync.Mutex
+	seen  *cid.Set
+	order []cid.Cid
+}
+
+func (r *recordingBlockstore) record(c cid.Cid) {
+	if c.Prefix().MhType == mh.IDENTITY {
+		return
+	}
+
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	if r.seen.Visit(c) {
+		r.order = append(r.order, c)
+	}
+}
+
+func (r *recordingBlockstore) Get(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	blk, err := r.Blockstore.Get(ctx, c)
+	if err == nil {
+		r.record(c)
+	}
+	return blk, err
+}
+
+func (r *recordingBlockstore) View(ctx context.Context, c cid.Cid, callback func([]byte) error) error {
+	err := r.Blockstore.View(ctx, c, callback)
+	if err == nil {
+		r.record(c)
+	}
+	return err
+}
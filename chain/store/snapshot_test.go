@@ -0,0 +1,202 @@
+package store_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	syncds "github.com/ipfs/go-datastore/sync"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/ipld/go-car"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	blockadt "github.com/filecoin-project/specs-actors/actors/util/adt"
+
+	"github.com/filecoin-project/lotus/blockstore"
+	"github.com/filecoin-project/lotus/chain/consensus/filcns"
+	"github.com/filecoin-project/lotus/chain/state"
+	"github.com/filecoin-project/lotus/chain/store"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+)
+
+func TestExportActorState(t *testing.T) {
+	ctx := context.Background()
+
+	bs := blockstore.NewMemorySync()
+	cs := store.NewChainStore(bs, bs, syncds.MutexWrap(datastore.NewMapDatastore()), filcns.Weight, nil)
+	defer cs.Close() //nolint:errcheck
+
+	cst := cbor.NewCborStore(bs)
+	tree, err := state.NewStateTree(cst, types.StateTreeVersion5)
+	require.NoError(t, err)
+
+	put := func(nonce uint64) cid.Cid {
+		c, err := cst.Put(ctx, &types.Message{From: mock.Address(1), To: mock.Address(2), Value: big.Zero(), GasFeeCap: big.Zero(), GasPremium: big.Zero(), Nonce: nonce})
+		require.NoError(t, err)
+		return c
+	}
+
+	// the state of the exported actor links to two more objects
+	leaf1, leaf2 := put(0), put(1)
+	head, err := cst.Put(ctx, &types.StateRoot{Version: 1, Actors: leaf1, Info: leaf2})
+	require.NoError(t, err)
+
+	// enough other actors for the state tree to have more than one level
+	target := mock.Address(1000)
+	heads := map[address.Address]cid.Cid{target: head}
+	for i := uint64(1001); i < 1500; i++ {
+		heads[mock.Address(i)] = put(i)
+	}
+	for a, h := range heads {
+		require.NoError(t, tree.SetActor(a, &types.Actor{Code: leaf1, Head: h, Balance: big.Zero()}))
+	}
+	root, err := tree.Flush(ctx)
+	require.NoError(t, err)
+
+	blk := mock.MkBlock(nil, 0, 0)
+	blk.ParentStateRoot = root
+	ts := mock.TipSet(blk)
+
+	var buf bytes.Buffer
+	require.NoError(t, cs.ExportActorState(ctx, ts, target, &buf))
+
+	exported := blockstore.NewMemory()
+	hdr, err := car.LoadCar(ctx, exported, &buf)
+	require.NoError(t, err)
+	require.Equal(t, []cid.Cid{root}, hdr.Roots)
+
+	// the actor and its state can be loaded from the exported state root
+	etree, err := state.LoadStateTree(cbor.NewCborStore(exported), root)
+	require.NoError(t, err)
+	act, err := etree.GetActor(target)
+	require.NoError(t, err)
+	require.Equal(t, head, act.Head)
+
+	var st types.StateRoot
+	require.NoError(t, cbor.NewCborStore(exported).Get(ctx, act.Head, &st))
+	for _, c := range []cid.Cid{leaf1, leaf2} {
+		has, err := exported.Has(ctx, c)
+		require.NoError(t, err)
+		require.True(t, has)
+	}
+
+	// but the state of other actors is not included
+	has, err := exported.Has(ctx, heads[mock.Address(1001)])
+	require.NoError(t, err)
+	require.False(t, has)
+	require.Less(t, len(exported), 20)
+}
+
+func TestExportHeaders(t *testing.T) {
+	ctx := context.Background()
+
+	bs := blockstore.NewMemorySync()
+	cs := store.NewChainStore(bs, bs, syncds.MutexWrap(datastore.NewMapDatastore()), filcns.Weight, nil)
+	defer cs.Close() //nolint:errcheck
+
+	cst := cbor.NewCborStore(bs)
+	adtStore := blockadt.WrapStore(ctx, cst)
+
+	// the state root of the blocks, which is only exported as the genesis state
+	tree, err := state.NewStateTree(cst, types.StateTreeVersion5)
+	require.NoError(t, err)
+	stateRoot, err := tree.Flush(ctx)
+	require.NoError(t, err)
+
+	msgMeta := func(nonce uint64) cid.Cid {
+		mc, err := cs.PutMessage(ctx, &types.Message{From: mock.Address(1), To: mock.Address(2), Value: big.Zero(), GasFeeCap: big.Zero(), GasPremium: big.Zero(), Nonce: nonce})
+		require.NoError(t, err)
+
+		bls := blockadt.MakeEmptyArray(adtStore)
+		mcc := cbg.CborCid(mc)
+		require.NoError(t, bls.Set(0, &mcc))
+		blsRoot, err := bls.Root()
+		require.NoError(t, err)
+		secpRoot, err := blockadt.MakeEmptyArray(adtStore).Root()
+		require.NoError(t, err)
+
+		c, err := cst.Put(ctx, &types.MsgMeta{BlsMessages: blsRoot, SecpkMessages: secpRoot})
+		require.NoError(t, err)
+		return c
+	}
+
+	// even the genesis block has a parent, linking to the genesis object
+	genesisParent, err := cst.Put(ctx, &types.StateRoot{Version: 1, Actors: stateRoot, Info: stateRoot})
+	require.NoError(t, err)
+
+	gen := mock.MkBlock(nil, 0, 0)
+	gen.Parents = []cid.Cid{genesisParent}
+	gen.ParentStateRoot = stateRoot
+	gen.Messages = msgMeta(0)
+	tipsets := []*types.TipSet{mock.TipSet(gen)}
+	for i := uint64(1); i < 10; i++ {
+		blk := mock.MkBlock(tipsets[len(tipsets)-1], 1, i)
+		blk.Messages = msgMeta(i)
+		tipsets = append(tipsets, mock.TipSet(blk))
+	}
+	require.NoError(t, cs.PersistTipsets(ctx, tipsets))
+	head := tipsets[len(tipsets)-1]
+
+	// a range of headers
+	var buf bytes.Buffer
+	require.NoError(t, cs.ExportHeaders(ctx, head, 5, &buf))
+
+	exported := blockstore.NewMemorySync()
+	hdr, err := car.LoadCar(ctx, exported, &buf)
+	require.NoError(t, err)
+	require.Equal(t, head.Cids(), hdr.Roots)
+
+	ecs := store.NewChainStore(exported, exported, syncds.MutexWrap(datastore.NewMapDatastore()), filcns.Weight, nil)
+	defer ecs.Close() //nolint:errcheck
+
+	// the header chain and the messages can be loaded from the head to the tail
+	ts, err := ecs.LoadTipSet(ctx, head.Key())
+	require.NoError(t, err)
+	for {
+		bmsgs, _, err := ecs.MessagesForBlock(ctx, ts.Blocks()[0])
+		require.NoError(t, err)
+		require.Len(t, bmsgs, 1)
+		require.Equal(t, uint64(ts.Height()), bmsgs[0].Nonce)
+
+		if ts.Height() == 5 {
+			break
+		}
+		ts, err = ecs.LoadTipSet(ctx, ts.Parents())
+		require.NoError(t, err)
+	}
+	_, err = ecs.LoadTipSet(ctx, ts.Parents())
+	require.Error(t, err)
+
+	// the state is not included
+	has, err := exported.Has(ctx, stateRoot)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	// when exported back to genesis, the headers can be imported
+	buf.Reset()
+	require.NoError(t, cs.ExportHeaders(ctx, head, 0, &buf))
+
+	ibs := blockstore.NewMemorySync()
+	ics := store.NewChainStore(ibs, ibs, syncds.MutexWrap(datastore.NewMapDatastore()), filcns.Weight, nil)
+	defer ics.Close() //nolint:errcheck
+
+	root, genesis, err := ics.Import(ctx, &buf)
+	require.NoError(t, err)
+	require.True(t, root.Equals(head))
+	require.Equal(t, gen.Cid(), genesis.Cid())
+
+	// with the genesis state to execute the chain from
+	has, err = ibs.Has(ctx, stateRoot)
+	require.NoError(t, err)
+	require.True(t, has)
+	_, err = state.LoadStateTree(cbor.NewCborStore(ibs), genesis.ParentStateRoot)
+	require.NoError(t, err)
+
+	require.Error(t, cs.ExportHeaders(ctx, head, head.Height()+1, &buf))
+}
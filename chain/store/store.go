@@ -547,6 +547,8 @@ func (cs *ChainStore) exceedsForkLength(ctx context.Context, synced, external *t
 
 		// Now check to see if we've walked back to the checkpoint.
 		if synced.Equals(cs.checkpoint) {
+			log.Warnf("refusing to switch to a heavier chain which would revert past the checkpoint %s (height %d)",
+				cs.checkpoint.Key(), cs.checkpoint.Height())
 			return true, nil
 		}
 
@@ -801,14 +803,10 @@ func (cs *ChainStore) removeCheckpoint(ctx context.Context) error {
 // NOTE: Checkpoints cannot revert more than policy.Finality epochs.
 // NOTE: The new checkpoint must already be synced.
 func (cs *ChainStore) SetCheckpoint(ctx context.Context, ts *types.TipSet) error {
-	tskBytes, err := json.Marshal(ts.Key())
-	if err != nil {
-		return err
-	}
-
 	cs.heaviestLk.Lock()
 	defer cs.heaviestLk.Unlock()
 
+	var err error
 	finality := cs.heaviest.Height() - policy.ChainFinality
 	targetChain, currentChain := ts, cs.heaviest
 
@@ -858,6 +856,36 @@ func (cs *ChainStore) SetCheckpoint(ctx context.Context, ts *types.TipSet) error
 	}
 
 	// Finally, set the checkpoint.
+	return cs.writeCheckpoint(ctx, ts)
+}
+
+// SetCheckpointOnHead sets a checkpoint past which the chainstore will not allow forks, like
+// SetCheckpoint, but only if the checkpoint is the current head or one of its ancestors, so that
+// setting it never switches chains.
+func (cs *ChainStore) SetCheckpointOnHead(ctx context.Context, ts *types.TipSet) error {
+	cs.heaviestLk.Lock()
+	defer cs.heaviestLk.Unlock()
+
+	if !cs.heaviest.Equals(ts) {
+		anc, err := cs.IsAncestorOf(ctx, ts, cs.heaviest)
+		if err != nil {
+			return xerrors.Errorf("checkpoint failed: error when walking the current chain: %w", err)
+		}
+		if !anc {
+			return xerrors.Errorf("checkpoint failed: %s (height %d) is not an ancestor of the current head %s (height %d)",
+				ts.Key(), ts.Height(), cs.heaviest.Key(), cs.heaviest.Height())
+		}
+	}
+
+	return cs.writeCheckpoint(ctx, ts)
+}
+
+func (cs *ChainStore) writeCheckpoint(ctx context.Context, ts *types.TipSet) error {
+	tskBytes, err := json.Marshal(ts.Key())
+	if err != nil {
+		return err
+	}
+
 	err = cs.metadataDs.Put(ctx, checkpointKey, tskBytes)
 	if err != nil {
 		return xerrors.Errorf("checkpoint failed: failed to record checkpoint in the datastore: %w", err)
@@ -212,9 +212,36 @@ func fetchCids(
 	return nil
 }
 
+// blockMsgCidSize is the size of a CBOR encoded message CID in a block message
+const blockMsgCidSize = 43
+
+// MinBlocksTopicMaxMessageSize is the lowest allowed size limit of blocks topic messages, which
+// fits the header and the message CIDs of a block with the maximum number of messages.
+const MinBlocksTopicMaxMessageSize = buildconstants.BlockMessageLimit*blockMsgCidSize + 16<<10
+
+// MinMessagesTopicMaxMessageSize is the lowest allowed size limit of messages topic messages, the
+// default maximum size of a message accepted into the message pool.
+const MinMessagesTopicMaxMessageSize = messagepool.MaxMessageSize
+
+// CheckTopicMessageSizes checks that the size limits of the blocks and messages topic messages
+// allow for all blocks and messages valid under the protocol.
+func CheckTopicMessageSizes(blocks, messages int) error {
+	if blocks < MinBlocksTopicMaxMessageSize {
+		return xerrors.Errorf("blocks topic max message size %d is lower than the minimum %d, which fits a block with %d messages",
+			blocks, MinBlocksTopicMaxMessageSize, buildconstants.BlockMessageLimit)
+	}
+	if messages < MinMessagesTopicMaxMessageSize {
+		return xerrors.Errorf("messages topic max message size %d is lower than the minimum %d, the max size of a message",
+			messages, MinMessagesTopicMaxMessageSize)
+	}
+	return nil
+}
+
 type BlockValidator struct {
 	self peer.ID
 
+	maxMsgSize int
+
 	peers *lru.TwoQueueCache[peer.ID, int]
 
 	killThresh int
@@ -228,10 +255,11 @@ type BlockValidator struct {
 	consensus consensus.Consensus
 }
 
-func NewBlockValidator(self peer.ID, chain *store.ChainStore, cns consensus.Consensus, blacklist func(peer.ID)) *BlockValidator {
+func NewBlockValidator(self peer.ID, chain *store.ChainStore, cns consensus.Consensus, maxMsgSize int, blacklist func(peer.ID)) *BlockValidator {
 	p, _ := lru.New2Q[peer.ID, int](4096)
 	return &BlockValidator{
 		self:       self,
+		maxMsgSize: maxMsgSize,
 		peers:      p,
 		killThresh: 10,
 		blacklist:  blacklist,
@@ -268,6 +296,12 @@ func (bv *BlockValidator) Validate(ctx context.Context, pid peer.ID, msg *pubsub
 		}
 	}()
 
+	if len(msg.GetData()) > bv.maxMsgSize {
+		log.Warnf("block message is too large! (%dB)", len(msg.GetData()))
+		recordFailure(ctx, metrics.BlockValidationFailure, "oversize")
+		return pubsub.ValidationIgnore
+	}
+
 	var what string
 	res, what = consensus.ValidateBlockPubsub(ctx, bv.consensus, pid == bv.self, msg)
 	if res == pubsub.ValidationAccept {
@@ -312,15 +346,22 @@ func (brc *blockReceiptCache) add(bcid cid.Cid) int {
 }
 
 type MessageValidator struct {
-	self  peer.ID
-	mpool *messagepool.MessagePool
+	self       peer.ID
+	mpool      *messagepool.MessagePool
+	maxMsgSize int
 }
 
-func NewMessageValidator(self peer.ID, mp *messagepool.MessagePool) *MessageValidator {
-	return &MessageValidator{self: self, mpool: mp}
+func NewMessageValidator(self peer.ID, mp *messagepool.MessagePool, maxMsgSize int) *MessageValidator {
+	return &MessageValidator{self: self, mpool: mp, maxMsgSize: maxMsgSize}
 }
 
 func (mv *MessageValidator) Validate(ctx context.Context, pid peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	if len(msg.GetData()) > mv.maxMsgSize {
+		log.Warnf("message is too large! (%dB)", len(msg.GetData()))
+		recordFailure(ctx, metrics.MessageValidationFailure, "oversize")
+		return pubsub.ValidationIgnore
+	}
+
 	if pid == mv.self {
 		return mv.validateLocalMessage(ctx, msg)
 	}
@@ -408,7 +449,7 @@ func (mv *MessageValidator) validateLocalMessage(ctx context.Context, msg *pubsu
 		return pubsub.ValidationIgnore
 	}
 
-	if m.Size() > messagepool.MaxMessageSize {
+	if m.Size() > mv.mpool.MaxMessageSize() {
 		log.Warnf("local message is too large! (%dB)", m.Size())
 		recordFailure(ctx, metrics.MessageValidationFailure, "oversize")
 		return pubsub.ValidationIgnore
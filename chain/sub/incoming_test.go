@@ -6,10 +6,15 @@ import (
 
 	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/go-address"
 
+	"github.com/filecoin-project/lotus/build/buildconstants"
 	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
 )
 
 type getter struct {
@@ -63,3 +68,26 @@ func TestFetchCidsWithDedup(t *testing.T) {
 		t.Fatalf("there is a nil message: first %p, last %p", res[0], res[len(res)-1])
 	}
 }
+
+func TestTopicMessageSizes(t *testing.T) {
+	require.NoError(t, CheckTopicMessageSizes(MinBlocksTopicMaxMessageSize, MinMessagesTopicMaxMessageSize))
+	require.NoError(t, CheckTopicMessageSizes(4<<20, 1<<20))
+	require.Error(t, CheckTopicMessageSizes(MinBlocksTopicMaxMessageSize-1, MinMessagesTopicMaxMessageSize))
+	require.Error(t, CheckTopicMessageSizes(MinBlocksTopicMaxMessageSize, MinMessagesTopicMaxMessageSize-1))
+
+	// a block with the maximum number of messages fits the minimum limit
+	msg := &types.Message{To: address.TestAddress, From: address.TestAddress, Value: types.NewInt(0), GasFeeCap: types.NewInt(0), GasPremium: types.NewInt(0)}
+	bmsg := &types.BlockMsg{Header: mock.MkBlock(nil, 0, 0)}
+	for i := 0; i < buildconstants.BlockMessageLimit/2; i++ {
+		bmsg.BlsMessages = append(bmsg.BlsMessages, msg.Cid())
+		bmsg.SecpkMessages = append(bmsg.SecpkMessages, msg.Cid())
+	}
+	data, err := bmsg.Serialize()
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(data), MinBlocksTopicMaxMessageSize)
+
+	// oversized messages are ignored before they are validated
+	mv := NewMessageValidator("", nil, MinMessagesTopicMaxMessageSize)
+	res := mv.Validate(context.Background(), "peer", &pubsub.Message{Message: &pb.Message{Data: make([]byte, MinMessagesTopicMaxMessageSize+1)}})
+	require.Equal(t, pubsub.ValidationIgnore, res)
+}
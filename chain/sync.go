@@ -116,6 +116,9 @@ type Syncer struct {
 	tickerCtxCancel context.CancelFunc
 
 	ds dtypes.MetadataDS
+
+	// number of blocks pre-validated in parallel while catching up, see preValidate
+	validationParallelism int
 }
 
 type SyncManagerCtor func(syncFn SyncFunc) SyncManager
@@ -140,7 +143,8 @@ func NewSyncer(ds dtypes.MetadataDS,
 	self peer.ID,
 	beacon beacon.Schedule,
 	gent Genesis,
-	consensus consensus.Consensus) (*Syncer, error) {
+	consensus consensus.Consensus,
+	validationParallelism int) (*Syncer, error) {
 
 	s := &Syncer{
 		ds:             ds,
@@ -156,6 +160,8 @@ func NewSyncer(ds dtypes.MetadataDS,
 		connmgr:        connmgr,
 
 		incoming: pubsub.New(50),
+
+		validationParallelism: validationParallelism,
 	}
 
 	s.syncmgr = syncMgrCtor(s.Sync)
@@ -867,6 +873,8 @@ loop:
 
 	if !ignoreCheckpoint {
 		if chkpt := syncer.store.GetCheckpoint(); chkpt != nil && base.Height() <= chkpt.Height() {
+			log.Warnf("refusing to sync chain %s (height %d): it diverges from the checkpoint %s (height %d)",
+				incoming.Cids(), incoming.Height(), chkpt.Key(), chkpt.Height())
 			for _, b := range incoming.Blocks() {
 				syncer.bad.Add(b.Cid(), NewBadBlockReason(incoming.Cids(), "diverges from checkpoint"))
 			}
@@ -883,6 +891,9 @@ loop:
 	log.Warnf("(fork detected) synced header chain (%s - %d) does not link to our best block (%s - %d)", incoming.Cids(), incoming.Height(), known.Cids(), known.Height())
 	fork, err := syncer.syncFork(ctx, base, known, ignoreCheckpoint)
 	if err != nil {
+		if errors.Is(err, ErrForkCheckpoint) {
+			log.Warnf("refusing to sync fork %s (height %d): it would revert past the checkpoint", incoming.Cids(), incoming.Height())
+		}
 		if errors.Is(err, ErrForkTooLong) || errors.Is(err, ErrForkCheckpoint) {
 			// TODO: we're marking this block bad in the same way that we mark invalid blocks bad. Maybe distinguish?
 			log.Warn("adding forked chain to our bad tipset cache")
@@ -1011,6 +1022,7 @@ func (syncer *Syncer) syncMessagesAndCheckState(ctx context.Context, headers []*
 		}
 
 		stats.Record(ctx, metrics.ChainNodeWorkerHeight.M(int64(fts.TipSet().Height())))
+		stats.Record(ctx, metrics.SyncValidatedTipsets.M(1))
 		ss.SetHeight(fts.TipSet().Height())
 
 		return nil
@@ -1052,6 +1064,13 @@ func (syncer *Syncer) iterFullTipsets(ctx context.Context, headers []*types.TipS
 			return xerrors.Errorf("failed to fetch messages: %w", batchErr)
 		}
 
+		// zip the tipsets of the batch first so that they can be pre-validated while they are
+		// validated in order; a tipset failing to zip is only reported once it's reached
+		var (
+			zipped []*store.FullTipSet
+			bss    []bstore.Blockstore
+			zipErr error
+		)
 		for bsi := 0; bsi < len(bstout); bsi++ {
 			// temp storage so we don't persist data we dont want to
 			bs := bstore.NewMemory()
@@ -1064,21 +1083,38 @@ func (syncer *Syncer) iterFullTipsets(ctx context.Context, headers []*types.TipS
 				log.Warnw("zipping failed", "error", err, "bsi", bsi, "i", i,
 					"height", this.Height(),
 					"next-height", i+batchSize)
-				return xerrors.Errorf("message processing failed: %w", err)
+				zipErr = xerrors.Errorf("message processing failed: %w", err)
+				break
 			}
 
+			zipped = append(zipped, fts)
+			bss = append(bss, bs)
+		}
+
+		pvctx, cancel := context.WithCancel(ctx)
+		syncer.preValidate(pvctx, zipped)
+
+		for bsi, fts := range zipped {
 			if err := cb(ctx, fts); err != nil {
+				cancel()
 				return err
 			}
 
-			if err := persistMessages(ctx, bs, bstip); err != nil {
+			if err := persistMessages(ctx, bss[bsi], bstout[len(bstout)-(bsi+1)]); err != nil {
+				cancel()
 				return err
 			}
 
-			if err := copyBlockstore(ctx, bs, syncer.store.ChainBlockstore()); err != nil {
+			if err := copyBlockstore(ctx, bss[bsi], syncer.store.ChainBlockstore()); err != nil {
+				cancel()
 				return xerrors.Errorf("message processing failed: %w", err)
 			}
 		}
+		cancel()
+
+		if zipErr != nil {
+			return zipErr
+		}
 
 		i -= batchSize
 	}
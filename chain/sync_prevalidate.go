@@ -0,0 +1,76 @@
+package chain
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+
+	"github.com/filecoin-project/lotus/build"
+	"github.com/filecoin-project/lotus/build/buildconstants"
+	"github.com/filecoin-project/lotus/chain/consensus"
+	"github.com/filecoin-project/lotus/chain/store"
+	"github.com/filecoin-project/lotus/metrics"
+)
+
+// preValidationHeadEpochs is the distance to the current epoch within which tipsets are only
+// validated in order. Near the head tipsets arrive one at a time, so there is nothing to verify
+// ahead of them.
+const preValidationHeadEpochs = 20
+
+// preValidate verifies the parts of the blocks of the tipsets, which must be in increasing height
+// order, that don't depend on the state of their parent tipsets, with up to validationParallelism
+// blocks in flight, while the tipsets are validated in order. The state of each tipset depends on
+// the previous one, so only the checks which don't need it run ahead. They are the same checks
+// ValidateBlock runs and pre-validated blocks only skip them there, so the outcome of the sync is
+// the same; blocks failing pre-validation are rejected by ValidateBlock.
+func (syncer *Syncer) preValidate(ctx context.Context, ftss []*store.FullTipSet) {
+	pv, ok := syncer.consensus.(consensus.BlockPreValidator)
+	if !ok || syncer.validationParallelism < 2 {
+		return
+	}
+
+	nearHead := build.Clock.Now().Add(-time.Duration(preValidationHeadEpochs*buildconstants.BlockDelaySecs) * time.Second)
+	for n, fts := range ftss {
+		if time.Unix(int64(fts.TipSet().MinTimestamp()), 0).After(nearHead) {
+			ftss = ftss[:n]
+			break
+		}
+	}
+	if len(ftss) == 0 {
+		return
+	}
+
+	go func() {
+		throttle := make(chan struct{}, syncer.validationParallelism)
+		for _, fts := range ftss {
+			for _, b := range fts.Blocks {
+				select {
+				case throttle <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+
+				go func() {
+					defer func() { <-throttle }()
+					defer func() {
+						// b.Cid() could panic for empty blocks that are used in tests.
+						if rerr := recover(); rerr != nil {
+							log.Debugw("block pre-validation panic", "error", rerr)
+						}
+					}()
+
+					if validated, err := syncer.store.IsBlockValidated(ctx, b.Cid()); err == nil && validated {
+						return
+					}
+
+					if err := pv.PreValidateBlock(ctx, b); err != nil {
+						log.Debugw("block pre-validation failed, it will be fully validated", "block", b.Cid(), "height", b.Header.Height, "error", err)
+						return
+					}
+					stats.Record(ctx, metrics.SyncPreValidatedBlocks.M(1))
+				}()
+			}
+		}
+	}()
+}
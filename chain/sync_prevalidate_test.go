@@ -0,0 +1,80 @@
+package chain
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/lotus/blockstore"
+	"github.com/filecoin-project/lotus/build"
+	"github.com/filecoin-project/lotus/chain/consensus"
+	"github.com/filecoin-project/lotus/chain/store"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+)
+
+type preValidator struct {
+	consensus.Consensus
+
+	lk      sync.Mutex
+	checked map[cid.Cid]struct{}
+}
+
+func (pv *preValidator) PreValidateBlock(_ context.Context, b *types.FullBlock) error {
+	pv.lk.Lock()
+	defer pv.lk.Unlock()
+	pv.checked[b.Cid()] = struct{}{}
+	return nil
+}
+
+func (pv *preValidator) count() int {
+	pv.lk.Lock()
+	defer pv.lk.Unlock()
+	return len(pv.checked)
+}
+
+func TestPreValidate(t *testing.T) {
+	ctx := context.Background()
+	bs := blockstore.NewMemorySync()
+	cs := store.NewChainStore(bs, bs, datastore.NewMapDatastore(), nil, nil)
+
+	var ftss []*store.FullTipSet
+	var parent *types.TipSet
+	for i := 0; i < 5; i++ {
+		blk := mock.MkBlock(parent, 1, uint64(i))
+		if i == 4 {
+			// near the head
+			blk.Timestamp = uint64(build.Clock.Now().Unix())
+		}
+		parent = mock.TipSet(blk)
+		ftss = append(ftss, &store.FullTipSet{Blocks: []*types.FullBlock{{Header: blk}}})
+	}
+
+	// blocks which were already validated are skipped
+	require.NoError(t, cs.MarkBlockAsValidated(ctx, ftss[0].Blocks[0].Cid()))
+
+	pv := &preValidator{checked: map[cid.Cid]struct{}{}}
+	syncer := &Syncer{store: cs, consensus: pv, validationParallelism: 2}
+	syncer.preValidate(ctx, ftss)
+
+	require.Eventually(t, func() bool { return pv.count() == 3 }, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	pv.lk.Lock()
+	for i, fts := range ftss {
+		_, ok := pv.checked[fts.Blocks[0].Cid()]
+		require.Equal(t, i > 0 && i < 4, ok, "tipset %d", i)
+	}
+	pv.lk.Unlock()
+
+	// sequential validation only
+	pv = &preValidator{checked: map[cid.Cid]struct{}{}}
+	syncer = &Syncer{store: cs, consensus: pv, validationParallelism: 1}
+	syncer.preValidate(ctx, ftss)
+	time.Sleep(50 * time.Millisecond)
+	require.Zero(t, pv.count())
+}
@@ -987,10 +987,12 @@ func TestSyncCheckpointHead(t *testing.T) {
 	require.True(tu.t, p1Head.Equals(a.TipSet()))
 	tu.assertBad(p1, b.TipSet())
 
-	// Should be able to switch forks.
-	tu.checkpointTs(p1, b.TipSet().Key())
+	// Checkpoints on another fork are refused.
+	require.Error(t, tu.nds[p1].SyncCheckpoint(context.TODO(), b.TipSet().Key()))
 	p1Head = tu.getHead(p1)
-	require.True(tu.t, p1Head.Equals(b.TipSet()))
+	require.True(tu.t, p1Head.Equals(a.TipSet()))
+
+	require.NoError(t, tu.nds[p1].SyncCheckpointClear(context.TODO()))
 }
 
 func TestSyncCheckpointPartial(t *testing.T) {
@@ -1031,7 +1033,7 @@ func TestSyncCheckpointPartial(t *testing.T) {
 	}
 	tu.waitUntilSyncTarget(p1, a.TipSet())
 
-	tu.pushFtsAndWait(p2, a, true)
+	tu.pushFtsAndWait(p2, aPartial, true)
 	tu.checkpointTs(p2, aPartial.TipSet().Key())
 	t.Logf("p1 head: %v, p2 head: %v, a: %v", tu.getHead(p1), tu.getHead(p2), a.TipSet())
 	tu.pushTsExpectErr(p2, aPartial2, true)
@@ -1046,9 +1048,11 @@ func TestSyncCheckpointPartial(t *testing.T) {
 	tu.connect(p1, p2)
 
 	tu.pushFtsAndWait(p2, c, true)
-	tu.waitUntilNodeHasTs(p1, c.TipSet().Key())
-	tu.checkpointTs(p1, c.TipSet().Key())
+	tu.checkpointTs(p2, c.TipSet().Key())
 
+	// p1 is on the fork through the full tipset, so it can't checkpoint c.
+	tu.waitUntilNodeHasTs(p1, c.TipSet().Key())
+	require.Error(t, tu.nds[p1].SyncCheckpoint(context.TODO(), c.TipSet().Key()))
 }
 
 func TestSyncCheckpointSubmitOneOfTheBlocks(t *testing.T) {
@@ -1128,10 +1132,12 @@ func TestSyncCheckpointEarlierThanHead(t *testing.T) {
 	require.True(tu.t, p1Head.Equals(a.TipSet()))
 	tu.assertBad(p1, b.TipSet())
 
-	// Should be able to switch forks.
-	tu.checkpointTs(p1, b.TipSet().Key())
+	// Checkpoints on another fork are refused.
+	require.Error(t, tu.nds[p1].SyncCheckpoint(context.TODO(), b.TipSet().Key()))
 	p1Head = tu.getHead(p1)
-	require.True(tu.t, p1Head.Equals(b.TipSet()))
+	require.True(tu.t, p1Head.Equals(a.TipSet()))
+
+	require.NoError(t, tu.nds[p1].SyncCheckpointClear(context.TODO()))
 }
 
 func TestInvalidHeight(t *testing.T) {
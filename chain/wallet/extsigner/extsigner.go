@@ -0,0 +1,179 @@
+// Package extsigner implements a wallet backend which delegates signing to external signers,
+// such as a KMS or an HSM, so that the node only needs to know the addresses of the keys.
+package extsigner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/lib/sigs"
+	_ "github.com/filecoin-project/lotus/lib/sigs/bls" // enable bls signatures
+	_ "github.com/filecoin-project/lotus/lib/sigs/delegated"
+	_ "github.com/filecoin-project/lotus/lib/sigs/secp" // enable secp signatures
+)
+
+var log = logging.Logger("wallet-extsigner")
+
+// Signer signs data with the private key of an address held outside of the node.
+type Signer interface {
+	Sign(ctx context.Context, signer address.Address, toSign []byte, meta api.MsgMeta) (*crypto.Signature, error)
+}
+
+// ExternalWallet is a wallet backend for addresses whose keys are held by external signers. The
+// wallet only holds the addresses; keys can't be created, imported, exported or deleted.
+type ExternalWallet struct {
+	signers map[address.Address]Signer
+}
+
+var _ api.Wallet = (*ExternalWallet)(nil)
+
+// NewExternalWallet creates a wallet signing for each address with the given signer.
+func NewExternalWallet(signers map[address.Address]Signer) *ExternalWallet {
+	return &ExternalWallet{signers: signers}
+}
+
+// SetupHTTPSigners returns a constructor of an ExternalWallet which signs for each configured
+// address using the HTTP signer at the corresponding URL.
+func SetupHTTPSigners(cfg map[string]string) func() (*ExternalWallet, error) {
+	return func() (*ExternalWallet, error) {
+		signers := make(map[address.Address]Signer, len(cfg))
+		for a, url := range cfg {
+			addr, err := address.NewFromString(a)
+			if err != nil {
+				return nil, xerrors.Errorf("parsing external signer address %q: %w", a, err)
+			}
+			signers[addr] = NewHTTPSigner(url)
+		}
+		return NewExternalWallet(signers), nil
+	}
+}
+
+func (w *ExternalWallet) WalletSign(ctx context.Context, signer address.Address, toSign []byte, meta api.MsgMeta) (*crypto.Signature, error) {
+	s, ok := w.signers[signer]
+	if !ok {
+		return nil, xerrors.Errorf("no external signer for %s", signer)
+	}
+
+	sig, err := s.Sign(ctx, signer, toSign, meta)
+	if err != nil {
+		return nil, xerrors.Errorf("external signer for %s: %w", signer, err)
+	}
+
+	// the external signer is not trusted to be configured for the right key
+	if err := sigs.Verify(sig, signer, toSign); err != nil {
+		return nil, xerrors.Errorf("external signer for %s returned an invalid signature: %w", signer, err)
+	}
+
+	log.Debugw("signed with external signer", "address", signer, "type", meta.Type)
+	return sig, nil
+}
+
+func (w *ExternalWallet) WalletHas(ctx context.Context, addr address.Address) (bool, error) {
+	_, ok := w.signers[addr]
+	return ok, nil
+}
+
+func (w *ExternalWallet) WalletList(ctx context.Context) ([]address.Address, error) {
+	out := make([]address.Address, 0, len(w.signers))
+	for a := range w.signers {
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].String() < out[j].String()
+	})
+	return out, nil
+}
+
+func (w *ExternalWallet) WalletNew(ctx context.Context, kt types.KeyType) (address.Address, error) {
+	return address.Undef, xerrors.Errorf("keys of external signers can't be created by the node")
+}
+
+func (w *ExternalWallet) WalletExport(ctx context.Context, addr address.Address) (*types.KeyInfo, error) {
+	return nil, xerrors.Errorf("keys of external signers can't be exported")
+}
+
+func (w *ExternalWallet) WalletImport(ctx context.Context, ki *types.KeyInfo) (address.Address, error) {
+	return address.Undef, xerrors.Errorf("keys can't be imported into external signers")
+}
+
+func (w *ExternalWallet) WalletDelete(ctx context.Context, addr address.Address) error {
+	return xerrors.Errorf("address %s is signed for by an external signer, remove it from the Wallet.ExternalSigners config instead", addr)
+}
+
+func (w *ExternalWallet) Get() api.Wallet {
+	if w == nil {
+		return nil
+	}
+
+	return w
+}
+
+// SignRequest is the body of requests made by HTTPSigner.
+type SignRequest struct {
+	Address address.Address
+	Data    []byte
+	Meta    api.MsgMeta
+}
+
+// HTTPSigner is a reference Signer, which POSTs a JSON encoded SignRequest to a URL, and expects
+// a JSON encoded crypto.Signature in response. Credentials can be passed as the userinfo of the
+// URL, and are sent using basic authentication.
+type HTTPSigner struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPSigner creates an HTTPSigner for the given URL.
+func NewHTTPSigner(url string) *HTTPSigner {
+	return &HTTPSigner{
+		URL:    url,
+		Client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (s *HTTPSigner) Sign(ctx context.Context, signer address.Address, toSign []byte, meta api.MsgMeta) (*crypto.Signature, error) {
+	body, err := json.Marshal(&SignRequest{
+		Address: signer,
+		Data:    toSign,
+		Meta:    meta,
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("marshaling sign request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, xerrors.Errorf("creating sign request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, xerrors.Errorf("sending sign request: %w", err)
+	}
+	defer resp.Body.Close() // nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, xerrors.Errorf("sign request failed with status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	var sig crypto.Signature
+	if err := json.NewDecoder(resp.Body).Decode(&sig); err != nil {
+		return nil, xerrors.Errorf("decoding signature: %w", err)
+	}
+	return &sig, nil
+}
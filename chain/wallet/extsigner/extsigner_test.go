@@ -0,0 +1,91 @@
+package extsigner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/wallet/key"
+	"github.com/filecoin-project/lotus/lib/sigs"
+)
+
+func TestHTTPSigner(t *testing.T) {
+	ctx := context.Background()
+
+	k, err := key.GenerateKey(types.KTSecp256k1)
+	require.NoError(t, err)
+	other, err := key.GenerateKey(types.KTSecp256k1)
+	require.NoError(t, err)
+
+	signWith := k
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != "user" || p != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var req SignRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if req.Address != k.Address || req.Meta.Type != api.MTChainMsg {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		sig, err := sigs.Sign(key.ActSigType(signWith.Type), signWith.PrivateKey, req.Data)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(sig)
+	}))
+	defer srv.Close()
+
+	authURL := "http://user:pass@" + srv.Listener.Addr().String()
+
+	w, err := SetupHTTPSigners(map[string]string{k.Address.String(): authURL})()
+	require.NoError(t, err)
+
+	has, err := w.WalletHas(ctx, k.Address)
+	require.NoError(t, err)
+	require.True(t, has)
+	has, err = w.WalletHas(ctx, other.Address)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	list, err := w.WalletList(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []address.Address{k.Address}, list)
+
+	data := []byte("cthulhu")
+	sig, err := w.WalletSign(ctx, k.Address, data, api.MsgMeta{Type: api.MTChainMsg})
+	require.NoError(t, err)
+	require.NoError(t, sigs.Verify(sig, k.Address, data))
+
+	// addresses without signer
+	_, err = w.WalletSign(ctx, other.Address, data, api.MsgMeta{Type: api.MTChainMsg})
+	require.Error(t, err)
+
+	// signatures made with the wrong key are rejected
+	signWith = other
+	_, err = w.WalletSign(ctx, k.Address, data, api.MsgMeta{Type: api.MTChainMsg})
+	require.ErrorContains(t, err, "invalid signature")
+
+	// signer errors are passed on
+	w = NewExternalWallet(map[address.Address]Signer{k.Address: NewHTTPSigner(srv.URL)})
+	_, err = w.WalletSign(ctx, k.Address, data, api.MsgMeta{Type: api.MTChainMsg})
+	require.ErrorContains(t, err, "status 401")
+
+	_, err = w.WalletExport(ctx, k.Address)
+	require.Error(t, err)
+}
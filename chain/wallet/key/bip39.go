@@ -0,0 +1,64 @@
+package key
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/xerrors"
+)
+
+var bip39Words = func() map[string]uint16 {
+	words := strings.Fields(bip39English)
+	out := make(map[string]uint16, len(words))
+	for i, w := range words {
+		out[w] = uint16(i)
+	}
+	return out
+}()
+
+// bip39Seed returns the seed of a BIP39 mnemonic of English words and a passphrase, after checking
+// the mnemonic checksum, as specified by BIP39.
+func bip39Seed(mnemonic, passphrase string) ([]byte, error) {
+	mnemonic = norm.NFKD.String(mnemonic)
+	words := strings.Split(mnemonic, " ")
+
+	// every word encodes 11 bits, of which 1/33 are the checksum
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, xerrors.Errorf("mnemonic must have 12, 15, 18, 21 or 24 words, got %d", len(words))
+	}
+
+	bits := make([]bool, 0, len(words)*11)
+	for _, w := range words {
+		idx, ok := bip39Words[w]
+		if !ok {
+			return nil, xerrors.Errorf("%q is not a BIP39 English word", w)
+		}
+		for i := 10; i >= 0; i-- {
+			bits = append(bits, idx&(1<<i) != 0)
+		}
+	}
+
+	csBits := len(bits) / 33
+	entropy := make([]byte, (len(bits)-csBits)/8)
+	for i := range entropy {
+		for j := 0; j < 8; j++ {
+			if bits[i*8+j] {
+				entropy[i] |= 1 << (7 - j)
+			}
+		}
+	}
+
+	sum := sha256.Sum256(entropy)
+	for i := 0; i < csBits; i++ {
+		if bits[len(entropy)*8+i] != (sum[0]&(1<<(7-i)) != 0) {
+			return nil, xerrors.Errorf("mnemonic checksum is incorrect")
+		}
+	}
+
+	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+norm.NFKD.String(passphrase)), 2048, 64, sha512.New), nil
+}
@@ -0,0 +1,147 @@
+package key
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/lotus/chain/types"
+)
+
+// Default BIP44 derivation paths of keys derived from mnemonics. Secp256k1 keys use the Filecoin
+// coin type 461, as used by Ledger and most Filecoin wallets, and delegated keys use the Ethereum
+// coin type 60, so that a mnemonic from an Ethereum wallet yields the same f4 / 0x address.
+// The last element is the address index.
+const (
+	FilecoinDerivationPath = "m/44'/461'/0'/0/0"
+	EthDerivationPath      = "m/44'/60'/0'/0/0"
+)
+
+// DefaultDerivationPath returns the default derivation path of the key type.
+func DefaultDerivationPath(typ types.KeyType) (string, error) {
+	switch typ {
+	case types.KTSecp256k1:
+		return FilecoinDerivationPath, nil
+	case types.KTDelegated:
+		return EthDerivationPath, nil
+	default:
+		return "", xerrors.Errorf("deriving %s keys from mnemonics is not supported", typ)
+	}
+}
+
+// KeyInfoFromMnemonic derives the key of the given type at the BIP32 derivation path from the seed
+// of a BIP39 mnemonic and passphrase. Only secp256k1 and delegated keys can be derived.
+func KeyInfoFromMnemonic(mnemonic, passphrase string, typ types.KeyType, path string) (*types.KeyInfo, error) {
+	if typ != types.KTSecp256k1 && typ != types.KTDelegated {
+		return nil, xerrors.Errorf("deriving %s keys from mnemonics is not supported", typ)
+	}
+
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	seed, err := bip39Seed(mnemonic, passphrase)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid mnemonic: %w", err)
+	}
+
+	pk, err := deriveBIP32(seed, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.KeyInfo{
+		Type:       typ,
+		PrivateKey: pk,
+	}, nil
+}
+
+const hardenedOffset = 1 << 31
+
+// parseDerivationPath parses a path such as m/44'/461'/0'/0/0 into the child indexes.
+func parseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if parts[0] != "m" {
+		return nil, xerrors.Errorf("derivation path %q must start with m", path)
+	}
+
+	out := make([]uint32, 0, len(parts)-1)
+	for _, p := range parts[1:] {
+		var offset uint32
+		if strings.HasSuffix(p, "'") || strings.HasSuffix(p, "h") || strings.HasSuffix(p, "H") {
+			offset = hardenedOffset
+			p = p[:len(p)-1]
+		}
+
+		i, err := strconv.ParseUint(p, 10, 31)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid index %q in derivation path %q", p, path)
+		}
+		out = append(out, uint32(i)+offset)
+	}
+	return out, nil
+}
+
+// deriveBIP32 derives the private key at the path from the seed, as specified by BIP32.
+func deriveBIP32(seed []byte, path string) ([]byte, error) {
+	idxs, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	var k secp256k1.ModNScalar
+	if overflow := k.SetByteSlice(sum[:32]); overflow || k.IsZero() {
+		return nil, xerrors.Errorf("invalid master key, use another mnemonic")
+	}
+	chainCode := sum[32:]
+
+	for depth, idx := range idxs {
+		data := make([]byte, 0, 37)
+		if idx >= hardenedOffset {
+			kb := k.Bytes()
+			data = append(data, 0)
+			data = append(data, kb[:]...)
+		} else {
+			data = append(data, secp256k1.NewPrivateKey(&k).PubKey().SerializeCompressed()...)
+		}
+		data = binary.BigEndian.AppendUint32(data, idx)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+
+		var il secp256k1.ModNScalar
+		if overflow := il.SetByteSlice(sum[:32]); overflow {
+			return nil, invalidChildErr(idxs[:depth+1])
+		}
+		k.Add(&il)
+		if k.IsZero() {
+			return nil, invalidChildErr(idxs[:depth+1])
+		}
+		chainCode = sum[32:]
+	}
+
+	kb := k.Bytes()
+	return kb[:], nil
+}
+
+func invalidChildErr(idxs []uint32) error {
+	var sb strings.Builder
+	sb.WriteString("m")
+	for _, idx := range idxs {
+		if idx >= hardenedOffset {
+			_, _ = fmt.Fprintf(&sb, "/%d'", idx-hardenedOffset)
+		} else {
+			_, _ = fmt.Fprintf(&sb, "/%d", idx)
+		}
+	}
+	// this has a probability lower than 1 in 2^127, BIP32 says to proceed with the next index
+	return xerrors.Errorf("invalid key at derivation path %s, use the next index", sb.String())
+}
@@ -0,0 +1,105 @@
+package key
+
+import (
+	"encoding/hex"
+	"hash/crc32"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+	_ "github.com/filecoin-project/lotus/lib/sigs/delegated"
+	_ "github.com/filecoin-project/lotus/lib/sigs/secp"
+)
+
+func TestBIP39Seed(t *testing.T) {
+	// the CRC32 of english.txt published with BIP39
+	require.Equal(t, uint32(0xc1dbd296), crc32.ChecksumIEEE([]byte(bip39English)))
+	require.Len(t, bip39Words, 2048)
+
+	// BIP39 test vectors, with the TREZOR passphrase
+	for mnemonic, expected := range map[string]string{
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about":                               "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow":                                                 "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		"letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always": "107d7c02a5aa6f38c58083ff74f04c607c2d2c0ecc55501dadd72d025b751bc27fe913ffb796f841c49b1d33b610cf0e91d3aa239027f5e99fe4ce9e5088cd65",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote":                            "dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+	} {
+		seed, err := bip39Seed(mnemonic, "TREZOR")
+		require.NoError(t, err, mnemonic)
+		require.Equal(t, expected, hex.EncodeToString(seed), mnemonic)
+	}
+
+	for _, mnemonic := range []string{
+		// bad checksum
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		// unsupported length
+		strings.Repeat("abandon ", 10) + "about",
+		// not a word of the list
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abou",
+	} {
+		_, err := bip39Seed(mnemonic, "")
+		require.Error(t, err, mnemonic)
+	}
+}
+
+func TestDeriveBIP32(t *testing.T) {
+	// BIP32 test vector 1
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	for path, expected := range map[string]string{
+		"m":                      "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+		"m/0'":                   "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		"m/0'/1":                 "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+		"m/0H/1/2'":              "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca",
+		"m/0'/1/2'/2/1000000000": "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8",
+	} {
+		pk, err := deriveBIP32(seed, path)
+		require.NoError(t, err, path)
+		require.Equal(t, expected, hex.EncodeToString(pk), path)
+	}
+
+	for _, path := range []string{"", "0/1", "m/x", "m/2147483648", "m//1"} {
+		_, err := deriveBIP32(seed, path)
+		require.Error(t, err, path)
+	}
+}
+
+func TestKeyInfoFromMnemonic(t *testing.T) {
+	const mnemonic = "test test test test test test test test test test test junk"
+
+	// the first account of this mnemonic in Ethereum wallets
+	ki, err := KeyInfoFromMnemonic(mnemonic, "", types.KTDelegated, EthDerivationPath)
+	require.NoError(t, err)
+	require.Equal(t, "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80", hex.EncodeToString(ki.PrivateKey))
+
+	k, err := NewKey(*ki)
+	require.NoError(t, err)
+	ea, err := ethtypes.EthAddressFromFilecoinAddress(k.Address)
+	require.NoError(t, err)
+	require.Equal(t, "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266", ea.String())
+
+	// extra whitespace doesn't change the key, the passphrase and path do
+	ki2, err := KeyInfoFromMnemonic("  test test test test test test test test test test\ttest junk\n", "", types.KTDelegated, EthDerivationPath)
+	require.NoError(t, err)
+	require.Equal(t, ki.PrivateKey, ki2.PrivateKey)
+
+	ki2, err = KeyInfoFromMnemonic(mnemonic, "passphrase", types.KTDelegated, EthDerivationPath)
+	require.NoError(t, err)
+	require.NotEqual(t, ki.PrivateKey, ki2.PrivateKey)
+
+	ki2, err = KeyInfoFromMnemonic(mnemonic, "", types.KTSecp256k1, FilecoinDerivationPath)
+	require.NoError(t, err)
+	require.NotEqual(t, ki.PrivateKey, ki2.PrivateKey)
+	_, err = NewKey(*ki2)
+	require.NoError(t, err)
+
+	// bad checksum
+	_, err = KeyInfoFromMnemonic("test test test test test test test test test test test test", "", types.KTSecp256k1, FilecoinDerivationPath)
+	require.Error(t, err)
+
+	_, err = KeyInfoFromMnemonic(mnemonic, "", types.KTBLS, FilecoinDerivationPath)
+	require.Error(t, err)
+}
@@ -1,14 +1,22 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/urfave/cli/v2"
 	"golang.org/x/xerrors"
 
+	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-jsonrpc/auth"
 
 	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/api/client"
+	"github.com/filecoin-project/lotus/chain/actors/builtin"
 	"github.com/filecoin-project/lotus/node/repo"
 )
 
@@ -18,6 +26,7 @@ var AuthCmd = &cli.Command{
 	Subcommands: []*cli.Command{
 		AuthCreateAdminToken,
 		AuthApiInfoToken,
+		AuthTestToken,
 	},
 }
 
@@ -132,3 +141,109 @@ var AuthApiInfoToken = &cli.Command{
 		return nil
 	},
 }
+
+var AuthTestToken = &cli.Command{
+	Name:      "test-token",
+	Usage:     "Show the permissions of an API token",
+	ArgsUsage: "<token>",
+	Description: `Verifies the token with the node and prints the permissions it grants.
+
+With --probe, a method of each permission level is called on the full node using
+the token, to check which levels it can actually access:
+   read:  ChainHead
+   write: LogList
+   sign:  WalletSign (with an address not in the wallet, nothing is signed)
+   admin: LogAlerts`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "probe",
+			Usage: "call a representative method of each permission level with the token",
+		},
+	},
+
+	Action: func(cctx *cli.Context) error {
+		if cctx.NArg() != 1 {
+			return IncorrectNumArgs(cctx)
+		}
+		token := strings.TrimSpace(cctx.Args().First())
+
+		napi, closer, err := GetAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		ctx := ReqContext(cctx)
+
+		perms, err := napi.AuthVerify(ctx, token)
+		if err != nil {
+			return xerrors.Errorf("verifying token: %w", err)
+		}
+		fmt.Printf("Permissions: %s\n", perms)
+
+		if !cctx.Bool("probe") {
+			return nil
+		}
+
+		if t, ok := cctx.App.Metadata["repoType"]; ok && t != repo.FullNode {
+			return xerrors.New("--probe is only supported for full node tokens")
+		}
+
+		addr, _, err := GetRawAPI(cctx, repo.FullNode, "v1")
+		if err != nil {
+			return err
+		}
+		headers := http.Header{}
+		headers.Add("Authorization", "Bearer "+token)
+
+		tapi, tcloser, err := client.NewFullNodeRPCV1(ctx, addr, headers)
+		if err != nil {
+			return xerrors.Errorf("connecting with the token: %w", err)
+		}
+		defer tcloser()
+
+		probes := []struct {
+			perm   auth.Permission
+			method string
+			call   func() error
+		}{
+			{api.PermRead, "ChainHead", func() error {
+				_, err := tapi.ChainHead(ctx)
+				return err
+			}},
+			{api.PermWrite, "LogList", func() error {
+				_, err := tapi.LogList(ctx)
+				return err
+			}},
+			{api.PermSign, "WalletSign", func() error {
+				_, err := tapi.WalletSign(ctx, builtin.SystemActorAddr, []byte{})
+				return err
+			}},
+			{api.PermAdmin, "LogAlerts", func() error {
+				_, err := tapi.LogAlerts(ctx)
+				return err
+			}},
+		}
+
+		fmt.Println()
+		tw := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "Permission\tMethod\tResult")
+		for _, p := range probes {
+			res := "allowed"
+			if err := p.call(); err != nil {
+				var cerr *jsonrpc.RPCConnectionError
+				switch {
+				case strings.Contains(err.Error(), "missing permission"):
+					res = "denied"
+				case errors.As(err, &cerr):
+					return xerrors.Errorf("calling %s: %w", p.method, err)
+				case p.perm != api.PermSign:
+					// the call passed the permission check
+					res = fmt.Sprintf("allowed (call failed: %s)", err)
+				}
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", p.perm, p.method, res)
+		}
+		return tw.Flush()
+	},
+}
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multicodec"
 	"github.com/urfave/cli/v2"
 	cbg "github.com/whyrusleeping/cbor-gen"
 	"golang.org/x/xerrors"
@@ -52,12 +53,15 @@ var ChainCmd = &cli.Command{
 		ChainReadObjCmd,
 		ChainDeleteObjCmd,
 		ChainStatObjCmd,
+		ChainGetBlockCidInfoCmd,
 		ChainGetMsgCmd,
 		ChainSetHeadCmd,
 		ChainListCmd,
+		ChainTailCmd,
 		ChainGetCmd,
 		ChainBisectCmd,
 		ChainExportCmd,
+		ChainExportActorStateCmd,
 		ChainExportRangeCmd,
 		SlashConsensusFault,
 		ChainGasPriceCmd,
@@ -66,6 +70,8 @@ var ChainCmd = &cli.Command{
 		ChainEncodeCmd,
 		ChainDisputeSetCmd,
 		ChainPruneCmd,
+		ChainMsgIndexCmd,
+		ChainVerifyContinuityCmd,
 	},
 }
 
@@ -325,6 +331,152 @@ var ChainStatObjCmd = &cli.Command{
 	},
 }
 
+var ChainGetBlockCidInfoCmd = &cli.Command{
+	Name:      "get-block-cid-info",
+	Usage:     "Show information about a CID and the object it references in the chain blockstore",
+	ArgsUsage: "[cid]",
+	Description: `Shows the codec and multihash of the CID, whether the object is present in the local
+   chain blockstore and its size.
+
+   For present objects, checks whether they are reachable from the head tipset: its block headers,
+   messages, receipts and parent state. This walks the whole state tree on the node and may take a
+   while, skip it with --skip-reachable.
+
+   For missing objects, shows the splitstore compaction boundary: objects which are only referenced
+   by tipsets below it are moved out of the hotstore, and discarded unless the coldstore keeps them.
+   Pass the epoch the object was created at with --epoch to compare it with the boundary.
+`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "skip-reachable",
+			Usage: "don't check whether the object is reachable from the head tipset",
+		},
+		&cli.Int64Flag{
+			Name:  "epoch",
+			Usage: "epoch the object was created at, to check whether it is expected to be pruned",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		afmt := NewAppFmt(cctx.App)
+		api, closer, err := GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		ctx := ReqContext(cctx)
+
+		if cctx.NArg() != 1 {
+			return IncorrectNumArgs(cctx)
+		}
+
+		c, err := cid.Decode(cctx.Args().First())
+		if err != nil {
+			return fmt.Errorf("failed to parse cid input: %s", err)
+		}
+
+		pref := c.Prefix()
+		afmt.Printf("CID:\t\t%s\n", c)
+		afmt.Printf("Version:\t%d\n", pref.Version)
+		afmt.Printf("Codec:\t\t%s (0x%x)\n", multicodec.Code(pref.Codec), pref.Codec)
+		afmt.Printf("Multihash:\t%s (0x%x), %d byte digest\n", multicodec.Code(pref.MhType), pref.MhType, pref.MhLength)
+
+		has, err := api.ChainHasObj(ctx, c)
+		if err != nil {
+			return xerrors.Errorf("checking blockstore: %w", err)
+		}
+		afmt.Printf("Present:\t%t\n", has)
+
+		if has {
+			obj, err := api.ChainReadObj(ctx, c)
+			if err != nil {
+				return xerrors.Errorf("reading object: %w", err)
+			}
+			afmt.Printf("Size:\t\t%s (%d)\n", types.SizeStr(types.NewInt(uint64(len(obj)))), len(obj))
+
+			if cctx.Bool("skip-reachable") {
+				return nil
+			}
+
+			head, err := api.ChainHead(ctx)
+			if err != nil {
+				return xerrors.Errorf("getting chain head: %w", err)
+			}
+
+			from, err := reachableFromHead(ctx, api, head, c)
+			if err != nil {
+				return xerrors.Errorf("checking reachability: %w", err)
+			}
+			if from == "" {
+				afmt.Printf("Reachable:\tfalse (from head at epoch %d)\n", head.Height())
+			} else {
+				afmt.Printf("Reachable:\ttrue (from %s of head at epoch %d)\n", from, head.Height())
+			}
+			return nil
+		}
+
+		info, err := api.ChainBlockstoreInfo(ctx)
+		if err != nil {
+			afmt.Println("Splitstore:\tnot enabled, objects are never pruned")
+			return nil
+		}
+		baseEpoch, ok := info["base epoch"].(float64)
+		if !ok {
+			afmt.Println("Splitstore:\tno compaction boundary reported")
+			return nil
+		}
+		boundary := abi.ChainEpoch(baseEpoch)
+		afmt.Printf("Splitstore:\tcompaction boundary at epoch %d\n", boundary)
+
+		if cctx.IsSet("epoch") {
+			if abi.ChainEpoch(cctx.Int64("epoch")) < boundary {
+				afmt.Println("Pruned:\t\texpected, the object is below the compaction boundary")
+			} else {
+				afmt.Println("Pruned:\t\tnot expected, the object is above the compaction boundary")
+			}
+		}
+		return nil
+	},
+}
+
+// reachableFromHead returns which part of the head tipset references the object, or an empty
+// string if it isn't reachable from the head.
+func reachableFromHead(ctx context.Context, api lapi.FullNode, head *types.TipSet, c cid.Cid) (string, error) {
+	for _, b := range head.Blocks() {
+		if b.Cid() == c {
+			return "a block header", nil
+		}
+	}
+
+	type root struct {
+		name string
+		c    cid.Cid
+	}
+	roots := []root{
+		{"the parent state", head.ParentState()},
+		{"the parent receipts", head.Blocks()[0].ParentMessageReceipts},
+	}
+	for _, b := range head.Blocks() {
+		roots = append(roots, root{"the messages", b.Messages})
+	}
+
+	for _, r := range roots {
+		if r.c == c {
+			return r.name, nil
+		}
+
+		// after walking the root, the object adds no links to the stats if it was visited already
+		st, err := api.ChainStatObj(ctx, c, r.c)
+		if err != nil {
+			return "", xerrors.Errorf("walking %s: %w", r.name, err)
+		}
+		if st.Links == 0 {
+			return r.name, nil
+		}
+	}
+
+	return "", nil
+}
+
 var ChainGetMsgCmd = &cli.Command{
 	Name:      "getmessage",
 	Aliases:   []string{"get-message", "get-msg"},
@@ -1107,9 +1259,26 @@ var ChainExportCmd = &cli.Command{
 		&cli.BoolFlag{
 			Name: "skip-old-msgs",
 		},
+		&cli.BoolFlag{
+			Name:  "headers-only",
+			Usage: "only export the block headers and messages, without any state",
+		},
+		&cli.Int64Flag{
+			Name:  "tail-height",
+			Usage: "with --headers-only, the height to export the headers back to",
+		},
 	},
+	Description: `By default the export contains the header chain back to genesis, the genesis state and the
+recent state roots, and can be used to initialize a node.
+
+With --headers-only, only the block headers and messages from the tipset back to --tail-height
+are exported. The chain structure and the messages can be reconstructed from such an export,
+but not the state at any height, nor the message receipts, so it is much smaller and suited
+for indexing the chain history. It can only be imported when exported back to genesis, in
+which case it includes the genesis state, and the state at other heights then has to be
+recomputed by executing the chain.`,
 	Action: func(cctx *cli.Context) error {
-		api, closer, err := GetFullNodeAPI(cctx)
+		api, closer, err := GetFullNodeAPIV1(cctx)
 		if err != nil {
 			return err
 		}
@@ -1120,6 +1289,14 @@ var ChainExportCmd = &cli.Command{
 			return IncorrectNumArgs(cctx)
 		}
 
+		headersOnly := cctx.Bool("headers-only")
+		if headersOnly && (cctx.IsSet("recent-stateroots") || cctx.IsSet("skip-old-msgs")) {
+			return fmt.Errorf("\"headers-only\" can't be combined with \"recent-stateroots\" or \"skip-old-msgs\"")
+		}
+		if !headersOnly && cctx.IsSet("tail-height") {
+			return fmt.Errorf("\"tail-height\" can only be used with \"headers-only\"")
+		}
+
 		rsrs := abi.ChainEpoch(cctx.Int64("recent-stateroots"))
 		if cctx.IsSet("recent-stateroots") && rsrs < policy.ChainFinality {
 			return fmt.Errorf("\"recent-stateroots\" has to be greater than %d", policy.ChainFinality)
@@ -1147,7 +1324,83 @@ var ChainExportCmd = &cli.Command{
 			return fmt.Errorf("must pass recent stateroots along with skip-old-msgs")
 		}
 
-		stream, err := api.ChainExport(ctx, rsrs, skipold, ts.Key())
+		var stream <-chan []byte
+		if headersOnly {
+			stream, err = api.ChainExportHeaders(ctx, abi.ChainEpoch(cctx.Int64("tail-height")), ts.Key())
+		} else {
+			stream, err = api.ChainExport(ctx, rsrs, skipold, ts.Key())
+		}
+		if err != nil {
+			return err
+		}
+
+		var last bool
+		for b := range stream {
+			last = len(b) == 0
+
+			_, err := fi.Write(b)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !last {
+			return xerrors.Errorf("incomplete export (remote connection lost?)")
+		}
+
+		return nil
+	},
+}
+
+var ChainExportActorStateCmd = &cli.Command{
+	Name:      "export-actor",
+	Usage:     "export the state of a single actor to a car file",
+	ArgsUsage: "<address> <outputPath>",
+	Description: `Exports the state of the actor in the parent state of the given tipset. The car file is
+rooted at the state root, and contains the path from the state root to the actor and all of
+the actor's state, so that the actor can be inspected offline as if the full state tree was
+available. Other actors, the history of the actor and its code are not included.`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "tipset",
+			Usage: "specify tipset to export the actor state at",
+			Value: "@head",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		if cctx.NArg() != 2 {
+			return IncorrectNumArgs(cctx)
+		}
+
+		api, closer, err := GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		ctx := ReqContext(cctx)
+
+		addr, err := address.NewFromString(cctx.Args().Get(0))
+		if err != nil {
+			return xerrors.Errorf("parsing address: %w", err)
+		}
+
+		ts, err := LoadTipSet(ctx, cctx, api)
+		if err != nil {
+			return err
+		}
+
+		fi, err := createExportFile(cctx.App, cctx.Args().Get(1))
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err := fi.Close()
+			if err != nil {
+				fmt.Printf("error closing output file: %+v", err)
+			}
+		}()
+
+		stream, err := api.ChainExportActorState(ctx, addr, ts.Key())
 		if err != nil {
 			return err
 		}
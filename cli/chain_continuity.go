@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/api/v1api"
+	"github.com/filecoin-project/lotus/chain/types"
+)
+
+var ChainVerifyContinuityCmd = &cli.Command{
+	Name:  "verify-continuity",
+	Usage: "Check that the chain can be walked back over a height range, with the state roots present",
+	Description: `Walks the chain from the tipset at --to back to the tipset at --from, checking that the
+parent tipset of each tipset can be loaded from the blockstore, and that the parent state root
+of each tipset is present. The first break found is reported, and the command fails.
+
+Only the state root objects are checked, not the whole state trees. Nodes with the splitstore
+enabled only keep the state of recent tipsets, use --skip-state to check only the tipset links
+of older ranges.`,
+	Flags: []cli.Flag{
+		&cli.Int64Flag{
+			Name:     "from",
+			Usage:    "lowest height to check",
+			Required: true,
+		},
+		&cli.Int64Flag{
+			Name:        "to",
+			Usage:       "height to start walking back from",
+			DefaultText: "head",
+		},
+		&cli.BoolFlag{
+			Name:  "skip-state",
+			Usage: "don't check that the parent state roots are present",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		afmt := NewAppFmt(cctx.App)
+
+		api, closer, err := GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		ctx := ReqContext(cctx)
+
+		from := abi.ChainEpoch(cctx.Int64("from"))
+		if from < 0 {
+			return xerrors.Errorf("--from can't be negative")
+		}
+
+		top, err := api.ChainHead(ctx)
+		if err != nil {
+			return xerrors.Errorf("getting chain head: %w", err)
+		}
+		if cctx.IsSet("to") {
+			to := abi.ChainEpoch(cctx.Int64("to"))
+			if to < from {
+				return xerrors.Errorf("--to %d is below --from %d", to, from)
+			}
+			if to > top.Height() {
+				return xerrors.Errorf("--to %d is above the chain head at %d", to, top.Height())
+			}
+			top, err = api.ChainGetTipSetByHeight(ctx, to, top.Key())
+			if err != nil {
+				return xerrors.Errorf("getting tipset at height %d: %w", to, err)
+			}
+		} else if top.Height() < from {
+			return xerrors.Errorf("--from %d is above the chain head at %d", from, top.Height())
+		}
+
+		checked, brk, err := verifyChainContinuity(ctx, api, top, from, !cctx.Bool("skip-state"))
+		if err != nil {
+			return err
+		}
+		if brk != nil {
+			afmt.Printf("Chain break at height %d, tipset %s: %s\n", brk.Height, brk.TipSet, brk.Problem)
+			afmt.Printf("Checked %d tipsets from height %d before the break\n", checked, top.Height())
+			return xerrors.Errorf("chain is not continuous")
+		}
+
+		afmt.Printf("Chain is continuous from height %d to %d, checked %d tipsets\n", top.Height(), from, checked)
+		return nil
+	},
+}
+
+// continuityBreak is the first tipset found with missing parent links or state
+type continuityBreak struct {
+	Height  abi.ChainEpoch
+	TipSet  types.TipSetKey
+	Problem string
+}
+
+// verifyChainContinuity walks the chain back from the top tipset until the tipset at or below the
+// from height, returning the number of checked tipsets and the first break found. Failures to
+// load tipsets or objects are reported as breaks, as the API doesn't tell missing blocks apart
+// from other errors.
+func verifyChainContinuity(ctx context.Context, api v1api.FullNode, top *types.TipSet, from abi.ChainEpoch, checkState bool) (int, *continuityBreak, error) {
+	var checked int
+	for ts := top; ; {
+		if err := ctx.Err(); err != nil {
+			return checked, nil, err
+		}
+
+		broken := func(format string, args ...interface{}) (int, *continuityBreak, error) {
+			return checked, &continuityBreak{Height: ts.Height(), TipSet: ts.Key(), Problem: fmt.Sprintf(format, args...)}, nil
+		}
+
+		if checkState {
+			has, err := api.ChainHasObj(ctx, ts.ParentState())
+			if err != nil {
+				return broken("checking parent state root %s: %s", ts.ParentState(), err)
+			}
+			if !has {
+				return broken("parent state root %s is missing", ts.ParentState())
+			}
+		}
+		checked++
+
+		if ts.Height() <= from || ts.Height() == 0 {
+			return checked, nil, nil
+		}
+
+		parent, err := api.ChainGetTipSet(ctx, ts.Parents())
+		if err != nil {
+			return broken("loading parent tipset %s: %s", ts.Parents(), err)
+		}
+		if parent.Height() >= ts.Height() {
+			return broken("parent tipset %s is at height %d, not below the tipset", ts.Parents(), parent.Height())
+		}
+		ts = parent
+	}
+}
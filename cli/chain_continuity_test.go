@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/lotus/api/mocks"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+)
+
+func TestVerifyChainContinuity(t *testing.T) {
+	ctx := context.Background()
+
+	// a chain of 5 tipsets, each with its own parent state root
+	var tss []*types.TipSet
+	for i := 0; i < 5; i++ {
+		var parent *types.TipSet
+		if i > 0 {
+			parent = tss[i-1]
+		}
+		blk := mock.MkBlock(parent, 1, uint64(i))
+		blk.ParentStateRoot = blocks.NewBlock([]byte(fmt.Sprintf("state %d", i))).Cid()
+		tss = append(tss, mock.TipSet(blk))
+	}
+	head := tss[4]
+
+	setup := func(t *testing.T, missingTipSet, missingState int) *mocks.MockFullNode {
+		api := mocks.NewMockFullNode(gomock.NewController(t))
+		for i, ts := range tss {
+			if i == missingTipSet {
+				api.EXPECT().ChainGetTipSet(ctx, ts.Key()).Return(nil, xerrors.New("blockstore: block not found")).AnyTimes()
+			} else {
+				api.EXPECT().ChainGetTipSet(ctx, ts.Key()).Return(ts, nil).AnyTimes()
+			}
+			api.EXPECT().ChainHasObj(ctx, ts.ParentState()).Return(i != missingState, nil).AnyTimes()
+		}
+		return api
+	}
+
+	checked, brk, err := verifyChainContinuity(ctx, setup(t, -1, -1), head, 1, true)
+	require.NoError(t, err)
+	require.Nil(t, brk)
+	require.Equal(t, 4, checked)
+
+	// down to genesis
+	checked, brk, err = verifyChainContinuity(ctx, setup(t, -1, -1), head, 0, true)
+	require.NoError(t, err)
+	require.Nil(t, brk)
+	require.Equal(t, 5, checked)
+
+	// missing parent tipset
+	checked, brk, err = verifyChainContinuity(ctx, setup(t, 2, -1), head, 0, true)
+	require.NoError(t, err)
+	require.NotNil(t, brk)
+	require.Equal(t, tss[3].Key(), brk.TipSet)
+	require.Equal(t, 2, checked)
+
+	// missing state root
+	checked, brk, err = verifyChainContinuity(ctx, setup(t, -1, 2), head, 0, true)
+	require.NoError(t, err)
+	require.NotNil(t, brk)
+	require.Equal(t, tss[2].Key(), brk.TipSet)
+	require.Contains(t, brk.Problem, "parent state root")
+	require.Equal(t, 2, checked)
+
+	// state isn't checked with skip-state
+	_, brk, err = verifyChainContinuity(ctx, setup(t, -1, 2), head, 0, false)
+	require.NoError(t, err)
+	require.Nil(t, brk)
+}
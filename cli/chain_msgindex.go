@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	lapi "github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/api/v1api"
+)
+
+var ChainMsgIndexCmd = &cli.Command{
+	Name:  "msgindex",
+	Usage: "Inspect and repair the message index used for message lookups",
+	Description: `The chain index records which tipset each message was executed in, and backs
+StateSearchMsg, StateWaitMsg and the Ethereum transaction APIs. These commands check the index
+against the chain and repair it one epoch at a time, so the node keeps operating normally.`,
+	Subcommands: []*cli.Command{
+		chainMsgIndexVerifyCmd,
+		chainMsgIndexReindexCmd,
+	},
+}
+
+var msgIndexRangeFlags = []cli.Flag{
+	&cli.Int64Flag{
+		Name:     "from",
+		Usage:    "lowest epoch of the range (inclusive)",
+		Required: true,
+	},
+	&cli.Int64Flag{
+		Name:  "to",
+		Usage: "highest epoch of the range (inclusive), defaults to the last epoch before the chain head",
+	},
+}
+
+var chainMsgIndexVerifyCmd = &cli.Command{
+	Name:  "verify",
+	Usage: "Check the message index against the chain for a range of epochs",
+	Description: `Reports epochs at which the index is missing the canonical tipset or its messages
+(missing), holds more messages than the chain (extra), or is otherwise inconsistent with the chain
+(corrupt). The index is not modified; use 'lotus chain msgindex reindex' to repair it.`,
+	Flags: msgIndexRangeFlags,
+	Action: func(cctx *cli.Context) error {
+		api, closer, err := GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		ctx := ReqContext(cctx)
+
+		from, to, err := msgIndexRange(cctx, api)
+		if err != nil {
+			return err
+		}
+
+		counts := map[string]int{}
+		for epoch := from; epoch <= to; epoch++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			_, err := api.ChainValidateIndex(ctx, epoch, false)
+			if err == nil {
+				counts["ok"]++
+				continue
+			}
+
+			kind := classifyIndexError(err)
+			counts[kind]++
+			_, _ = fmt.Fprintf(cctx.App.Writer, "%d\t%s\t%s\n", epoch, kind, err)
+		}
+
+		_, _ = fmt.Fprintf(cctx.App.Writer, "\nVerified epochs %d to %d\n", from, to)
+		_, _ = fmt.Fprintf(cctx.App.Writer, "Consistent: %d\n", counts["ok"])
+		_, _ = fmt.Fprintf(cctx.App.Writer, "Missing:    %d\n", counts["missing"])
+		_, _ = fmt.Fprintf(cctx.App.Writer, "Extra:      %d\n", counts["extra"])
+		_, _ = fmt.Fprintf(cctx.App.Writer, "Corrupt:    %d\n", counts["corrupt"])
+		if counts["error"] > 0 {
+			_, _ = fmt.Fprintf(cctx.App.Writer, "Errored:    %d\n", counts["error"])
+		}
+
+		if bad := counts["missing"] + counts["extra"] + counts["corrupt"] + counts["error"]; bad > 0 {
+			return xerrors.Errorf("message index has problems at %d epochs", bad)
+		}
+		return nil
+	},
+}
+
+var chainMsgIndexReindexCmd = &cli.Command{
+	Name:  "reindex",
+	Usage: "Rebuild the message index for a range of epochs",
+	Description: `Re-indexes each epoch in the range whose index entries are missing or do not match
+the chain. Epochs are processed one at a time, each briefly holding the index write lock, so
+normal indexing of new tipsets is not blocked; use --delay to further reduce load on the node.
+
+Re-indexing an epoch writes the entries of its canonical tipset again. Epochs whose entries still
+don't match the chain afterwards, e.g. because the index holds several tipsets for the epoch, are
+reported as not repaired together with the inconsistency found; such epochs need the chain index
+to be rebuilt from scratch.
+
+With --resume-file, the last processed epoch is recorded in the given file, and a later run
+with the same file continues after it.`,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "resume-file",
+			Usage: "record progress in this file, and continue from the epoch recorded in it",
+		},
+		&cli.DurationFlag{
+			Name:  "delay",
+			Usage: "pause between epochs",
+		},
+	}, msgIndexRangeFlags...),
+	Action: func(cctx *cli.Context) error {
+		api, closer, err := GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		ctx := ReqContext(cctx)
+
+		from, to, err := msgIndexRange(cctx, api)
+		if err != nil {
+			return err
+		}
+
+		resumeFile := cctx.String("resume-file")
+		if resumeFile != "" {
+			last, ok, err := readMsgIndexProgress(resumeFile)
+			if err != nil {
+				return err
+			}
+			if ok && last >= from {
+				from = last + 1
+				_, _ = fmt.Fprintf(cctx.App.Writer, "resuming after epoch %d\n", last)
+			}
+		}
+
+		var reindexed, unrepaired, failed int
+		for epoch := from; epoch <= to; epoch++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			res, err := api.ChainValidateIndex(ctx, epoch, true)
+			if kind := classifyIndexError(err); err != nil && kind != "error" {
+				unrepaired++
+				_, _ = fmt.Fprintf(cctx.App.ErrWriter, "%d\tnot repaired (%s)\t%s\n", epoch, kind, err)
+			} else if err != nil {
+				failed++
+				_, _ = fmt.Fprintf(cctx.App.ErrWriter, "%d\tfailed\t%s\n", epoch, err)
+			} else if res.Backfilled {
+				reindexed++
+				_, _ = fmt.Fprintf(cctx.App.Writer, "%d\treindexed\n", epoch)
+			}
+
+			if resumeFile != "" {
+				if err := os.WriteFile(resumeFile, []byte(strconv.FormatInt(int64(epoch), 10)+"\n"), 0644); err != nil {
+					return xerrors.Errorf("writing resume file: %w", err)
+				}
+			}
+
+			if (epoch-from+1)%2880 == 0 {
+				_, _ = fmt.Fprintf(cctx.App.ErrWriter, "%s processed epochs up to %d\n", currentTimeString(), epoch)
+			}
+
+			if d := cctx.Duration("delay"); d > 0 {
+				select {
+				case <-time.After(d):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+
+		_, _ = fmt.Fprintf(cctx.App.Writer, "\nProcessed epochs %d to %d; reindexed: %d; not repaired: %d; failed: %d\n", from, to, reindexed, unrepaired, failed)
+		if unrepaired+failed > 0 {
+			return xerrors.Errorf("failed to reindex %d epochs", unrepaired+failed)
+		}
+		return nil
+	},
+}
+
+func msgIndexRange(cctx *cli.Context, api v1api.FullNode) (abi.ChainEpoch, abi.ChainEpoch, error) {
+	head, err := api.ChainHead(ReqContext(cctx))
+	if err != nil {
+		return 0, 0, xerrors.Errorf("getting chain head: %w", err)
+	}
+
+	from := abi.ChainEpoch(cctx.Int64("from"))
+	to := head.Height() - 1
+	if cctx.IsSet("to") {
+		to = abi.ChainEpoch(cctx.Int64("to"))
+	}
+
+	if from < 0 {
+		return 0, 0, xerrors.Errorf("invalid from epoch %d", from)
+	}
+	if to < from {
+		return 0, 0, xerrors.Errorf("to epoch (%d) must not be lower than from epoch (%d)", to, from)
+	}
+	if to >= head.Height() {
+		return 0, 0, xerrors.Errorf("to epoch (%d) must be lower than the chain head (%d)", to, head.Height())
+	}
+
+	return from, to, nil
+}
+
+// classifyIndexError returns the kind of index inconsistency reported by ChainValidateIndex, or
+// "error" when the validation itself failed
+func classifyIndexError(err error) string {
+	var ie *lapi.ErrIndexInconsistent
+	if errors.As(err, &ie) {
+		return string(ie.Kind)
+	}
+	return "error"
+}
+
+func readMsgIndexProgress(path string) (abi.ChainEpoch, bool, error) {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, xerrors.Errorf("reading resume file: %w", err)
+	}
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return 0, false, nil
+	}
+	e, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return 0, false, xerrors.Errorf("malformed resume file %s: %w", path, err)
+	}
+	return abi.ChainEpoch(e), true, nil
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+)
+
+func msgIndexTestHead(height abi.ChainEpoch) *types.TipSet {
+	blk := mock.MkBlock(nil, 0, 0)
+	blk.Height = height
+	return mock.TipSet(blk)
+}
+
+func TestClassifyIndexError(t *testing.T) {
+	require.Equal(t, "missing", classifyIndexError(api.NewErrIndexInconsistent(10, api.IndexMissing, "missing tipset")))
+	require.Equal(t, "extra", classifyIndexError(api.NewErrIndexInconsistent(10, api.IndexExtra, "count mismatch")))
+	require.Equal(t, "corrupt", classifyIndexError(xerrors.Errorf("validating: %w", api.NewErrIndexInconsistent(10, api.IndexCorrupt, "index corruption"))))
+	require.Equal(t, "error", classifyIndexError(xerrors.New("index corruption: looks like one, but isn't typed")))
+}
+
+func TestChainMsgIndexVerify(t *testing.T) {
+	app, mockApi, buf, done := NewMockAppWithFullAPI(t, WithCategory("chain", ChainMsgIndexCmd))
+	defer done()
+
+	mockApi.EXPECT().ChainHead(gomock.Any()).Return(msgIndexTestHead(10), nil)
+	gomock.InOrder(
+		mockApi.EXPECT().ChainValidateIndex(gomock.Any(), abi.ChainEpoch(5), false).Return(&types.IndexValidation{Height: 5}, nil),
+		mockApi.EXPECT().ChainValidateIndex(gomock.Any(), abi.ChainEpoch(6), false).Return(nil, api.NewErrIndexInconsistent(6, api.IndexMissing, "missing tipset at height 6")),
+		mockApi.EXPECT().ChainValidateIndex(gomock.Any(), abi.ChainEpoch(7), false).Return(nil, api.NewErrIndexInconsistent(7, api.IndexExtra, "message count mismatch for height 7")),
+		mockApi.EXPECT().ChainValidateIndex(gomock.Any(), abi.ChainEpoch(8), false).Return(nil, api.NewErrIndexInconsistent(8, api.IndexCorrupt, "index corruption at height 8")),
+		mockApi.EXPECT().ChainValidateIndex(gomock.Any(), abi.ChainEpoch(9), false).Return(&types.IndexValidation{Height: 9, IsNullRound: true}, nil),
+	)
+
+	err := app.Run([]string{"chain", "msgindex", "verify", "--from", "5"})
+	require.ErrorContains(t, err, "problems at 3 epochs")
+
+	out := buf.String()
+	require.Contains(t, out, "6\tmissing\tmissing tipset at height 6\n")
+	require.Contains(t, out, "7\textra\tmessage count mismatch for height 7\n")
+	require.Contains(t, out, "8\tcorrupt\tindex corruption at height 8\n")
+	require.Contains(t, out, "Consistent: 2\n")
+}
+
+func TestChainMsgIndexVerifyRange(t *testing.T) {
+	app, mockApi, _, done := NewMockAppWithFullAPI(t, WithCategory("chain", ChainMsgIndexCmd))
+	defer done()
+
+	// the head itself can't be validated yet
+	mockApi.EXPECT().ChainHead(gomock.Any()).Return(msgIndexTestHead(10), nil)
+	err := app.Run([]string{"chain", "msgindex", "verify", "--from", "5", "--to", "10"})
+	require.ErrorContains(t, err, "must be lower than the chain head")
+}
+
+func TestChainMsgIndexReindexResume(t *testing.T) {
+	resumeFile := filepath.Join(t.TempDir(), "progress")
+
+	run := func(expect func(*gomock.Call) *gomock.Call, args ...string) (string, error) {
+		app, mockApi, buf, done := NewMockAppWithFullAPI(t, WithCategory("chain", ChainMsgIndexCmd))
+		defer done()
+		app.ErrWriter = &bytes.Buffer{}
+
+		mockApi.EXPECT().ChainHead(gomock.Any()).Return(msgIndexTestHead(10), nil)
+		expect(mockApi.EXPECT().ChainValidateIndex(gomock.Any(), gomock.Any(), true))
+
+		err := app.Run(append([]string{"chain", "msgindex", "reindex", "--resume-file", resumeFile}, args...))
+		return buf.String() + app.ErrWriter.(*bytes.Buffer).String(), err
+	}
+
+	// the first run stops at epoch 7, which re-indexing can't repair
+	var epochs []abi.ChainEpoch
+	out, err := run(func(c *gomock.Call) *gomock.Call {
+		return c.DoAndReturn(func(_ interface{}, epoch abi.ChainEpoch, _ bool) (*types.IndexValidation, error) {
+			epochs = append(epochs, epoch)
+			switch epoch {
+			case 6:
+				return &types.IndexValidation{Height: epoch, Backfilled: true}, nil
+			case 7:
+				return nil, api.NewErrIndexInconsistent(epoch, api.IndexCorrupt, "index corruption: height 7 has multiple non-reverted tipsets")
+			}
+			return &types.IndexValidation{Height: epoch}, nil
+		}).Times(3)
+	}, "--from", "5", "--to", "7")
+	require.ErrorContains(t, err, "failed to reindex 1 epochs")
+	require.Equal(t, []abi.ChainEpoch{5, 6, 7}, epochs)
+	require.Contains(t, out, "6\treindexed\n")
+	require.Contains(t, out, "7\tnot repaired (corrupt)\tindex corruption: height 7 has multiple non-reverted tipsets\n")
+
+	progress, err := os.ReadFile(resumeFile)
+	require.NoError(t, err)
+	require.Equal(t, "7\n", string(progress))
+
+	// the next run continues after the recorded epoch
+	epochs = nil
+	out, err = run(func(c *gomock.Call) *gomock.Call {
+		return c.DoAndReturn(func(_ interface{}, epoch abi.ChainEpoch, _ bool) (*types.IndexValidation, error) {
+			epochs = append(epochs, epoch)
+			return &types.IndexValidation{Height: epoch}, nil
+		}).Times(2)
+	}, "--from", "5")
+	require.NoError(t, err)
+	require.Equal(t, []abi.ChainEpoch{8, 9}, epochs)
+	require.Contains(t, out, "resuming after epoch 7\n")
+}
@@ -0,0 +1,280 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/api/v1api"
+	"github.com/filecoin-project/lotus/chain/actors/builtin"
+	"github.com/filecoin-project/lotus/chain/consensus"
+	"github.com/filecoin-project/lotus/chain/store"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/vm"
+)
+
+var ChainTailCmd = &cli.Command{
+	Name:  "tail",
+	Usage: "Follow the chain and print messages matching the given filters",
+	Description: `Prints the messages included in new tipsets as they arrive. Messages must match all of the
+given filters to be printed. When the chain reorgs, the messages of the reverted tipsets are
+printed again, marked as reverted, before the messages of the tipsets replacing them.
+
+The method filter accepts a method name, e.g. PublishStorageDeals, or number, and the actor
+filter accepts the type of the recipient actor, e.g. storageminer or multisig.`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "to",
+			Usage: "only print messages sent to this address",
+		},
+		&cli.StringFlag{
+			Name:  "from",
+			Usage: "only print messages sent from this address",
+		},
+		&cli.StringFlag{
+			Name:  "method",
+			Usage: "only print messages calling this method name or number",
+		},
+		&cli.StringFlag{
+			Name:  "actor",
+			Usage: "only print messages sent to actors of this type",
+		},
+		&cli.Int64Flag{
+			Name:  "from-height",
+			Usage: "print messages included since this height before following new tipsets",
+			Value: -1,
+		},
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "print messages as json objects, one per line",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		afmt := NewAppFmt(cctx.App)
+
+		api, closer, err := GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		ctx := ReqContext(cctx)
+
+		f := &tailFilter{
+			method: cctx.String("method"),
+			actor:  cctx.String("actor"),
+		}
+		if f.to, err = tailAddrFilter(ctx, api, cctx.String("to")); err != nil {
+			return xerrors.Errorf("parsing --to: %w", err)
+		}
+		if f.from, err = tailAddrFilter(ctx, api, cctx.String("from")); err != nil {
+			return xerrors.Errorf("parsing --from: %w", err)
+		}
+
+		t := &chainTail{
+			api:    api,
+			filter: f,
+			ar:     consensus.NewActorRegistry(), // TODO: use remote map
+			codes:  map[address.Address]cid.Cid{},
+			print: func(m tailMessage) error {
+				if cctx.Bool("json") {
+					return json.NewEncoder(cctx.App.Writer).Encode(m)
+				}
+				status := "applied"
+				if m.Reverted {
+					status = "reverted"
+				}
+				afmt.Printf("%d\t%s\t%s\t%s -> %s\t%s.%s\t%s\n", m.Height, status, m.Cid, m.From, m.To, m.Actor, m.MethodName, types.FIL(m.Value))
+				return nil
+			},
+		}
+
+		notifs, err := api.ChainNotify(ctx)
+		if err != nil {
+			return err
+		}
+
+		for changes := range notifs {
+			for _, change := range changes {
+				switch change.Type {
+				case store.HCCurrent:
+					if h := cctx.Int64("from-height"); h >= 0 {
+						if err := t.replay(ctx, change.Val, abi.ChainEpoch(h)); err != nil {
+							return err
+						}
+					}
+				case store.HCApply:
+					if err := t.printTipSet(ctx, change.Val, false); err != nil {
+						return err
+					}
+				case store.HCRevert:
+					if err := t.printTipSet(ctx, change.Val, true); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		return xerrors.Errorf("chain notify stream closed")
+	},
+}
+
+type tailMessage struct {
+	Height     abi.ChainEpoch
+	TipSet     types.TipSetKey
+	Reverted   bool
+	Cid        cid.Cid
+	From       address.Address
+	To         address.Address
+	Actor      string
+	Method     abi.MethodNum
+	MethodName string
+	Value      abi.TokenAmount
+}
+
+type tailFilter struct {
+	// to and from hold all known forms of the filtered addresses
+	to     map[address.Address]struct{}
+	from   map[address.Address]struct{}
+	method string
+	actor  string
+}
+
+func (f *tailFilter) match(m tailMessage) bool {
+	if f.to != nil {
+		if _, ok := f.to[m.To]; !ok {
+			return false
+		}
+	}
+	if f.from != nil {
+		if _, ok := f.from[m.From]; !ok {
+			return false
+		}
+	}
+	if f.method != "" && !strings.EqualFold(f.method, m.MethodName) && f.method != strconv.FormatUint(uint64(m.Method), 10) {
+		return false
+	}
+	if f.actor != "" && !strings.EqualFold(f.actor, m.Actor) {
+		return false
+	}
+	return true
+}
+
+// tailAddrFilter returns the ID and robust forms of the address, as messages can address an actor
+// by either of them, or nil when no address is given.
+func tailAddrFilter(ctx context.Context, api v1api.FullNode, s string) (map[address.Address]struct{}, error) {
+	if s == "" {
+		return nil, nil
+	}
+	addr, err := address.NewFromString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	out := map[address.Address]struct{}{addr: {}}
+	if addr.Protocol() == address.ID {
+		if robust, err := api.StateLookupRobustAddress(ctx, addr, types.EmptyTSK); err == nil {
+			out[robust] = struct{}{}
+		}
+	} else if id, err := api.StateLookupID(ctx, addr, types.EmptyTSK); err == nil {
+		out[id] = struct{}{}
+	}
+	return out, nil
+}
+
+type chainTail struct {
+	api    v1api.FullNode
+	filter *tailFilter
+	print  func(tailMessage) error
+
+	ar *vm.ActorRegistry
+	// codes caches the code of recipient actors
+	codes map[address.Address]cid.Cid
+}
+
+// replay prints the messages of the tipsets from the given height up to the head.
+func (t *chainTail) replay(ctx context.Context, head *types.TipSet, from abi.ChainEpoch) error {
+	var tss []*types.TipSet
+	for ts := head; ts.Height() >= from; {
+		tss = append(tss, ts)
+		if ts.Height() == 0 {
+			break
+		}
+		var err error
+		ts, err = t.api.ChainGetTipSet(ctx, ts.Parents())
+		if err != nil {
+			return xerrors.Errorf("loading parent tipset: %w", err)
+		}
+	}
+
+	for i := len(tss) - 1; i >= 0; i-- {
+		if err := t.printTipSet(ctx, tss[i], false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *chainTail) printTipSet(ctx context.Context, ts *types.TipSet, reverted bool) error {
+	msgs, err := t.api.ChainGetMessagesInTipset(ctx, ts.Key())
+	if err != nil {
+		return xerrors.Errorf("getting messages in tipset %s: %w", ts.Key(), err)
+	}
+
+	for _, msg := range msgs {
+		m := tailMessage{
+			Height:     ts.Height(),
+			TipSet:     ts.Key(),
+			Reverted:   reverted,
+			Cid:        msg.Cid,
+			From:       msg.Message.From,
+			To:         msg.Message.To,
+			Actor:      "unknown",
+			Method:     msg.Message.Method,
+			MethodName: strconv.FormatUint(uint64(msg.Message.Method), 10),
+			Value:      msg.Message.Value,
+		}
+
+		if code, ok := t.actorCode(ctx, msg.Message.To, ts); ok {
+			m.Actor = path.Base(builtin.ActorNameByCode(code))
+			if mm, ok := t.ar.Methods[code][msg.Message.Method]; ok {
+				m.MethodName = mm.Name
+			}
+		}
+		if msg.Message.Method == 0 {
+			m.MethodName = "Send"
+		}
+
+		if !t.filter.match(m) {
+			continue
+		}
+		if err := t.print(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *chainTail) actorCode(ctx context.Context, addr address.Address, ts *types.TipSet) (cid.Cid, bool) {
+	if c, ok := t.codes[addr]; ok {
+		return c, true
+	}
+	act, err := t.api.StateGetActor(ctx, addr, ts.Key())
+	if err != nil {
+		// the recipient may not exist yet, or anymore
+		return cid.Undef, false
+	}
+	t.codes[addr] = act.Code
+	return act.Code, true
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+)
+
+func TestChainTailFilter(t *testing.T) {
+	miner, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	other, err := address.NewIDAddress(1001)
+	require.NoError(t, err)
+
+	m := tailMessage{
+		From:       other,
+		To:         miner,
+		Actor:      "storageminer",
+		Method:     6,
+		MethodName: "PreCommitSector",
+	}
+
+	require.True(t, (&tailFilter{}).match(m))
+
+	// all filters must match
+	f := &tailFilter{
+		to:     map[address.Address]struct{}{miner: {}},
+		method: "precommitsector",
+		actor:  "storageminer",
+	}
+	require.True(t, f.match(m))
+	f.actor = "multisig"
+	require.False(t, f.match(m))
+
+	// methods match by number too
+	require.True(t, (&tailFilter{method: "6"}).match(m))
+	require.False(t, (&tailFilter{method: "7"}).match(m))
+
+	require.False(t, (&tailFilter{to: map[address.Address]struct{}{other: {}}}).match(m))
+	require.True(t, (&tailFilter{from: map[address.Address]struct{}{other: {}}}).match(m))
+}
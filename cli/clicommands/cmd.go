@@ -19,6 +19,7 @@ var Commands = []*cli.Command{
 	lcli.WithCategory("developer", lcli.ChainCmd),
 	lcli.WithCategory("developer", lcli.LogCmd),
 	lcli.WithCategory("developer", lcli.WaitApiCmd),
+	lcli.WithCategory("developer", lcli.RPCStatsCmd),
 	lcli.WithCategory("developer", lcli.FetchParamCmd),
 	lcli.WithCategory("developer", lcli.EvmCmd),
 	lcli.WithCategory("developer", lcli.IndexCmd),
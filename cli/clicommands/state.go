@@ -28,12 +28,15 @@ var StateCmd = &cli.Command{
 		lcli.StateListActorsCmd,
 		lcli.StateListMinersCmd,
 		lcli.StateCircSupplyCmd,
+		lcli.StateBurnedCmd,
 		lcli.StateSectorCmd,
 		lcli.StateGetActorCmd,
 		lcli.StateLookupIDCmd,
 		lcli.StateReplayCmd,
+		lcli.StateCallTreeCmd,
 		lcli.StateSectorSizeCmd,
 		lcli.StateReadStateCmd,
+		lcli.StateDecodeActorCmd,
 		lcli.StateListMessagesCmd,
 		lcli.StateComputeStateCmd,
 		lcli.StateCallCmd,
@@ -62,6 +62,7 @@ var CommonCommands = []*cli.Command{
 	WaitApiCmd,
 	FetchParamCmd,
 	PprofCmd,
+	RPCStatsCmd,
 	VersionCmd,
 }
 
@@ -5,11 +5,14 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/urfave/cli/v2"
 	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/crypto/sha3"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-address"
@@ -36,6 +39,7 @@ var EvmCmd = &cli.Command{
 		EvmCallSimulateCmd,
 		EvmGetContractAddress,
 		EvmGetBytecode,
+		EvmEventsCmd,
 	},
 }
 
@@ -535,3 +539,148 @@ var EvmGetBytecode = &cli.Command{
 		return nil
 	},
 }
+
+var EvmEventsCmd = &cli.Command{
+	Name:      "events",
+	Usage:     "Print the events emitted by a contract as JSON",
+	ArgsUsage: "[contract-address]",
+	Description: `Queries the event index of the node for the events emitted by a contract, like eth_getLogs,
+and prints them as a JSON array of logs, with their topics, data, and the transaction and block
+which emitted them.
+
+The height range defaults to the latest tipset, and is limited by the Events.MaxFilterHeightRange
+option of the node. Events can be filtered by their first topic, which is the event signature
+hash of non-anonymous Solidity events, either as a hash or as the signature, for example
+'Transfer(address,address,uint256)'.
+
+The node must have the eth RPC and its event index enabled, with Fevm.EnableEthRPC and
+ChainIndexer.EnableIndexer.`,
+	Flags: []cli.Flag{
+		&cli.Int64Flag{
+			Name:        "from-height",
+			Usage:       "height to start from",
+			DefaultText: "latest",
+		},
+		&cli.Int64Flag{
+			Name:        "to-height",
+			Usage:       "height to end at, inclusive",
+			DefaultText: "latest",
+		},
+		&cli.StringFlag{
+			Name:  "topic0",
+			Usage: "only print events with this first topic, as a hash or an event signature",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		if cctx.NArg() != 1 {
+			return IncorrectNumArgs(cctx)
+		}
+
+		contractAddr, err := parseEthOrFilecoinAddress(cctx.Args().First())
+		if err != nil {
+			return err
+		}
+
+		filter := &ethtypes.EthFilterSpec{
+			Address: ethtypes.EthAddressList{contractAddr},
+		}
+		if cctx.IsSet("from-height") && cctx.IsSet("to-height") && cctx.Int64("from-height") > cctx.Int64("to-height") {
+			return xerrors.Errorf("--from-height %d is above --to-height %d", cctx.Int64("from-height"), cctx.Int64("to-height"))
+		}
+		for _, f := range []struct {
+			flag  string
+			block **string
+		}{{"from-height", &filter.FromBlock}, {"to-height", &filter.ToBlock}} {
+			if !cctx.IsSet(f.flag) {
+				continue
+			}
+			h := cctx.Int64(f.flag)
+			if h < 0 {
+				return xerrors.Errorf("--%s can't be negative", f.flag)
+			}
+			blk := ethtypes.EthUint64(h).Hex()
+			*f.block = &blk
+		}
+		if cctx.IsSet("topic0") {
+			topic0, err := parseEventTopic(cctx.String("topic0"))
+			if err != nil {
+				return err
+			}
+			filter.Topics = ethtypes.EthTopicSpec{ethtypes.EthHashList{topic0}}
+		}
+
+		api, closer, err := GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		ctx := ReqContext(cctx)
+
+		res, err := api.EthGetLogs(ctx, filter)
+		if err != nil {
+			return xerrors.Errorf("getting events: %w", err)
+		}
+
+		// the results are decoded from JSON as maps by the RPC client
+		logs := []ethtypes.EthLog{}
+		if res != nil && len(res.Results) > 0 {
+			b, err := json.Marshal(res.Results)
+			if err != nil {
+				return err
+			}
+			if err := json.Unmarshal(b, &logs); err != nil {
+				return xerrors.Errorf("decoding events: %w", err)
+			}
+		}
+
+		if len(logs) == 0 {
+			_, _ = fmt.Fprintf(cctx.App.ErrWriter, "no events found for contract %s\n", contractAddr)
+		}
+
+		out, err := json.MarshalIndent(logs, "", "  ")
+		if err != nil {
+			return err
+		}
+		afmt := NewAppFmt(cctx.App)
+		afmt.Println(string(out))
+		return nil
+	},
+}
+
+// parseEthOrFilecoinAddress parses an eth address, or a filecoin ID or f410 address which can be converted to one
+func parseEthOrFilecoinAddress(s string) (ethtypes.EthAddress, error) {
+	if faddr, err := address.NewFromString(s); err == nil {
+		eaddr, err := ethtypes.EthAddressFromFilecoinAddress(faddr)
+		if err != nil {
+			return ethtypes.EthAddress{}, xerrors.Errorf("converting %s to an eth address: %w", s, err)
+		}
+		return eaddr, nil
+	}
+
+	eaddr, err := ethtypes.ParseEthAddress(s)
+	if err != nil {
+		return ethtypes.EthAddress{}, xerrors.Errorf("address is not a filecoin or eth address")
+	}
+	return eaddr, nil
+}
+
+// parseEventTopic parses an event topic given as a hash, or as an event signature which is hashed
+// into the topic like in Solidity
+func parseEventTopic(s string) (ethtypes.EthHash, error) {
+	if strings.HasPrefix(s, "0x") {
+		h, err := ethtypes.ParseEthHash(s)
+		if err != nil {
+			return ethtypes.EthHash{}, xerrors.Errorf("parsing topic: %w", err)
+		}
+		return h, nil
+	}
+
+	if !strings.Contains(s, "(") || !strings.HasSuffix(s, ")") {
+		return ethtypes.EthHash{}, xerrors.Errorf("topic %q is neither a 0x-prefixed hash nor an event signature", s)
+	}
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(s))
+	var h ethtypes.EthHash
+	copy(h[:], hasher.Sum(nil))
+	return h, nil
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+)
+
+func TestEvmEvents(t *testing.T) {
+	app, mockApi, buf, done := NewMockAppWithFullAPI(t, WithCategory("evm", EvmEventsCmd))
+	defer done()
+	errBuf := new(bytes.Buffer)
+	app.ErrWriter = errBuf
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	contract, err := ethtypes.ParseEthAddress("0xff000000000000000000000000000000000003e8")
+	require.NoError(t, err)
+	topic0, err := parseEventTopic("Transfer(address,address,uint256)")
+	require.NoError(t, err)
+	require.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", topic0.String())
+
+	from, to := "0xa", "0x14"
+	log := ethtypes.EthLog{
+		Address:     contract,
+		Data:        ethtypes.EthBytes{1, 2, 3},
+		Topics:      []ethtypes.EthHash{topic0},
+		BlockNumber: 12,
+	}
+	// the RPC client decodes the results as maps
+	var result map[string]interface{}
+	b, err := json.Marshal(log)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &result))
+
+	mockApi.EXPECT().EthGetLogs(ctx, &ethtypes.EthFilterSpec{
+		FromBlock: &from,
+		ToBlock:   &to,
+		Address:   ethtypes.EthAddressList{contract},
+		Topics:    ethtypes.EthTopicSpec{{topic0}},
+	}).Return(&ethtypes.EthFilterResult{Results: []interface{}{result}}, nil)
+
+	// f01000 is the ID address of the contract
+	err = app.Run([]string{"evm", "events", "--from-height", "10", "--to-height", "20", "--topic0", "Transfer(address,address,uint256)", "f01000"})
+	require.NoError(t, err)
+
+	var logs []ethtypes.EthLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logs))
+	require.Equal(t, []ethtypes.EthLog{log}, logs)
+
+	// no events
+	buf.Reset()
+	mockApi.EXPECT().EthGetLogs(ctx, &ethtypes.EthFilterSpec{
+		Address: ethtypes.EthAddressList{contract},
+	}).Return(&ethtypes.EthFilterResult{}, nil)
+
+	err = app.Run([]string{"evm", "events", contract.String()})
+	require.NoError(t, err)
+	require.Equal(t, "[]\n", buf.String())
+	require.Contains(t, errBuf.String(), "no events found")
+
+	err = app.Run([]string{"evm", "events", "--from-height", "20", "--to-height", "10", contract.String()})
+	require.Error(t, err)
+
+	err = app.Run([]string{"evm", "events", "--topic0", "Transfer", contract.String()})
+	require.Error(t, err)
+}
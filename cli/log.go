@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -16,6 +18,7 @@ var LogCmd = &cli.Command{
 		LogList,
 		LogSetLevel,
 		LogAlerts,
+		LogTail,
 	},
 }
 
@@ -151,3 +154,69 @@ var LogAlerts = &cli.Command{
 		return nil
 	},
 }
+
+var LogTail = &cli.Command{
+	Name:  "tail",
+	Usage: "Stream log entries of the node",
+	Description: `Streams the log entries of the node at or above the given level.
+
+   Entries below the level set on a log system with set-level are not produced, so to stream
+   debug entries of a system, set its level to debug first.
+
+   eg) log tail --level warn --system chain`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "level",
+			Usage: "minimum level of the streamed entries",
+			Value: "info",
+		},
+		&cli.StringSliceFlag{
+			Name:  "system",
+			Usage: "limit to log system",
+			Value: &cli.StringSlice{},
+		},
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "print entries as json objects, one per line",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		api, closer, err := GetAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		ctx := ReqContext(cctx)
+
+		entries, err := api.LogStream(ctx, cctx.String("level"), cctx.StringSlice("system"))
+		if err != nil {
+			return err
+		}
+
+		enc := json.NewEncoder(cctx.App.Writer)
+		for e := range entries {
+			if cctx.Bool("json") {
+				if err := enc.Encode(e); err != nil {
+					return err
+				}
+				continue
+			}
+
+			var fields string
+			if len(e.Fields) > 0 {
+				fb, err := json.Marshal(e.Fields)
+				if err != nil {
+					return err
+				}
+				fields = " " + string(fb)
+			}
+			_, _ = fmt.Fprintf(cctx.App.Writer, "%s\t%s\t%s\t%s\t%s%s\n", e.Time.Format(time.RFC3339Nano), strings.ToUpper(e.Level), e.Logger, e.Caller, e.Message, fields)
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		return xerrors.Errorf("log stream closed")
+	},
+}
@@ -614,6 +614,11 @@ var MpoolConfig = &cli.Command{
 	Name:      "config",
 	Usage:     "get or set current mpool configuration",
 	ArgsUsage: "[new-config]",
+	Description: `Without arguments, prints the mpool configuration the node currently applies, as JSON.
+
+With a JSON config argument, replaces the whole configuration, so fields which
+are left out are set to zero, and prints the configuration applied by the node
+after the change. PruneCooldown is in nanoseconds.`,
 	Action: func(cctx *cli.Context) error {
 		if cctx.NArg() > 1 {
 			return IncorrectNumArgs(cctx)
@@ -650,7 +655,22 @@ var MpoolConfig = &cli.Command{
 				return err
 			}
 
-			return api.MpoolSetConfig(ctx, cfg)
+			if err := api.MpoolSetConfig(ctx, cfg); err != nil {
+				return err
+			}
+
+			// read the config back, to confirm what the node applies
+			cfg, err = api.MpoolGetConfig(ctx)
+			if err != nil {
+				return xerrors.Errorf("getting applied config: %w", err)
+			}
+
+			bytes, err = json.Marshal(cfg)
+			if err != nil {
+				return err
+			}
+
+			afmt.Println(string(bytes))
 		}
 
 		return nil
@@ -541,7 +541,7 @@ func TestConfig(t *testing.T) {
 	})
 
 	t.Run("set", func(t *testing.T) {
-		app, mockApi, _, done := NewMockAppWithFullAPI(t, WithCategory("mpool", MpoolConfig))
+		app, mockApi, buf, done := NewMockAppWithFullAPI(t, WithCategory("mpool", MpoolConfig))
 		defer done()
 
 		ctx, cancel := context.WithCancel(context.Background())
@@ -556,6 +556,7 @@ func TestConfig(t *testing.T) {
 		mpoolCfg := &types.MpoolConfig{PriorityAddrs: []address.Address{senderAddr}, SizeLimitHigh: 234567, SizeLimitLow: 3, ReplaceByFeeRatio: types.Percent(33)}
 		gomock.InOrder(
 			mockApi.EXPECT().MpoolSetConfig(ctx, mpoolCfg).Return(nil),
+			mockApi.EXPECT().MpoolGetConfig(ctx).Return(mpoolCfg, nil),
 		)
 
 		bytes, err := json.Marshal(mpoolCfg)
@@ -565,5 +566,8 @@ func TestConfig(t *testing.T) {
 
 		err = app.Run([]string{"mpool", "config", string(bytes)})
 		assert.NoError(t, err)
+
+		// the applied config is printed
+		assert.Equal(t, string(bytes)+"\n", buf.String())
 	})
 }
@@ -38,6 +38,7 @@ var NetCmd = &cli.Command{
 		NetListen,
 		NetId,
 		NetFindPeer,
+		NetPeerProtocols,
 		NetScores,
 		NetReachability,
 		NetBandwidthCmd,
@@ -47,6 +48,7 @@ var NetCmd = &cli.Command{
 		NetProtectAdd,
 		NetProtectRemove,
 		NetProtectList,
+		NetSubnets,
 	},
 }
 
@@ -427,6 +429,40 @@ var NetFindPeer = &cli.Command{
 	},
 }
 
+var NetPeerProtocols = &cli.Command{
+	Name:      "protocols",
+	Usage:     "Print the libp2p protocols supported by a peer",
+	ArgsUsage: "[peerId]",
+	Action: func(cctx *cli.Context) error {
+		if cctx.NArg() != 1 {
+			return IncorrectNumArgs(cctx)
+		}
+
+		pid, err := peer.Decode(cctx.Args().First())
+		if err != nil {
+			return err
+		}
+
+		api, closer, err := GetFullNodeAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		ctx := ReqContext(cctx)
+
+		protos, err := api.NetPeerProtocols(ctx, pid)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range protos {
+			fmt.Println(p)
+		}
+		return nil
+	},
+}
+
 var NetReachability = &cli.Command{
 	Name:  "reachability",
 	Usage: "Print information about reachability from the internet",
@@ -779,6 +815,42 @@ func BarString(total, y, g float64) string {
 	return barString
 }
 
+var NetSubnets = &cli.Command{
+	Name:  "subnets",
+	Usage: "Print the number of open connections per /24 IPv4 and /64 IPv6 subnet",
+	Action: func(cctx *cli.Context) error {
+		api, closer, err := GetFullNodeAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		ctx := ReqContext(cctx)
+
+		subnets, err := api.NetSubnetConns(ctx)
+		if err != nil {
+			return err
+		}
+
+		sort.Slice(subnets, func(i, j int) bool {
+			if subnets[i].Conns != subnets[j].Conns {
+				return subnets[i].Conns > subnets[j].Conns
+			}
+			return subnets[i].Subnet < subnets[j].Subnet
+		})
+
+		tw := tabwriter.NewWriter(os.Stdout, 4, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintf(tw, "Subnet\tConnections\tLimit\n")
+		for _, s := range subnets {
+			limit := "none"
+			if s.Limit > 0 {
+				limit = fmt.Sprint(s.Limit)
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\n", s.Subnet, s.Conns, limit)
+		}
+		return tw.Flush()
+	},
+}
+
 var NetStatCmd = &cli.Command{
 	Name:  "stat",
 	Usage: "Report resource usage for a scope",
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var RPCStatsCmd = &cli.Command{
+	Name:  "rpc-stats",
+	Usage: "Show RPC method calls per client",
+	Description: `Shows the number of calls and the cumulative duration of RPC methods for each client
+connection or API token, most recently active clients first. Recording must be enabled with the
+API.RPCStats config option.`,
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:  "top",
+			Usage: "number of methods with the highest cumulative duration to show per client (0 for all)",
+			Value: 10,
+		},
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "output in json format",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		api, closer, err := GetAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		ctx := ReqContext(cctx)
+
+		stats, err := api.RPCStats(ctx)
+		if err != nil {
+			return err
+		}
+
+		if cctx.Bool("json") {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(stats)
+		}
+
+		if len(stats) == 0 {
+			fmt.Println("No RPC calls recorded, check that API.RPCStats is enabled in the node config")
+			return nil
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+		for _, cs := range stats {
+			var calls uint64
+			var total time.Duration
+			for _, ms := range cs.Methods {
+				calls += ms.Calls
+				total += ms.Duration
+			}
+			_, _ = fmt.Fprintf(tw, "%s\tcalls: %d\ttime: %s\tlast call: %s\n", cs.Client, calls, total.Round(time.Millisecond), cs.LastCall.Format(time.DateTime))
+
+			methods := cs.Methods
+			if top := cctx.Int("top"); top > 0 && len(methods) > top {
+				methods = methods[:top]
+			}
+			for _, ms := range methods {
+				_, _ = fmt.Fprintf(tw, "  %s\t%d\t%s\t%d errors\n", ms.Method, ms.Calls, ms.Duration.Round(time.Millisecond), ms.Errors)
+			}
+		}
+		return tw.Flush()
+	},
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"strconv"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/docker/go-units"
 	cbor "github.com/ipfs/go-ipld-cbor"
@@ -225,6 +227,143 @@ func ActorWithdrawCmd(getActor ActorAddressGetter) *cli.Command {
 	}
 }
 
+func ActorWithdrawBatchCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "withdraw-batch",
+		Usage:     "withdraw available balance from multiple miner actors",
+		ArgsUsage: "[minerAddress=amount (FIL) or minerAddress=all ...]",
+		Description: `Withdraws the given amounts, or the whole available balance with 'all', from each of the
+miner actors to their beneficiaries. The available balances of all the actors are checked
+before any message is sent, and the messages are sent in order, so that the messages from the
+same sender get consecutive nonces. Sending stops at the first message which can't be pushed.
+
+Example:
+   lotus-miner actor withdraw-batch f01000=100 f01001=all`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "beneficiary",
+				Usage: "send withdraw messages from the beneficiary addresses",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "only check the available balances and print the withdrawals",
+			},
+		},
+		Action: func(cctx *cli.Context) error {
+			if !cctx.Args().Present() {
+				return lcli.IncorrectNumArgs(cctx)
+			}
+
+			api, acloser, err := lcli.GetFullNodeAPIV1(cctx)
+			if err != nil {
+				return err
+			}
+			defer acloser()
+
+			ctx := lcli.ReqContext(cctx)
+
+			head, err := api.ChainHead(ctx)
+			if err != nil {
+				return err
+			}
+
+			type withdrawal struct {
+				maddr     address.Address
+				sender    address.Address
+				amount    abi.TokenAmount
+				available abi.TokenAmount
+			}
+
+			var withdrawals []*withdrawal
+			requested := map[address.Address]*withdrawal{}
+			for _, arg := range cctx.Args().Slice() {
+				as, amt, ok := strings.Cut(arg, "=")
+				if !ok {
+					return xerrors.Errorf("expected minerAddress=amount, got %q", arg)
+				}
+
+				maddr, err := address.NewFromString(as)
+				if err != nil {
+					return xerrors.Errorf("parsing miner address %q: %w", as, err)
+				}
+
+				w, ok := requested[maddr]
+				if !ok {
+					available, err := api.StateMinerAvailableBalance(ctx, maddr, head.Key())
+					if err != nil {
+						return xerrors.Errorf("getting available balance of %s: %w", maddr, err)
+					}
+
+					mi, err := api.StateMinerInfo(ctx, maddr, head.Key())
+					if err != nil {
+						return xerrors.Errorf("getting miner info of %s: %w", maddr, err)
+					}
+
+					w = &withdrawal{maddr: maddr, sender: mi.Owner, amount: big.Zero(), available: available}
+					if cctx.Bool("beneficiary") {
+						w.sender = mi.Beneficiary
+					}
+					requested[maddr] = w
+					withdrawals = append(withdrawals, w)
+				}
+
+				if amt == "all" {
+					w.amount = w.available
+					continue
+				}
+
+				f, err := types.ParseFIL(amt)
+				if err != nil {
+					return xerrors.Errorf("parsing amount for %s: %w", maddr, err)
+				}
+				w.amount = big.Add(w.amount, abi.TokenAmount(f))
+			}
+
+			for _, w := range withdrawals {
+				if w.amount.IsZero() {
+					return xerrors.Errorf("nothing to withdraw from %s, the available balance is %s", w.maddr, types.FIL(w.available))
+				}
+				if w.amount.GreaterThan(w.available) {
+					return xerrors.Errorf("can't withdraw more funds than available from %s; requested: %s; available: %s", w.maddr, types.FIL(w.amount), types.FIL(w.available))
+				}
+			}
+
+			tw := tabwriter.NewWriter(cctx.App.Writer, 2, 4, 2, ' ', 0)
+			_, _ = fmt.Fprintln(tw, "Miner\tSender\tAmount\tAvailable\tMessage")
+			defer tw.Flush() //nolint:errcheck
+
+			for _, w := range withdrawals {
+				msgCid := "(dry run)"
+				if !cctx.Bool("dry-run") {
+					params, aerr := actors.SerializeParams(&lminer.WithdrawBalanceParams{
+						AmountRequested: w.amount,
+					})
+					if aerr != nil {
+						return aerr
+					}
+
+					smsg, err := api.MpoolPushMessage(ctx, &types.Message{
+						To:     w.maddr,
+						From:   w.sender,
+						Value:  types.NewInt(0),
+						Method: builtin.MethodsMiner.WithdrawBalance,
+						Params: params,
+					}, nil)
+					if err != nil {
+						_ = tw.Flush()
+						return xerrors.Errorf("pushing withdrawal from %s, the withdrawals above were sent: %w", w.maddr, err)
+					}
+					msgCid = smsg.Cid().String()
+				}
+
+				_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", w.maddr, w.sender, types.FIL(w.amount), types.FIL(w.available), msgCid)
+			}
+
+			return nil
+		},
+	}
+}
+
 func ActorSetAddrsCmd(getActor ActorAddressGetter) *cli.Command {
 	return &cli.Command{
 		Name:      "set-addresses",
@@ -499,14 +638,14 @@ func ActorRepayDebtCmd(getActor ActorAddressGetter) *cli.Command {
 	}
 }
 
-func ActorControlCmd(getActor ActorAddressGetter, actorControlListCmd *cli.Command) *cli.Command {
+func ActorControlCmd(getActor ActorAddressGetter, actorControlListCmd *cli.Command, extraCmds ...*cli.Command) *cli.Command {
 	return &cli.Command{
 		Name:  "control",
 		Usage: "Manage control addresses",
-		Subcommands: []*cli.Command{
+		Subcommands: append([]*cli.Command{
 			actorControlListCmd,
 			actorControlSet(getActor),
-		},
+		}, extraCmds...),
 	}
 }
 
@@ -848,6 +987,10 @@ func ActorProposeChangeBeneficiaryCmd(getActor ActorAddressGetter) *cli.Command
 				Usage: "Overwrite the current beneficiary change proposal",
 				Value: false,
 			},
+			&cli.BoolFlag{
+				Name:  "allow-quota-above-balance",
+				Usage: "allow a quota above the current available balance of the miner, to let the beneficiary also withdraw future rewards",
+			},
 			&cli.StringFlag{
 				Name:  "actor",
 				Usage: "specify the address of miner actor",
@@ -900,6 +1043,28 @@ func ActorProposeChangeBeneficiaryCmd(getActor ActorAddressGetter) *cli.Command
 				return fmt.Errorf("beneficiary %s already set to owner address", mi.Beneficiary)
 			}
 
+			if abi.TokenAmount(quota).LessThan(big.Zero()) {
+				return fmt.Errorf("quota must not be negative")
+			}
+
+			if newAddr != mi.Owner {
+				head, err := api.ChainHead(ctx)
+				if err != nil {
+					return xerrors.Errorf("getting chain head: %w", err)
+				}
+				if abi.ChainEpoch(expiration) <= head.Height() {
+					return fmt.Errorf("expiration epoch %d must be in the future, current height is %d", expiration, head.Height())
+				}
+
+				available, err := api.StateMinerAvailableBalance(ctx, maddr, head.Key())
+				if err != nil {
+					return xerrors.Errorf("getting miner available balance: %w", err)
+				}
+				if abi.TokenAmount(quota).GreaterThan(available) && !cctx.Bool("allow-quota-above-balance") {
+					return fmt.Errorf("quota %s is above the available balance %s of the miner, pass --allow-quota-above-balance to use it anyway", quota, types.FIL(available))
+				}
+			}
+
 			if mi.PendingBeneficiaryTerm != nil {
 				fmt.Println("WARNING: replacing Pending Beneficiary Term of:")
 				fmt.Println("Beneficiary: ", mi.PendingBeneficiaryTerm.NewBeneficiary)
@@ -960,6 +1125,7 @@ func ActorProposeChangeBeneficiaryCmd(getActor ActorAddressGetter) *cli.Command
 				fmt.Println("Beneficiary address successfully changed")
 			} else {
 				fmt.Println("Beneficiary address change awaiting additional confirmations")
+				printBeneficiaryConfirmations(updatedMinerInfo)
 			}
 
 			return nil
@@ -1087,7 +1253,7 @@ func ActorConfirmChangeBeneficiaryCmd(getActor ActorAddressGetter) *cli.Command
 			},
 		},
 		Action: func(cctx *cli.Context) error {
-			if cctx.NArg() != 1 {
+			if cctx.NArg() > 1 {
 				return lcli.IncorrectNumArgs(cctx)
 			}
 
@@ -1099,9 +1265,17 @@ func ActorConfirmChangeBeneficiaryCmd(getActor ActorAddressGetter) *cli.Command
 
 			ctx := lcli.ReqContext(cctx)
 
-			maddr, err := address.NewFromString(cctx.Args().First())
-			if err != nil {
-				return xerrors.Errorf("parsing beneficiary address: %w", err)
+			var maddr address.Address
+			if cctx.Args().Present() {
+				maddr, err = address.NewFromString(cctx.Args().First())
+				if err != nil {
+					return xerrors.Errorf("parsing miner address: %w", err)
+				}
+			} else {
+				maddr, err = getActor(cctx)
+				if err != nil {
+					return xerrors.Errorf("getting miner address: %w", err)
+				}
 			}
 
 			mi, err := api.StateMinerInfo(ctx, maddr, types.EmptyTSK)
@@ -1184,6 +1358,7 @@ func ActorConfirmChangeBeneficiaryCmd(getActor ActorAddressGetter) *cli.Command
 				fmt.Println("Beneficiary address successfully changed")
 			} else {
 				fmt.Println("Beneficiary address change awaiting additional confirmations")
+				printBeneficiaryConfirmations(updatedMinerInfo)
 			}
 
 			return nil
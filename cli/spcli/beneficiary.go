@@ -0,0 +1,128 @@
+package spcli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/big"
+
+	lapi "github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types"
+	lcli "github.com/filecoin-project/lotus/cli"
+	cliutil "github.com/filecoin-project/lotus/cli/util"
+)
+
+// ActorBeneficiaryCmd groups the commands managing the beneficiary of the miner actor (FIP-0029).
+func ActorBeneficiaryCmd(getActor ActorAddressGetter) *cli.Command {
+	propose := ActorProposeChangeBeneficiaryCmd(getActor)
+	propose.Name = "propose"
+
+	confirm := ActorConfirmChangeBeneficiaryCmd(getActor)
+	confirm.Name = "confirm"
+
+	return &cli.Command{
+		Name:  "beneficiary",
+		Usage: "Manage the beneficiary of the miner actor",
+		Description: `The beneficiary receives the funds withdrawn from the miner actor, up to its quota and until its
+expiration epoch, after which withdrawals go to the owner again. Changing the beneficiary is a
+two-step process:
+
+ 1. The owner proposes the new beneficiary, quota and expiration with 'beneficiary propose'.
+ 2. The change is confirmed with 'beneficiary confirm', from the current beneficiary (unless it is
+    the owner, or its term has expired or is used up) and from the new beneficiary (unless it is
+    the owner).
+
+'beneficiary info' shows the current term and which confirmations a pending change is waiting for.`,
+		Subcommands: []*cli.Command{
+			ActorBeneficiaryInfoCmd(getActor),
+			propose,
+			confirm,
+		},
+	}
+}
+
+func ActorBeneficiaryInfoCmd(getActor ActorAddressGetter) *cli.Command {
+	return &cli.Command{
+		Name:  "info",
+		Usage: "Show the current beneficiary and any pending beneficiary change",
+		Action: func(cctx *cli.Context) error {
+			api, acloser, err := lcli.GetFullNodeAPI(cctx)
+			if err != nil {
+				return xerrors.Errorf("getting fullnode api: %w", err)
+			}
+			defer acloser()
+
+			ctx := lcli.ReqContext(cctx)
+
+			maddr, err := getActor(cctx)
+			if err != nil {
+				return xerrors.Errorf("getting miner address: %w", err)
+			}
+
+			head, err := api.ChainHead(ctx)
+			if err != nil {
+				return xerrors.Errorf("getting chain head: %w", err)
+			}
+
+			mi, err := api.StateMinerInfo(ctx, maddr, head.Key())
+			if err != nil {
+				return xerrors.Errorf("getting miner info: %w", err)
+			}
+
+			available, err := api.StateMinerAvailableBalance(ctx, maddr, head.Key())
+			if err != nil {
+				return xerrors.Errorf("getting miner available balance: %w", err)
+			}
+
+			fmt.Printf("Owner:\t\t%s\n", mi.Owner)
+			fmt.Printf("Available:\t%s\n", types.FIL(available))
+			if mi.Beneficiary == mi.Owner {
+				fmt.Printf("Beneficiary:\t%s (owner)\n", mi.Beneficiary)
+			} else {
+				fmt.Printf("Beneficiary:\t%s\n", mi.Beneficiary)
+			}
+			if mi.BeneficiaryTerm != nil && mi.Beneficiary != mi.Owner {
+				remaining := big.Sub(mi.BeneficiaryTerm.Quota, mi.BeneficiaryTerm.UsedQuota)
+				fmt.Printf("Quota:\t\t%s\n", types.FIL(mi.BeneficiaryTerm.Quota))
+				fmt.Printf("Used Quota:\t%s (%s remaining)\n", types.FIL(mi.BeneficiaryTerm.UsedQuota), types.FIL(remaining))
+				fmt.Printf("Expiration:\t%s\n", cliutil.EpochTime(head.Height(), mi.BeneficiaryTerm.Expiration))
+				if mi.BeneficiaryTerm.Expiration <= head.Height() || remaining.LessThanEqual(big.Zero()) {
+					fmt.Println("\t\tthe term is over, withdrawals go to the owner")
+				}
+			}
+
+			if mi.PendingBeneficiaryTerm == nil {
+				fmt.Println("\nNo pending beneficiary change")
+				return nil
+			}
+
+			fmt.Println("\nPending beneficiary change:")
+			fmt.Printf("  New Beneficiary:\t%s\n", mi.PendingBeneficiaryTerm.NewBeneficiary)
+			fmt.Printf("  New Quota:\t\t%s\n", types.FIL(mi.PendingBeneficiaryTerm.NewQuota))
+			fmt.Printf("  New Expiration:\t%s\n", cliutil.EpochTime(head.Height(), mi.PendingBeneficiaryTerm.NewExpiration))
+			printBeneficiaryConfirmations(mi)
+
+			return nil
+		},
+	}
+}
+
+// printBeneficiaryConfirmations prints which confirmations the pending beneficiary change of the
+// miner is waiting for.
+func printBeneficiaryConfirmations(mi lapi.MinerInfo) {
+	pending := mi.PendingBeneficiaryTerm
+	if pending == nil {
+		return
+	}
+
+	// the current beneficiary doesn't need to approve when it is the owner, or when its term is
+	// used up or expired, which the actor checks when the change is confirmed
+	if mi.Beneficiary != mi.Owner && !pending.ApprovedByBeneficiary {
+		fmt.Printf("  Waiting for confirmation by the current beneficiary %s, send with 'beneficiary confirm --existing-beneficiary'\n", mi.Beneficiary)
+	}
+	if pending.NewBeneficiary != mi.Owner && !pending.ApprovedByNominee {
+		fmt.Printf("  Waiting for confirmation by the new beneficiary %s, send with 'beneficiary confirm --new-beneficiary'\n", pending.NewBeneficiary)
+	}
+}
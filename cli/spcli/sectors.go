@@ -160,6 +160,12 @@ func SectorsStatusCmd(getActorAddress ActorAddressGetter, getOnDiskInfo OnDiskIn
 				}
 
 				if status == nil {
+					_, err := fullApi.StateSectorPartition(ctx, maddr, abi.SectorNumber(id), head.Key())
+					if api.ErrorIsIn(err, []error{&api.ErrSectorNotAssigned{}}) {
+						fmt.Println("Sector is pre-committed, not yet assigned to a deadline")
+						return nil
+					}
+
 					fmt.Println("Sector status not found on chain")
 					return nil
 				}
@@ -176,6 +182,14 @@ func SectorsStatusCmd(getActorAddress ActorAddressGetter, getOnDiskInfo OnDiskIn
 				fmt.Printf("VerifiedDealWeight:\t\t%v\n", status.VerifiedDealWeight)
 				fmt.Printf("InitialPledge:\t\t%v\n", types.FIL(status.InitialPledge))
 				fmt.Printf("SectorID:\t\t{Miner: %v, Number: %v}\n", abi.ActorID(mid), status.SectorNumber)
+
+				loc, err := fullApi.StateSectorPartition(ctx, maddr, abi.SectorNumber(id), head.Key())
+				if err != nil {
+					fmt.Printf("Deadline:\t\tunknown (%s)\n", err)
+				} else {
+					fmt.Printf("Deadline:\t\t%d\n", loc.Deadline)
+					fmt.Printf("Partition:\t\t%d\n", loc.Partition)
+				}
 			}
 
 			if cctx.Bool("partition-info") {
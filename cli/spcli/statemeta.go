@@ -69,6 +69,7 @@ var StateList = []StateMeta{
 	{Col: color.FgRed, State: sealing.ComputeProofFailed},
 	{Col: color.FgRed, State: sealing.RemoteCommitFailed},
 	{Col: color.FgRed, State: sealing.CommitFailed},
+	{Col: color.FgRed, State: sealing.PreCommitExpired},
 	{Col: color.FgRed, State: sealing.CommitFinalizeFailed},
 	{Col: color.FgRed, State: sealing.PackingFailed},
 	{Col: color.FgRed, State: sealing.FinalizeFailed},
@@ -451,6 +451,76 @@ var StateReplayCmd = &cli.Command{
 	},
 }
 
+var StateCallTreeCmd = &cli.Command{
+	Name:      "call-tree",
+	Usage:     "Print the tree of internal sends of a message, with the gas charged in each call",
+	ArgsUsage: "<messageCid>",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "print the call tree as json",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		if cctx.NArg() != 1 {
+			return IncorrectNumArgs(cctx)
+		}
+
+		mcid, err := cid.Decode(cctx.Args().First())
+		if err != nil {
+			return fmt.Errorf("message cid was invalid: %s", err)
+		}
+
+		fapi, closer, err := GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		ctx := ReqContext(cctx)
+
+		tree, err := fapi.StateCallTree(ctx, mcid)
+		if err != nil {
+			return xerrors.Errorf("getting call tree: %w", err)
+		}
+
+		if cctx.Bool("json") {
+			out, err := json.MarshalIndent(tree, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+
+		if tree.MsgCid != mcid {
+			fmt.Printf("Message was replaced on chain by %s\n", tree.MsgCid)
+		}
+		fmt.Printf("Exit code: %d\n", tree.ExitCode)
+		fmt.Printf("Gas Used: %d\n", tree.GasUsed)
+		if tree.GasMismatch {
+			fmt.Printf("Warning: the execution trace accounts for %d gas, the call tree is incomplete\n", tree.TraceGasUsed)
+		}
+		fmt.Println()
+
+		tw := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "Call\tActor\tMethod\tValue\tExit\tGas\tTotal Gas")
+		printCallTree(tw, "", tree.Root)
+		return tw.Flush()
+	},
+}
+
+func printCallTree(w io.Writer, prefix string, n api.CallTreeNode) {
+	method := fmt.Sprint(n.Method)
+	if n.MethodName != "" {
+		method = fmt.Sprintf("%s (%d)", n.MethodName, n.Method)
+	}
+	_, _ = fmt.Fprintf(w, "%s%s -> %s\t%s\t%s\t%s\t%d\t%d\t%d\n", prefix, n.From, n.To, n.Actor, method, types.FIL(n.Value).Short(), n.ExitCode, n.GasUsed, n.TotalGasUsed)
+	for _, sc := range n.Subcalls {
+		printCallTree(w, prefix+"  ", sc)
+	}
+}
+
 var StateGetDealSetCmd = &cli.Command{
 	Name:      "get-deal",
 	Usage:     "View on-chain deal info",
@@ -734,8 +804,18 @@ var StateReadStateCmd = &cli.Command{
 	Name:      "read-state",
 	Usage:     "View a json representation of an actors state",
 	ArgsUsage: "[actorAddress]",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "path",
+			Usage: "only print the state field at this dot-separated path, e.g. 'Info' or 'Sectors'",
+		},
+		&cli.IntFlag{
+			Name:  "depth",
+			Usage: "limit the nesting depth of printed objects and arrays, 1 prints only top-level fields (0 for unlimited)",
+		},
+	},
 	Action: func(cctx *cli.Context) error {
-		api, closer, err := GetFullNodeAPI(cctx)
+		api, closer, err := GetFullNodeAPIV1(cctx)
 		if err != nil {
 			return err
 		}
@@ -757,7 +837,15 @@ var StateReadStateCmd = &cli.Command{
 			return err
 		}
 
-		as, err := api.StateReadState(ctx, addr, ts.Key())
+		var as *lapi.ActorState
+		if cctx.IsSet("path") || cctx.IsSet("depth") {
+			as, err = api.StateReadStateWithOpts(ctx, addr, lapi.ReadStateOpts{
+				Path:  cctx.String("path"),
+				Depth: cctx.Int("depth"),
+			}, ts.Key())
+		} else {
+			as, err = api.StateReadState(ctx, addr, ts.Key())
+		}
 		if err != nil {
 			return err
 		}
@@ -772,6 +860,100 @@ var StateReadStateCmd = &cli.Command{
 	},
 }
 
+var StateDecodeActorCmd = &cli.Command{
+	Name:      "decode-actor",
+	Usage:     "Decode the state of an actor to json, with its type and actors version",
+	ArgsUsage: "[actorAddress]",
+	Description: `Loads the actor, identifies its builtin actor type and actors version from its code CID, and
+decodes its state with the bindings of that version. For actors which can't be decoded, such as
+actors of unknown code, the raw state CID is printed instead, which can be inspected with
+'lotus chain get'.`,
+	Action: func(cctx *cli.Context) error {
+		api, closer, err := GetFullNodeAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		ctx := ReqContext(cctx)
+
+		if cctx.NArg() != 1 {
+			return IncorrectNumArgs(cctx)
+		}
+
+		addr, err := address.NewFromString(cctx.Args().First())
+		if err != nil {
+			return err
+		}
+
+		ts, err := LoadTipSet(ctx, cctx, api)
+		if err != nil {
+			return err
+		}
+
+		act, err := api.StateGetActor(ctx, addr, ts.Key())
+		if err != nil {
+			return err
+		}
+
+		out := struct {
+			Address      address.Address
+			ID           address.Address `json:",omitempty"`
+			Code         cid.Cid
+			Type         string
+			ActorVersion *actorstypes.Version `json:",omitempty"`
+			Balance      types.FIL
+			Nonce        uint64
+			Head         cid.Cid
+			State        interface{} `json:",omitempty"`
+			Note         string      `json:",omitempty"`
+		}{
+			Address: addr,
+			Code:    act.Code,
+			Type:    "unknown",
+			Balance: types.FIL(act.Balance),
+			Nonce:   act.Nonce,
+			Head:    act.Head,
+		}
+
+		if id, err := api.StateLookupID(ctx, addr, ts.Key()); err == nil && id != addr {
+			out.ID = id
+		}
+
+		if name, av, ok := actorTypeByCode(act.Code); ok {
+			out.Type = name
+			out.ActorVersion = &av
+		}
+
+		as, err := api.StateReadState(ctx, addr, ts.Key())
+		if err != nil {
+			out.Note = fmt.Sprintf("can't decode the state of %s actors, inspect the raw state at Head: %s", out.Type, err)
+		} else {
+			out.State = as.State
+		}
+
+		return PrintJson(out)
+	},
+}
+
+// actorTypeByCode returns the builtin actor type and actors version of the actor code.
+func actorTypeByCode(c cid.Cid) (string, actorstypes.Version, bool) {
+	if name, av, ok := actors.GetActorMetaByCode(c); ok {
+		return name, av, true
+	}
+
+	// actors before v8 are named fil/<version>/<type>
+	parts := strings.Split(builtin.ActorNameByCode(c), "/")
+	if len(parts) != 3 || parts[0] != "fil" {
+		return "", 0, false
+	}
+	v, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, false
+	}
+	return parts[2], actorstypes.Version(v), true
+}
+
 var StateListMessagesCmd = &cli.Command{
 	Name:  "list-messages",
 	Usage: "list messages on chain matching given criteria",
@@ -1399,13 +1581,14 @@ var StateCircSupplyCmd = &cli.Command{
 		},
 	},
 	Action: func(cctx *cli.Context) error {
-		api, closer, err := GetFullNodeAPI(cctx)
+		api, closer, err := GetFullNodeAPIV1(cctx)
 		if err != nil {
 			return err
 		}
 		defer closer()
 
 		ctx := ReqContext(cctx)
+		afmt := NewAppFmt(cctx.App)
 
 		ts, err := LoadTipSet(ctx, cctx, api)
 		if err != nil {
@@ -1418,18 +1601,24 @@ var StateCircSupplyCmd = &cli.Command{
 				return err
 			}
 
-			fmt.Println("Circulating supply: ", types.FIL(circ.FilCirculating))
-			fmt.Println("Mined: ", types.FIL(circ.FilMined))
-			fmt.Println("Vested: ", types.FIL(circ.FilVested))
-			fmt.Println("Burnt: ", types.FIL(circ.FilBurnt))
-			fmt.Println("Locked: ", types.FIL(circ.FilLocked))
+			afmt.Println("Circulating supply: ", types.FIL(circ.FilCirculating))
+			afmt.Println("Mined: ", types.FIL(circ.FilMined))
+			afmt.Println("Vested: ", types.FIL(circ.FilVested))
+			afmt.Println("Burnt: ", types.FIL(circ.FilBurnt))
+			afmt.Println("Locked: ", types.FIL(circ.FilLocked))
 		} else {
 			circ, err := api.StateCirculatingSupply(ctx, ts.Key())
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Exact circulating supply: ", types.FIL(circ))
+			burned, err := api.StateTotalBurned(ctx, ts.Key())
+			if err != nil {
+				return err
+			}
+
+			afmt.Println("Exact circulating supply: ", types.FIL(circ))
+			afmt.Println("Total burned: ", types.FIL(burned))
 			return nil
 		}
 
@@ -1437,6 +1626,69 @@ var StateCircSupplyCmd = &cli.Command{
 	},
 }
 
+var StateBurnedCmd = &cli.Command{
+	Name:  "burned",
+	Usage: "Get the total amount of FIL burned",
+	Description: `Prints the total amount of FIL burned by the messages of the chain below the tipset, which is
+the balance of the burnt funds actor (f099) in the tipset's parent state; the messages of the
+tipset itself aren't executed yet. With --since, also prints the amount burned between the given
+height and the tipset.`,
+	Flags: []cli.Flag{
+		&cli.Int64Flag{
+			Name:  "since",
+			Usage: "also print the amount burned since this epoch",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		api, closer, err := GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		ctx := ReqContext(cctx)
+		afmt := NewAppFmt(cctx.App)
+
+		ts, err := LoadTipSet(ctx, cctx, api)
+		if err != nil {
+			return err
+		}
+
+		burned, err := api.StateTotalBurned(ctx, ts.Key())
+		if err != nil {
+			return err
+		}
+
+		afmt.Printf("Total burned: %s\n", types.FIL(burned))
+
+		if cctx.IsSet("since") {
+			since := abi.ChainEpoch(cctx.Int64("since"))
+			if since >= ts.Height() {
+				return xerrors.Errorf("since epoch %d must be below the tipset height %d", since, ts.Height())
+			}
+
+			sinceTs, err := api.ChainGetTipSetAfterHeight(ctx, since, ts.Key())
+			if err != nil {
+				return xerrors.Errorf("getting tipset at height %d: %w", since, err)
+			}
+			sinceBurned, err := api.StateTotalBurned(ctx, sinceTs.Key())
+			if err != nil {
+				return err
+			}
+
+			diff := big.Sub(burned, sinceBurned)
+			epochs := ts.Height() - sinceTs.Height()
+			if epochs == 0 {
+				return xerrors.Errorf("no tipsets between epoch %d and the tipset", since)
+			}
+			perDay := big.Div(big.Mul(diff, big.NewInt(int64(builtin.EpochsInDay))), big.NewInt(int64(epochs)))
+			afmt.Printf("Burned since %d: %s (%d epochs, %s/day)\n", sinceTs.Height(), types.FIL(diff), epochs, types.FIL(perDay).Short())
+		}
+
+		return nil
+	},
+}
+
 var StateSectorCmd = &cli.Command{
 	Name:      "sector",
 	Aliases:   []string{"sector-info"},
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/manifest"
+	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
+
+	"github.com/filecoin-project/lotus/chain/actors"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+)
+
+func stateTestTipSet(height abi.ChainEpoch) *types.TipSet {
+	blk := mock.MkBlock(nil, 0, 0)
+	blk.Height = height
+	return mock.TipSet(blk)
+}
+
+func TestStateBurned(t *testing.T) {
+	app, mockApi, buf, done := NewMockAppWithFullAPI(t, WithCategory("state", StateBurnedCmd))
+	defer done()
+
+	head := stateTestTipSet(2900)
+	// the tipset after a null round at the since epoch
+	since := stateTestTipSet(21)
+
+	gomock.InOrder(
+		mockApi.EXPECT().ChainHead(gomock.Any()).Return(head, nil),
+		mockApi.EXPECT().StateTotalBurned(gomock.Any(), head.Key()).Return(abi.TokenAmount(types.MustParseFIL("1000")), nil),
+		mockApi.EXPECT().ChainGetTipSetAfterHeight(gomock.Any(), abi.ChainEpoch(20), head.Key()).Return(since, nil),
+		mockApi.EXPECT().StateTotalBurned(gomock.Any(), since.Key()).Return(abi.TokenAmount(types.MustParseFIL("712")), nil),
+	)
+
+	err := app.Run([]string{"state", "burned", "--since", "20"})
+	require.NoError(t, err)
+	require.Equal(t, "Total burned: 1000 FIL\nBurned since 21: 288 FIL (2879 epochs, 288.1 FIL/day)\n", buf.String())
+}
+
+func TestStateBurnedSinceHead(t *testing.T) {
+	app, mockApi, _, done := NewMockAppWithFullAPI(t, WithCategory("state", StateBurnedCmd))
+	defer done()
+
+	head := stateTestTipSet(100)
+	mockApi.EXPECT().ChainHead(gomock.Any()).Return(head, nil)
+	mockApi.EXPECT().StateTotalBurned(gomock.Any(), head.Key()).Return(abi.TokenAmount(types.MustParseFIL("1")), nil)
+
+	err := app.Run([]string{"state", "burned", "--since", "100"})
+	require.ErrorContains(t, err, "must be below the tipset height")
+}
+
+func TestStateCircSupply(t *testing.T) {
+	app, mockApi, buf, done := NewMockAppWithFullAPI(t, WithCategory("state", StateCircSupplyCmd))
+	defer done()
+
+	head := stateTestTipSet(100)
+	mockApi.EXPECT().ChainHead(gomock.Any()).Return(head, nil)
+	mockApi.EXPECT().StateCirculatingSupply(gomock.Any(), head.Key()).Return(abi.TokenAmount(types.MustParseFIL("500")), nil)
+	mockApi.EXPECT().StateTotalBurned(gomock.Any(), head.Key()).Return(abi.TokenAmount(types.MustParseFIL("12.5")), nil)
+
+	err := app.Run([]string{"state", "circulating-supply"})
+	require.NoError(t, err)
+	require.Equal(t, "Exact circulating supply:  500 FIL\nTotal burned:  12.5 FIL\n", buf.String())
+}
+
+func TestActorTypeByCode(t *testing.T) {
+	minerCode, ok := actors.GetActorCodeID(actorstypes.Version16, manifest.MinerKey)
+	require.True(t, ok)
+	evmCode, ok := actors.GetActorCodeID(actorstypes.Version16, manifest.EvmKey)
+	require.True(t, ok)
+
+	// an identity CID named like a builtin actor code, but not one of them
+	fakeCode, err := cid.Prefix{Version: 1, Codec: cid.Raw, MhType: multihash.IDENTITY, MhLength: -1}.Sum([]byte("fil/2/notanactor"))
+	require.NoError(t, err)
+	unknownCode, err := cid.Prefix{Version: 1, Codec: cid.Raw, MhType: multihash.SHA2_256, MhLength: -1}.Sum([]byte("unknown"))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		code    cid.Cid
+		name    string
+		version actorstypes.Version
+		ok      bool
+	}{
+		// actors of v8 and later are identified by the code CIDs in their manifests
+		{code: minerCode, name: manifest.MinerKey, version: actorstypes.Version16, ok: true},
+		{code: evmCode, name: manifest.EvmKey, version: actorstypes.Version16, ok: true},
+		// older actors by their identity code CIDs
+		{code: builtin2.StorageMinerActorCodeID, name: manifest.MinerKey, version: actorstypes.Version2, ok: true},
+		{code: builtin2.AccountActorCodeID, name: manifest.AccountKey, version: actorstypes.Version2, ok: true},
+		{code: fakeCode},
+		{code: unknownCode},
+		{code: cid.Undef},
+	} {
+		name, version, ok := actorTypeByCode(tc.code)
+		require.Equal(t, tc.ok, ok, tc.code.String())
+		require.Equal(t, tc.name, name, tc.code.String())
+		require.Equal(t, tc.version, version, tc.code.String())
+	}
+}
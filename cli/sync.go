@@ -213,8 +213,22 @@ var SyncCheckpointCmd = &cli.Command{
 			Name:  "epoch",
 			Usage: "checkpoint the tipset at the given epoch",
 		},
+		&cli.BoolFlag{
+			Name:  "clear",
+			Usage: "remove the current checkpoint instead of setting one",
+		},
 	},
 	Action: func(cctx *cli.Context) error {
+		if cctx.Bool("clear") {
+			napi, closer, err := GetFullNodeAPIV1(cctx)
+			if err != nil {
+				return err
+			}
+			defer closer()
+
+			return napi.SyncCheckpointClear(ReqContext(cctx))
+		}
+
 		napi, closer, err := GetFullNodeAPI(cctx)
 		if err != nil {
 			return err
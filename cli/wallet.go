@@ -36,6 +36,7 @@ var WalletCmd = &cli.Command{
 		walletBalance,
 		walletExport,
 		walletImport,
+		walletImportMnemonic,
 		walletGetDefault,
 		walletSetDefault,
 		walletSign,
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/wallet/key"
+)
+
+var walletImportMnemonic = &cli.Command{
+	Name:  "import-mnemonic",
+	Usage: "Import a secp256k1 or delegated key derived from a BIP39 mnemonic",
+	Description: `Derives a key from a BIP39 mnemonic (and optional passphrase) and imports it into the wallet.
+The mnemonic is read from the terminal without echo, or from the first line of stdin.
+
+Keys are derived following BIP32 at these BIP44 derivation paths by default:
+  secp256k1 (f1 address):  ` + key.FilecoinDerivationPath + `  (Filecoin coin type 461, as used by Ledger)
+  delegated (f4 address):  ` + key.EthDerivationPath + `  (Ethereum coin type 60, as used by MetaMask)
+
+The last element of the path is the address index, set with --index. Importing the same mnemonic
+with the same passphrase, type and path always yields the same address. Use --path to match the
+derivation path of other wallets.
+
+The mnemonic gives full access to all keys derived from it. Never enter it on a machine you don't
+trust, never pass it as a command line argument or store it unencrypted, and keep in mind that the
+imported key is stored by this node like any other wallet key.`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "type",
+			Usage: "type of the key to derive, secp256k1 or delegated",
+			Value: string(types.KTSecp256k1),
+		},
+		&cli.Uint64Flag{
+			Name:  "index",
+			Usage: "address index, the last element of the default derivation path",
+		},
+		&cli.StringFlag{
+			Name:  "path",
+			Usage: "BIP32 derivation path to use instead of the default path of the key type, e.g. m/44'/461'/0'/0/0",
+		},
+		&cli.BoolFlag{
+			Name:  "passphrase",
+			Usage: "prompt for the BIP39 passphrase of the mnemonic",
+		},
+		&cli.BoolFlag{
+			Name:  "as-default",
+			Usage: "import the derived key as your new default key",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		if cctx.NArg() != 0 {
+			return IncorrectNumArgs(cctx)
+		}
+
+		typ := types.KeyType(cctx.String("type"))
+		path := cctx.String("path")
+		if path == "" {
+			var err error
+			if path, err = key.DefaultDerivationPath(typ); err != nil {
+				return err
+			}
+			path = path[:strings.LastIndex(path, "/")+1] + fmt.Sprint(cctx.Uint64("index"))
+		} else if cctx.IsSet("index") {
+			return xerrors.Errorf("--index can't be used with --path")
+		}
+
+		api, closer, err := GetFullNodeAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		ctx := ReqContext(cctx)
+
+		afmt := NewAppFmt(cctx.App)
+
+		stdin := bufio.NewReader(os.Stdin)
+		mnemonic, err := readSecret(stdin, "Enter mnemonic (not displayed in the terminal): ")
+		if err != nil {
+			return xerrors.Errorf("reading mnemonic: %w", err)
+		}
+		var passphrase string
+		if cctx.Bool("passphrase") {
+			if passphrase, err = readSecret(stdin, "Enter passphrase (not displayed in the terminal): "); err != nil {
+				return xerrors.Errorf("reading passphrase: %w", err)
+			}
+		}
+
+		ki, err := key.KeyInfoFromMnemonic(mnemonic, passphrase, typ, path)
+		if err != nil {
+			return err
+		}
+
+		addr, err := api.WalletImport(ctx, ki)
+		if err != nil {
+			return err
+		}
+
+		if cctx.Bool("as-default") {
+			if err := api.WalletSetDefault(ctx, addr); err != nil {
+				return fmt.Errorf("failed to set default key: %w", err)
+			}
+		}
+
+		afmt.Printf("imported key %s derived at %s successfully!\n", addr, path)
+		return nil
+	},
+}
+
+// readSecret reads a line from the terminal without echoing it, or from stdin when it's not a
+// terminal.
+func readSecret(stdin *bufio.Reader, prompt string) (string, error) {
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Print(prompt)
+		b, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	// spaces are significant in passphrases, only strip the line ending
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"os/signal"
+	"syscall"
 
 	logging "github.com/ipfs/go-log/v2"
 	manet "github.com/multiformats/go-multiaddr/net"
@@ -162,6 +164,11 @@ var runCmd = &cli.Command{
 			Usage: "The maximum number of filters plus subscriptions that a single websocket connection can maintain",
 			Value: gateway.DefaultEthMaxFiltersPerConn,
 		},
+		&cli.IntFlag{
+			Name:  "immutable-cache-size",
+			Usage: "Number of responses to immutable API calls (lookups by CID, state at finalized tipsets) to keep in memory. Send SIGUSR1 to flush the cache. Use 0 to disable",
+			Value: 0,
+		},
 	},
 	Action: func(cctx *cli.Context) error {
 		log.Info("Starting lotus gateway")
@@ -190,6 +197,7 @@ var runCmd = &cli.Command{
 			rateLimitTimeout            = cctx.Duration("rate-limit-timeout")
 			perHostConnectionsPerMinute = cctx.Int("conn-per-minute")
 			maxFiltersPerConn           = cctx.Int("eth-max-filters-per-conn")
+			immutableCacheSize          = cctx.Int("immutable-cache-size")
 		)
 
 		serverOptions := make([]jsonrpc.ServerOption, 0)
@@ -217,7 +225,25 @@ var runCmd = &cli.Command{
 			gateway.WithRateLimit(globalRateLimit),
 			gateway.WithRateLimitTimeout(rateLimitTimeout),
 			gateway.WithEthMaxFiltersPerConn(maxFiltersPerConn),
+			gateway.WithImmutableCacheSize(immutableCacheSize),
 		)
+
+		if immutableCacheSize > 0 {
+			flushCh := make(chan os.Signal, 1)
+			signal.Notify(flushCh, syscall.SIGUSR1)
+			defer signal.Stop(flushCh)
+			go func() {
+				for {
+					select {
+					case <-flushCh:
+						log.Info("flushing immutable response cache")
+						gwapi.FlushCache()
+					case <-cctx.Context.Done():
+						return
+					}
+				}
+			}()
+		}
 		handler, err := gateway.Handler(
 			gwapi,
 			api,
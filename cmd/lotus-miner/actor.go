@@ -25,15 +25,17 @@ var actorCmd = &cli.Command{
 		spcli.ActorSetAddrsCmd(LMActorGetter),
 		spcli.ActorDealSettlementCmd(LMActorGetter),
 		spcli.ActorWithdrawCmd(LMActorGetter),
+		spcli.ActorWithdrawBatchCmd(),
 		spcli.ActorRepayDebtCmd(LMActorGetter),
 		spcli.ActorSetPeeridCmd(LMActorGetter),
 		spcli.ActorSetOwnerCmd(LMConfigOrActorGetter),
-		spcli.ActorControlCmd(LMConfigOrActorGetter, actorControlListCmd),
+		spcli.ActorControlCmd(LMConfigOrActorGetter, actorControlListCmd, actorControlAuditCmd),
 		spcli.ActorProposeChangeWorkerCmd(LMActorGetter),
 		spcli.ActorConfirmChangeWorkerCmd(LMActorGetter),
 		spcli.ActorCompactAllocatedCmd(LMActorGetter),
 		spcli.ActorProposeChangeBeneficiaryCmd(LMActorGetter),
 		spcli.ActorConfirmChangeBeneficiaryCmd(LMConfigOrActorGetter),
+		spcli.ActorBeneficiaryCmd(LMConfigOrActorGetter),
 	},
 }
 
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	lapi "github.com/filecoin-project/lotus/api"
+	builtin2 "github.com/filecoin-project/lotus/chain/actors/builtin"
+	"github.com/filecoin-project/lotus/chain/types"
+	lcli "github.com/filecoin-project/lotus/cli"
+	"github.com/filecoin-project/lotus/lib/tablewriter"
+)
+
+type controlAuditEntry struct {
+	Address address.Address
+	Key     address.Address
+	KeyType string
+	Balance abi.TokenAmount
+
+	// OnChain are the roles of the address in the miner actor, Configured the uses configured in
+	// the Addresses section of the miner config
+	OnChain    []string
+	Configured []string
+	InWallet   bool
+
+	Issues []string
+}
+
+// configuredControl is a control address configured for a use, with its ID address, which is
+// undefined when the address doesn't exist on chain.
+type configuredControl struct {
+	use  string
+	addr address.Address
+	id   address.Address
+}
+
+var actorControlAuditCmd = &cli.Command{
+	Name:  "audit",
+	Usage: "Check the configured control addresses against the miner actor",
+	Description: `Lists the owner, worker, beneficiary and control addresses of the miner actor, and the control
+addresses configured in the Addresses section of the miner config, with their balance and key type.
+
+Flags addresses configured for a use which are not authorized in the miner actor, and addresses the
+node needs to send messages from whose keys are not in the wallet of the full node. Control addresses
+set on-chain but not configured for any use are used for WindowPoSt. Exits with an error when any
+issues are found.`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "output in json format",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		api, acloser, err := lcli.GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer acloser()
+
+		ctx := lcli.ReqContext(cctx)
+
+		maddr, err := LMActorOrEnvGetter(cctx)
+		if err != nil {
+			return err
+		}
+
+		mi, err := api.StateMinerInfo(ctx, maddr, types.EmptyTSK)
+		if err != nil {
+			return err
+		}
+
+		ac, err := getControlAddresses(cctx, maddr)
+		if err != nil {
+			return err
+		}
+
+		var configured []configuredControl
+		for _, uses := range []struct {
+			use   string
+			addrs []address.Address
+		}{
+			{"precommit", ac.PreCommitControl},
+			{"commit", ac.CommitControl},
+			{"terminate", ac.TerminateControl},
+			{"deals", ac.DealPublishControl},
+		} {
+			for _, a := range uses.addrs {
+				id, err := api.StateLookupID(ctx, a, types.EmptyTSK)
+				if err != nil {
+					id = address.Undef
+				}
+				configured = append(configured, configuredControl{use: uses.use, addr: a, id: id})
+			}
+		}
+
+		entries := auditControlAddresses(mi, configured)
+
+		var issues int
+		for _, e := range entries {
+			if e.Address.Protocol() != address.ID {
+				issues += len(e.Issues)
+				continue // not on chain
+			}
+
+			act, err := api.StateGetActor(ctx, e.Address, types.EmptyTSK)
+			if err != nil {
+				return xerrors.Errorf("getting actor %s: %w", e.Address, err)
+			}
+			e.Balance = act.Balance
+
+			e.KeyType = "actor"
+			if builtin2.IsAccountActor(act.Code) || builtin2.IsEthAccountActor(act.Code) {
+				if e.Key, err = api.StateAccountKey(ctx, e.Address, types.EmptyTSK); err != nil {
+					return xerrors.Errorf("getting account key of %s: %w", e.Address, err)
+				}
+				switch e.Key.Protocol() {
+				case address.SECP256K1:
+					e.KeyType = "secp256k1"
+				case address.BLS:
+					e.KeyType = "bls"
+				case address.Delegated:
+					e.KeyType = "delegated"
+				}
+
+				if e.InWallet, err = api.WalletHas(ctx, e.Key); err != nil {
+					return xerrors.Errorf("checking wallet for %s: %w", e.Key, err)
+				}
+			} else {
+				if builtin2.IsMultisigActor(act.Code) {
+					e.KeyType = "multisig"
+				}
+				e.Key = e.Address
+			}
+
+			// messages are sent from the worker, control addresses and addresses configured for use
+			sender := len(e.Configured) > 0
+			for _, r := range e.OnChain {
+				sender = sender || r == "worker" || r == "control"
+			}
+			if sender && !e.InWallet {
+				if e.KeyType == "secp256k1" || e.KeyType == "bls" || e.KeyType == "delegated" {
+					e.Issues = append(e.Issues, "key not in the wallet of the full node")
+				} else {
+					e.Issues = append(e.Issues, fmt.Sprintf("%s can't send messages", e.KeyType))
+				}
+			}
+
+			issues += len(e.Issues)
+		}
+
+		if cctx.Bool("json") {
+			if err := lcli.PrintJson(entries); err != nil {
+				return err
+			}
+		} else {
+			tw := tablewriter.New(
+				tablewriter.Col("ID"),
+				tablewriter.Col("key"),
+				tablewriter.Col("type"),
+				tablewriter.Col("on-chain"),
+				tablewriter.Col("configured"),
+				tablewriter.Col("balance"),
+				tablewriter.Col("issues"),
+			)
+			for _, e := range entries {
+				row := map[string]interface{}{
+					"ID":         e.Address,
+					"key":        e.Key,
+					"type":       e.KeyType,
+					"on-chain":   strings.Join(e.OnChain, " "),
+					"configured": strings.Join(e.Configured, " "),
+				}
+				if e.Address.Protocol() == address.ID {
+					row["balance"] = types.FIL(e.Balance).Short()
+				}
+				if len(e.Issues) > 0 {
+					row["issues"] = color.RedString(strings.Join(e.Issues, "; "))
+				}
+				tw.Write(row)
+			}
+			if err := tw.Flush(os.Stdout); err != nil {
+				return err
+			}
+		}
+
+		if issues > 0 {
+			return xerrors.Errorf("found %d control address issues", issues)
+		}
+		if !cctx.Bool("json") {
+			fmt.Println("No control address issues found")
+		}
+		return nil
+	},
+}
+
+// auditControlAddresses returns the addresses of the miner actor and the configured control
+// addresses, ordered as owner, worker, beneficiary, on-chain control addresses and then addresses
+// configured but not set on-chain, with the issues found in their authorization.
+func auditControlAddresses(mi lapi.MinerInfo, configured []configuredControl) []*controlAuditEntry {
+	var out []*controlAuditEntry
+	byAddr := map[address.Address]*controlAuditEntry{}
+
+	entry := func(a address.Address) *controlAuditEntry {
+		if e, ok := byAddr[a]; ok {
+			return e
+		}
+		e := &controlAuditEntry{Address: a}
+		byAddr[a] = e
+		out = append(out, e)
+		return e
+	}
+
+	role := func(a address.Address, r string) {
+		e := entry(a)
+		e.OnChain = append(e.OnChain, r)
+	}
+	role(mi.Owner, "owner")
+	role(mi.Worker, "worker")
+	role(mi.Beneficiary, "beneficiary")
+	for _, ca := range mi.ControlAddresses {
+		role(ca, "control")
+	}
+
+	authorized := func(e *controlAuditEntry) bool {
+		for _, r := range e.OnChain {
+			if r == "owner" || r == "worker" || r == "control" {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, c := range configured {
+		if c.id == address.Undef {
+			e := entry(c.addr)
+			e.Key = c.addr
+			e.Configured = append(e.Configured, c.use)
+			e.Issues = append(e.Issues, fmt.Sprintf("configured for %s but not found on-chain", c.use))
+			continue
+		}
+
+		e := entry(c.id)
+		e.Configured = append(e.Configured, c.use)
+		if !authorized(e) {
+			e.Issues = append(e.Issues, fmt.Sprintf("configured for %s but not a control address of the miner", c.use))
+		}
+	}
+
+	// control addresses not configured for other uses are used for WindowPoSt
+	for _, ca := range mi.ControlAddresses {
+		if e := byAddr[ca]; len(e.Configured) == 0 {
+			e.Configured = append(e.Configured, "post")
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/lotus/api"
+)
+
+func TestAuditControlAddresses(t *testing.T) {
+	id := func(i uint64) address.Address {
+		a, err := address.NewIDAddress(i)
+		require.NoError(t, err)
+		return a
+	}
+	robust, err := address.NewFromString("f1abjxfbp274xpdqcpuaykwkfb43omjotacm2p3za")
+	require.NoError(t, err)
+
+	mi := api.MinerInfo{
+		Owner:            id(100),
+		Worker:           id(101),
+		Beneficiary:      id(100),
+		ControlAddresses: []address.Address{id(102), id(103)},
+	}
+
+	entries := auditControlAddresses(mi, []configuredControl{
+		{use: "precommit", addr: id(102), id: id(102)},
+		{use: "commit", addr: id(102), id: id(102)},
+		{use: "commit", addr: id(101), id: id(101)},
+		{use: "deals", addr: id(104), id: id(104)},
+		{use: "terminate", addr: robust, id: address.Undef},
+	})
+
+	byAddr := map[address.Address]*controlAuditEntry{}
+	var order []address.Address
+	for _, e := range entries {
+		byAddr[e.Address] = e
+		order = append(order, e.Address)
+	}
+	require.Equal(t, []address.Address{id(100), id(101), id(102), id(103), id(104), robust}, order)
+
+	require.Equal(t, []string{"owner", "beneficiary"}, byAddr[id(100)].OnChain)
+	require.Empty(t, byAddr[id(100)].Issues)
+
+	// the worker is allowed for any use
+	require.Equal(t, []string{"commit"}, byAddr[id(101)].Configured)
+	require.Empty(t, byAddr[id(101)].Issues)
+
+	require.Equal(t, []string{"precommit", "commit"}, byAddr[id(102)].Configured)
+	require.Empty(t, byAddr[id(102)].Issues)
+
+	// control addresses not configured for any use are used for post
+	require.Equal(t, []string{"post"}, byAddr[id(103)].Configured)
+	require.Empty(t, byAddr[id(103)].Issues)
+
+	require.Empty(t, byAddr[id(104)].OnChain)
+	require.Len(t, byAddr[id(104)].Issues, 1)
+	require.Contains(t, byAddr[id(104)].Issues[0], "not a control address")
+
+	require.Len(t, byAddr[robust].Issues, 1)
+	require.Contains(t, byAddr[robust].Issues[0], "not found on-chain")
+}
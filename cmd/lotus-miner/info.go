@@ -41,6 +41,8 @@ var infoCmd = &cli.Command{
 	Usage: "Print miner info",
 	Subcommands: []*cli.Command{
 		infoAllCmd,
+		infoPledgeCmd,
+		infoProjectionCmd,
 	},
 	Flags: []cli.Flag{
 		&cli.BoolFlag{
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/lotus/chain/actors/builtin/miner"
+	"github.com/filecoin-project/lotus/chain/actors/policy"
+	"github.com/filecoin-project/lotus/chain/types"
+	lcli "github.com/filecoin-project/lotus/cli"
+	cliutil "github.com/filecoin-project/lotus/cli/util"
+)
+
+var infoPledgeCmd = &cli.Command{
+	Name:  "pledge",
+	Usage: "Compute the collateral required to onboard new committed capacity sectors at current network conditions",
+	Flags: []cli.Flag{
+		&cli.Uint64Flag{
+			Name:  "sectors",
+			Usage: "number of new sectors to compute the collateral for",
+			Value: 1,
+		},
+		&cli.Uint64Flag{
+			Name:  "expiration",
+			Usage: "the epoch when the sectors will expire (defaults to the maximum sector lifetime)",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		nApi, nCloser, err := lcli.GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer nCloser()
+
+		ctx := lcli.ReqContext(cctx)
+
+		count := cctx.Uint64("sectors")
+		if count == 0 {
+			return xerrors.Errorf("--sectors must be greater than 0")
+		}
+
+		maddr, err := getActorAddress(ctx, cctx)
+		if err != nil {
+			return err
+		}
+
+		head, err := nApi.ChainHead(ctx)
+		if err != nil {
+			return err
+		}
+
+		mi, err := nApi.StateMinerInfo(ctx, maddr, head.Key())
+		if err != nil {
+			return err
+		}
+
+		nv, err := nApi.StateNetworkVersion(ctx, head.Key())
+		if err != nil {
+			return err
+		}
+
+		spt, err := miner.PreferredSealProofTypeFromWindowPoStType(nv, mi.WindowPoStProofType, false)
+		if err != nil {
+			return err
+		}
+
+		expiration := abi.ChainEpoch(cctx.Uint64("expiration"))
+		if expiration == 0 {
+			maxExtension, err := policy.GetMaxSectorExpirationExtension(nv)
+			if err != nil {
+				return xerrors.Errorf("failed to get max extension: %w", err)
+			}
+			expiration = head.Height() + maxExtension
+		}
+		if expiration <= head.Height() {
+			return xerrors.Errorf("expiration %d is not after the current epoch %d", expiration, head.Height())
+		}
+
+		pci := miner.SectorPreCommitInfo{
+			SealProof:  spt,
+			Expiration: expiration,
+		}
+
+		deposit, err := nApi.StateMinerPreCommitDepositForPower(ctx, maddr, pci, head.Key())
+		if err != nil {
+			return xerrors.Errorf("computing precommit deposit: %w", err)
+		}
+
+		pledge, err := nApi.StateMinerInitialPledgeForSector(ctx, expiration-head.Height(), mi.SectorSize, 0, head.Key())
+		if err != nil {
+			return xerrors.Errorf("computing initial pledge: %w", err)
+		}
+
+		total := func(v abi.TokenAmount) types.FIL {
+			return types.FIL(big.Mul(v, big.NewIntUnsigned(count)))
+		}
+
+		fmt.Printf("Epoch:       %d\n", head.Height())
+		fmt.Printf("Sector Size: %s\n", types.SizeStr(types.NewInt(uint64(mi.SectorSize))))
+		fmt.Printf("Expiration:  %d (%s)\n", expiration, cliutil.EpochTime(head.Height(), expiration))
+		fmt.Println()
+
+		tw := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintf(tw, "\tPer Sector\tTotal (%d sectors)\n", count)
+		_, _ = fmt.Fprintf(tw, "PreCommit Deposit:\t%s\t%s\n", types.FIL(deposit), total(deposit))
+		_, _ = fmt.Fprintf(tw, "Initial Pledge:\t%s\t%s\n", types.FIL(pledge), total(pledge))
+		_, _ = fmt.Fprintf(tw, "Required Collateral:\t%s\t%s\n", types.FIL(big.Max(pledge, deposit)), total(big.Max(pledge, deposit)))
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Println("The precommit deposit is returned when the sector is proven and the initial pledge is locked instead.")
+		fmt.Println("Values depend on network power and reward state at the time each sector is committed and will fluctuate.")
+
+		return nil
+	},
+}
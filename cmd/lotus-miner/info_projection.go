@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	corebig "math/big"
+	"os"
+	"text/tabwriter"
+
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/lotus/blockstore"
+	"github.com/filecoin-project/lotus/chain/actors/adt"
+	"github.com/filecoin-project/lotus/chain/actors/builtin"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/reward"
+	"github.com/filecoin-project/lotus/chain/actors/policy"
+	"github.com/filecoin-project/lotus/chain/types"
+	lcli "github.com/filecoin-project/lotus/cli"
+)
+
+const (
+	// share of block rewards which is locked and vests linearly, the rest is available immediately
+	lockedRewardShare = 0.75
+	rewardVestingDays = 180
+)
+
+var infoProjectionCmd = &cli.Command{
+	Name:  "projection",
+	Usage: "Project expected block rewards and pledge for onboarding new committed capacity sectors",
+	Description: `Projects the block rewards which adding the given number of committed capacity sectors is
+expected to earn over the given number of days, and how much of it vests in that time, from the
+current network power, block reward and pledge requirements.
+
+The projection is a model, not a forecast. It assumes that:
+ - the sectors are all onboarded now, and are proven for the whole period without faults,
+ - the block reward per epoch stays at its current value,
+ - network power grows at the given annual rate (0 by default), diluting the share of the sectors,
+ - 75% of block rewards vest linearly over 180 days, the rest is available immediately,
+ - fees, penalties and gas costs of onboarding and proving are not accounted for.`,
+	Flags: []cli.Flag{
+		&cli.Uint64Flag{
+			Name:  "sectors",
+			Usage: "number of new sectors to project rewards for",
+			Value: 1,
+		},
+		&cli.Uint64Flag{
+			Name:  "days",
+			Usage: "number of days to project rewards for",
+			Value: 180,
+		},
+		&cli.Float64Flag{
+			Name:  "network-growth",
+			Usage: "assumed annual growth rate of network quality-adjusted power, e.g. 0.1 for 10%",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		nApi, nCloser, err := lcli.GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer nCloser()
+
+		ctx := lcli.ReqContext(cctx)
+
+		count := cctx.Uint64("sectors")
+		if count == 0 {
+			return xerrors.Errorf("--sectors must be greater than 0")
+		}
+		days := cctx.Uint64("days")
+		if days == 0 {
+			return xerrors.Errorf("--days must be greater than 0")
+		}
+		growth := cctx.Float64("network-growth")
+		if growth <= -1 {
+			return xerrors.Errorf("--network-growth must be greater than -1")
+		}
+
+		maddr, err := getActorAddress(ctx, cctx)
+		if err != nil {
+			return err
+		}
+
+		head, err := nApi.ChainHead(ctx)
+		if err != nil {
+			return err
+		}
+
+		mi, err := nApi.StateMinerInfo(ctx, maddr, head.Key())
+		if err != nil {
+			return err
+		}
+
+		pow, err := nApi.StateMinerPower(ctx, maddr, head.Key())
+		if err != nil {
+			return xerrors.Errorf("getting network power: %w", err)
+		}
+		if pow.TotalPower.QualityAdjPower.IsZero() {
+			return xerrors.Errorf("network has no power")
+		}
+
+		rewardActor, err := nApi.StateGetActor(ctx, reward.Address, head.Key())
+		if err != nil {
+			return xerrors.Errorf("loading reward actor: %w", err)
+		}
+		tbs := blockstore.NewTieredBstore(blockstore.NewAPIBlockstore(nApi), blockstore.NewMemory())
+		rewardState, err := reward.Load(adt.WrapStore(ctx, cbor.NewCborStore(tbs)), rewardActor)
+		if err != nil {
+			return xerrors.Errorf("loading reward actor state: %w", err)
+		}
+		epochReward, err := rewardState.ThisEpochReward()
+		if err != nil {
+			return err
+		}
+
+		// pledge is computed for the whole sector lifetime, which can't be shorter than the minimum
+		duration := abi.ChainEpoch(days) * builtin.EpochsInDay
+		duration = max(duration, policy.GetMinSectorExpiration())
+		pledge, err := nApi.StateMinerInitialPledgeForSector(ctx, duration, mi.SectorSize, 0, head.Key())
+		if err != nil {
+			return xerrors.Errorf("computing initial pledge: %w", err)
+		}
+
+		power := big.Mul(big.NewIntUnsigned(uint64(mi.SectorSize)), big.NewIntUnsigned(count))
+		// expected reward of the sectors on the first day; the expected reward per epoch is the share
+		// of the sectors in network power times the total block reward
+		daily := big.Div(big.Mul(big.Mul(epochReward, power), big.NewInt(int64(builtin.EpochsInDay))), pow.TotalPower.QualityAdjPower)
+
+		earned, vested := projectRewards(daily, int(days), growth)
+		totalPledge := big.Mul(pledge, big.NewIntUnsigned(count))
+
+		fmt.Printf("Epoch:                %d\n", head.Height())
+		fmt.Printf("Network QA Power:     %s\n", types.SizeStr(pow.TotalPower.QualityAdjPower))
+		fmt.Printf("Block Reward / Epoch: %s\n", types.FIL(epochReward))
+		fmt.Printf("Network Growth:       %.1f%% / year\n", growth*100)
+		fmt.Println()
+
+		tw := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintf(tw, "Sectors:\t%d x %s\n", count, types.SizeStr(types.NewInt(uint64(mi.SectorSize))))
+		_, _ = fmt.Fprintf(tw, "Added QA Power:\t%s\n", types.SizeStr(power))
+		_, _ = fmt.Fprintf(tw, "Initial Pledge:\t%s\n", types.FIL(totalPledge))
+		_, _ = fmt.Fprintf(tw, "Expected Rewards (%d days):\t%s\n", days, types.FIL(earned))
+		_, _ = fmt.Fprintf(tw, "Vested Rewards (%d days):\t%s\n", days, types.FIL(vested))
+		_, _ = fmt.Fprintf(tw, "Still Vesting:\t%s\n", types.FIL(big.Sub(earned, vested)))
+		if !totalPledge.IsZero() {
+			ratio := new(corebig.Rat).SetFrac(earned.Int, totalPledge.Int)
+			r, _ := ratio.Float64()
+			_, _ = fmt.Fprintf(tw, "Rewards / Pledge:\t%.2f%%\n", r*100)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Println("Assumptions: sectors are onboarded now and never fault, the block reward per epoch stays constant,")
+		fmt.Printf("network power grows at %.1f%% per year, 75%% of rewards vest linearly over 180 days, fees are not included.\n", growth*100)
+
+		return nil
+	},
+}
+
+// projectRewards projects the rewards earned over the given number of days, starting at the given
+// daily reward, which is diluted by network power growing at the annual growth rate. It returns the
+// total earned rewards and the part of them which has vested at the end of the period.
+func projectRewards(daily abi.TokenAmount, days int, annualGrowth float64) (earned, vested abi.TokenAmount) {
+	d := new(corebig.Float).SetInt(daily.Int)
+
+	totalEarned := new(corebig.Float)
+	totalVested := new(corebig.Float)
+	for i := 0; i < days; i++ {
+		dilution := math.Pow(1+annualGrowth, float64(i)/365)
+		r := new(corebig.Float).Quo(d, corebig.NewFloat(dilution))
+		totalEarned.Add(totalEarned, r)
+
+		// rewards earned on day i have been vesting for the remaining days of the period
+		vestedShare := (1 - lockedRewardShare) + lockedRewardShare*math.Min(float64(days-i-1), rewardVestingDays)/rewardVestingDays
+		totalVested.Add(totalVested, new(corebig.Float).Mul(r, corebig.NewFloat(vestedShare)))
+	}
+
+	e, _ := totalEarned.Int(nil)
+	v, _ := totalVested.Int(nil)
+	return big.NewFromGo(e), big.NewFromGo(v)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+func TestProjectRewards(t *testing.T) {
+	daily := big.NewInt(1_000_000)
+
+	// without growth every day earns the same, and 25% vests immediately with the remaining 75%
+	// vesting for 9, 8, ... 0 days
+	earned, vested := projectRewards(daily, 10, 0)
+	require.Equal(t, big.NewInt(10_000_000), earned)
+	require.InDelta(t, 2_500_000+750_000*45/180, float64(vested.Int64()), 1)
+
+	// rewards earned on the last day only have their unlocked share available
+	earned, vested = projectRewards(daily, 1, 0)
+	require.Equal(t, daily, earned)
+	require.Equal(t, big.NewInt(250_000), vested)
+
+	// network growth dilutes rewards
+	grown, _ := projectRewards(daily, 365, 1)
+	flat, _ := projectRewards(daily, 365, 0)
+	require.True(t, grown.LessThan(flat))
+	require.True(t, grown.GreaterThan(big.Div(flat, big.NewInt(2))))
+}
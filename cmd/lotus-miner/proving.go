@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"text/tabwriter"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"github.com/filecoin-project/go-state-types/proof"
 
 	"github.com/filecoin-project/lotus/build/buildconstants"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/miner"
 	"github.com/filecoin-project/lotus/chain/types"
 	lcli "github.com/filecoin-project/lotus/cli"
 	"github.com/filecoin-project/lotus/cli/spcli"
@@ -37,6 +39,8 @@ var provingCmd = &cli.Command{
 		workersCmd(false),
 		provingComputeCmd,
 		provingRecoverFaultsCmd,
+		provingAckFaultsCmd,
+		provingForecastCmd,
 	},
 }
 
@@ -217,7 +221,11 @@ var provingComputeWindowPoStCmd = &cli.Command{
 	Aliases: []string{"window-post"},
 	Usage:   "Compute WindowPoSt for a specific deadline",
 	Description: `Note: This command is intended to be used to verify PoSt compute performance.
-It will not send any messages to the chain.`,
+It will not send any messages to the chain.
+
+The proofs are generated locally for the partitions of the deadline, as they would be for the
+live proving window. The proof parameters are printed, followed by a summary of the sectors
+skipped because they failed the challenge, which would be declared faulty.`,
 	ArgsUsage: "[deadline index]",
 	Action: func(cctx *cli.Context) error {
 		if cctx.NArg() != 1 {
@@ -228,6 +236,9 @@ It will not send any messages to the chain.`,
 		if err != nil {
 			return xerrors.Errorf("could not parse deadline index: %w", err)
 		}
+		if dlIdx >= miner.WPoStPeriodDeadlines {
+			return xerrors.Errorf("deadline index %d out of range, there are %d deadlines", dlIdx, miner.WPoStPeriodDeadlines)
+		}
 
 		minerApi, scloser, err := lcli.GetStorageMinerAPI(cctx)
 		if err != nil {
@@ -288,10 +299,113 @@ It will not send any messages to the chain.`,
 		}
 		fmt.Println(string(jr))
 
+		var partitions int
+		var skipped []string
+		for _, p := range postParams {
+			partitions += len(p.Partitions)
+			for _, part := range p.Partitions {
+				for _, s := range part.Skipped {
+					skipped = append(skipped, fmt.Sprintf("%d (partition %d)", s, part.Index))
+				}
+			}
+		}
+
+		fmt.Println()
+		switch {
+		case partitions == 0:
+			fmt.Printf("Deadline %d has no partitions to prove\n", dlIdx)
+		case len(skipped) == 0:
+			fmt.Printf("WindowPoSt for deadline %d computed successfully: %d partitions in %d proofs, no sectors skipped\n", dlIdx, partitions, len(postParams))
+		default:
+			fmt.Printf("WindowPoSt for deadline %d computed: %d partitions in %d proofs, %s\n", dlIdx, partitions, len(postParams), color.RedString("%d sectors failed the challenge and were skipped", len(skipped)))
+			fmt.Printf("Skipped sectors: %s\n", strings.Join(skipped, ", "))
+		}
+
 		return nil
 	},
 }
 
+var provingAckFaultsCmd = &cli.Command{
+	Name:  "ack-faults",
+	Usage: "Acknowledge the faulty sectors of a deadline and submit its halted WindowPoSt",
+	Description: `WindowPoSt submission for a deadline is halted, and an alert is raised, when more of its
+sectors are faulty than allowed by the Proving.HaltSubmissionFaultySectors or
+Proving.HaltSubmissionFaultyFraction config options. This command acknowledges the faults, and
+the proofs are submitted on the next chain head change if the deadline is still open.`,
+	ArgsUsage: "[deadline index]",
+	Action: func(cctx *cli.Context) error {
+		if cctx.NArg() != 1 {
+			return lcli.IncorrectNumArgs(cctx)
+		}
+
+		dlIdx, err := strconv.ParseUint(cctx.Args().Get(0), 10, 64)
+		if err != nil {
+			return xerrors.Errorf("could not parse deadline index: %w", err)
+		}
+
+		minerApi, closer, err := lcli.GetStorageMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		ctx := lcli.ReqContext(cctx)
+
+		if err := minerApi.AckWindowPoStFaults(ctx, dlIdx); err != nil {
+			return err
+		}
+
+		fmt.Printf("Faults of deadline %d acknowledged, submitting WindowPoSt\n", dlIdx)
+		return nil
+	},
+}
+
+var provingForecastCmd = &cli.Command{
+	Name:  "forecast",
+	Usage: "Estimate the resources needed to prove the upcoming deadlines",
+	Description: `Compute times are estimated from the mean time it took to prove a partition in the recently
+computed WindowPoSt proofs, and are only known after the node computed proofs. Deadlines whose
+proofs are estimated to take longer than the deadline is open for are marked as at risk.`,
+	Action: func(cctx *cli.Context) error {
+		minerApi, closer, err := lcli.GetStorageMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		ctx := lcli.ReqContext(cctx)
+
+		fc, err := minerApi.ProvingResourceForecast(ctx)
+		if err != nil {
+			return err
+		}
+
+		if fc.Samples == 0 {
+			fmt.Println("No WindowPoSt proofs were computed yet, compute times are unknown")
+		} else {
+			fmt.Printf("Partition proving time: %s (from %d proofs)\n", fc.PartitionTime, fc.Samples)
+		}
+		fmt.Println()
+
+		tw := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "deadline\topen\tpartitions\tsectors\tproofs\tpeak memory\tcompute time\t")
+		for _, dl := range fc.Deadlines {
+			computeTime := "unknown"
+			if fc.Samples > 0 {
+				computeTime = dl.ComputeTime.Round(time.Second).String()
+			}
+			if dl.AtRisk {
+				computeTime = color.RedString("%s (at risk)", computeTime)
+			}
+
+			_, _ = fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%d\t%s\t%s\t\n", dl.Index, dl.Open, dl.Partitions, dl.Sectors, dl.Proofs,
+				types.SizeStr(types.NewInt(dl.PeakMemory)), computeTime)
+		}
+
+		return tw.Flush()
+	},
+}
+
 var provingRecoverFaultsCmd = &cli.Command{
 	Name:      "recover-faults",
 	Usage:     "Manually recovers faulty sectors on chain",
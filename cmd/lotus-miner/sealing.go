@@ -311,10 +311,19 @@ var sealingJobsCmd = &cli.Command{
 var sealingSchedDiagCmd = &cli.Command{
 	Name:  "sched-diag",
 	Usage: "Dump internal scheduler state",
+	Description: `The dump includes the worker resources reserved by the scheduler for the tasks it
+   started. Reservations for which no task of the same type has been tracked on the worker for
+   a while are flagged as leaked; they can be left behind e.g. by crashes, and make the
+   scheduler refuse tasks the worker has the resources for. With --repair the leaked
+   reservations are released before the state is dumped.`,
 	Flags: []cli.Flag{
 		&cli.BoolFlag{
 			Name: "force-sched",
 		},
+		&cli.BoolFlag{
+			Name:  "repair",
+			Usage: "release leaked resource reservations",
+		},
 	},
 	Action: func(cctx *cli.Context) error {
 		minerApi, closer, err := lcli.GetStorageMinerAPI(cctx)
@@ -325,6 +334,18 @@ var sealingSchedDiagCmd = &cli.Command{
 
 		ctx := lcli.ReqContext(cctx)
 
+		if cctx.Bool("repair") {
+			released, err := minerApi.SealingSchedReleaseLeaked(ctx)
+			if err != nil {
+				return xerrors.Errorf("releasing leaked reservations: %w", err)
+			}
+			for _, r := range released {
+				_, _ = fmt.Fprintf(os.Stderr, "released leaked reservation of %s for %s on worker %s (%s), reserved %s ago\n",
+					r.SchedId, r.Task.Short(), r.Worker, r.Hostname, time.Since(r.Since).Truncate(time.Second))
+			}
+			_, _ = fmt.Fprintf(os.Stderr, "released %d leaked reservations\n", len(released))
+		}
+
 		st, err := minerApi.SealingSchedDiag(ctx, cctx.Bool("force-sched"))
 		if err != nil {
 			return err
@@ -337,6 +358,24 @@ var sealingSchedDiagCmd = &cli.Command{
 
 		fmt.Println(string(j))
 
+		var res struct {
+			SchedInfo struct {
+				Reservations []storiface.SchedReservation
+			}
+		}
+		if err := json.Unmarshal(j, &res); err != nil {
+			return xerrors.Errorf("decoding reservations: %w", err)
+		}
+		var leaked int
+		for _, r := range res.SchedInfo.Reservations {
+			if r.Leaked {
+				leaked++
+			}
+		}
+		if leaked > 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "%d leaked resource reservations, release them with --repair\n", leaked)
+		}
+
 		return nil
 	},
 }
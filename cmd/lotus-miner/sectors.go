@@ -45,8 +45,11 @@ var sectorsCmd = &cli.Command{
 	Subcommands: []*cli.Command{
 		spcli.SectorsStatusCmd(LMActorOrEnvGetter, getOnDiskInfo),
 		sectorsListCmd,
+		sectorsSummaryCmd,
 		sectorsRefsCmd,
 		sectorsUpdateCmd,
+		sectorsRetryCmd,
+		sectorsRecoverFailedCmd,
 		sectorsPledgeCmd,
 		sectorsNumbersCmd,
 		spcli.SectorPreCommitsCmd(LMActorOrEnvGetter),
@@ -64,6 +67,12 @@ var sectorsCmd = &cli.Command{
 		sectorsRefreshPieceMatchingCmd,
 		spcli.SectorsCompactPartitionsCmd(LMActorOrEnvGetter),
 		sectorsUnsealCmd,
+		sectorsCheckFilesCmd,
+		sectorsVerifyCommitmentCmd,
+		sectorsDealsExpiringCmd,
+		sectorsDealsExportCmd,
+		sectorsFindDuplicatesCmd,
+		sectorsOpenCmd,
 	},
 }
 
@@ -1023,6 +1032,93 @@ var sectorsUpdateCmd = &cli.Command{
 	},
 }
 
+var sectorsRetryCmd = &cli.Command{
+	Name:      "retry",
+	Usage:     "ADVANCED: re-run a sealing step of a stuck sector",
+	ArgsUsage: "<sectorNum>",
+	Description: `Moves the sector back to the state which re-runs the given sealing step. Unlike update-state,
+transitions are only allowed from states the step can be re-run from, and when they are safe given
+the on-chain status of the sector, e.g. precommit steps can't be re-run once the sector is
+precommitted on chain. Sectors waiting in a PreCommit or Commit batch can't be moved until the
+batch is sent. The retry is recorded in the sector log.
+
+Steps:
+  precommit1     re-run PreCommit1
+  precommit2     re-run PreCommit2
+  precommit      resubmit the precommit message
+  wait-seed      wait for the interactive seed of a precommitted sector
+  commit         recompute the PoRep proof
+  submit-commit  resubmit the commit message
+  finalize       re-run finalization of a committed sector`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "step",
+			Usage:    "sealing step to re-run",
+			Required: true,
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		minerAPI, closer, err := lcli.GetStorageMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		ctx := lcli.ReqContext(cctx)
+		if cctx.NArg() != 1 {
+			return lcli.IncorrectNumArgs(cctx)
+		}
+
+		id, err := strconv.ParseUint(cctx.Args().Get(0), 10, 64)
+		if err != nil {
+			return xerrors.Errorf("could not parse sector number: %w", err)
+		}
+
+		if err := minerAPI.SectorRetryStep(ctx, abi.SectorNumber(id), cctx.String("step")); err != nil {
+			return err
+		}
+
+		fmt.Printf("Retrying %s of sector %d\n", cctx.String("step"), id)
+		return nil
+	},
+}
+
+var sectorsRecoverFailedCmd = &cli.Command{
+	Name:      "recover-failed",
+	Usage:     "ADVANCED: move a FailedUnrecoverable sector back into the sealing pipeline",
+	ArgsUsage: "<sectorNum>",
+	Description: `Resumes sealing of a sector in the FailedUnrecoverable state from its last completed step, e.g.
+after restoring storage which was lost. The sector is refused when it can't be recovered:
+  - it is committed on chain
+  - it is in a terminal state which can't be left, like PreCommitExpired or FaultedFinal
+  - the start epoch of its deals passed
+  - its sealed replica can't be read from storage
+The recovery is recorded in the sector log.`,
+	Action: func(cctx *cli.Context) error {
+		minerAPI, closer, err := lcli.GetStorageMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		ctx := lcli.ReqContext(cctx)
+		if cctx.NArg() != 1 {
+			return lcli.IncorrectNumArgs(cctx)
+		}
+
+		id, err := strconv.ParseUint(cctx.Args().Get(0), 10, 64)
+		if err != nil {
+			return xerrors.Errorf("could not parse sector number: %w", err)
+		}
+
+		st, err := minerAPI.SectorRecoverFailed(ctx, abi.SectorNumber(id))
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Recovering sector %d, resuming sealing in %s\n", id, st)
+		return nil
+	},
+}
+
 var sectorsExpiredCmd = &cli.Command{
 	Name:  "expired",
 	Usage: "Get or cleanup expired sectors",
@@ -1427,6 +1523,74 @@ func yesno(b bool) string {
 	return color.RedString("NO")
 }
 
+var sectorsSummaryCmd = &cli.Command{
+	Name:  "summary",
+	Usage: "show the number of sectors by health, with sample sector numbers",
+	Description: `Groups the sectors of the miner by their on-chain health at the chain head, and
+their local sealing state for sectors not on chain yet:
+   healthy:    live and not faulty, including sectors not proven yet
+   faulty:     faulty and not declared as recovering
+   recovering: faulty and declared as recovering
+   terminated: terminated or expired, or terminating locally
+   sealing:    in the local sealing pipeline, including failed sealing states`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "output in json format",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		minerAPI, closer, err := lcli.GetStorageMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		ctx := lcli.ReqContext(cctx)
+
+		summary, err := minerAPI.SectorsSummaryByHealth(ctx)
+		if err != nil {
+			return err
+		}
+
+		if cctx.Bool("json") {
+			return lcli.PrintJson(summary)
+		}
+
+		tw := tablewriter.New(
+			tablewriter.Col("Health"),
+			tablewriter.Col("Count"),
+			tablewriter.Col("Samples"))
+
+		for _, g := range []struct {
+			name  string
+			color *color.Color
+			group api.SectorHealthGroup
+		}{
+			{"healthy", color.New(color.FgGreen), summary.Healthy},
+			{"faulty", color.New(color.FgRed), summary.Faulty},
+			{"recovering", color.New(color.FgYellow), summary.Recovering},
+			{"terminated", color.New(color.FgHiBlack), summary.Terminated},
+			{"sealing", color.New(color.FgBlue), summary.Sealing},
+		} {
+			samples := make([]string, len(g.group.Samples))
+			for i, s := range g.group.Samples {
+				samples[i] = s.String()
+			}
+			if len(samples) > 0 && len(samples) < g.group.Count {
+				samples = append(samples, "...")
+			}
+
+			tw.Write(map[string]interface{}{
+				"Health":  g.color.Sprint(g.name),
+				"Count":   g.group.Count,
+				"Samples": strings.Join(samples, ", "),
+			})
+		}
+
+		return tw.Flush(os.Stdout)
+	},
+}
+
 var sectorsNumbersCmd = &cli.Command{
 	Name:  "numbers",
 	Usage: "manage sector number assignments",
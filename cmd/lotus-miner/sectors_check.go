@@ -0,0 +1,532 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/ipfs/go-cid"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/miner"
+	"github.com/filecoin-project/lotus/chain/types"
+	lcli "github.com/filecoin-project/lotus/cli"
+	"github.com/filecoin-project/lotus/storage/sealer/commitment"
+	"github.com/filecoin-project/lotus/storage/sealer/storiface"
+)
+
+const (
+	checkFilesHealthy = "healthy"
+	checkFilesMissing = "missing"
+	checkFilesCorrupt = "corrupt"
+	checkFilesError   = "error"
+)
+
+type checkFilesResult struct {
+	Sector abi.SectorNumber
+	Status string
+	Detail string
+}
+
+var sectorsCheckFilesCmd = &cli.Command{
+	Name:      "check-files",
+	Usage:     "Verify that the files of proving sectors are present and intact in storage",
+	ArgsUsage: "[sectorNum ...]",
+	Description: `Checks that the sealed (or updated) replica and cache of each sector can be found in
+the storage index, and for storage paths local to the miner, that the replica has the expected
+size. With --slow, a vanilla PoSt proof is also generated for each sector, which reads and
+verifies a random sample of merkle tree nodes.
+
+Results are streamed as they complete. With --resume-file, results are also appended to the
+given file, and sectors already recorded in it are skipped, so that an interrupted scan can be
+resumed by running the same command again.`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "all",
+			Usage: "check all sectors of the miner which are on chain",
+		},
+		&cli.IntFlag{
+			Name:  "concurrency",
+			Usage: "number of sectors to check in parallel",
+			Value: 8,
+		},
+		&cli.BoolFlag{
+			Name:  "slow",
+			Usage: "additionally generate a vanilla proof for each sector, reading a sample of merkle tree nodes",
+		},
+		&cli.StringFlag{
+			Name:  "resume-file",
+			Usage: "record results in this file, skipping sectors already recorded in it",
+		},
+		&cli.BoolFlag{
+			Name:  "only-bad",
+			Usage: "print only sectors which failed checks",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		if cctx.Bool("all") == (cctx.NArg() > 0) {
+			return xerrors.Errorf("specify either --all or a list of sector numbers")
+		}
+		concurrency := cctx.Int("concurrency")
+		if concurrency <= 0 {
+			return xerrors.Errorf("--concurrency must be greater than 0")
+		}
+
+		fullApi, closer, err := lcli.GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		minerApi, scloser, err := lcli.GetStorageMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer scloser()
+
+		ctx := lcli.ReqContext(cctx)
+
+		maddr, err := minerApi.ActorAddress(ctx)
+		if err != nil {
+			return err
+		}
+
+		mid, err := address.IDFromAddress(maddr)
+		if err != nil {
+			return err
+		}
+
+		mi, err := fullApi.StateMinerInfo(ctx, maddr, types.EmptyTSK)
+		if err != nil {
+			return err
+		}
+
+		var sectors []*miner.SectorOnChainInfo
+		if cctx.Bool("all") {
+			sectors, err = fullApi.StateMinerSectors(ctx, maddr, nil, types.EmptyTSK)
+			if err != nil {
+				return xerrors.Errorf("getting miner sectors: %w", err)
+			}
+		} else {
+			for _, arg := range cctx.Args().Slice() {
+				sn, err := strconv.ParseUint(arg, 10, 64)
+				if err != nil {
+					return xerrors.Errorf("could not parse sector number %q: %w", arg, err)
+				}
+				si, err := fullApi.StateSectorGetInfo(ctx, maddr, abi.SectorNumber(sn), types.EmptyTSK)
+				if err != nil {
+					return xerrors.Errorf("getting sector %d info: %w", sn, err)
+				}
+				if si == nil {
+					return xerrors.Errorf("sector %d not found on chain", sn)
+				}
+				sectors = append(sectors, si)
+			}
+		}
+
+		counts := map[string]int{}
+
+		var resume *os.File
+		done := map[abi.SectorNumber]struct{}{}
+		if path := cctx.String("resume-file"); path != "" {
+			prev, err := readCheckFilesResults(path)
+			if err != nil {
+				return err
+			}
+			for _, r := range prev {
+				if r.Status == checkFilesError {
+					continue // retry sectors which couldn't be checked
+				}
+				done[r.Sector] = struct{}{}
+				counts[r.Status]++
+			}
+			if len(done) > 0 {
+				fmt.Printf("resuming scan, %d sectors already checked\n", len(done))
+			}
+
+			resume, err = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+			if err != nil {
+				return xerrors.Errorf("opening resume file: %w", err)
+			}
+			defer resume.Close() //nolint:errcheck
+		}
+
+		localPaths, err := minerApi.StorageLocal(ctx)
+		if err != nil {
+			return xerrors.Errorf("getting local storage paths: %w", err)
+		}
+
+		todo := make(chan *miner.SectorOnChainInfo)
+		results := make(chan checkFilesResult)
+
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for si := range todo {
+					sid := abi.SectorID{Miner: abi.ActorID(mid), Number: si.SectorNumber}
+					results <- checkSectorFiles(ctx, minerApi, localPaths, sid, si, mi.SectorSize, mi.WindowPoStProofType, cctx.Bool("slow"))
+				}
+			}()
+		}
+
+		go func() {
+			defer close(todo)
+			for _, si := range sectors {
+				if _, ok := done[si.SectorNumber]; ok {
+					continue
+				}
+				select {
+				case todo <- si:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		for r := range results {
+			counts[r.Status]++
+
+			if resume != nil {
+				if _, err := fmt.Fprintf(resume, "%d\t%s\t%s\n", r.Sector, r.Status, r.Detail); err != nil {
+					return xerrors.Errorf("writing resume file: %w", err)
+				}
+			}
+
+			switch r.Status {
+			case checkFilesHealthy:
+				if !cctx.Bool("only-bad") {
+					fmt.Printf("%d\t%s\n", r.Sector, color.GreenString(r.Status))
+				}
+			case checkFilesError:
+				fmt.Printf("%d\t%s (%s)\n", r.Sector, color.YellowString(r.Status), r.Detail)
+			default:
+				fmt.Printf("%d\t%s (%s)\n", r.Sector, color.RedString(r.Status), r.Detail)
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Printf("Healthy: %d\n", counts[checkFilesHealthy])
+		fmt.Printf("Missing: %d\n", counts[checkFilesMissing])
+		fmt.Printf("Corrupt: %d\n", counts[checkFilesCorrupt])
+		if counts[checkFilesError] > 0 {
+			fmt.Printf("Errored: %d\n", counts[checkFilesError])
+		}
+
+		return nil
+	},
+}
+
+func checkSectorFiles(ctx context.Context, minerApi api.StorageMiner, localPaths map[storiface.ID]string, sid abi.SectorID, si *miner.SectorOnChainInfo, ssize abi.SectorSize, ppt abi.RegisteredPoStProof, slow bool) checkFilesResult {
+	res := checkFilesResult{Sector: sid.Number, Status: checkFilesHealthy}
+
+	replica, cache := storiface.FTSealed, storiface.FTCache
+	if si.SectorKeyCID != nil {
+		replica, cache = storiface.FTUpdate, storiface.FTUpdateCache
+	}
+
+	var problems []string
+	for _, ft := range []storiface.SectorFileType{replica, cache} {
+		found, err := minerApi.StorageFindSector(ctx, sid, ft, ssize, false)
+		if err != nil {
+			return checkFilesResult{Sector: sid.Number, Status: checkFilesError, Detail: fmt.Sprintf("finding %s: %s", ft, err)}
+		}
+		if len(found) == 0 {
+			res.Status = checkFilesMissing
+			problems = append(problems, fmt.Sprintf("%s not found in any storage path", ft))
+			continue
+		}
+
+		for _, info := range found {
+			lp, ok := localPaths[info.ID]
+			if !ok {
+				continue // remote path, only index checks are possible
+			}
+
+			p := filepath.Join(lp, ft.String(), storiface.SectorName(sid))
+			st, err := os.Stat(p)
+			switch {
+			case os.IsNotExist(err):
+				res.Status = checkFilesMissing
+				problems = append(problems, fmt.Sprintf("%s declared in %s but not on disk", ft, info.ID))
+			case err != nil:
+				return checkFilesResult{Sector: sid.Number, Status: checkFilesError, Detail: fmt.Sprintf("stat %s: %s", p, err)}
+			case ft == replica && st.Size() != int64(ssize):
+				if res.Status == checkFilesHealthy {
+					res.Status = checkFilesCorrupt
+				}
+				problems = append(problems, fmt.Sprintf("%s in %s has size %d, expected %d", ft, info.ID, st.Size(), ssize))
+			case ft == cache && !st.IsDir():
+				if res.Status == checkFilesHealthy {
+					res.Status = checkFilesCorrupt
+				}
+				problems = append(problems, fmt.Sprintf("%s in %s is not a directory", ft, info.ID))
+			}
+		}
+	}
+
+	if res.Status == checkFilesHealthy && slow {
+		bad, err := minerApi.CheckProvable(ctx, ppt, []storiface.SectorRef{{ID: sid, ProofType: si.SealProof}})
+		if err != nil {
+			return checkFilesResult{Sector: sid.Number, Status: checkFilesError, Detail: fmt.Sprintf("checking provable: %s", err)}
+		}
+		if reason, ok := bad[sid.Number]; ok {
+			res.Status = checkFilesCorrupt
+			problems = append(problems, reason)
+		}
+	}
+
+	res.Detail = strings.Join(problems, "; ")
+	return res
+}
+
+// readCheckFilesResults reads results recorded by a previous, possibly partial, run
+func readCheckFilesResults(path string) ([]checkFilesResult, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("opening resume file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	var out []checkFilesResult
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) < 2 {
+			return nil, xerrors.Errorf("malformed resume file line %q", line)
+		}
+		sn, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return nil, xerrors.Errorf("malformed sector number in resume file line %q: %w", line, err)
+		}
+		r := checkFilesResult{Sector: abi.SectorNumber(sn), Status: parts[1]}
+		if len(parts) == 3 {
+			r.Detail = parts[2]
+		}
+		out = append(out, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, xerrors.Errorf("reading resume file: %w", err)
+	}
+
+	return out, nil
+}
+
+var sectorsVerifyCommitmentCmd = &cli.Command{
+	Name:      "verify-commitment",
+	Usage:     "Compare the sealed commitment of a sector on disk with the commitment recorded on chain",
+	ArgsUsage: "<sectorNum>",
+	Description: `Compares the sealed commitment (CommR) of the sector recorded on chain with the commitment
+recorded by the sealing pipeline, and with the commitment recomputed from the p_aux file in the
+sector cache, for each copy of the cache in storage paths local to the miner. For snapped up
+sectors the commitment of the updated replica is checked.
+
+For sectors which are active on chain, a vanilla PoSt proof is also generated for a random
+challenge, which reads and verifies a sample of the replica and its merkle trees.
+
+The command fails if any of the checks fails.`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "skip-post",
+			Usage: "don't generate a vanilla PoSt proof for active sectors",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		if cctx.NArg() != 1 {
+			return lcli.IncorrectNumArgs(cctx)
+		}
+
+		sn, err := strconv.ParseUint(cctx.Args().First(), 10, 64)
+		if err != nil {
+			return xerrors.Errorf("could not parse sector number: %w", err)
+		}
+		sector := abi.SectorNumber(sn)
+
+		fullApi, closer, err := lcli.GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		minerApi, scloser, err := lcli.GetStorageMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer scloser()
+
+		ctx := lcli.ReqContext(cctx)
+
+		maddr, err := minerApi.ActorAddress(ctx)
+		if err != nil {
+			return err
+		}
+
+		mid, err := address.IDFromAddress(maddr)
+		if err != nil {
+			return err
+		}
+		sid := abi.SectorID{Miner: abi.ActorID(mid), Number: sector}
+
+		mi, err := fullApi.StateMinerInfo(ctx, maddr, types.EmptyTSK)
+		if err != nil {
+			return err
+		}
+
+		// the commitment on chain, and the file types holding the replica it commits to
+		var onChain, sectorKey *cid.Cid
+		var sealProof abi.RegisteredSealProof
+		cache := storiface.FTCache
+		si, err := fullApi.StateSectorGetInfo(ctx, maddr, sector, types.EmptyTSK)
+		if err != nil {
+			return xerrors.Errorf("getting sector info: %w", err)
+		}
+		if si != nil {
+			onChain, sealProof = &si.SealedCID, si.SealProof
+			if si.SectorKeyCID != nil {
+				sectorKey, cache = si.SectorKeyCID, storiface.FTUpdateCache
+			}
+		} else {
+			pci, err := fullApi.StateSectorPreCommitInfo(ctx, maddr, sector, types.EmptyTSK)
+			if err != nil {
+				return xerrors.Errorf("getting sector precommit info: %w", err)
+			}
+			if pci == nil {
+				return xerrors.Errorf("sector %d not found on chain", sector)
+			}
+			onChain, sealProof = &pci.Info.SealedCID, pci.Info.SealProof
+			fmt.Println("Sector is pre-committed, checking the pre-committed commitment")
+		}
+
+		fmt.Printf("On-chain CommR:  %s\n", onChain)
+
+		failed := false
+		check := func(what string, c cid.Cid, want cid.Cid) {
+			if c == want {
+				fmt.Printf("%s%s (%s)\n", what, c, color.GreenString("match"))
+				return
+			}
+			failed = true
+			fmt.Printf("%s%s (%s, expected %s)\n", what, c, color.RedString("mismatch"), want)
+		}
+
+		// the pipeline only records the commitment of the original replica, which is the sector key
+		// of snapped up sectors
+		pipelineWant := *onChain
+		if sectorKey != nil {
+			pipelineWant = *sectorKey
+		}
+		status, err := minerApi.SectorsStatus(ctx, sector, false)
+		switch {
+		case err != nil:
+			fmt.Printf("Pipeline CommR:  %s\n", color.YellowString("unknown (%s)", err))
+		case status.CommR == nil:
+			fmt.Printf("Pipeline CommR:  %s\n", color.YellowString("not recorded"))
+		default:
+			check("Pipeline CommR:  ", *status.CommR, pipelineWant)
+		}
+
+		localPaths, err := minerApi.StorageLocal(ctx)
+		if err != nil {
+			return xerrors.Errorf("getting local storage paths: %w", err)
+		}
+
+		found, err := minerApi.StorageFindSector(ctx, sid, cache, mi.SectorSize, false)
+		if err != nil {
+			return xerrors.Errorf("finding sector %s: %w", cache, err)
+		}
+		if len(found) == 0 {
+			failed = true
+			fmt.Printf("Sector %s:    %s\n", cache, color.RedString("not found in any storage path"))
+		}
+		for _, info := range found {
+			lp, ok := localPaths[info.ID]
+			if !ok {
+				fmt.Printf("p_aux in %s: %s\n", info.ID, color.YellowString("skipped, storage path is not local to the miner"))
+				continue
+			}
+
+			commr, err := commitment.PAuxCommR(filepath.Join(lp, cache.String(), storiface.SectorName(sid)))
+			if err != nil {
+				failed = true
+				fmt.Printf("p_aux in %s: %s\n", info.ID, color.RedString("reading: %s", err))
+				continue
+			}
+			c, err := commcid.ReplicaCommitmentV1ToCID(commr[:])
+			if err != nil {
+				return xerrors.Errorf("converting commitment to CID: %w", err)
+			}
+			check(fmt.Sprintf("p_aux in %s: ", info.ID), c, *onChain)
+		}
+
+		if si != nil && !cctx.Bool("skip-post") {
+			active, err := sectorActive(ctx, fullApi, maddr, sector)
+			if err != nil {
+				return err
+			}
+			if active {
+				bad, err := minerApi.CheckProvable(ctx, mi.WindowPoStProofType, []storiface.SectorRef{{ID: sid, ProofType: sealProof}})
+				if err != nil {
+					return xerrors.Errorf("checking provable: %w", err)
+				}
+				if reason, ok := bad[sector]; ok {
+					failed = true
+					fmt.Printf("PoSt challenge:  %s\n", color.RedString("failed: %s", reason))
+				} else {
+					fmt.Printf("PoSt challenge:  %s\n", color.GreenString("ok"))
+				}
+			}
+		}
+
+		if failed {
+			return xerrors.Errorf("sector %d failed commitment verification", sector)
+		}
+		return nil
+	},
+}
+
+// sectorActive returns whether the sector is active in its partition, i.e. it is proven and not faulty
+func sectorActive(ctx context.Context, fullApi api.FullNode, maddr address.Address, sector abi.SectorNumber) (bool, error) {
+	loc, err := fullApi.StateSectorPartition(ctx, maddr, sector, types.EmptyTSK)
+	if err != nil {
+		return false, xerrors.Errorf("getting sector location: %w", err)
+	}
+
+	parts, err := fullApi.StateMinerPartitions(ctx, maddr, loc.Deadline, types.EmptyTSK)
+	if err != nil {
+		return false, xerrors.Errorf("getting deadline partitions: %w", err)
+	}
+	if loc.Partition >= uint64(len(parts)) {
+		return false, xerrors.Errorf("sector %d partition %d not found in deadline %d", sector, loc.Partition, loc.Deadline)
+	}
+
+	return parts[loc.Partition].ActiveSectors.IsSet(uint64(sector))
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/ipfs/go-cid"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/actors/builtin"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/miner"
+	"github.com/filecoin-project/lotus/chain/types"
+	lcli "github.com/filecoin-project/lotus/cli"
+	cliutil "github.com/filecoin-project/lotus/cli/util"
+	sealing "github.com/filecoin-project/lotus/storage/pipeline"
+)
+
+type expiringDeal struct {
+	DealID    abi.DealID
+	Client    address.Address
+	PieceSize abi.PaddedPieceSize
+	EndEpoch  abi.ChainEpoch
+}
+
+type expiringDealsSector struct {
+	Sector     abi.SectorNumber
+	Expiration abi.ChainEpoch
+	// ActiveDeals is the number of all active deals in the sector, including ones not expiring
+	ActiveDeals int
+	Deals       []expiringDeal
+	// AllDealsExpiring is set when all active deals of the sector expire within the window, after
+	// which the sector holds no active deals
+	AllDealsExpiring bool
+}
+
+var sectorsDealsExpiringCmd = &cli.Command{
+	Name:  "deals-expiring",
+	Usage: "List active storage market deals which expire soon, grouped by sector",
+	Description: `Lists the active storage market deals in active sectors of the miner which end within the
+given number of epochs. Sectors in which all active deals expire are marked, as they will hold no
+deals afterwards, and can be considered for snap-ups or termination.
+
+Deals are found from the deal IDs recorded by the sealing pipeline, so sectors unknown to the
+pipeline are skipped. Pieces onboarded without storage market deals (DDO) have no end epoch and
+are not listed.`,
+	Flags: []cli.Flag{
+		&cli.Int64Flag{
+			Name:  "epochs",
+			Usage: "list deals ending within this number of epochs",
+			Value: 30 * builtin.EpochsInDay,
+		},
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "output in json format",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		within := abi.ChainEpoch(cctx.Int64("epochs"))
+		if within <= 0 {
+			return xerrors.Errorf("--epochs must be greater than 0")
+		}
+
+		fullApi, closer, err := lcli.GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		minerApi, scloser, err := lcli.GetStorageMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer scloser()
+
+		ctx := lcli.ReqContext(cctx)
+
+		maddr, err := minerApi.ActorAddress(ctx)
+		if err != nil {
+			return err
+		}
+
+		head, err := fullApi.ChainHead(ctx)
+		if err != nil {
+			return err
+		}
+
+		sectors, err := fullApi.StateMinerActiveSectors(ctx, maddr, head.Key())
+		if err != nil {
+			return xerrors.Errorf("getting active sectors: %w", err)
+		}
+
+		deals := map[abi.DealID]*api.MarketDeal{}
+		sectorDeals := map[abi.SectorNumber][]abi.DealID{}
+		var unknown int
+		for _, si := range sectors {
+			st, err := minerApi.SectorsStatus(ctx, si.SectorNumber, false)
+			if err != nil {
+				unknown++
+				continue
+			}
+
+			for _, id := range st.Deals {
+				if id == 0 {
+					continue // pieces without a market deal
+				}
+				if _, ok := deals[id]; !ok {
+					md, err := fullApi.StateMarketStorageDeal(ctx, id, head.Key())
+					if err != nil {
+						continue // the deal has expired or was slashed and removed from market state
+					}
+					deals[id] = md
+				}
+				sectorDeals[si.SectorNumber] = append(sectorDeals[si.SectorNumber], id)
+			}
+		}
+		if unknown > 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "skipped %d active sectors unknown to the sealing pipeline\n", unknown)
+		}
+
+		out := findExpiringDeals(sectors, sectorDeals, deals, head.Height(), within)
+
+		if cctx.Bool("json") {
+			return lcli.PrintJson(out)
+		}
+
+		if len(out) == 0 {
+			fmt.Printf("No active deals end within %d epochs\n", within)
+			return nil
+		}
+
+		for i, s := range out {
+			if i > 0 {
+				fmt.Println()
+			}
+
+			note := fmt.Sprintf("%d of %d active deals expiring", len(s.Deals), s.ActiveDeals)
+			if s.AllDealsExpiring {
+				note = color.YellowString("all %d active deals expiring", s.ActiveDeals)
+			}
+			fmt.Printf("Sector %d (expires %s), %s\n", s.Sector, cliutil.EpochTime(head.Height(), s.Expiration), note)
+
+			tw := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+			_, _ = fmt.Fprintln(tw, "  Deal\tClient\tPiece Size\tEnd Epoch\tEnds")
+			for _, d := range s.Deals {
+				_, _ = fmt.Fprintf(tw, "  %d\t%s\t%s\t%d\t%s\n", d.DealID, d.Client, types.SizeStr(types.NewInt(uint64(d.PieceSize))), d.EndEpoch, cliutil.EpochTime(head.Height(), d.EndEpoch))
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// findExpiringDeals returns the sectors with active deals ending within the given number of epochs
+// after the current epoch, ordered by sector number, with their deals ordered by end epoch.
+func findExpiringDeals(sectors []*miner.SectorOnChainInfo, sectorDeals map[abi.SectorNumber][]abi.DealID, deals map[abi.DealID]*api.MarketDeal, curr, within abi.ChainEpoch) []expiringDealsSector {
+	var out []expiringDealsSector
+	for _, si := range sectors {
+		s := expiringDealsSector{Sector: si.SectorNumber, Expiration: si.Expiration}
+
+		for _, id := range sectorDeals[si.SectorNumber] {
+			md, ok := deals[id]
+			if !ok || md.State.SlashEpoch != -1 || md.Proposal.EndEpoch <= curr {
+				continue
+			}
+			s.ActiveDeals++
+
+			if md.Proposal.EndEpoch > curr+within {
+				continue
+			}
+			s.Deals = append(s.Deals, expiringDeal{
+				DealID:    id,
+				Client:    md.Proposal.Client,
+				PieceSize: md.Proposal.PieceSize,
+				EndEpoch:  md.Proposal.EndEpoch,
+			})
+		}
+		if len(s.Deals) == 0 {
+			continue
+		}
+		s.AllDealsExpiring = len(s.Deals) == s.ActiveDeals
+
+		sort.Slice(s.Deals, func(i, j int) bool {
+			return s.Deals[i].EndEpoch < s.Deals[j].EndEpoch
+		})
+		out = append(out, s)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Sector < out[j].Sector
+	})
+	return out
+}
+
+// dealExportRow is a deal, or a piece onboarded without a storage market deal (DDO), in a sector.
+type dealExportRow struct {
+	Sector     abi.SectorNumber
+	DealID     abi.DealID `json:",omitempty"`
+	PieceCID   cid.Cid
+	Client     string
+	PieceSize  abi.PaddedPieceSize
+	StartEpoch abi.ChainEpoch
+	EndEpoch   abi.ChainEpoch
+}
+
+var sectorsDealsExportCmd = &cli.Command{
+	Name:  "deals-export",
+	Usage: "Export the deals in each sector for reconciliation against the on-chain deal set",
+	Description: `Writes a row for every deal in the sectors known to the sealing pipeline, with the sector
+number, deal ID, piece CID, client, padded piece size, and start and end epoch of the deal. Pieces
+onboarded without storage market deals (DDO) are included without a deal ID, with the client of
+their verified allocation if they have one. Rows are written as they are found, so that large
+miners can export to a file without holding all deals in memory.`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "output",
+			Usage: "file to write to, standard output if not set",
+		},
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "write a json array instead of csv",
+		},
+		&cli.BoolFlag{
+			Name:  "show-removed",
+			Usage: "include removed sectors",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		minerApi, closer, err := lcli.GetStorageMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		ctx := lcli.ReqContext(cctx)
+
+		sectors, err := minerApi.SectorsList(ctx)
+		if err != nil {
+			return xerrors.Errorf("listing sectors: %w", err)
+		}
+		sort.Slice(sectors, func(i, j int) bool {
+			return sectors[i] < sectors[j]
+		})
+
+		var w io.Writer = os.Stdout
+		if out := cctx.String("output"); out != "" {
+			f, err := os.Create(out)
+			if err != nil {
+				return xerrors.Errorf("creating output file: %w", err)
+			}
+			defer f.Close() //nolint:errcheck
+			w = f
+		}
+
+		var write func(dealExportRow) error
+		var finish func() error
+		if cctx.Bool("json") {
+			var n int
+			write = func(r dealExportRow) error {
+				b, err := json.Marshal(r)
+				if err != nil {
+					return err
+				}
+				sep := ",\n  "
+				if n == 0 {
+					sep = "[\n  "
+				}
+				n++
+				_, err = fmt.Fprintf(w, "%s%s", sep, b)
+				return err
+			}
+			finish = func() error {
+				if n == 0 {
+					_, err := fmt.Fprintln(w, "[]")
+					return err
+				}
+				_, err := fmt.Fprint(w, "\n]\n")
+				return err
+			}
+		} else {
+			cw := csv.NewWriter(w)
+			if err := cw.Write([]string{"sector", "deal_id", "piece_cid", "client", "piece_size", "start_epoch", "end_epoch"}); err != nil {
+				return xerrors.Errorf("writing csv header: %w", err)
+			}
+			write = func(r dealExportRow) error {
+				dealID := ""
+				if r.DealID != 0 {
+					dealID = strconv.FormatUint(uint64(r.DealID), 10)
+				}
+				return cw.Write([]string{
+					strconv.FormatUint(uint64(r.Sector), 10),
+					dealID,
+					r.PieceCID.String(),
+					r.Client,
+					strconv.FormatUint(uint64(r.PieceSize), 10),
+					strconv.FormatInt(int64(r.StartEpoch), 10),
+					strconv.FormatInt(int64(r.EndEpoch), 10),
+				})
+			}
+			finish = func() error {
+				cw.Flush()
+				return cw.Error()
+			}
+		}
+
+		var rows int
+		for _, sn := range sectors {
+			st, err := minerApi.SectorsStatus(ctx, sn, false)
+			if err != nil {
+				return xerrors.Errorf("getting status of sector %d: %w", sn, err)
+			}
+			if st.State == api.SectorState(sealing.Removed) && !cctx.Bool("show-removed") {
+				continue
+			}
+
+			for _, r := range sectorDealRows(sn, st.Pieces) {
+				if err := write(r); err != nil {
+					return xerrors.Errorf("writing deal: %w", err)
+				}
+				rows++
+			}
+		}
+		if err := finish(); err != nil {
+			return xerrors.Errorf("writing output: %w", err)
+		}
+
+		if cctx.IsSet("output") {
+			fmt.Printf("Exported %d deals from %d sectors to %s\n", rows, len(sectors), cctx.String("output"))
+		}
+		return nil
+	},
+}
+
+// sectorDealRows returns the deals and DDO pieces of a sector, skipping filler pieces.
+func sectorDealRows(sn abi.SectorNumber, pieces []api.SectorPiece) []dealExportRow {
+	var out []dealExportRow
+	for _, p := range pieces {
+		if p.DealInfo == nil {
+			continue
+		}
+		di := p.DealInfo
+
+		r := dealExportRow{
+			Sector:     sn,
+			DealID:     di.DealID,
+			PieceCID:   p.Piece.PieceCID,
+			PieceSize:  p.Piece.Size,
+			StartEpoch: di.DealSchedule.StartEpoch,
+			EndEpoch:   di.DealSchedule.EndEpoch,
+		}
+		switch {
+		case di.DealProposal != nil:
+			r.Client = di.DealProposal.Client.String()
+			r.StartEpoch = di.DealProposal.StartEpoch
+			r.EndEpoch = di.DealProposal.EndEpoch
+		case di.PieceActivationManifest != nil && di.PieceActivationManifest.VerifiedAllocationKey != nil:
+			client, err := address.NewIDAddress(uint64(di.PieceActivationManifest.VerifiedAllocationKey.Client))
+			if err == nil {
+				r.Client = client.String()
+			}
+		}
+		out = append(out, r)
+	}
+	return out
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/market"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/miner"
+	"github.com/filecoin-project/lotus/storage/pipeline/piece"
+)
+
+func TestFindExpiringDeals(t *testing.T) {
+	client, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+
+	deal := func(end, slashed abi.ChainEpoch) *api.MarketDeal {
+		return &api.MarketDeal{
+			Proposal: market.DealProposal{Client: client, PieceSize: 2048, EndEpoch: end},
+			State:    api.MarketDealState{SlashEpoch: slashed},
+		}
+	}
+
+	deals := map[abi.DealID]*api.MarketDeal{
+		1: deal(1200, -1),  // expiring
+		2: deal(5000, -1),  // not expiring
+		3: deal(1100, -1),  // expiring
+		4: deal(1050, 900), // slashed
+		5: deal(900, -1),   // already ended
+		6: deal(2000, -1),  // exactly at the end of the window
+	}
+	sectors := []*miner.SectorOnChainInfo{
+		{SectorNumber: 3, Expiration: 10000},
+		{SectorNumber: 1, Expiration: 10000},
+		{SectorNumber: 2, Expiration: 10000},
+	}
+	sectorDeals := map[abi.SectorNumber][]abi.DealID{
+		1: {1, 2},
+		2: {4, 5},
+		3: {6, 3, 4},
+	}
+
+	out := findExpiringDeals(sectors, sectorDeals, deals, 1000, 1000)
+	require.Len(t, out, 2)
+
+	require.Equal(t, abi.SectorNumber(1), out[0].Sector)
+	require.Equal(t, 2, out[0].ActiveDeals)
+	require.False(t, out[0].AllDealsExpiring)
+	require.Len(t, out[0].Deals, 1)
+	require.Equal(t, abi.DealID(1), out[0].Deals[0].DealID)
+	require.Equal(t, client, out[0].Deals[0].Client)
+	require.Equal(t, abi.PaddedPieceSize(2048), out[0].Deals[0].PieceSize)
+
+	// deals are ordered by end epoch, and the slashed deal is not counted as active
+	require.Equal(t, abi.SectorNumber(3), out[1].Sector)
+	require.Equal(t, 2, out[1].ActiveDeals)
+	require.True(t, out[1].AllDealsExpiring)
+	require.Equal(t, []abi.DealID{3, 6}, []abi.DealID{out[1].Deals[0].DealID, out[1].Deals[1].DealID})
+
+	require.Empty(t, findExpiringDeals(sectors, sectorDeals, deals, 1000, 10))
+}
+
+func TestSectorDealRows(t *testing.T) {
+	client, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	pc, err := cid.Parse("baga6ea4seaqao7s73y24kcutaosvacpdjgfe5pw76ooefnyqw4ynr3d2y6x2mpq")
+	require.NoError(t, err)
+
+	pieces := []api.SectorPiece{
+		{Piece: abi.PieceInfo{Size: 1024, PieceCID: pc}}, // filler
+		{
+			Piece: abi.PieceInfo{Size: 2048, PieceCID: pc},
+			DealInfo: &piece.PieceDealInfo{
+				DealID:       7,
+				DealProposal: &market.DealProposal{Client: client, StartEpoch: 100, EndEpoch: 200},
+				DealSchedule: piece.DealSchedule{StartEpoch: 1, EndEpoch: 2},
+			},
+		},
+		{
+			Piece: abi.PieceInfo{Size: 4096, PieceCID: pc},
+			DealInfo: &piece.PieceDealInfo{
+				PieceActivationManifest: &miner.PieceActivationManifest{
+					CID:                   pc,
+					Size:                  4096,
+					VerifiedAllocationKey: &miner.VerifiedAllocationKey{Client: 1001, ID: 3},
+				},
+				DealSchedule: piece.DealSchedule{StartEpoch: 300, EndEpoch: 400},
+			},
+		},
+	}
+
+	rows := sectorDealRows(5, pieces)
+	require.Equal(t, []dealExportRow{
+		{Sector: 5, DealID: 7, PieceCID: pc, Client: "f01000", PieceSize: 2048, StartEpoch: 100, EndEpoch: 200},
+		{Sector: 5, PieceCID: pc, Client: "f01001", PieceSize: 4096, StartEpoch: 300, EndEpoch: 400},
+	}, rows)
+}
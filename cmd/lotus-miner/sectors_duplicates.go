@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/ipfs/go-cid"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/api"
+	lcli "github.com/filecoin-project/lotus/cli"
+	"github.com/filecoin-project/lotus/lib/result"
+	sealing "github.com/filecoin-project/lotus/storage/pipeline"
+	"github.com/filecoin-project/lotus/storage/pipeline/piece"
+)
+
+// duplicateGroup is a set of sectors sharing a commitment, a deal or a piece
+type duplicateGroup struct {
+	// What is the shared commitment or piece CID, or the shared deal
+	What    string
+	Sectors []abi.SectorNumber
+}
+
+type sectorDuplicates struct {
+	// Sealed are the sectors with the same sealed commitment (CommR)
+	Sealed []duplicateGroup
+	// Unsealed are the sectors holding deal data with the same unsealed commitment (CommD)
+	Unsealed []duplicateGroup
+	// Deals are the deals or DDO pieces which were sealed into more than one sector
+	Deals []duplicateGroup
+	// SharedPieces are the piece CIDs stored in more than one sector by different deals
+	SharedPieces []duplicateGroup
+}
+
+func (d sectorDuplicates) found() bool {
+	return len(d.Sealed) > 0 || len(d.Unsealed) > 0 || len(d.Deals) > 0
+}
+
+var sectorsFindDuplicatesCmd = &cli.Command{
+	Name:  "find-duplicates",
+	Usage: "Find sectors holding the same data",
+	Description: `Scans the sectors known to the sealing pipeline for duplicated data, and reports:
+- sectors with the same sealed commitment (CommR)
+- sectors holding deal data with the same unsealed commitment (CommD), which contain
+  the same full-sector data; CC sectors all have the same CommD and are skipped
+- deals and DDO pieces which were sealed into more than one sector
+
+Pieces stored in multiple sectors by different deals, e.g. when a client makes more than one
+deal for the same data, are legitimately shared and are only listed with --show-shared.
+
+Removed sectors are skipped.`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "show-shared",
+			Usage: "list the pieces shared by different deals",
+		},
+		&cli.Int64Flag{
+			Name:  "check-parallelism",
+			Usage: "number of parallel requests to make for checking sector states",
+			Value: parallelSectorChecks,
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		minerApi, closer, err := lcli.GetStorageMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		ctx := lcli.ReqContext(cctx)
+
+		list, err := minerApi.SectorsList(ctx)
+		if err != nil {
+			return xerrors.Errorf("listing sectors: %w", err)
+		}
+
+		throttle := make(chan struct{}, cctx.Int64("check-parallelism"))
+		slist := make([]result.Result[api.SectorInfo], len(list))
+		var wg sync.WaitGroup
+		for i, s := range list {
+			throttle <- struct{}{}
+			wg.Add(1)
+			go func(i int, s abi.SectorNumber) {
+				defer wg.Done()
+				defer func() { <-throttle }()
+				slist[i] = result.Wrap(minerApi.SectorsStatus(ctx, s, false))
+			}(i, s)
+		}
+		wg.Wait()
+
+		sectors := make([]api.SectorInfo, 0, len(slist))
+		for i, r := range slist {
+			if r.Error != nil {
+				return xerrors.Errorf("getting status of sector %d: %w", list[i], r.Error)
+			}
+			if r.Value.State == api.SectorState(sealing.Removed) {
+				continue
+			}
+			sectors = append(sectors, r.Value)
+		}
+
+		dups := findDuplicateSectors(sectors)
+
+		printGroups := func(title string, groups []duplicateGroup) {
+			if len(groups) == 0 {
+				return
+			}
+			fmt.Println(title)
+			for _, g := range groups {
+				sns := make([]string, len(g.Sectors))
+				for i, sn := range g.Sectors {
+					sns[i] = fmt.Sprint(sn)
+				}
+				fmt.Printf("  %s: sectors %s\n", g.What, strings.Join(sns, ", "))
+			}
+			fmt.Println()
+		}
+
+		printGroups(color.RedString("Sectors with the same sealed commitment (CommR):"), dups.Sealed)
+		printGroups(color.RedString("Sectors with the same deal data (CommD):"), dups.Unsealed)
+		printGroups(color.RedString("Deals sealed into more than one sector:"), dups.Deals)
+		if cctx.Bool("show-shared") {
+			printGroups("Pieces shared by different deals:", dups.SharedPieces)
+		}
+
+		if !dups.found() {
+			fmt.Printf("No duplicates found in %d sectors\n", len(sectors))
+		}
+		if len(dups.SharedPieces) > 0 && !cctx.Bool("show-shared") {
+			fmt.Printf("%d pieces are shared by different deals, list them with --show-shared\n", len(dups.SharedPieces))
+		}
+		return nil
+	},
+}
+
+// findDuplicateSectors groups the sectors sharing commitments, deals or pieces. Sectors without
+// deal pieces are not compared by CommD, as all CC sectors of a size have the same CommD.
+func findDuplicateSectors(sectors []api.SectorInfo) sectorDuplicates {
+	sealed := map[cid.Cid][]abi.SectorNumber{}
+	unsealed := map[cid.Cid][]abi.SectorNumber{}
+	deals := map[string][]abi.SectorNumber{}
+	pieceDeals := map[cid.Cid]map[string][]abi.SectorNumber{}
+
+	for _, s := range sectors {
+		if s.CommR != nil {
+			sealed[*s.CommR] = append(sealed[*s.CommR], s.SectorID)
+		}
+
+		hasDeals := false
+		seenDeals := map[string]struct{}{}
+		for i, p := range s.Pieces {
+			if p.DealInfo == nil {
+				continue
+			}
+			hasDeals = true
+
+			key, ok := pieceDealKey(p.DealInfo)
+			if !ok {
+				// DDO pieces without an allocation can't be told apart from other pieces with
+				// the same CID
+				key = fmt.Sprintf("sector %d piece %d", s.SectorID, i)
+			}
+			if _, ok := seenDeals[key]; ok {
+				continue
+			}
+			seenDeals[key] = struct{}{}
+			if ok {
+				deals[key] = append(deals[key], s.SectorID)
+			}
+
+			pc := p.Piece.PieceCID
+			if pieceDeals[pc] == nil {
+				pieceDeals[pc] = map[string][]abi.SectorNumber{}
+			}
+			pieceDeals[pc][key] = append(pieceDeals[pc][key], s.SectorID)
+		}
+
+		if hasDeals && s.CommD != nil {
+			unsealed[*s.CommD] = append(unsealed[*s.CommD], s.SectorID)
+		}
+	}
+
+	var out sectorDuplicates
+	for c, sns := range sealed {
+		out.Sealed = appendDuplicates(out.Sealed, c.String(), sns)
+	}
+	for c, sns := range unsealed {
+		out.Unsealed = appendDuplicates(out.Unsealed, c.String(), sns)
+	}
+	for key, sns := range deals {
+		out.Deals = appendDuplicates(out.Deals, key, sns)
+	}
+	for pc, byDeal := range pieceDeals {
+		if len(byDeal) < 2 {
+			continue
+		}
+
+		var sns []abi.SectorNumber
+		seen := map[abi.SectorNumber]struct{}{}
+		for _, dsns := range byDeal {
+			for _, sn := range dsns {
+				if _, ok := seen[sn]; !ok {
+					seen[sn] = struct{}{}
+					sns = append(sns, sn)
+				}
+			}
+		}
+		out.SharedPieces = appendDuplicates(out.SharedPieces, pc.String(), sns)
+	}
+
+	for _, groups := range [][]duplicateGroup{out.Sealed, out.Unsealed, out.Deals, out.SharedPieces} {
+		sort.Slice(groups, func(i, j int) bool {
+			if groups[i].Sectors[0] != groups[j].Sectors[0] {
+				return groups[i].Sectors[0] < groups[j].Sectors[0]
+			}
+			return groups[i].What < groups[j].What
+		})
+	}
+	return out
+}
+
+// pieceDealKey identifies the deal of a piece, by deal ID for storage market deals and by
+// allocation for DDO pieces. DDO pieces without an allocation have no identity apart from their
+// piece CID.
+func pieceDealKey(di *piece.PieceDealInfo) (string, bool) {
+	switch {
+	case di.PublishCid != nil:
+		return fmt.Sprintf("deal %d", di.DealID), true
+	case di.PieceActivationManifest != nil && di.PieceActivationManifest.VerifiedAllocationKey != nil:
+		ak := di.PieceActivationManifest.VerifiedAllocationKey
+		return fmt.Sprintf("allocation %d of client f0%d", ak.ID, ak.Client), true
+	default:
+		return "", false
+	}
+}
+
+func appendDuplicates(groups []duplicateGroup, what string, sectors []abi.SectorNumber) []duplicateGroup {
+	if len(sectors) < 2 {
+		return groups
+	}
+	sort.Slice(sectors, func(i, j int) bool {
+		return sectors[i] < sectors[j]
+	})
+	return append(groups, duplicateGroup{What: what, Sectors: sectors})
+}
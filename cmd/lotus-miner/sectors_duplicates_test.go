@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/miner"
+	"github.com/filecoin-project/lotus/storage/pipeline/piece"
+)
+
+func TestFindDuplicateSectors(t *testing.T) {
+	mkCid := func(s string) *cid.Cid {
+		c := blocks.NewBlock([]byte(s)).Cid()
+		return &c
+	}
+	publish := mkCid("publish")
+	pieceP, pieceQ := *mkCid("piece-p"), *mkCid("piece-q")
+
+	deal := func(id abi.DealID, pc cid.Cid) api.SectorPiece {
+		return api.SectorPiece{
+			Piece:    abi.PieceInfo{Size: 2048, PieceCID: pc},
+			DealInfo: &piece.PieceDealInfo{PublishCid: publish, DealID: id},
+		}
+	}
+	ddo := func(pc cid.Cid, alloc *miner.VerifiedAllocationKey) api.SectorPiece {
+		return api.SectorPiece{
+			Piece: abi.PieceInfo{Size: 2048, PieceCID: pc},
+			DealInfo: &piece.PieceDealInfo{PieceActivationManifest: &miner.PieceActivationManifest{
+				CID:                   pc,
+				Size:                  2048,
+				VerifiedAllocationKey: alloc,
+			}},
+		}
+	}
+	filler := api.SectorPiece{Piece: abi.PieceInfo{Size: 2048, PieceCID: *mkCid("filler")}}
+	sector := func(sn abi.SectorNumber, commR, commD string, pieces ...api.SectorPiece) api.SectorInfo {
+		return api.SectorInfo{SectorID: sn, CommR: mkCid(commR), CommD: mkCid(commD), Pieces: pieces}
+	}
+
+	dups := findDuplicateSectors([]api.SectorInfo{
+		// the same data in different deals
+		sector(2, "r2", "dA", deal(11, pieceP), filler),
+		sector(1, "r1", "dA", deal(10, pieceP), filler),
+		// CC sectors share their CommD
+		sector(3, "r3", "cc", filler),
+		sector(4, "r4", "cc", filler),
+		// the same deal sealed twice, into sectors with the same CommR
+		sector(5, "r5", "dB", deal(12, pieceQ)),
+		sector(6, "r5", "dC", deal(12, pieceQ)),
+		// DDO pieces, the one without allocation only shares its piece
+		sector(7, "r7", "dD", ddo(pieceP, nil), ddo(pieceQ, &miner.VerifiedAllocationKey{Client: 1000, ID: 3})),
+		sector(8, "r8", "dE", ddo(pieceQ, &miner.VerifiedAllocationKey{Client: 1000, ID: 3})),
+		{SectorID: 9}, // no commitments yet
+	})
+
+	require.Equal(t, []duplicateGroup{{What: mkCid("r5").String(), Sectors: []abi.SectorNumber{5, 6}}}, dups.Sealed)
+	require.Equal(t, []duplicateGroup{{What: mkCid("dA").String(), Sectors: []abi.SectorNumber{1, 2}}}, dups.Unsealed)
+	require.Equal(t, []duplicateGroup{
+		{What: "deal 12", Sectors: []abi.SectorNumber{5, 6}},
+		{What: "allocation 3 of client f01000", Sectors: []abi.SectorNumber{7, 8}},
+	}, dups.Deals)
+	require.Equal(t, []duplicateGroup{
+		{What: pieceP.String(), Sectors: []abi.SectorNumber{1, 2, 7}},
+		{What: pieceQ.String(), Sectors: []abi.SectorNumber{5, 6, 7, 8}},
+	}, dups.SharedPieces)
+	require.True(t, dups.found())
+
+	require.False(t, findDuplicateSectors([]api.SectorInfo{
+		sector(1, "r1", "cc", filler),
+		sector(2, "r2", "cc", filler),
+	}).found())
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types"
+	lcli "github.com/filecoin-project/lotus/cli"
+	sealing "github.com/filecoin-project/lotus/storage/pipeline"
+)
+
+// openSectorStates are the states of the sectors deal pieces can be added to
+var openSectorStates = []api.SectorState{
+	api.SectorState(sealing.WaitDeals),
+	api.SectorState(sealing.AddPiece),
+	api.SectorState(sealing.SnapDealsWaitDeals),
+	api.SectorState(sealing.SnapDealsAddPiece),
+}
+
+type openSectorFill struct {
+	Sector abi.SectorNumber
+	State  api.SectorState
+	Size   abi.SectorSize
+
+	Deals int
+	// DealBytes and PaddingBytes are the padded sizes of the deal and the padding pieces added to the sector
+	DealBytes    abi.PaddedPieceSize
+	PaddingBytes abi.PaddedPieceSize
+	// Fill is the share of the sector holding deal data
+	Fill float64
+	// LastDealEnd is the end epoch of the last ending deal in the sector
+	LastDealEnd abi.ChainEpoch
+}
+
+var sectorsOpenCmd = &cli.Command{
+	Name:  "open",
+	Usage: "List the sectors open for deal pieces, with how much of them is filled",
+	Description: `Lists the sectors in which deal pieces are being packed, with the share of the sector filled with
+deal data, the space taken by padding between pieces, and the end of the last ending deal in the
+sector. Pieces are assigned to open sectors using the Sealing.DealPackingStrategy option.
+
+Only pieces which were already added to sectors are counted.`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "output in json format",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		minerApi, closer, err := lcli.GetStorageMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		ctx := lcli.ReqContext(cctx)
+
+		list, err := minerApi.SectorsListInStates(ctx, openSectorStates)
+		if err != nil {
+			return xerrors.Errorf("listing sectors: %w", err)
+		}
+
+		out := make([]openSectorFill, 0, len(list))
+		for _, sn := range list {
+			st, err := minerApi.SectorsStatus(ctx, sn, false)
+			if err != nil {
+				return xerrors.Errorf("getting status of sector %d: %w", sn, err)
+			}
+			fill, err := sectorFill(st)
+			if err != nil {
+				return xerrors.Errorf("sector %d: %w", sn, err)
+			}
+			out = append(out, fill)
+		}
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].Sector < out[j].Sector
+		})
+
+		if cctx.Bool("json") {
+			return lcli.PrintJson(out)
+		}
+
+		if len(out) == 0 {
+			fmt.Println("No sectors open for deals")
+			return nil
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "Sector\tState\tDeals\tDeal Data\tPadding\tFree\tFilled\tLast Deal End")
+		for _, s := range out {
+			free := abi.PaddedPieceSize(s.Size) - s.DealBytes - s.PaddingBytes
+			dealEnd := "-"
+			if s.LastDealEnd > 0 {
+				dealEnd = fmt.Sprint(s.LastDealEnd)
+			}
+			_, _ = fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\t%.1f%%\t%s\n", s.Sector, s.State, s.Deals,
+				types.SizeStr(types.NewInt(uint64(s.DealBytes))),
+				types.SizeStr(types.NewInt(uint64(s.PaddingBytes))),
+				types.SizeStr(types.NewInt(uint64(free))),
+				s.Fill*100, dealEnd)
+		}
+		return tw.Flush()
+	},
+}
+
+// sectorFill computes how much of a sector is filled with deal data and padding
+func sectorFill(st api.SectorInfo) (openSectorFill, error) {
+	ssize, err := st.SealProof.SectorSize()
+	if err != nil {
+		return openSectorFill{}, xerrors.Errorf("getting sector size: %w", err)
+	}
+
+	out := openSectorFill{
+		Sector: st.SectorID,
+		State:  st.State,
+		Size:   ssize,
+	}
+	for _, p := range st.Pieces {
+		if p.DealInfo == nil {
+			out.PaddingBytes += p.Piece.Size
+			continue
+		}
+
+		out.Deals++
+		out.DealBytes += p.Piece.Size
+		end, err := p.DealInfo.EndEpoch()
+		if err != nil {
+			return openSectorFill{}, xerrors.Errorf("getting deal end epoch: %w", err)
+		}
+		if end > out.LastDealEnd {
+			out.LastDealEnd = end
+		}
+	}
+	out.Fill = float64(out.DealBytes) / float64(ssize)
+
+	return out, nil
+}
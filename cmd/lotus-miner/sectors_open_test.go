@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/storage/pipeline/piece"
+)
+
+func TestSectorFill(t *testing.T) {
+	deal := func(size abi.PaddedPieceSize, end abi.ChainEpoch) api.SectorPiece {
+		return api.SectorPiece{
+			Piece:    abi.PieceInfo{Size: size},
+			DealInfo: &piece.PieceDealInfo{DealSchedule: piece.DealSchedule{EndEpoch: end}},
+		}
+	}
+
+	fill, err := sectorFill(api.SectorInfo{
+		SectorID:  3,
+		State:     "WaitDeals",
+		SealProof: abi.RegisteredSealProof_StackedDrg2KiBV1_1,
+		Pieces: []api.SectorPiece{
+			deal(256, 2000),
+			{Piece: abi.PieceInfo{Size: 256}}, // padding
+			deal(512, 1000),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, openSectorFill{
+		Sector:       3,
+		State:        "WaitDeals",
+		Size:         2048,
+		Deals:        2,
+		DealBytes:    768,
+		PaddingBytes: 256,
+		Fill:         0.375,
+		LastDealEnd:  2000,
+	}, fill)
+
+	// empty sector
+	fill, err = sectorFill(api.SectorInfo{SectorID: 4, SealProof: abi.RegisteredSealProof_StackedDrg2KiBV1_1})
+	require.NoError(t, err)
+	require.Zero(t, fill.Fill)
+	require.Zero(t, fill.Deals)
+}
@@ -50,6 +50,7 @@ stored while moving through the sealing pipeline (references as 'seal').`,
 		storageListCmd,
 		storageFindCmd,
 		storageCleanupCmd,
+		storagePrunePiecesCmd,
 		storageLocks,
 	},
 }
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types"
+	lcli "github.com/filecoin-project/lotus/cli"
+	sealing "github.com/filecoin-project/lotus/storage/pipeline"
+	"github.com/filecoin-project/lotus/storage/sealer/fsutil"
+	"github.com/filecoin-project/lotus/storage/sealer/storiface"
+)
+
+// prunablePipelineStates are sealing pipeline states in which a sector no longer needs its
+// unsealed data on behalf of the pipeline; whether the data is still needed then only depends
+// on whether the sector is live on chain. FailedUnrecoverable sectors are only prunable with
+// --include-failed, as recovering them with 'sectors recover-failed' may need their data.
+var prunablePipelineStates = []api.SectorState{
+	api.SectorState(sealing.Proving),
+	api.SectorState(sealing.Available),
+	api.SectorState(sealing.TerminateFinality),
+	api.SectorState(sealing.Removed),
+}
+
+var storagePrunePiecesCmd = &cli.Command{
+	Name:  "prune-pieces",
+	Usage: "find and remove unsealed piece data which is no longer referenced by any sector",
+	Description: `Unsealed sector files hold the piece data of deals. Sectors which expired, were terminated or
+failed sealing can leave unsealed files behind which are no longer needed.
+
+An unsealed file is considered orphaned when its sector is not live on chain, and is not in the
+sealing pipeline in a state which still needs the data, so that data of deals which are still
+being sealed is never removed. The data of sectors in the FailedUnrecoverable state is kept, as
+'lotus-miner sectors recover-failed' may still need it, unless --include-failed is set.
+
+Orphaned files are removed from all storage paths, use --dry-run to only report them along with
+the space which would be reclaimed.`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only report orphaned files and reclaimable space, without removing anything",
+		},
+		&cli.BoolFlag{
+			Name:  "include-failed",
+			Usage: "also remove the unsealed data of sectors which failed unrecoverably",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		minerApi, closer, err := lcli.GetStorageMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		napi, closer2, err := lcli.GetFullNodeAPIV1(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer2()
+
+		ctx := lcli.ReqContext(cctx)
+
+		maddr, err := minerApi.ActorAddress(ctx)
+		if err != nil {
+			return err
+		}
+
+		aid, err := address.IDFromAddress(maddr)
+		if err != nil {
+			return err
+		}
+
+		// Storage is listed first, so that any sector with unsealed data found there is already
+		// known to the pipeline, and the chain is listed last, so that a sector which finishes
+		// sealing in the meantime is seen as either in-flight or live on chain.
+		decls, err := minerApi.StorageList(ctx)
+		if err != nil {
+			return xerrors.Errorf("listing storage: %w", err)
+		}
+
+		pipeline, err := minerApi.SectorsList(ctx)
+		if err != nil {
+			return xerrors.Errorf("listing sectors: %w", err)
+		}
+		prunable := append([]api.SectorState{}, prunablePipelineStates...)
+		if cctx.Bool("include-failed") {
+			prunable = append(prunable, api.SectorState(sealing.FailedUnrecoverable))
+		}
+		done, err := minerApi.SectorsListInStates(ctx, prunable)
+		if err != nil {
+			return xerrors.Errorf("listing sectors in final states: %w", err)
+		}
+
+		onChain, err := napi.StateMinerSectors(ctx, maddr, nil, types.EmptyTSK)
+		if err != nil {
+			return xerrors.Errorf("getting on-chain sectors: %w", err)
+		}
+
+		referenced := map[abi.SectorNumber]struct{}{}
+		for _, sn := range pipeline {
+			referenced[sn] = struct{}{}
+		}
+		for _, sn := range done {
+			delete(referenced, sn)
+		}
+		for _, si := range onChain {
+			referenced[si.SectorNumber] = struct{}{}
+		}
+
+		local, err := minerApi.StorageLocal(ctx)
+		if err != nil {
+			return xerrors.Errorf("getting local storage paths: %w", err)
+		}
+
+		type orphan struct {
+			sector  abi.SectorNumber
+			storage storiface.ID
+			size    int64 // -1 if the path isn't local
+		}
+
+		var orphans []orphan
+		for id, ds := range decls {
+			for _, d := range ds {
+				if d.SectorFileType&storiface.FTUnsealed == 0 || d.Miner != abi.ActorID(aid) {
+					continue
+				}
+				if _, ok := referenced[d.Number]; ok {
+					continue
+				}
+
+				o := orphan{sector: d.Number, storage: id, size: -1}
+				if lp, ok := local[id]; ok {
+					si, err := fsutil.FileSize(filepath.Join(lp, storiface.FTUnsealed.String(), storiface.SectorName(d.SectorID)))
+					if err != nil && !os.IsNotExist(err) {
+						return xerrors.Errorf("getting size of unsealed file for sector %d: %w", d.Number, err)
+					}
+					o.size = si.OnDisk
+				}
+				orphans = append(orphans, o)
+			}
+		}
+
+		sort.Slice(orphans, func(i, j int) bool {
+			if orphans[i].sector != orphans[j].sector {
+				return orphans[i].sector < orphans[j].sector
+			}
+			return orphans[i].storage < orphans[j].storage
+		})
+
+		if len(orphans) == 0 {
+			fmt.Println("No orphaned unsealed files found")
+			return nil
+		}
+
+		var reclaimable int64
+		var remote int
+		tw := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintf(tw, "Sector\tStorage\tSize\n")
+		for _, o := range orphans {
+			size := "remote"
+			if o.size >= 0 {
+				size = types.SizeStr(types.NewInt(uint64(o.size)))
+				reclaimable += o.size
+			} else {
+				remote++
+			}
+			_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\n", o.sector, o.storage, size)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Printf("Orphaned unsealed files: %d\n", len(orphans))
+		fmt.Printf("Reclaimable space: %s", types.SizeStr(types.NewInt(uint64(reclaimable))))
+		if remote > 0 {
+			fmt.Printf(" (plus %d files in paths not local to the miner)", remote)
+		}
+		fmt.Println()
+
+		if cctx.Bool("dry-run") {
+			return nil
+		}
+
+		removed := map[abi.SectorNumber]struct{}{}
+		for _, o := range orphans {
+			if _, ok := removed[o.sector]; ok {
+				continue // all paths are cleaned up by a single call
+			}
+			removed[o.sector] = struct{}{}
+
+			if err := minerApi.StorageRemoveUnsealed(ctx, abi.SectorID{Miner: abi.ActorID(aid), Number: o.sector}); err != nil {
+				return xerrors.Errorf("removing unsealed data of sector %d: %w", o.sector, err)
+			}
+			fmt.Printf("removed unsealed data of sector %d\n", o.sector)
+		}
+
+		return nil
+	},
+}
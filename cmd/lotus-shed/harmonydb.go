@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/lotus/chain/types"
+	lcli "github.com/filecoin-project/lotus/cli"
+	"github.com/filecoin-project/lotus/lib/harmony/harmonydb"
+	"github.com/filecoin-project/lotus/node/config"
+	"github.com/filecoin-project/lotus/node/repo"
+)
+
+var harmonyDBCmd = &cli.Command{
+	Name:  "harmonydb",
+	Usage: "Tools for the HarmonyDB database backing the lotus-miner sector index and keystore",
+	Subcommands: []*cli.Command{
+		harmonyDBLatencyCmd,
+		harmonyDBMigrateKeystoreCmd,
+	},
+}
+
+var harmonyDBLatencyCmd = &cli.Command{
+	Name:  "latency",
+	Usage: "Measure round-trip latency of representative sector index queries",
+	Description: `Connects to the HarmonyDB configured in the lotus-miner config and repeatedly runs queries
+representative of the sector index: a bare round trip, a sector location lookup, a storage path
+listing, and a storage path heartbeat update, which is rolled back. Reports p50/p95/p99
+latencies, and flags queries whose p95 latency is above --warn, which slows down sector storage
+operations like locking and finding sectors.
+
+Note that connecting applies any pending schema migrations, like starting lotus-miner does.`,
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:  "count",
+			Usage: "number of times to run each query",
+			Value: 100,
+		},
+		&cli.DurationFlag{
+			Name:  "warn",
+			Usage: "flag queries with a p95 latency above this",
+			Value: 50 * time.Millisecond,
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		if cctx.Int("count") <= 0 {
+			return xerrors.Errorf("count must be positive")
+		}
+
+		repoPath, err := homedir.Expand(cctx.String("miner-repo"))
+		if err != nil {
+			return err
+		}
+		mcfg, err := loadMinerConfig(repoPath)
+		if err != nil {
+			return err
+		}
+		if !mcfg.Subsystems.EnableSectorIndexDB {
+			fmt.Println("Note: the sector index DB is not enabled in the miner config")
+		}
+
+		db, err := harmonydb.NewFromConfig(mcfg.HarmonyDB)
+		if err != nil {
+			return xerrors.Errorf("connecting to harmonydb: %w", err)
+		}
+
+		ctx := lcli.ReqContext(cctx)
+
+		queries := []struct {
+			name string
+			run  func(context.Context) error
+		}{
+			{"round trip", func(ctx context.Context) error {
+				var one int
+				return db.QueryRow(ctx, "SELECT 1").Scan(&one)
+			}},
+			{"find sector", func(ctx context.Context) error {
+				var rows []struct {
+					StorageID string `db:"storage_id"`
+					IsPrimary bool   `db:"is_primary"`
+				}
+				return db.Select(ctx, &rows, "SELECT storage_id, is_primary FROM sector_location WHERE miner_id=$1 AND sector_num=$2", 0, 0)
+			}},
+			{"list paths", func(ctx context.Context) error {
+				var rows []struct {
+					StorageID     string     `db:"storage_id"`
+					LastHeartbeat *time.Time `db:"last_heartbeat"`
+				}
+				return db.Select(ctx, &rows, "SELECT storage_id, last_heartbeat FROM storage_path")
+			}},
+			{"heartbeat", func(ctx context.Context) error {
+				_, err := db.BeginTransaction(ctx, func(tx *harmonydb.Tx) (commit bool, err error) {
+					_, err = tx.Exec("UPDATE storage_path SET last_heartbeat=NOW() WHERE storage_id IN (SELECT storage_id FROM storage_path LIMIT 1)")
+					return false, err // never commit
+				})
+				return err
+			}},
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "Query\tCount\tp50\tp95\tp99\tMax\t")
+
+		var slow bool
+		for _, q := range queries {
+			took := make([]time.Duration, 0, cctx.Int("count"))
+			for i := 0; i < cctx.Int("count"); i++ {
+				start := time.Now()
+				if err := q.run(ctx); err != nil {
+					return xerrors.Errorf("running %s query: %w", q.name, err)
+				}
+				took = append(took, time.Since(start))
+			}
+			sort.Slice(took, func(i, j int) bool {
+				return took[i] < took[j]
+			})
+
+			p95 := latencyPercentile(took, 95)
+			flag := ""
+			if p95 > cctx.Duration("warn") {
+				flag = "HIGH"
+				slow = true
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n", q.name, len(took),
+				latencyPercentile(took, 50).Round(time.Microsecond), p95.Round(time.Microsecond),
+				latencyPercentile(took, 99).Round(time.Microsecond), took[len(took)-1].Round(time.Microsecond), flag)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		if slow {
+			fmt.Printf("\nSome queries have a p95 latency above %s. Check the network between this host and the database, and the placement of database nodes.\n", cctx.Duration("warn"))
+		}
+		return nil
+	},
+}
+
+var harmonyDBMigrateKeystoreCmd = &cli.Command{
+	Name:  "migrate-keystore",
+	Usage: "Copy the keys of a lotus-miner between its repo keystore and the HarmonyDB keystore",
+	Description: `Copies the keys of the miner repo keystore to the HarmonyDB keystore, which the miner uses
+when Subsystems.EnableKeyStoreDB is set, or back with --to=repo. Keys which already exist in the
+target keystore are left as they are, and reported when they differ. The keys are not removed
+from the source keystore. The libp2p host key identifies the node and is never copied.
+
+The miner must be stopped, as its repo is locked.`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "to",
+			Usage: "target keystore, 'db' or 'repo'",
+			Value: "db",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		repoPath, err := homedir.Expand(cctx.String("miner-repo"))
+		if err != nil {
+			return err
+		}
+		mcfg, err := loadMinerConfig(repoPath)
+		if err != nil {
+			return err
+		}
+
+		lr, err := openLockedRepo(repoPath)
+		if err != nil {
+			return err
+		}
+		defer lr.Close() //nolint:errcheck
+
+		local, err := lr.KeyStore()
+		if err != nil {
+			return xerrors.Errorf("opening repo keystore: %w", err)
+		}
+		db, err := harmonydb.NewFromConfig(mcfg.HarmonyDB)
+		if err != nil {
+			return xerrors.Errorf("connecting to harmonydb: %w", err)
+		}
+		dbks := repo.NewDBKeyStore(db, local)
+
+		var from, to types.KeyStore
+		switch cctx.String("to") {
+		case "db":
+			from, to = local, dbks
+		case "repo":
+			from, to = dbks, local
+		default:
+			return xerrors.Errorf("unknown target keystore %q, expected 'db' or 'repo'", cctx.String("to"))
+		}
+
+		names, err := from.List()
+		if err != nil {
+			return xerrors.Errorf("listing keys: %w", err)
+		}
+
+		var copied, conflicts int
+		for _, name := range names {
+			if _, ok := repo.LocalKeys[name]; ok {
+				continue
+			}
+
+			ki, err := from.Get(name)
+			if err != nil {
+				return xerrors.Errorf("getting key %s: %w", name, err)
+			}
+
+			existing, err := to.Get(name)
+			switch {
+			case err == nil:
+				if existing.Type != ki.Type || !bytes.Equal(existing.PrivateKey, ki.PrivateKey) {
+					fmt.Printf("%s: a different key already exists in the target keystore, skipping\n", name)
+					conflicts++
+				}
+				continue
+			case !errors.Is(err, types.ErrKeyInfoNotFound):
+				return xerrors.Errorf("checking key %s: %w", name, err)
+			}
+
+			if err := to.Put(name, ki); err != nil {
+				return xerrors.Errorf("copying key %s: %w", name, err)
+			}
+			fmt.Printf("%s: copied\n", name)
+			copied++
+		}
+
+		fmt.Printf("copied %d keys to the %s keystore\n", copied, cctx.String("to"))
+		if conflicts > 0 {
+			return xerrors.Errorf("%d keys differ between the keystores", conflicts)
+		}
+		return nil
+	},
+}
+
+func loadMinerConfig(repoPath string) (*config.StorageMiner, error) {
+	cfg, err := config.FromFile(filepath.Join(repoPath, "config.toml"), config.SetDefault(func() (interface{}, error) {
+		return config.DefaultStorageMiner(), nil
+	}))
+	if err != nil {
+		return nil, xerrors.Errorf("loading miner config: %w", err)
+	}
+	mcfg, ok := cfg.(*config.StorageMiner)
+	if !ok {
+		return nil, xerrors.Errorf("wrong config type: %T", cfg)
+	}
+	return mcfg, nil
+}
+
+// latencyPercentile returns the p-th percentile of the sorted durations (nearest rank).
+func latencyPercentile(sorted []time.Duration, p int) time.Duration {
+	idx := (len(sorted)*p + 99) / 100
+	if idx < 1 {
+		idx = 1
+	}
+	return sorted[idx-1]
+}
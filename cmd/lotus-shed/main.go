@@ -25,6 +25,7 @@ func main() {
 		addressCmd,
 		statActorCmd,
 		statSnapshotCmd,
+		snapshotCmd,
 		statObjCmd,
 		base64Cmd,
 		base32Cmd,
@@ -44,6 +45,7 @@ func main() {
 		importObjectCmd,
 		commpToCidCmd,
 		fetchParamCmd,
+		paramsCmd,
 		postFindCmd,
 		proofsCmd,
 		verifRegCmd,
@@ -91,6 +93,7 @@ func main() {
 		blockCmd,
 		adlCmd,
 		f3Cmd,
+		harmonyDBCmd,
 	}
 
 	app := &cli.App{
@@ -1,8 +1,20 @@
 package main
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
 	"github.com/docker/go-units"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/crypto/blake2b"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-paramfetch"
@@ -34,3 +46,169 @@ var fetchParamCmd = &cli.Command{
 		return nil
 	},
 }
+
+var paramsCmd = &cli.Command{
+	Name:  "params",
+	Usage: "Tools for proving parameters",
+	Subcommands: []*cli.Command{
+		paramsVerifyCmd,
+	},
+}
+
+var paramsVerifyCmd = &cli.Command{
+	Name:  "verify",
+	Usage: "Verify installed proving parameters against the manifest compiled into this binary",
+	Description: `Checks the checksum of every parameter file needed for the given sector sizes, and reports
+files which are missing, corrupt, or not part of the manifest. Exits with a non-zero status
+when any needed file is missing or corrupt, so that it can gate starting a node.
+
+Parameters are read from $FIL_PROOFS_PARAMETER_CACHE, or /var/tmp/filecoin-proof-parameters
+when it's not set. Checking the parameters of large sector sizes reads tens of GiB.`,
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:     "sector-size",
+			Usage:    "sector sizes to check the parameters of, i.e. 32GiB",
+			Required: true,
+		},
+		&cli.IntFlag{
+			Name:  "parallel",
+			Usage: "number of files to check in parallel",
+			Value: 4,
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		dir := os.Getenv("FIL_PROOFS_PARAMETER_CACHE")
+		if dir == "" {
+			dir = "/var/tmp/filecoin-proof-parameters"
+		}
+
+		manifest := map[string]paramManifestEntry{}
+		for _, b := range [][]byte{build.ParametersJSON(), build.SrsJSON()} {
+			var entries map[string]paramManifestEntry
+			if err := json.Unmarshal(b, &entries); err != nil {
+				return xerrors.Errorf("decoding parameter manifest: %w", err)
+			}
+			for name, e := range entries {
+				manifest[name] = e
+			}
+		}
+
+		sizes := map[uint64]struct{}{}
+		for _, s := range cctx.StringSlice("sector-size") {
+			ss, err := units.RAMInBytes(s)
+			if err != nil {
+				return xerrors.Errorf("parsing sector size %q: %w", s, err)
+			}
+			sizes[uint64(ss)] = struct{}{}
+		}
+
+		needed, err := neededParams(manifest, sizes)
+		if err != nil {
+			return err
+		}
+		status := checkParams(dir, manifest, needed, cctx.Int("parallel"))
+
+		var problems int
+		tw := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "File\tStatus")
+		for i, name := range needed {
+			if status[i] != "ok" {
+				problems++
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t%s\n", name, status[i])
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil && !os.IsNotExist(err) {
+			return xerrors.Errorf("listing parameter directory: %w", err)
+		}
+		for _, e := range entries {
+			if _, known := manifest[e.Name()]; known || e.IsDir() || e.Name() == "fetch.lock" {
+				continue
+			}
+			// extra files don't break proving, but may be partial downloads or parameters of other versions
+			_, _ = fmt.Fprintf(tw, "%s\textra\n", e.Name())
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		if problems > 0 {
+			return xerrors.Errorf("%d of %d parameter files in %s are missing or corrupt", problems, len(needed), dir)
+		}
+		fmt.Printf("All %d parameter files in %s are ok\n", len(needed), dir)
+		return nil
+	},
+}
+
+type paramManifestEntry struct {
+	Digest     string `json:"digest"`
+	SectorSize uint64 `json:"sector_size"`
+}
+
+// neededParams returns the sorted names of the manifest files needed to prove sectors of the given
+// sizes, failing for sizes the manifest has no parameters for.
+func neededParams(manifest map[string]paramManifestEntry, sizes map[uint64]struct{}) ([]string, error) {
+	var needed []string
+	found := map[uint64]struct{}{}
+	for name, e := range manifest {
+		// like paramfetch, verifying keys and srs files are needed for all sector sizes
+		if strings.HasSuffix(name, ".params") {
+			if _, ok := sizes[e.SectorSize]; !ok {
+				continue
+			}
+			found[e.SectorSize] = struct{}{}
+		}
+		needed = append(needed, name)
+	}
+	for ss := range sizes {
+		if _, ok := found[ss]; !ok {
+			return nil, xerrors.Errorf("no proving parameters for sector size %s in the manifest", units.BytesSize(float64(ss)))
+		}
+	}
+	sort.Strings(needed)
+	return needed, nil
+}
+
+// checkParams returns the status of each of the named parameter files in dir, checking up to
+// parallel files at once.
+func checkParams(dir string, manifest map[string]paramManifestEntry, names []string, parallel int) []string {
+	status := make([]string, len(names))
+	throttle := make(chan struct{}, max(parallel, 1))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		throttle <- struct{}{}
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			defer func() { <-throttle }()
+			status[i] = checkParamFile(filepath.Join(dir, name), manifest[name].Digest)
+		}(i, name)
+	}
+	wg.Wait()
+	return status
+}
+
+// checkParamFile returns the status of a parameter file, checking its digest the way paramfetch does.
+func checkParamFile(path, digest string) string {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return "missing"
+	}
+	if err != nil {
+		return fmt.Sprintf("unreadable: %s", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	h, err := blake2b.New512(nil)
+	if err != nil {
+		return fmt.Sprintf("error: %s", err)
+	}
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Sprintf("unreadable: %s", err)
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)[:16]); sum != digest {
+		return fmt.Sprintf("corrupt (digest %s, expected %s)", sum, digest)
+	}
+	return "ok"
+}
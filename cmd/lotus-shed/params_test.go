@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestParamsVerify(t *testing.T) {
+	dir := t.TempDir()
+
+	digest := func(data string) string {
+		sum := blake2b.Sum512([]byte(data))
+		return hex.EncodeToString(sum[:16])
+	}
+
+	manifest := map[string]paramManifestEntry{
+		"v28-2k.params":         {Digest: digest("2k params"), SectorSize: 2 << 10},
+		"v28-2k.vk":             {Digest: digest("2k vk"), SectorSize: 2 << 10},
+		"v28-8m.params":         {Digest: digest("8m params"), SectorSize: 8 << 20},
+		"v28-8m.vk":             {Digest: digest("8m vk"), SectorSize: 8 << 20},
+		"v28-inner-product.srs": {Digest: digest("srs"), SectorSize: 0},
+	}
+
+	for name, data := range map[string]string{
+		"v28-2k.params":         "2k params",
+		"v28-2k.vk":             "corrupt 2k vk",
+		"v28-inner-product.srs": "srs",
+		"v27-2k.params":         "params of another version",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0600))
+	}
+
+	// verifying keys and srs files are needed for all sector sizes
+	needed, err := neededParams(manifest, map[uint64]struct{}{2 << 10: {}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"v28-2k.params", "v28-2k.vk", "v28-8m.vk", "v28-inner-product.srs"}, needed)
+
+	status := checkParams(dir, manifest, needed, 2)
+	require.Equal(t, "ok", status[0])
+	require.True(t, strings.HasPrefix(status[1], "corrupt"), status[1])
+	require.Equal(t, "missing", status[2])
+	require.Equal(t, "ok", status[3])
+
+	// the parameters of a sector size are checked even when none of its files are on disk
+	needed, err = neededParams(manifest, map[uint64]struct{}{2 << 10: {}, 8 << 20: {}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"v28-2k.params", "v28-2k.vk", "v28-8m.params", "v28-8m.vk", "v28-inner-product.srs"}, needed)
+	require.Equal(t, "missing", checkParams(dir, manifest, needed, 2)[2])
+
+	// sector sizes without parameters can't be checked
+	_, err = neededParams(manifest, map[uint64]struct{}{32 << 30: {}})
+	require.ErrorContains(t, err, "no proving parameters for sector size 32GiB")
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/DataDog/zstd"
+	"github.com/ipfs/go-cid"
+	carv2 "github.com/ipld/go-car/v2"
+	"github.com/multiformats/go-multicodec"
+	"github.com/urfave/cli/v2"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	lcli "github.com/filecoin-project/lotus/cli"
+)
+
+var snapshotCmd = &cli.Command{
+	Name:  "snapshot",
+	Usage: "Tools for working with chain snapshots",
+	Subcommands: []*cli.Command{
+		snapshotCompareCmd,
+	},
+}
+
+var snapshotCompareCmd = &cli.Command{
+	Name:      "compare",
+	Usage:     "Check that two snapshot CAR files are equivalent",
+	ArgsUsage: "[a.car] [b.car]",
+	Description: `Checks that both snapshots declare the same roots, and that the same set of blocks is reachable
+from the roots in both of them. Links to blocks which aren't in a snapshot, such as the state of
+epochs older than the exported state, aren't followed. Blocks contained in a snapshot but not
+reachable from its roots are counted, but don't make the snapshots differ.
+
+Each file is read once as a stream, zstd compressed snapshots are decompressed on the fly, so no
+extra disk space is needed. The CIDs of all blocks of both snapshots and the links between them are
+kept in memory, so comparing full mainnet snapshots needs several GiB of memory. Divergent CIDs are
+printed in sorted order. Exits with an error when the snapshots differ.`,
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:  "max-diffs",
+			Usage: "number of divergent CIDs to print on each side",
+			Value: 10,
+		},
+		&cli.BoolFlag{
+			Name:  "verify",
+			Usage: "also check that the data of all blocks hashes to their CIDs",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		if cctx.NArg() != 2 {
+			return lcli.IncorrectNumArgs(cctx)
+		}
+		ctx := lcli.ReqContext(cctx)
+		maxDiffs := cctx.Int("max-diffs")
+
+		a, err := walkSnapshot(ctx, cctx.Args().Get(0), cctx.Bool("verify"))
+		if err != nil {
+			return err
+		}
+		b, err := walkSnapshot(ctx, cctx.Args().Get(1), cctx.Bool("verify"))
+		if err != nil {
+			return err
+		}
+
+		onlyA, err := snapshotDiff(a.walked, b.walked)
+		if err != nil {
+			return err
+		}
+		onlyB, err := snapshotDiff(b.walked, a.walked)
+		if err != nil {
+			return err
+		}
+
+		sameRoots := len(a.roots) == len(b.roots)
+		for i := 0; sameRoots && i < len(a.roots); i++ {
+			sameRoots = a.roots[i].Equals(b.roots[i])
+		}
+
+		fmt.Printf("Roots A: %s\n", a.roots)
+		fmt.Printf("Roots B: %s\n", b.roots)
+		if !sameRoots {
+			fmt.Println("Roots differ")
+		}
+		for _, s := range []struct {
+			name string
+			dag  *snapshotDag
+		}{{"A", a}, {"B", b}} {
+			fmt.Printf("Blocks in %s: %d reachable, %d missing links, %d unreachable\n", s.name, s.dag.reachable, s.dag.missing, s.dag.unreachable)
+		}
+		fmt.Printf("Reachable blocks: %d only in A, %d only in B\n", len(onlyA), len(onlyB))
+		for _, d := range []struct {
+			name string
+			cids []cid.Cid
+		}{{"A", onlyA}, {"B", onlyB}} {
+			if len(d.cids) == 0 {
+				continue
+			}
+			fmt.Printf("First blocks only in %s:\n", d.name)
+			for _, c := range d.cids[:min(len(d.cids), maxDiffs)] {
+				fmt.Printf("  %s\n", c)
+			}
+		}
+
+		if !sameRoots || len(onlyA) > 0 || len(onlyB) > 0 {
+			return xerrors.Errorf("snapshots differ")
+		}
+		fmt.Println("Snapshots are equivalent")
+		return nil
+	},
+}
+
+// snapshotDag is the set of blocks of a snapshot reachable from its roots
+type snapshotDag struct {
+	roots []cid.Cid
+	// walked holds the KeyString of the CIDs of all walked links, set to whether the block is in
+	// the snapshot
+	walked map[string]bool
+	// reachable counts the walked blocks which are in the snapshot
+	reachable int
+	// missing counts the linked blocks which aren't in the snapshot
+	missing int
+	// unreachable counts the blocks in the snapshot which aren't reachable from the roots
+	unreachable int
+}
+
+// snapshotDiff returns the CIDs of the blocks reachable in a but not in b, sorted by their string
+// form
+func snapshotDiff(a, b map[string]bool) ([]cid.Cid, error) {
+	var out []cid.Cid
+	for k, present := range a {
+		if !present || b[k] {
+			continue
+		}
+		c, err := cid.Cast([]byte(k))
+		if err != nil {
+			return nil, xerrors.Errorf("decoding cid: %w", err)
+		}
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].String() < out[j].String()
+	})
+	return out, nil
+}
+
+// walkSnapshot reads the snapshot at the path in a single pass, and walks the blocks reachable from
+// its roots, following the links of CBOR blocks, as the snapshot export does.
+func walkSnapshot(ctx context.Context, path string, verify bool) (*snapshotDag, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, xerrors.Errorf("opening snapshot: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	r, err := snapshotReader(f)
+	if err != nil {
+		return nil, xerrors.Errorf("reading %s: %w", path, err)
+	}
+	defer r.Close() //nolint:errcheck
+
+	// the snapshot is walked once it has been read, as blocks can link to blocks stored after them
+	br, err := carv2.NewBlockReader(r, carv2.ZeroLengthSectionAsEOF(true), carv2.WithTrustedCAR(!verify))
+	if err != nil {
+		return nil, xerrors.Errorf("reading header of %s: %w", path, err)
+	}
+
+	g := newSnapshotGraph()
+	for n := 1; ; n++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		blk, err := br.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("reading block of %s: %w", path, err)
+		}
+		if err := g.add(blk.Cid(), blk.RawData()); err != nil {
+			return nil, xerrors.Errorf("scanning for links of %s in %s: %w", blk.Cid(), path, err)
+		}
+
+		if n%1_000_000 == 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "read %d blocks of %s\n", n, path)
+		}
+	}
+
+	return g.walk(br.Roots), nil
+}
+
+// snapshotReader returns a reader of the snapshot as a plain CAR file, decompressing zstd
+// compressed snapshots.
+func snapshotReader(f io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReaderSize(f, 1<<20)
+	header, err := br.Peek(4)
+	if err != nil {
+		return nil, xerrors.Errorf("peek header: %w", err)
+	}
+	if string(header[1:]) != "\xB5\x2F\xFD" { // zstd
+		return io.NopCloser(br), nil
+	}
+	return zstd.NewReader(br), nil
+}
+
+// snapshotGraph holds the links between the blocks of a snapshot in memory, so that the blocks
+// reachable from its roots can be found after reading the snapshot once in its stored order.
+type snapshotGraph struct {
+	// ids maps the KeyString of the CIDs of blocks and of their links to node ids
+	ids     map[string]uint32
+	keys    []string
+	present []bool
+	links   [][]uint32
+}
+
+func newSnapshotGraph() *snapshotGraph {
+	return &snapshotGraph{ids: map[string]uint32{}}
+}
+
+func (g *snapshotGraph) id(c cid.Cid) uint32 {
+	k := c.KeyString()
+	if id, ok := g.ids[k]; ok {
+		return id
+	}
+	id := uint32(len(g.keys))
+	g.ids[k] = id
+	g.keys = append(g.keys, k)
+	g.present = append(g.present, false)
+	g.links = append(g.links, nil)
+	return id
+}
+
+// add records a block of the snapshot, and the links of CBOR blocks.
+func (g *snapshotGraph) add(c cid.Cid, data []byte) error {
+	id := g.id(c)
+	if g.present[id] {
+		return nil // duplicate block
+	}
+	g.present[id] = true
+
+	if codec := multicodec.Code(c.Prefix().Codec); codec != multicodec.Cbor && codec != multicodec.DagCbor {
+		return nil
+	}
+	var links []uint32
+	err := cbg.ScanForLinks(bytes.NewReader(data), func(l cid.Cid) {
+		links = append(links, g.id(l))
+	})
+	g.links[id] = links
+	return err
+}
+
+// walk finds the blocks reachable from the roots.
+func (g *snapshotGraph) walk(roots []cid.Cid) *snapshotDag {
+	dag := &snapshotDag{
+		roots:  roots,
+		walked: map[string]bool{},
+	}
+
+	todo := make([]uint32, 0, len(roots))
+	for _, r := range roots {
+		todo = append(todo, g.id(r))
+	}
+	for len(todo) > 0 {
+		id := todo[len(todo)-1]
+		todo = todo[:len(todo)-1]
+
+		k := g.keys[id]
+		if _, ok := dag.walked[k]; ok {
+			continue
+		}
+		dag.walked[k] = false
+
+		// same as the export, identity CIDs are inlined and only raw and CBOR blocks are written
+		c, _ := cid.Cast([]byte(k))
+		if multicodec.Code(c.Prefix().MhType) == multicodec.Identity {
+			continue
+		}
+		switch multicodec.Code(c.Prefix().Codec) {
+		case multicodec.Cbor, multicodec.DagCbor, multicodec.Raw:
+		default:
+			continue
+		}
+
+		if !g.present[id] {
+			dag.missing++
+			continue
+		}
+		dag.walked[k] = true
+		dag.reachable++
+
+		for _, l := range g.links[id] {
+			if _, ok := dag.walked[g.keys[l]]; !ok {
+				todo = append(todo, l)
+			}
+		}
+	}
+
+	for id, present := range g.present {
+		if present && !dag.walked[g.keys[id]] {
+			dag.unreachable++
+		}
+	}
+
+	return dag
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DataDog/zstd"
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-car"
+	carutil "github.com/ipld/go-car/util"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+type testSnapshotBlock struct {
+	c    cid.Cid
+	data []byte
+}
+
+func testCborBlock(t *testing.T, links ...cid.Cid) testSnapshotBlock {
+	var buf bytes.Buffer
+	require.NoError(t, cbg.WriteMajorTypeHeader(&buf, cbg.MajArray, uint64(len(links))))
+	for _, l := range links {
+		require.NoError(t, cbg.WriteCid(&buf, l))
+	}
+	c, err := cid.Prefix{Version: 1, Codec: cid.DagCBOR, MhType: multihash.BLAKE2B_MIN + 31, MhLength: -1}.Sum(buf.Bytes())
+	require.NoError(t, err)
+	return testSnapshotBlock{c: c, data: buf.Bytes()}
+}
+
+func testRawBlock(t *testing.T, data string) testSnapshotBlock {
+	c, err := cid.Prefix{Version: 1, Codec: cid.Raw, MhType: multihash.SHA2_256, MhLength: -1}.Sum([]byte(data))
+	require.NoError(t, err)
+	return testSnapshotBlock{c: c, data: []byte(data)}
+}
+
+func writeTestSnapshot(t *testing.T, path string, compress bool, roots []cid.Cid, blks ...testSnapshotBlock) {
+	var buf bytes.Buffer
+	require.NoError(t, car.WriteHeader(&car.CarHeader{Roots: roots, Version: 1}, &buf))
+	for _, b := range blks {
+		require.NoError(t, carutil.LdWrite(&buf, b.c.Bytes(), b.data))
+	}
+
+	data := buf.Bytes()
+	if compress {
+		var err error
+		data, err = zstd.Compress(nil, data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+func TestWalkSnapshot(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	leaf := testRawBlock(t, "leaf")
+	missing := testRawBlock(t, "not in the snapshot")
+	unreachable := testRawBlock(t, "unreachable")
+	// the child is stored after the block linking to it, as in exported snapshots
+	child := testCborBlock(t, leaf.c, missing.c)
+	root := testCborBlock(t, child.c, leaf.c)
+
+	for _, compress := range []bool{false, true} {
+		path := filepath.Join(dir, "snapshot.car")
+		writeTestSnapshot(t, path, compress, []cid.Cid{root.c}, root, unreachable, child, leaf, leaf)
+
+		dag, err := walkSnapshot(ctx, path, true)
+		require.NoError(t, err)
+		require.Equal(t, []cid.Cid{root.c}, dag.roots)
+		require.Equal(t, 3, dag.reachable)
+		require.Equal(t, 1, dag.missing)
+		require.Equal(t, 1, dag.unreachable)
+		require.Equal(t, map[string]bool{
+			root.c.KeyString():    true,
+			child.c.KeyString():   true,
+			leaf.c.KeyString():    true,
+			missing.c.KeyString(): false,
+		}, dag.walked)
+	}
+
+	// blocks not matching their CID are only detected with verify
+	corrupt := testSnapshotBlock{c: leaf.c, data: []byte("corrupt")}
+	path := filepath.Join(dir, "corrupt.car")
+	writeTestSnapshot(t, path, false, []cid.Cid{root.c}, root, child, corrupt)
+	_, err := walkSnapshot(ctx, path, false)
+	require.NoError(t, err)
+	_, err = walkSnapshot(ctx, path, true)
+	require.ErrorContains(t, err, "mismatch in content integrity")
+}
+
+func TestSnapshotDiff(t *testing.T) {
+	blks := make([]testSnapshotBlock, 4)
+	for i, data := range []string{"a", "b", "c", "d"} {
+		blks[i] = testRawBlock(t, data)
+	}
+
+	a := map[string]bool{
+		blks[0].c.KeyString(): true,
+		blks[1].c.KeyString(): true,
+		blks[2].c.KeyString(): true,
+		// links missing from a snapshot aren't part of it
+		blks[3].c.KeyString(): false,
+	}
+	b := map[string]bool{
+		blks[1].c.KeyString(): true,
+		blks[3].c.KeyString(): true,
+	}
+
+	onlyA, err := snapshotDiff(a, b)
+	require.NoError(t, err)
+	require.Len(t, onlyA, 2)
+	require.ElementsMatch(t, []cid.Cid{blks[0].c, blks[2].c}, onlyA)
+	require.Less(t, onlyA[0].String(), onlyA[1].String())
+
+	onlyB, err := snapshotDiff(b, a)
+	require.NoError(t, err)
+	require.Equal(t, []cid.Cid{blks[3].c}, onlyB)
+
+	same, err := snapshotDiff(a, a)
+	require.NoError(t, err)
+	require.Empty(t, same)
+}
@@ -258,6 +258,18 @@ var runCmd = &cli.Command{
 			Value:   5,
 			EnvVars: []string{"LOTUS_WORKER_PARALLEL_FETCH_LIMIT"},
 		},
+		&cli.IntFlag{
+			Name:    "fetch-retries",
+			Usage:   "number of retries of sector file fetches failing with network errors",
+			Value:   3,
+			EnvVars: []string{"LOTUS_WORKER_FETCH_RETRIES"},
+		},
+		&cli.DurationFlag{
+			Name:    "fetch-retry-backoff",
+			Usage:   "wait before the first retry of a failed fetch, doubled after every retry",
+			Value:   10 * time.Second,
+			EnvVars: []string{"LOTUS_WORKER_FETCH_RETRY_BACKOFF"},
+		},
 		&cli.IntFlag{
 			Name:    "post-parallel-reads",
 			Usage:   "maximum number of parallel challenge reads (0 = no limit)",
@@ -412,7 +424,7 @@ Example invocation of lotus-bench as external executor:
 		defer closer()
 		// Register all metric views
 		if err := view.Register(
-			metrics.DefaultViews...,
+			append([]*view.View{metrics.StorageFetchRetriesView}, metrics.DefaultViews...)...,
 		); err != nil {
 			log.Fatalf("Cannot register the view: %v", err)
 		}
@@ -641,7 +653,11 @@ Example invocation of lotus-bench as external executor:
 		}
 
 		remote := paths.NewRemote(localStore, nodeApi, sminfo.AuthHeader(), cctx.Int("parallel-fetch-limit"),
-			&paths.DefaultPartialFileHandler{})
+			&paths.DefaultPartialFileHandler{},
+			paths.WithFetchRetry(paths.FetchRetryConfig{
+				MaxRetries: cctx.Int("fetch-retries"),
+				Backoff:    cctx.Duration("fetch-retry-backoff"),
+			}))
 
 		fh := &paths.FetchHandler{Local: localStore, PfHandler: &paths.DefaultPartialFileHandler{}}
 		remoteHandler := func(w http.ResponseWriter, r *http.Request) {
@@ -133,6 +133,10 @@ var DaemonCmd = &cli.Command{
 			Name:  "lite",
 			Usage: "start lotus in lite mode",
 		},
+		&cli.BoolFlag{
+			Name:  "no-warmup",
+			Usage: "skip the splitstore hotstore warmup on this start, moving objects to the hotstore as they are accessed instead",
+		},
 		&cli.StringFlag{
 			Name:  "pprof",
 			Usage: "specify name of file for writing cpu profile to",
@@ -418,6 +422,9 @@ var DaemonCmd = &cli.Command{
 				node.Unset(node.RunPeerMgrKey),
 				node.Unset(new(*peermgr.PeerMgr)),
 			),
+			node.ApplyIf(func(s *node.Settings) bool { return cctx.Bool("no-warmup") },
+				node.Override(new(dtypes.SkipSplitstoreWarmup), dtypes.SkipSplitstoreWarmup(true)),
+			),
 		)
 		if err != nil {
 			return xerrors.Errorf("initializing node: %w", err)
@@ -0,0 +1,106 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
+	"github.com/filecoin-project/lotus/chain/actors/policy"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/metrics"
+)
+
+// immutableCache holds responses for API calls whose results can never change once they have
+// been successfully computed, i.e. lookups addressed by CID or state queries against a tipset
+// that has reached finality. Calls relative to the chain head must never be placed in it.
+type immutableCache struct {
+	cache *lru.Cache[string, any]
+}
+
+func newImmutableCache(size int) (*immutableCache, error) {
+	c, err := lru.New[string, any](size)
+	if err != nil {
+		return nil, err
+	}
+	return &immutableCache{cache: c}, nil
+}
+
+func (c *immutableCache) flush() {
+	c.cache.Purge()
+}
+
+// cachedCall returns the cached response for method+params if there is one, otherwise it invokes
+// fetch and, when store returns true for the fetched value, caches it. Errors are never cached.
+// A nil cache turns this into a plain call to fetch.
+func cachedCall[T any](ctx context.Context, c *immutableCache, method string, fetch func() (T, error), store func(T) (bool, error), params ...any) (T, error) {
+	if c == nil {
+		return fetch()
+	}
+
+	pb, err := json.Marshal(params)
+	if err != nil {
+		return fetch()
+	}
+	key := method + ":" + string(pb)
+
+	ctx, _ = tag.New(ctx, tag.Upsert(metrics.Endpoint, method))
+	if v, ok := c.cache.Get(key); ok {
+		stats.Record(ctx, metrics.GatewayCacheHit.M(1))
+		return v.(T), nil
+	}
+	stats.Record(ctx, metrics.GatewayCacheMiss.M(1))
+
+	res, err := fetch()
+	if err != nil {
+		return res, err
+	}
+
+	if store != nil {
+		ok, err := store(res)
+		if err != nil {
+			log.Warnw("failed to determine whether response is cacheable", "method", method, "error", err)
+			return res, nil
+		}
+		if !ok {
+			return res, nil
+		}
+	}
+
+	c.cache.Add(key, res)
+	return res, nil
+}
+
+// cachedByKey caches responses unconditionally; only use it for calls addressed by CID or by a
+// non-empty tipset key.
+func cachedByKey[T any](ctx context.Context, c *immutableCache, method string, fetch func() (T, error), params ...any) (T, error) {
+	return cachedCall(ctx, c, method, fetch, nil, params...)
+}
+
+// isFinalized returns true if the tipset identified by tsk is at least ChainFinality epochs below
+// the current head. An empty key refers to the head and is therefore never final.
+func (gw *Node) isFinalized(ctx context.Context, tsk types.TipSetKey) (bool, error) {
+	if tsk.IsEmpty() {
+		return false, nil
+	}
+
+	ts, err := gw.target.ChainGetTipSet(ctx, tsk)
+	if err != nil {
+		return false, err
+	}
+	head, err := gw.target.ChainHead(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return ts.Height()+policy.ChainFinality <= head.Height(), nil
+}
+
+// FlushCache drops all entries held by the immutable response cache, if enabled.
+func (gw *Node) FlushCache() {
+	if gw.cache != nil {
+		gw.cache.flush()
+	}
+}
@@ -99,6 +99,7 @@ type TargetAPI interface {
 	StateSearchMsg(ctx context.Context, from types.TipSetKey, msg cid.Cid, limit abi.ChainEpoch, allowReplaced bool) (*api.MsgLookup, error)
 	StateWaitMsg(ctx context.Context, cid cid.Cid, confidence uint64, limit abi.ChainEpoch, allowReplaced bool) (*api.MsgLookup, error)
 	StateReadState(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*api.ActorState, error)
+	StateReadStateWithOpts(ctx context.Context, actor address.Address, opts api.ReadStateOpts, tsk types.TipSetKey) (*api.ActorState, error)
 	StateMinerPower(context.Context, address.Address, types.TipSetKey) (*api.MinerPower, error)
 	StateMinerFaults(context.Context, address.Address, types.TipSetKey) (bitfield.BitField, error)
 	StateMinerRecoveries(context.Context, address.Address, types.TipSetKey) (bitfield.BitField, error)
@@ -173,6 +174,7 @@ type Node struct {
 	rateLimitTimeout         time.Duration
 	ethMaxFiltersPerConn     int
 	errLookback              error
+	cache                    *immutableCache
 }
 
 var (
@@ -191,6 +193,7 @@ type options struct {
 	rateLimit                int
 	rateLimitTimeout         time.Duration
 	ethMaxFiltersPerConn     int
+	immutableCacheSize       int
 }
 
 type Option func(*options)
@@ -241,6 +244,15 @@ func WithEthMaxFiltersPerConn(ethMaxFiltersPerConn int) Option {
 	}
 }
 
+// WithImmutableCacheSize sets the maximum number of entries held in the cache of immutable
+// responses, i.e. lookups by CID and state queries against finalized tipsets. A size of 0 (the
+// default) disables the cache.
+func WithImmutableCacheSize(size int) Option {
+	return func(opts *options) {
+		opts.immutableCacheSize = size
+	}
+}
+
 // NewNode creates a new gateway node.
 func NewNode(api TargetAPI, opts ...Option) *Node {
 	options := &options{
@@ -257,6 +269,13 @@ func NewNode(api TargetAPI, opts ...Option) *Node {
 	if options.rateLimit > 0 {
 		limit = rate.Every(time.Second / time.Duration(options.rateLimit))
 	}
+	var cache *immutableCache
+	if options.immutableCacheSize > 0 {
+		var err error
+		if cache, err = newImmutableCache(options.immutableCacheSize); err != nil {
+			log.Errorw("failed to create immutable response cache, caching disabled", "error", err)
+		}
+	}
 	return &Node{
 		target:                   api,
 		subHnd:                   options.subHandler,
@@ -266,6 +285,7 @@ func NewNode(api TargetAPI, opts ...Option) *Node {
 		rateLimitTimeout:         options.rateLimitTimeout,
 		errLookback:              fmt.Errorf("lookbacks of more than %s are disallowed", options.maxLookbackDuration),
 		ethMaxFiltersPerConn:     options.ethMaxFiltersPerConn,
+		cache:                    cache,
 	}
 }
 
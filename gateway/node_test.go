@@ -15,6 +15,7 @@ import (
 
 	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/build/buildconstants"
+	"github.com/filecoin-project/lotus/chain/actors/policy"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/types/mock"
 )
@@ -233,6 +234,10 @@ func (m *mockGatewayDepsAPI) StateReadState(ctx context.Context, act address.Add
 	panic("implement me")
 }
 
+func (m *mockGatewayDepsAPI) StateReadStateWithOpts(ctx context.Context, act address.Address, opts api.ReadStateOpts, ts types.TipSetKey) (*api.ActorState, error) {
+	panic("implement me")
+}
+
 func (m *mockGatewayDepsAPI) Version(context.Context) (api.APIVersion, error) {
 	return api.APIVersion{
 		APIVersion: api.FullAPIVersion1,
@@ -286,3 +291,85 @@ func TestGatewayLimitTokensRate(t *testing.T) {
 	require.NoError(t, a.limit(ctx, tokens))
 	require.ErrorContains(t, a.limit(ctx, tokens), "server busy", "API calls should be hard rate limited when they hit limits")
 }
+
+type cacheCountingAPI struct {
+	*mockGatewayDepsAPI
+
+	lk    sync.Mutex
+	calls map[string]int
+}
+
+func (m *cacheCountingAPI) called(method string) {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+	m.calls[method]++
+}
+
+func (m *cacheCountingAPI) count(method string) int {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+	return m.calls[method]
+}
+
+func (m *cacheCountingAPI) ChainReadObj(ctx context.Context, c cid.Cid) ([]byte, error) {
+	m.called("ChainReadObj")
+	return []byte(c.String()), nil
+}
+
+func (m *cacheCountingAPI) StateGetActor(ctx context.Context, actor address.Address, ts types.TipSetKey) (*types.Actor, error) {
+	m.called("StateGetActor")
+	return &types.Actor{Nonce: 1}, nil
+}
+
+func TestGatewayImmutableCache(t *testing.T) {
+	ctx := context.Background()
+	mock := &cacheCountingAPI{mockGatewayDepsAPI: &mockGatewayDepsAPI{}, calls: map[string]int{}}
+	head := mock.createTipSets(policy.ChainFinality+10, 0)
+
+	c, err := cid.Parse("bafy2bzacea3wsdh6y3a36tb3skempjoxqpuyompjbmfeyf34fi3uy6uue42v4")
+	require.NoError(t, err)
+	addr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+
+	// disabled by default
+	a := NewNode(mock)
+	for i := 0; i < 2; i++ {
+		_, err := a.ChainReadObj(ctx, c)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, mock.count("ChainReadObj"))
+
+	a = NewNode(mock, WithImmutableCacheSize(16))
+	mock.calls = map[string]int{}
+
+	// lookups by CID are always cached
+	for i := 0; i < 3; i++ {
+		b, err := a.ChainReadObj(ctx, c)
+		require.NoError(t, err)
+		require.Equal(t, []byte(c.String()), b)
+	}
+	require.Equal(t, 1, mock.count("ChainReadObj"))
+
+	// head-relative and non-final state queries are not cached
+	for i := 0; i < 2; i++ {
+		_, err := a.StateGetActor(ctx, addr, types.EmptyTSK)
+		require.NoError(t, err)
+		_, err = a.StateGetActor(ctx, addr, head.Key())
+		require.NoError(t, err)
+	}
+	require.Equal(t, 4, mock.count("StateGetActor"))
+
+	// state queries at finalized tipsets are cached
+	final := mock.tipsets[5].Key()
+	for i := 0; i < 2; i++ {
+		_, err := a.StateGetActor(ctx, addr, final)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 5, mock.count("StateGetActor"))
+
+	// flushing drops cached entries
+	a.FlushCache()
+	_, err = a.ChainReadObj(ctx, c)
+	require.NoError(t, err)
+	require.Equal(t, 2, mock.count("ChainReadObj"))
+}
@@ -38,7 +38,9 @@ func (gw *Node) ChainGetBlock(ctx context.Context, c cid.Cid) (*types.BlockHeade
 	if err := gw.limit(ctx, chainRateLimitTokens); err != nil {
 		return nil, err
 	}
-	return gw.target.ChainGetBlock(ctx, c)
+	return cachedByKey(ctx, gw.cache, "ChainGetBlock", func() (*types.BlockHeader, error) {
+		return gw.target.ChainGetBlock(ctx, c)
+	}, c)
 }
 
 func (gw *Node) MinerGetBaseInfo(ctx context.Context, addr address.Address, h abi.ChainEpoch, tsk types.TipSetKey) (*api.MiningBaseInfo, error) {
@@ -96,21 +98,27 @@ func (gw *Node) ChainGetParentMessages(ctx context.Context, c cid.Cid) ([]api.Me
 	if err := gw.limit(ctx, chainRateLimitTokens); err != nil {
 		return nil, err
 	}
-	return gw.target.ChainGetParentMessages(ctx, c)
+	return cachedByKey(ctx, gw.cache, "ChainGetParentMessages", func() ([]api.Message, error) {
+		return gw.target.ChainGetParentMessages(ctx, c)
+	}, c)
 }
 
 func (gw *Node) ChainGetParentReceipts(ctx context.Context, c cid.Cid) ([]*types.MessageReceipt, error) {
 	if err := gw.limit(ctx, chainRateLimitTokens); err != nil {
 		return nil, err
 	}
-	return gw.target.ChainGetParentReceipts(ctx, c)
+	return cachedByKey(ctx, gw.cache, "ChainGetParentReceipts", func() ([]*types.MessageReceipt, error) {
+		return gw.target.ChainGetParentReceipts(ctx, c)
+	}, c)
 }
 
 func (gw *Node) ChainGetBlockMessages(ctx context.Context, c cid.Cid) (*api.BlockMessages, error) {
 	if err := gw.limit(ctx, chainRateLimitTokens); err != nil {
 		return nil, err
 	}
-	return gw.target.ChainGetBlockMessages(ctx, c)
+	return cachedByKey(ctx, gw.cache, "ChainGetBlockMessages", func() (*api.BlockMessages, error) {
+		return gw.target.ChainGetBlockMessages(ctx, c)
+	}, c)
 }
 
 func (gw *Node) ChainHasObj(ctx context.Context, c cid.Cid) (bool, error) {
@@ -132,14 +140,21 @@ func (gw *Node) ChainGetMessage(ctx context.Context, mc cid.Cid) (*types.Message
 	if err := gw.limit(ctx, chainRateLimitTokens); err != nil {
 		return nil, err
 	}
-	return gw.target.ChainGetMessage(ctx, mc)
+	return cachedByKey(ctx, gw.cache, "ChainGetMessage", func() (*types.Message, error) {
+		return gw.target.ChainGetMessage(ctx, mc)
+	}, mc)
 }
 
 func (gw *Node) ChainGetTipSet(ctx context.Context, tsk types.TipSetKey) (*types.TipSet, error) {
 	if err := gw.limit(ctx, chainRateLimitTokens); err != nil {
 		return nil, err
 	}
-	return gw.target.ChainGetTipSet(ctx, tsk)
+	if tsk.IsEmpty() {
+		return gw.target.ChainGetTipSet(ctx, tsk)
+	}
+	return cachedByKey(ctx, gw.cache, "ChainGetTipSet", func() (*types.TipSet, error) {
+		return gw.target.ChainGetTipSet(ctx, tsk)
+	}, tsk)
 }
 
 func (gw *Node) ChainGetTipSetByHeight(ctx context.Context, h abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error) {
@@ -229,7 +244,9 @@ func (gw *Node) ChainReadObj(ctx context.Context, c cid.Cid) ([]byte, error) {
 	if err := gw.limit(ctx, chainRateLimitTokens); err != nil {
 		return nil, err
 	}
-	return gw.target.ChainReadObj(ctx, c)
+	return cachedByKey(ctx, gw.cache, "ChainReadObj", func() ([]byte, error) {
+		return gw.target.ChainReadObj(ctx, c)
+	}, c)
 }
 
 func (gw *Node) ChainPutObj(context.Context, blocks.Block) error {
@@ -348,7 +365,14 @@ func (gw *Node) StateGetActor(ctx context.Context, actor address.Address, tsk ty
 	if err := gw.checkTipsetKey(ctx, tsk); err != nil {
 		return nil, err
 	}
-	return gw.target.StateGetActor(ctx, actor, tsk)
+	if tsk.IsEmpty() {
+		return gw.target.StateGetActor(ctx, actor, tsk)
+	}
+	return cachedCall(ctx, gw.cache, "StateGetActor", func() (*types.Actor, error) {
+		return gw.target.StateGetActor(ctx, actor, tsk)
+	}, func(*types.Actor) (bool, error) {
+		return gw.isFinalized(ctx, tsk)
+	}, actor, tsk)
 }
 
 func (gw *Node) StateListMiners(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error) {
@@ -478,6 +502,16 @@ func (gw *Node) StateReadState(ctx context.Context, actor address.Address, tsk t
 	return gw.target.StateReadState(ctx, actor, tsk)
 }
 
+func (gw *Node) StateReadStateWithOpts(ctx context.Context, actor address.Address, opts api.ReadStateOpts, tsk types.TipSetKey) (*api.ActorState, error) {
+	if err := gw.limit(ctx, stateRateLimitTokens); err != nil {
+		return nil, err
+	}
+	if err := gw.checkTipsetKey(ctx, tsk); err != nil {
+		return nil, err
+	}
+	return gw.target.StateReadStateWithOpts(ctx, actor, opts, tsk)
+}
+
 func (gw *Node) StateMinerPower(ctx context.Context, m address.Address, tsk types.TipSetKey) (*api.MinerPower, error) {
 	if err := gw.limit(ctx, stateRateLimitTokens); err != nil {
 		return nil, err
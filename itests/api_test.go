@@ -147,6 +147,15 @@ func (ts *apiSuite) testSearchMsg(t *testing.T) {
 	require.NotNil(t, searchRes)
 
 	require.Equalf(t, res.TipSet, searchRes.TipSet, "search ts: %s, different from wait ts: %s", searchRes.TipSet, res.TipSet)
+
+	rangeRes, err := full.StateSearchMsgInRange(ctx, sm.Cid(), res.Height, res.Height+5, true)
+	require.NoError(t, err)
+	require.NotNil(t, rangeRes)
+	require.Equal(t, res.TipSet, rangeRes.TipSet)
+
+	rangeRes, err = full.StateSearchMsgInRange(ctx, sm.Cid(), res.Height+1, res.Height+5, true)
+	require.NoError(t, err)
+	require.Nil(t, rangeRes, "message found outside of the searched range")
 }
 
 func (ts *apiSuite) testOutOfGasError(t *testing.T) {
@@ -223,6 +232,21 @@ func (ts *apiSuite) testMining(t *testing.T) {
 	h3, err := full.ChainHead(ctx)
 	require.NoError(t, err)
 	require.Greater(t, int64(h3.Height()), int64(h2.Height()))
+
+	// block rewards are locked and vest over time
+	maddr, err := miner.ActorAddress(ctx)
+	require.NoError(t, err)
+	vs, err := full.StateMinerVestingSchedule(ctx, maddr, h3.Key())
+	require.NoError(t, err)
+	require.NotEmpty(t, vs.Schedule)
+	total := big.Zero()
+	for i, f := range vs.Schedule {
+		if i > 0 {
+			require.Greater(t, f.Epoch, vs.Schedule[i-1].Epoch)
+		}
+		total = big.Add(total, f.Amount)
+	}
+	require.Equal(t, vs.Vesting, total)
 }
 
 func (ts *apiSuite) testMiningReal(t *testing.T) {
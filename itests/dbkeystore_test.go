@@ -0,0 +1,108 @@
+package itests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/wallet"
+	"github.com/filecoin-project/lotus/itests/kit"
+	"github.com/filecoin-project/lotus/node/modules"
+	"github.com/filecoin-project/lotus/node/repo"
+)
+
+// TestDBKeyStore needs a HarmonyDB (YugabyteDB) instance, see kit.HarmonyDB.
+func TestDBKeyStore(t *testing.T) {
+	ctx := context.Background()
+	db := kit.HarmonyDB(t)
+
+	local := wallet.NewMemKeyStore()
+	ks := repo.NewDBKeyStore(db, local)
+
+	key := "key"
+	ki := types.KeyInfo{Type: types.KTSecp256k1, PrivateKey: []byte{1, 2, 3}}
+
+	_, err := ks.Get(key)
+	require.ErrorIs(t, err, types.ErrKeyInfoNotFound)
+
+	require.NoError(t, ks.Put(key, ki))
+	got, err := ks.Get(key)
+	require.NoError(t, err)
+	require.Equal(t, ki, got)
+
+	require.ErrorIs(t, ks.Put(key, ki), types.ErrKeyExists)
+
+	// keys with the trash prefix get a numbered suffix instead of failing
+	trash := repo.KTrashPrefix + "key"
+	require.NoError(t, ks.Put(trash, ki))
+	require.NoError(t, ks.Put(trash, ki))
+
+	// the local keys are kept in the local keystore, other keys in it are ignored
+	hostKey := types.KeyInfo{Type: "libp2p-host", PrivateKey: []byte{4, 5, 6}}
+	require.NoError(t, ks.Put("libp2p-host", hostKey))
+	require.NoError(t, local.Put("other", ki))
+
+	var n int
+	require.NoError(t, db.QueryRow(ctx, "SELECT COUNT(*) FROM keystore WHERE name = 'libp2p-host'").Scan(&n))
+	require.Zero(t, n)
+	got, err = ks.Get("libp2p-host")
+	require.NoError(t, err)
+	require.Equal(t, hostKey, got)
+
+	names, err := ks.List()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{key, trash, trash + "-1", "libp2p-host"}, names)
+
+	require.NoError(t, ks.Delete(key))
+	_, err = ks.Get(key)
+	require.ErrorIs(t, err, types.ErrKeyInfoNotFound)
+	require.ErrorIs(t, ks.Delete(key), types.ErrKeyInfoNotFound)
+
+	require.NoError(t, ks.Delete("libp2p-host"))
+	_, err = local.Get("libp2p-host")
+	require.ErrorIs(t, err, types.ErrKeyInfoNotFound)
+}
+
+// TestDBKeyStoreAPISecret checks that nodes sharing the database keystore, which generate the API
+// secret concurrently, end up with the same secret.
+func TestDBKeyStoreAPISecret(t *testing.T) {
+	db := kit.HarmonyDB(t)
+
+	const nodes = 4
+	type result struct {
+		alg *jwt.HMACSHA
+		err error
+	}
+	results := make(chan result, nodes)
+	for i := 0; i < nodes; i++ {
+		go func() {
+			lr, err := repo.NewMemory(nil).Lock(repo.StorageMiner)
+			if err != nil {
+				results <- result{err: err}
+				return
+			}
+			defer lr.Close() //nolint:errcheck
+
+			alg, err := modules.APISecret(repo.NewDBKeyStore(db, wallet.NewMemKeyStore()), lr)
+			results <- result{alg: (*jwt.HMACSHA)(alg), err: err}
+		}()
+	}
+
+	algs := make([]*jwt.HMACSHA, 0, nodes)
+	for i := 0; i < nodes; i++ {
+		r := <-results
+		require.NoError(t, r.err)
+		algs = append(algs, r.alg)
+	}
+
+	// a token signed by any of the nodes is accepted by all of them
+	token, err := jwt.Sign(&modules.JwtPayload{}, algs[0])
+	require.NoError(t, err)
+	for _, alg := range algs {
+		_, err := jwt.Verify(token, alg, &modules.JwtPayload{})
+		require.NoError(t, err)
+	}
+}
@@ -254,6 +254,9 @@ func TestDeadlineToggling(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, secs, sectorsD)
 
+		dlMap, err := client.StateMinerSectorDeadlineMap(ctx, maddrD, types.EmptyTSK)
+		require.NoError(t, err)
+
 		for _, sectorNum := range secs {
 			sectorbit := bitfield.New()
 			sectorbit.Set(uint64(sectorNum))
@@ -261,6 +264,17 @@ func TestDeadlineToggling(t *testing.T) {
 			loca, err := client.StateSectorPartition(ctx, maddrD, sectorNum, types.EmptyTSK)
 			require.NoError(t, err)
 
+			// the bulk deadline map agrees with the sector location
+			var found bool
+			for _, dp := range dlMap {
+				if dp.Deadline != loca.Deadline || dp.Partition != loca.Partition {
+					continue
+				}
+				found, err = dp.Sectors.IsSet(uint64(sectorNum))
+				require.NoError(t, err)
+			}
+			require.True(t, found)
+
 			para := miner2.TerminationDeclaration{
 				Deadline:  loca.Deadline,
 				Partition: loca.Partition,
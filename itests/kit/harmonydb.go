@@ -0,0 +1,35 @@
+package kit
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/lotus/lib/harmony/harmonydb"
+	"github.com/filecoin-project/lotus/node/config"
+)
+
+// EnvHarmonyDBHosts is the environment variable listing the comma separated HarmonyDB
+// (YugabyteDB) hosts used by tests which need a database. Such tests are skipped when it is not
+// set.
+const EnvHarmonyDBHosts = "LOTUS_HARMONYDB_HOSTS"
+
+// HarmonyDB connects to the HarmonyDB configured with EnvHarmonyDBHosts, skipping the test if there
+// is none. The tables are kept in a schema of the test's own, which is dropped once the test is
+// done.
+func HarmonyDB(t *testing.T) *harmonydb.DB {
+	hosts := os.Getenv(EnvHarmonyDBHosts)
+	if hosts == "" {
+		t.Skipf("skipping test which needs a HarmonyDB; enable by setting env var %s to the database hosts", EnvHarmonyDBHosts)
+	}
+
+	cfg := config.DefaultStorageMiner().HarmonyDB
+	cfg.Hosts = strings.Split(hosts, ",")
+
+	db, err := harmonydb.NewFromConfigWithITestID(cfg, harmonydb.ITestNewID())
+	require.NoError(t, err)
+	t.Cleanup(db.ITestDeleteAll)
+	return db
+}
@@ -8,12 +8,14 @@ import (
 
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	manet "github.com/multiformats/go-multiaddr/net"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/itests/kit"
+	"github.com/filecoin-project/lotus/node/hello"
 )
 
 func TestNetConn(t *testing.T) {
@@ -48,6 +50,10 @@ func TestNetConn(t *testing.T) {
 		t.Errorf("agents not matching. %s", err.Error())
 	}
 
+	protos, err := firstNode.NetPeerProtocols(ctx, secondNodeID)
+	require.NoError(t, err)
+	require.Contains(t, protos, protocol.ID(hello.ProtocolID))
+
 	secondNodePeer, err := firstNode.NetFindPeer(ctx, secondNodeID)
 	require.NoError(t, err)
 
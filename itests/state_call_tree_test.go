@@ -0,0 +1,122 @@
+package itests
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/build/buildconstants"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/vm"
+	"github.com/filecoin-project/lotus/itests/kit"
+)
+
+func TestStateCallTreeOutOfGas(t *testing.T) {
+	ctx := context.Background()
+
+	kit.QuietMiningLogs()
+
+	// Use the pricelist the FVM actually charges, so that the inclusion cost computed below is
+	// exactly what the message is charged.
+	oldPrices := vm.Prices
+	vm.Prices = map[abi.ChainEpoch]vm.Pricelist{
+		0: oldPrices[buildconstants.UpgradeHyggeHeight],
+	}
+	t.Cleanup(func() { vm.Prices = oldPrices })
+
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs())
+	ens.InterconnectAll().BeginMining(10 * time.Millisecond)
+
+	// A send to a new address with only enough gas for inclusion runs out of gas while creating
+	// the receiving actor.
+	toBytes := client.DefaultKey.Address.Bytes()
+	toBytes[len(toBytes)-1] += 1 // revive:disable-line:increment-decrement
+	newAddr, err := address.NewFromBytes(toBytes)
+	require.NoError(t, err)
+
+	msg := &types.Message{
+		From:       client.DefaultKey.Address,
+		To:         newAddr,
+		Value:      big.Zero(),
+		GasFeeCap:  abi.NewTokenAmount(10000),
+		GasPremium: big.Zero(),
+	}
+
+	var smsg *types.SignedMessage
+	for i := 0; ; i++ {
+		smsg, err = client.WalletSignMessage(ctx, client.DefaultKey.Address, msg)
+		require.NoError(t, err)
+		estimatedGas := vm.PricelistByEpoch(math.MaxInt).OnChainMessage(smsg.ChainLength()).Total()
+		if estimatedGas == msg.GasLimit {
+			break
+		}
+		require.Less(t, i, 10, "unable to estimate gas: %d != %d", estimatedGas, msg.GasLimit)
+		msg.GasLimit = estimatedGas
+	}
+
+	mcid, err := client.MpoolPush(ctx, smsg)
+	require.NoError(t, err)
+	ret, err := client.StateWaitMsg(ctx, mcid, 3, api.LookbackNoLimit, true)
+	require.NoError(t, err)
+	require.Equal(t, exitcode.SysErrOutOfGas, ret.Receipt.ExitCode)
+
+	// The tree is returned even if the trace of the failed message doesn't account for all of
+	// the gas it used.
+	tree, err := client.StateCallTree(ctx, mcid)
+	require.NoError(t, err)
+	require.Equal(t, mcid, tree.MsgCid)
+	require.Equal(t, exitcode.SysErrOutOfGas, tree.ExitCode)
+	require.Equal(t, ret.Receipt.GasUsed, tree.GasUsed)
+	require.Equal(t, tree.Root.TotalGasUsed, tree.TraceGasUsed)
+	if tree.GasMismatch {
+		// the trace misses charges, it never accounts for more gas than the message used
+		require.Less(t, tree.TraceGasUsed, tree.GasUsed)
+	}
+	require.Equal(t, client.DefaultKey.Address, tree.Root.From)
+	require.Equal(t, newAddr, tree.Root.To)
+}
+
+func TestStateCallTreeGasUsed(t *testing.T) {
+	ctx := context.Background()
+
+	kit.QuietMiningLogs()
+
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs())
+	ens.InterconnectAll().BeginMining(10 * time.Millisecond)
+
+	// A send to a new address creates the receiving actor in a subcall.
+	to, err := client.WalletNew(ctx, types.KTSecp256k1)
+	require.NoError(t, err)
+
+	smsg, err := client.MpoolPushMessage(ctx, &types.Message{
+		From:  client.DefaultKey.Address,
+		To:    to,
+		Value: abi.NewTokenAmount(1000),
+	}, nil)
+	require.NoError(t, err)
+	ret, err := client.StateWaitMsg(ctx, smsg.Cid(), 3, api.LookbackNoLimit, true)
+	require.NoError(t, err)
+	require.Equal(t, exitcode.Ok, ret.Receipt.ExitCode)
+
+	// The trace of a successful message accounts for all of the gas it used.
+	tree, err := client.StateCallTree(ctx, smsg.Cid())
+	require.NoError(t, err)
+	require.Equal(t, smsg.Cid(), tree.MsgCid)
+	require.Equal(t, exitcode.Ok, tree.ExitCode)
+	require.Equal(t, ret.Receipt.GasUsed, tree.GasUsed)
+	require.Equal(t, tree.GasUsed, tree.TraceGasUsed)
+	require.Equal(t, tree.Root.TotalGasUsed, tree.TraceGasUsed)
+	require.False(t, tree.GasMismatch)
+	require.Equal(t, client.DefaultKey.Address, tree.Root.From)
+	require.Equal(t, to, tree.Root.To)
+	require.NotEmpty(t, tree.Root.Subcalls)
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"embed"
 	"fmt"
+	"math/rand"
 	"net"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -46,10 +48,36 @@ func NewFromConfig(cfg config.HarmonyDB) (*DB, error) {
 	)
 }
 
+// ITestID identifies the schema used by an integration test, so that tests sharing a database
+// don't see each other's tables.
+type ITestID string
+
+// ITestNewID returns a new random ITestID.
+func ITestNewID() ITestID {
+	return ITestID(strconv.Itoa(rand.Intn(99999)))
+}
+
+// NewFromConfigWithITestID is like NewFromConfig, but keeps all tables in a schema of their own
+// for the integration test with the given ID. Drop it with ITestDeleteAll once the test is done.
+func NewFromConfigWithITestID(cfg config.HarmonyDB, id ITestID) (*DB, error) {
+	return newWithSchema(
+		cfg.Hosts,
+		cfg.Username,
+		cfg.Password,
+		cfg.Database,
+		cfg.Port,
+		"itest_"+string(id),
+	)
+}
+
 // New is to be called once per binary to establish the pool.
 // log() is for errors. It returns an upgraded database's connection.
 // This entry point serves both production and integration tests, so it's more DI.
 func New(hosts []string, username, password, database, port string) (*DB, error) {
+	return newWithSchema(hosts, username, password, database, port, "curio")
+}
+
+func newWithSchema(hosts []string, username, password, database, port, schema string) (*DB, error) {
 	connString := ""
 	if len(hosts) > 0 {
 		connString = "host=" + hosts[0] + " "
@@ -60,8 +88,6 @@ func New(hosts []string, username, password, database, port string) (*DB, error)
 		}
 	}
 
-	schema := "curio"
-
 	if err := ensureSchemaExists(connString, schema); err != nil {
 		return nil, err
 	}
@@ -87,6 +113,20 @@ func New(hosts []string, username, password, database, port string) (*DB, error)
 	return &db, db.upgrade()
 }
 
+// ITestDeleteAll drops the schema of a database opened with NewFromConfigWithITestID, and closes
+// the connection pool.
+func (db *DB) ITestDeleteAll() {
+	if !strings.HasPrefix(db.schema, "itest_") {
+		logger.Error("ITestDeleteAll called on a database which is not an itest database")
+		return
+	}
+	defer db.pgx.Close()
+	_, err := db.pgx.Exec(context.Background(), "DROP SCHEMA "+db.schema+" CASCADE")
+	if err != nil {
+		logger.Errorw("dropping itest schema", "schema", db.schema, "error", err)
+	}
+}
+
 func (db *DB) GetRoutableIP() (string, error) {
 	tx, err := db.pgx.Begin(context.Background())
 	if err != nil {
@@ -0,0 +1,6 @@
+-- keys shared by the lotus-miner nodes using the database, see Subsystems.EnableKeyStoreDB
+CREATE TABLE IF NOT EXISTS keystore (
+    name TEXT PRIMARY KEY,
+    key_type TEXT NOT NULL,
+    private_key BYTEA NOT NULL
+);
@@ -0,0 +1,115 @@
+// Package rpcdrain tracks the RPC calls in flight on a server, so that a node shutting down can
+// let them complete before the server is closed, while new calls are rejected.
+package rpcdrain
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// ErrShuttingDown is returned to calls made while the server is draining.
+var ErrShuttingDown = xerrors.New("node shutting down")
+
+// DefaultTimeout is the default time the calls in flight are given to complete on shutdown.
+const DefaultTimeout = 30 * time.Second
+
+var timeout atomic.Int64
+
+func init() {
+	timeout.Store(int64(DefaultTimeout))
+}
+
+// SetTimeout sets the time the calls in flight are given to complete on shutdown, 0 to close the
+// servers without waiting.
+func SetTimeout(d time.Duration) {
+	timeout.Store(int64(d))
+}
+
+// Timeout returns the time the calls in flight are given to complete on shutdown.
+func Timeout() time.Duration {
+	return time.Duration(timeout.Load())
+}
+
+// Drainer counts the calls in flight made through the requests served by its Handler.
+type Drainer struct {
+	lk       sync.Mutex
+	draining bool
+	inflight int
+	idle     chan struct{} // closed once draining without calls in flight
+}
+
+func New() *Drainer {
+	return &Drainer{}
+}
+
+type drainerKey struct{}
+
+// Handler makes the calls of the requests to next tracked by the drainer, through their context.
+func (d *Drainer) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), drainerKey{}, d)))
+	})
+}
+
+// Enter records the start of a call made through a request served by a drainer's Handler, and
+// returns the function to call once the call completes. It returns ErrShuttingDown when the
+// drainer is draining. Calls made with other contexts aren't tracked.
+func Enter(ctx context.Context) (func(), error) {
+	d, ok := ctx.Value(drainerKey{}).(*Drainer)
+	if !ok {
+		return func() {}, nil
+	}
+
+	d.lk.Lock()
+	defer d.lk.Unlock()
+
+	if d.draining {
+		return nil, ErrShuttingDown
+	}
+	d.inflight++
+
+	var once sync.Once
+	return func() {
+		once.Do(d.exit)
+	}, nil
+}
+
+func (d *Drainer) exit() {
+	d.lk.Lock()
+	defer d.lk.Unlock()
+
+	d.inflight--
+	if d.draining && d.inflight == 0 {
+		close(d.idle)
+	}
+}
+
+// Drain rejects new calls and waits for the calls in flight to complete, or for the context to be
+// done. New calls stay rejected after it returns.
+func (d *Drainer) Drain(ctx context.Context) error {
+	d.lk.Lock()
+	if !d.draining {
+		d.draining = true
+		d.idle = make(chan struct{})
+		if d.inflight == 0 {
+			close(d.idle)
+		}
+	}
+	idle := d.idle
+	d.lk.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		d.lk.Lock()
+		n := d.inflight
+		d.lk.Unlock()
+		return xerrors.Errorf("%d calls still in flight: %w", n, ctx.Err())
+	}
+}
@@ -0,0 +1,45 @@
+package rpcdrain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrain(t *testing.T) {
+	d := New()
+
+	var ctx context.Context
+	d.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/rpc/v1", nil))
+
+	done, err := Enter(ctx)
+	require.NoError(t, err)
+
+	// the call in flight keeps the drain waiting
+	tctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, d.Drain(tctx), context.DeadlineExceeded)
+
+	// new calls are rejected while draining
+	_, err = Enter(ctx)
+	require.ErrorIs(t, err, ErrShuttingDown)
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- d.Drain(context.Background())
+	}()
+	done()
+	done() // no-op
+	require.NoError(t, <-drained)
+
+	// calls outside of the handler aren't tracked
+	done, err = Enter(context.Background())
+	require.NoError(t, err)
+	done()
+}
@@ -0,0 +1,52 @@
+package bls
+
+import (
+	"fmt"
+
+	ffi "github.com/filecoin-project/filecoin-ffi"
+	"github.com/filecoin-project/go-address"
+)
+
+// VerifyAggregate verifies a BLS signature aggregated from signatures made by the keys of addrs
+// over the corresponding msgs, i.e. addrs[i] signed msgs[i].
+//
+// Only distinct-message aggregation is supported, which is the scheme used for BLS message
+// signatures in Filecoin blocks. An aggregate including two signatures over the same message is
+// rejected: without a proof of possession for each key, same-message aggregation is open to
+// rogue-key attacks, and Filecoin keys carry no such proofs.
+func VerifyAggregate(sig []byte, addrs []address.Address, msgs [][]byte) error {
+	if len(addrs) != len(msgs) {
+		return fmt.Errorf("bls aggregate: got %d addresses but %d messages", len(addrs), len(msgs))
+	}
+	if len(msgs) == 0 {
+		return fmt.Errorf("bls aggregate: no messages to verify")
+	}
+	if len(sig) != ffi.SignatureBytes {
+		return fmt.Errorf("bls aggregate: invalid signature length %d", len(sig))
+	}
+
+	seen := make(map[string]struct{}, len(msgs))
+	ffiMsgs := make([]ffi.Message, len(msgs))
+	pks := make([]PublicKey, len(addrs))
+	for i, a := range addrs {
+		if a.Protocol() != address.BLS {
+			return fmt.Errorf("bls aggregate: address %s is not a bls address", a)
+		}
+		copy(pks[i][:], a.Payload())
+
+		if _, ok := seen[string(msgs[i])]; ok {
+			return fmt.Errorf("bls aggregate: message %d is not distinct, same-message aggregation is not supported", i)
+		}
+		seen[string(msgs[i])] = struct{}{}
+		ffiMsgs[i] = msgs[i]
+	}
+
+	sigS := new(AggregateSignature)
+	copy(sigS[:], sig)
+
+	if !ffi.HashVerify(sigS, ffiMsgs, pks) {
+		return fmt.Errorf("bls aggregate signature failed to verify")
+	}
+
+	return nil
+}
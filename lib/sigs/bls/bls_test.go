@@ -6,13 +6,14 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	ffi "github.com/filecoin-project/filecoin-ffi"
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/crypto"
 
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/wallet/key"
 	"github.com/filecoin-project/lotus/lib/sigs"
-	_ "github.com/filecoin-project/lotus/lib/sigs/bls"
+	"github.com/filecoin-project/lotus/lib/sigs/bls"
 )
 
 func TestRoundtrip(t *testing.T) {
@@ -72,6 +73,51 @@ func TestUncompressedFails(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestVerifyAggregate(t *testing.T) {
+	var (
+		addrs []address.Address
+		keys  [][]byte
+		sigsS []ffi.Signature
+	)
+	msgs := [][]byte{[]byte("potato"), []byte("tomato"), []byte("carrot")}
+
+	for _, msg := range msgs {
+		pk, err := sigs.Generate(crypto.SigTypeBLS)
+		require.NoError(t, err)
+		k, err := key.NewKey(types.KeyInfo{Type: types.KTBLS, PrivateKey: pk})
+		require.NoError(t, err)
+
+		si, err := sigs.Sign(crypto.SigTypeBLS, pk, msg)
+		require.NoError(t, err)
+
+		var s ffi.Signature
+		copy(s[:], si.Data)
+
+		addrs = append(addrs, k.Address)
+		keys = append(keys, pk)
+		sigsS = append(sigsS, s)
+	}
+
+	agg := ffi.Aggregate(sigsS)
+	require.NotNil(t, agg)
+
+	require.NoError(t, bls.VerifyAggregate(agg[:], addrs, msgs))
+
+	// wrong message
+	require.Error(t, bls.VerifyAggregate(agg[:], addrs, [][]byte{msgs[0], msgs[1], []byte("turnip")}))
+
+	// mismatched inputs
+	require.Error(t, bls.VerifyAggregate(agg[:], addrs[:2], msgs))
+
+	// same-message aggregation is rejected
+	si, err := sigs.Sign(crypto.SigTypeBLS, keys[1], msgs[0])
+	require.NoError(t, err)
+	var s ffi.Signature
+	copy(s[:], si.Data)
+	agg = ffi.Aggregate([]ffi.Signature{sigsS[0], s})
+	require.Error(t, bls.VerifyAggregate(agg[:], addrs[:2], [][]byte{msgs[0], msgs[0]}))
+}
+
 func mustAddr(a string) address.Address {
 	ad, _ := address.NewFromString(a)
 	return ad
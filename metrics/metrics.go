@@ -78,11 +78,18 @@ var (
 	ChainNodeHeight                     = stats.Int64("chain/node_height", "Current Height of the node", stats.UnitDimensionless)
 	ChainNodeHeightExpected             = stats.Int64("chain/node_height_expected", "Expected Height of the node", stats.UnitDimensionless)
 	ChainNodeWorkerHeight               = stats.Int64("chain/node_worker_height", "Current Height of workers on the node", stats.UnitDimensionless)
+	ReorgDepth                          = stats.Int64("reorg_depth", "Depth in epochs of the last chain reorg observed by the node", stats.UnitDimensionless)
+	SnapshotsGenerated                  = stats.Int64("chain/snapshots_generated", "Counter of automatically generated chain snapshots", stats.UnitDimensionless)
+	SnapshotFailures                    = stats.Int64("chain/snapshot_failures", "Counter of failed automatic chain snapshots", stats.UnitDimensionless)
+	SnapshotDuration                    = stats.Float64("chain/snapshot_duration_seconds", "Duration of the last automatically generated chain snapshot", stats.UnitSeconds)
+	SnapshotSize                        = stats.Int64("chain/snapshot_size_bytes", "Size of the last automatically generated chain snapshot", stats.UnitBytes)
 	IndexerMessageValidationFailure     = stats.Int64("indexer/failure", "Counter for indexer message validation failures", stats.UnitDimensionless)
 	IndexerMessageValidationSuccess     = stats.Int64("indexer/success", "Counter for indexer message validation successes", stats.UnitDimensionless)
 	MessagePublished                    = stats.Int64("message/published", "Counter for total locally published messages", stats.UnitDimensionless)
 	MessageReceived                     = stats.Int64("message/received", "Counter for total received messages", stats.UnitDimensionless)
 	MessageValidationFailure            = stats.Int64("message/failure", "Counter for message validation failures", stats.UnitDimensionless)
+	MpoolStrictRejected                 = stats.Int64("mpool/strict_rejected", "Counter for messages pushed through the API which were rejected by the strict acceptance policy", stats.UnitDimensionless)
+	MpoolAutoBumps                      = stats.Int64("mpool/auto_bumps", "Counter for stuck messages automatically replaced with a higher gas premium", stats.UnitDimensionless)
 	MessageValidationSuccess            = stats.Int64("message/success", "Counter for message validation successes", stats.UnitDimensionless)
 	MessageValidationDuration           = stats.Float64("message/validation_ms", "Duration of message validation", stats.UnitMilliseconds)
 	MpoolGetNonceDuration               = stats.Float64("mpool/getnonce_ms", "Duration of getStateNonce in mpool", stats.UnitMilliseconds)
@@ -96,6 +103,8 @@ var (
 	BlockValidationFailure              = stats.Int64("block/failure", "Counter for block validation failures", stats.UnitDimensionless)
 	BlockValidationSuccess              = stats.Int64("block/success", "Counter for block validation successes", stats.UnitDimensionless)
 	BlockValidationDurationMilliseconds = stats.Float64("block/validation_ms", "Duration for Block Validation in ms", stats.UnitMilliseconds)
+	SyncValidatedTipsets                = stats.Int64("sync/validated_tipsets", "Counter of tipsets validated by the syncer", stats.UnitDimensionless)
+	SyncPreValidatedBlocks              = stats.Int64("sync/prevalidated_blocks", "Counter of blocks pre-validated in parallel by the syncer while catching up", stats.UnitDimensionless)
 	BlockDelay                          = stats.Int64("block/delay", "Delay of accepted blocks, where delay is >5s", stats.UnitMilliseconds)
 	PubsubPublishMessage                = stats.Int64("pubsub/published", "Counter for total published messages", stats.UnitDimensionless)
 	PubsubDeliverMessage                = stats.Int64("pubsub/delivered", "Counter for total delivered messages", stats.UnitDimensionless)
@@ -122,8 +131,25 @@ var (
 	WorkerCallsReturnedCount     = stats.Int64("sealing/worker_calls_returned_count", "Counter of returned worker tasks", stats.UnitDimensionless)
 	WorkerCallsReturnedDuration  = stats.Float64("sealing/worker_calls_returned_ms", "Counter of returned worker tasks", stats.UnitMilliseconds)
 	WorkerUntrackedCallsReturned = stats.Int64("sealing/worker_untracked_calls_returned", "Counter of returned untracked worker tasks", stats.UnitDimensionless)
+	WorkerCallsSlow              = stats.Int64("sealing/worker_calls_slow", "Counter of worker tasks running longer than their timeout", stats.UnitDimensionless)
+	WorkerCallsTimedOut          = stats.Int64("sealing/worker_calls_timed_out", "Counter of worker tasks aborted after running longer than their timeout and grace period", stats.UnitDimensionless)
 
-	SectorStates = stats.Int64("sealing/states", "Number of sectors in each state", stats.UnitDimensionless)
+	WdPoStPartitionProofDuration = stats.Float64("wdpost/partition_proof_ms", "Duration of successful WindowPoSt partition proof computations", stats.UnitMilliseconds)
+	WdPoStSectorReadsInFlight    = stats.Int64("wdpost/sector_reads_in_flight", "Number of sectors the builtin WindowPoSt prover is reading challenges from", stats.UnitDimensionless)
+	WdPoStSectorReadDuration     = stats.Float64("wdpost/sector_read_ms", "Duration of challenge reads of the builtin WindowPoSt prover", stats.UnitMilliseconds)
+	WdPoStSectorReadFailures     = stats.Int64("wdpost/sector_read_failures", "Counter of sectors skipped by the builtin WindowPoSt prover because their challenges couldn't be read", stats.UnitDimensionless)
+
+	WinningPoStDuration = stats.Float64("miner/winning_post_ms", "Duration of successful WinningPoSt proof computations", stats.UnitMilliseconds)
+	WinningPoStTimeouts = stats.Int64("miner/winning_post_timeouts", "Counter of WinningPoSt proof computations running longer than the configured timeout", stats.UnitDimensionless)
+
+	SectorStates               = stats.Int64("sealing/states", "Number of sectors in each state", stats.UnitDimensionless)
+	SealPreCommitTicketFlushes = stats.Int64("sealing/precommit_ticket_expiry_flushes", "Counter of PreCommit batches sent early because a sector ticket was about to expire", stats.UnitDimensionless)
+
+	SealReplicaChecks        = stats.Int64("sealing/replica_checks", "Counter of sampled replica checks after PreCommit2", stats.UnitDimensionless)
+	SealReplicaCheckFailures = stats.Int64("sealing/replica_check_failures", "Counter of failed sampled replica checks after PreCommit2", stats.UnitDimensionless)
+	SealReplicaCheckDuration = stats.Float64("sealing/replica_check_ms", "Duration of sampled replica checks after PreCommit2", stats.UnitMilliseconds)
+
+	StorageFetchRetries = stats.Int64("storage/fetch_retries", "Counter of retried sector file fetches from remote storage", stats.UnitDimensionless)
 
 	StorageFSAvailable      = stats.Float64("storage/path_fs_available_frac", "Fraction of filesystem available storage", stats.UnitDimensionless)
 	StorageAvailable        = stats.Float64("storage/path_available_frac", "Fraction of available storage", stats.UnitDimensionless)
@@ -165,6 +191,9 @@ var (
 	SplitstoreCompactionHot         = stats.Int64("splitstore/hot", "Number of hot blocks in last compaction", stats.UnitDimensionless)
 	SplitstoreCompactionCold        = stats.Int64("splitstore/cold", "Number of cold blocks in last compaction", stats.UnitDimensionless)
 	SplitstoreCompactionDead        = stats.Int64("splitstore/dead", "Number of dead blocks in last compaction", stats.UnitDimensionless)
+	SplitstoreNextCompaction        = stats.Int64("splitstore/next_compaction", "Estimated unix time at which the next compaction will start", stats.UnitSeconds)
+	SplitstoreWarm                  = stats.Int64("splitstore/warm", "Whether the hotstore has been warmed up (1) or not (0)", stats.UnitDimensionless)
+	SplitstoreWarmupCopied          = stats.Int64("splitstore/warmup_copied", "Number of objects copied to the hotstore by the current hotstore warmup", stats.UnitDimensionless)
 
 	// rcmgr
 	RcmgrAllowConn      = stats.Int64("rcmgr/allow_conn", "Number of allowed connections", stats.UnitDimensionless)
@@ -184,6 +213,10 @@ var (
 
 	// gateway rate limit
 	RateLimitCount = stats.Int64("ratelimit/limited", "rate limited connections", stats.UnitDimensionless)
+
+	// gateway immutable response cache
+	GatewayCacheHit  = stats.Int64("gateway/cache_hit", "Counter for gateway API calls served from the immutable response cache", stats.UnitDimensionless)
+	GatewayCacheMiss = stats.Int64("gateway/cache_miss", "Counter for cacheable gateway API calls not found in the immutable response cache", stats.UnitDimensionless)
 )
 
 var (
@@ -206,6 +239,26 @@ var (
 		Measure:     ChainNodeWorkerHeight,
 		Aggregation: view.LastValue(),
 	}
+	ReorgDepthView = &view.View{
+		Measure:     ReorgDepth,
+		Aggregation: view.LastValue(),
+	}
+	SnapshotsGeneratedView = &view.View{
+		Measure:     SnapshotsGenerated,
+		Aggregation: view.Count(),
+	}
+	SnapshotFailuresView = &view.View{
+		Measure:     SnapshotFailures,
+		Aggregation: view.Count(),
+	}
+	SnapshotDurationView = &view.View{
+		Measure:     SnapshotDuration,
+		Aggregation: view.LastValue(),
+	}
+	SnapshotSizeView = &view.View{
+		Measure:     SnapshotSize,
+		Aggregation: view.LastValue(),
+	}
 	BlockReceivedView = &view.View{
 		Measure:     BlockReceived,
 		Aggregation: view.Count(),
@@ -223,6 +276,14 @@ var (
 		Measure:     BlockValidationDurationMilliseconds,
 		Aggregation: defaultMillisecondsDistribution,
 	}
+	SyncValidatedTipsetsView = &view.View{
+		Measure:     SyncValidatedTipsets,
+		Aggregation: view.Count(),
+	}
+	SyncPreValidatedBlocksView = &view.View{
+		Measure:     SyncPreValidatedBlocks,
+		Aggregation: view.Count(),
+	}
 	BlockDelayView = &view.View{
 		Measure: BlockDelay,
 		TagKeys: []tag.Key{MinerID},
@@ -263,6 +324,15 @@ var (
 		Aggregation: view.Count(),
 		TagKeys:     []tag.Key{FailureType, Local},
 	}
+	MpoolStrictRejectedView = &view.View{
+		Measure:     MpoolStrictRejected,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{FailureType},
+	}
+	MpoolAutoBumpsView = &view.View{
+		Measure:     MpoolAutoBumps,
+		Aggregation: view.Count(),
+	}
 	MessageValidationSuccessView = &view.View{
 		Measure:     MessageValidationSuccess,
 		Aggregation: view.Count(),
@@ -399,16 +469,70 @@ var (
 		Measure:     WorkerUntrackedCallsReturned,
 		Aggregation: view.Count(),
 	}
+	WorkerCallsSlowView = &view.View{
+		Measure:     WorkerCallsSlow,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{TaskType, WorkerHostname},
+	}
+	WorkerCallsTimedOutView = &view.View{
+		Measure:     WorkerCallsTimedOut,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{TaskType, WorkerHostname},
+	}
+	SealReplicaChecksView = &view.View{
+		Measure:     SealReplicaChecks,
+		Aggregation: view.Count(),
+	}
+	SealReplicaCheckFailuresView = &view.View{
+		Measure:     SealReplicaCheckFailures,
+		Aggregation: view.Count(),
+	}
+	SealReplicaCheckDurationView = &view.View{
+		Measure:     SealReplicaCheckDuration,
+		Aggregation: defaultMillisecondsDistribution,
+	}
 	WorkerCallsReturnedDurationView = &view.View{
 		Measure:     WorkerCallsReturnedDuration,
 		Aggregation: workMillisecondsDistribution,
 		TagKeys:     []tag.Key{TaskType, WorkerHostname},
 	}
+	WdPoStPartitionProofDurationView = &view.View{
+		Measure:     WdPoStPartitionProofDuration,
+		Aggregation: workMillisecondsDistribution,
+	}
+	WdPoStSectorReadsInFlightView = &view.View{
+		Measure:     WdPoStSectorReadsInFlight,
+		Aggregation: view.LastValue(),
+	}
+	WdPoStSectorReadDurationView = &view.View{
+		Measure:     WdPoStSectorReadDuration,
+		Aggregation: defaultMillisecondsDistribution,
+	}
+	WdPoStSectorReadFailuresView = &view.View{
+		Measure:     WdPoStSectorReadFailures,
+		Aggregation: view.Count(),
+	}
+	WinningPoStDurationView = &view.View{
+		Measure:     WinningPoStDuration,
+		Aggregation: defaultMillisecondsDistribution,
+	}
+	WinningPoStTimeoutsView = &view.View{
+		Measure:     WinningPoStTimeouts,
+		Aggregation: view.Count(),
+	}
 	SectorStatesView = &view.View{
 		Measure:     SectorStates,
 		Aggregation: view.LastValue(),
 		TagKeys:     []tag.Key{SectorState},
 	}
+	SealPreCommitTicketFlushesView = &view.View{
+		Measure:     SealPreCommitTicketFlushes,
+		Aggregation: view.Count(),
+	}
+	StorageFetchRetriesView = &view.View{
+		Measure:     StorageFetchRetries,
+		Aggregation: view.Count(),
+	}
 	StorageFSAvailableView = &view.View{
 		Measure:     StorageFSAvailable,
 		Aggregation: view.LastValue(),
@@ -563,6 +687,18 @@ var (
 		Measure:     SplitstoreCompactionDead,
 		Aggregation: view.Sum(),
 	}
+	SplitstoreNextCompactionView = &view.View{
+		Measure:     SplitstoreNextCompaction,
+		Aggregation: view.LastValue(),
+	}
+	SplitstoreWarmView = &view.View{
+		Measure:     SplitstoreWarm,
+		Aggregation: view.LastValue(),
+	}
+	SplitstoreWarmupCopiedView = &view.View{
+		Measure:     SplitstoreWarmupCopied,
+		Aggregation: view.LastValue(),
+	}
 
 	// rcmgr
 	RcmgrAllowConnView = &view.View{
@@ -637,6 +773,16 @@ var (
 		Measure:     RateLimitCount,
 		Aggregation: view.Count(),
 	}
+	GatewayCacheHitView = &view.View{
+		Measure:     GatewayCacheHit,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{Endpoint},
+	}
+	GatewayCacheMissView = &view.View{
+		Measure:     GatewayCacheMiss,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{Endpoint},
+	}
 )
 
 var views = []*view.View{
@@ -679,16 +825,25 @@ var ChainNodeViews = append([]*view.View{
 	ChainNodeHeightView,
 	ChainNodeHeightExpectedView,
 	ChainNodeWorkerHeightView,
+	ReorgDepthView,
+	SnapshotsGeneratedView,
+	SnapshotFailuresView,
+	SnapshotDurationView,
+	SnapshotSizeView,
 	BlockReceivedView,
 	BlockValidationFailureView,
 	BlockValidationSuccessView,
 	BlockValidationDurationView,
+	SyncValidatedTipsetsView,
+	SyncPreValidatedBlocksView,
 	BlockDelayView,
 	IndexerMessageValidationFailureView,
 	IndexerMessageValidationSuccessView,
 	MessagePublishedView,
 	MessageReceivedView,
 	MessageValidationFailureView,
+	MpoolStrictRejectedView,
+	MpoolAutoBumpsView,
 	MessageValidationSuccessView,
 	MessageValidationDurationView,
 	MpoolGetNonceDurationView,
@@ -712,6 +867,9 @@ var ChainNodeViews = append([]*view.View{
 	SplitstoreCompactionHotView,
 	SplitstoreCompactionColdView,
 	SplitstoreCompactionDeadView,
+	SplitstoreNextCompactionView,
+	SplitstoreWarmView,
+	SplitstoreWarmupCopiedView,
 	VMApplyBlocksTotalView,
 	VMApplyMessagesView,
 	VMApplyEarlyView,
@@ -728,8 +886,21 @@ var MinerNodeViews = append([]*view.View{
 	WorkerCallsReturnedCountView,
 	WorkerUntrackedCallsReturnedView,
 	WorkerCallsReturnedDurationView,
+	WorkerCallsSlowView,
+	WorkerCallsTimedOutView,
+	WdPoStPartitionProofDurationView,
+	WdPoStSectorReadsInFlightView,
+	WdPoStSectorReadDurationView,
+	WdPoStSectorReadFailuresView,
+	WinningPoStDurationView,
+	WinningPoStTimeoutsView,
 
 	SectorStatesView,
+	SealPreCommitTicketFlushesView,
+	SealReplicaChecksView,
+	SealReplicaCheckFailuresView,
+	SealReplicaCheckDurationView,
+	StorageFetchRetriesView,
 	StorageFSAvailableView,
 	StorageAvailableView,
 	StorageReservedView,
@@ -765,6 +936,8 @@ var MinerNodeViews = append([]*view.View{
 
 var GatewayNodeViews = append([]*view.View{
 	RateLimitedView,
+	GatewayCacheHitView,
+	GatewayCacheMissView,
 }, ChainNodeViews...)
 
 // SinceInMilliseconds returns the duration of time since the provide time as a float64.
@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	logging "github.com/ipfs/go-log/v2"
 	"go.opencensus.io/tag"
 
 	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/lib/rpcdrain"
 	"github.com/filecoin-project/lotus/metrics"
+	"github.com/filecoin-project/lotus/metrics/rpcstats"
 )
 
 func MetricedAPI[T, P any](a T) *P {
@@ -48,6 +51,8 @@ func MetricedGatewayAPI(a api.Gateway) api.Gateway {
 	return &out
 }
 
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
 func proxy(in interface{}, outstr interface{}) {
 	outs := api.GetInternalStructs(outstr)
 	for _, out := range outs {
@@ -66,12 +71,36 @@ func proxy(in interface{}, outstr interface{}) {
 				defer stop()
 				// pass tagged ctx back into function call
 				args[0] = reflect.ValueOf(ctx)
-				return fn.Call(args)
+
+				done, err := rpcdrain.Enter(ctx)
+				if err != nil {
+					return errorResults(field.Type, err)
+				}
+				defer done()
+
+				start := time.Now()
+				results = fn.Call(args)
+				failed := len(results) > 0 && results[len(results)-1].Type() == errorType && !results[len(results)-1].IsNil()
+				rpcstats.Record(ctx, field.Name, time.Since(start), failed)
+				return results
 			}))
 		}
 	}
 }
 
+// errorResults returns zero results for the method type, with the error as last result if the
+// method returns one.
+func errorResults(t reflect.Type, err error) []reflect.Value {
+	results := make([]reflect.Value, t.NumOut())
+	for i := range results {
+		results[i] = reflect.Zero(t.Out(i))
+	}
+	if n := t.NumOut(); n > 0 && t.Out(n-1) == errorType {
+		results[n-1] = reflect.ValueOf(&err).Elem()
+	}
+	return results
+}
+
 var log = logging.Logger("api_proxy")
 
 func LoggingAPI[T, P any](a T) *P {
@@ -0,0 +1,188 @@
+// Package rpcstats records the number and cumulative duration of calls of each RPC method, per
+// connection or per API token, to find clients loading the node. Recording is disabled by default
+// and costs a context lookup per call when disabled.
+package rpcstats
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/lotus/api"
+)
+
+// Mode selects how calls are attributed to clients.
+type Mode int32
+
+const (
+	Disabled Mode = iota
+	// ByConnection attributes calls to the remote address of the connection.
+	ByConnection
+	// ByToken attributes calls to a hash of the API token, or to the connection without a token.
+	ByToken
+)
+
+// ParseMode parses the mode from its config value: "" or "disabled", "connection", or "token".
+func ParseMode(s string) (Mode, error) {
+	switch s {
+	case "", "disabled":
+		return Disabled, nil
+	case "connection":
+		return ByConnection, nil
+	case "token":
+		return ByToken, nil
+	default:
+		return Disabled, xerrors.Errorf("unknown rpc stats mode %q, expected 'disabled', 'connection' or 'token'", s)
+	}
+}
+
+// maxClients is the number of clients statistics are kept for, the least recently active client
+// is dropped over that.
+const maxClients = 512
+
+type methodStats struct {
+	calls, errors uint64
+	duration      time.Duration
+}
+
+type clientStats struct {
+	lastCall time.Time
+	methods  map[string]*methodStats
+}
+
+type tracker struct {
+	mode atomic.Int32
+
+	lk      sync.Mutex
+	clients map[string]*clientStats
+}
+
+var global = &tracker{clients: map[string]*clientStats{}}
+
+// SetMode enables or disables recording. Disabling keeps the statistics recorded so far.
+func SetMode(m Mode) {
+	global.mode.Store(int32(m))
+}
+
+type clientKey struct{}
+
+// Handler attributes the requests to next to their client when recording is enabled.
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mode := Mode(global.mode.Load())
+		if mode == Disabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		client := "conn:" + r.RemoteAddr
+		if mode == ByToken {
+			if token := requestToken(r); token != "" {
+				h := sha256.Sum256([]byte(token))
+				client = "token:" + hex.EncodeToString(h[:8])
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientKey{}, client)))
+	})
+}
+
+// requestToken returns the API token of the request, read the same way as the auth handler does.
+func requestToken(r *http.Request) string {
+	token := r.Header.Get("Authorization")
+	if token == "" {
+		token = r.FormValue("token")
+	}
+	return strings.TrimPrefix(token, "Bearer ")
+}
+
+// Record records a call of the method made through a request attributed by Handler.
+func Record(ctx context.Context, method string, took time.Duration, failed bool) {
+	client, ok := ctx.Value(clientKey{}).(string)
+	if !ok {
+		return
+	}
+	global.record(client, method, took, failed)
+}
+
+func (t *tracker) record(client, method string, took time.Duration, failed bool) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	cs, ok := t.clients[client]
+	if !ok {
+		if len(t.clients) >= maxClients {
+			t.evictOldest()
+		}
+		cs = &clientStats{methods: map[string]*methodStats{}}
+		t.clients[client] = cs
+	}
+	cs.lastCall = time.Now()
+
+	ms, ok := cs.methods[method]
+	if !ok {
+		ms = &methodStats{}
+		cs.methods[method] = ms
+	}
+	ms.calls++
+	ms.duration += took
+	if failed {
+		ms.errors++
+	}
+}
+
+func (t *tracker) evictOldest() {
+	var oldest string
+	var oldestCall time.Time
+	for client, cs := range t.clients {
+		if oldest == "" || cs.lastCall.Before(oldestCall) {
+			oldest, oldestCall = client, cs.lastCall
+		}
+	}
+	delete(t.clients, oldest)
+}
+
+// Snapshot returns the statistics recorded for each client, most recently active clients first,
+// with methods sorted by cumulative duration.
+func Snapshot() []api.RPCClientStats {
+	return global.snapshot()
+}
+
+func (t *tracker) snapshot() []api.RPCClientStats {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	out := make([]api.RPCClientStats, 0, len(t.clients))
+	for client, cs := range t.clients {
+		st := api.RPCClientStats{
+			Client:   client,
+			LastCall: cs.lastCall,
+			Methods:  make([]api.RPCMethodStats, 0, len(cs.methods)),
+		}
+		for method, ms := range cs.methods {
+			st.Methods = append(st.Methods, api.RPCMethodStats{
+				Method:   method,
+				Calls:    ms.calls,
+				Errors:   ms.errors,
+				Duration: ms.duration,
+			})
+		}
+		sort.Slice(st.Methods, func(i, j int) bool {
+			return st.Methods[i].Duration > st.Methods[j].Duration
+		})
+		out = append(out, st)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].LastCall.After(out[j].LastCall)
+	})
+	return out
+}
@@ -0,0 +1,69 @@
+package rpcstats
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerClients(t *testing.T) {
+	defer SetMode(Disabled)
+
+	var client interface{}
+	h := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client = r.Context().Value(clientKey{})
+	}))
+
+	req := httptest.NewRequest("POST", "/rpc/v1", nil)
+	req.RemoteAddr = "10.0.0.1:5000"
+	req.Header.Set("Authorization", "Bearer secret")
+
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	require.Nil(t, client)
+
+	SetMode(ByConnection)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, "conn:10.0.0.1:5000", client)
+
+	SetMode(ByToken)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	require.Regexp(t, `^token:[0-9a-f]{16}$`, client)
+
+	req.Header.Del("Authorization")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, "conn:10.0.0.1:5000", client)
+}
+
+func TestTrackerRecord(t *testing.T) {
+	tr := &tracker{clients: map[string]*clientStats{}}
+	tr.record("a", "ChainHead", time.Second, false)
+	tr.record("a", "ChainHead", time.Second, true)
+	tr.record("a", "StateCall", 5*time.Second, false)
+	tr.clients["a"].lastCall = time.Now().Add(-time.Minute)
+	tr.record("b", "ChainHead", time.Second, false)
+
+	snap := tr.snapshot()
+	require.Len(t, snap, 2)
+	require.Equal(t, "b", snap[0].Client)
+	require.Equal(t, "a", snap[1].Client)
+	require.Equal(t, "StateCall", snap[1].Methods[0].Method)
+	require.Equal(t, uint64(2), snap[1].Methods[1].Calls)
+	require.Equal(t, uint64(1), snap[1].Methods[1].Errors)
+	require.Equal(t, 2*time.Second, snap[1].Methods[1].Duration)
+
+	// the least recently active client is dropped over maxClients
+	for i := 0; i < maxClients; i++ {
+		tr.record(fmt.Sprint("c", i), "ChainHead", time.Second, false)
+	}
+	require.Len(t, tr.clients, maxClients)
+	require.NotContains(t, tr.clients, "a")
+
+	// calls without a client aren't recorded
+	Record(context.Background(), "ChainHead", time.Second, false)
+	require.Empty(t, Snapshot())
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"os"
 	"sync"
@@ -13,6 +14,7 @@ import (
 	"github.com/hashicorp/golang-lru/arc/v2"
 	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log/v2"
+	"go.opencensus.io/stats"
 	"go.opencensus.io/trace"
 	"golang.org/x/xerrors"
 
@@ -20,6 +22,7 @@ import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/network"
 	"github.com/filecoin-project/go-state-types/proof"
 
 	"github.com/filecoin-project/lotus/api"
@@ -34,6 +37,7 @@ import (
 	"github.com/filecoin-project/lotus/chain/types"
 	cliutil "github.com/filecoin-project/lotus/cli/util"
 	"github.com/filecoin-project/lotus/journal"
+	"github.com/filecoin-project/lotus/metrics"
 )
 
 var log = logging.Logger("miner")
@@ -128,6 +132,21 @@ type Miner struct {
 
 	evtTypes [1]journal.EventType
 	journal  journal.Journal
+
+	// winningPoStTimeout is the time after which a warning is logged about slow
+	// winning PoSt computation, and the round is abandoned when
+	// abandonLateWinningPoSt is set. Zero disables the timeout.
+	winningPoStTimeout     time.Duration
+	abandonLateWinningPoSt bool
+}
+
+// SetWinningPoStTimeout sets the time winning PoSt computation is expected to
+// complete in. When the proof takes longer a warning is logged, and when
+// abandon is set the round is abandoned instead of producing a block which is
+// likely too late to be accepted. It must be called before Start.
+func (m *Miner) SetWinningPoStTimeout(timeout time.Duration, abandon bool) {
+	m.winningPoStTimeout = timeout
+	m.abandonLateWinningPoSt = abandon
 }
 
 // Address returns the address of the miner.
@@ -550,7 +569,10 @@ func (m *Miner) mineOne(ctx context.Context, base *MiningBase) (minedBlock *type
 		return nil, err
 	}
 
-	postProof, err := m.epp.ComputeProof(ctx, mbi.Sectors, prand, round, nv)
+	postProof, err := m.computeWinningPoSt(ctx, mbi.Sectors, prand, round, nv)
+	if errors.Is(err, errWinningPoStAbandoned) {
+		return nil, nil
+	}
 	if err != nil {
 		err = xerrors.Errorf("failed to compute winning post proof: %w", err)
 		return nil, err
@@ -657,6 +679,58 @@ func (m *Miner) mineOne(ctx context.Context, base *MiningBase) (minedBlock *type
 	return minedBlock, nil
 }
 
+var errWinningPoStAbandoned = xerrors.New("winning PoSt computation abandoned")
+
+// computeWinningPoSt computes the winning PoSt proof, warning when it takes
+// longer than the configured timeout. When late proofs are abandoned, it
+// returns errWinningPoStAbandoned once the timeout elapses, and cancels the
+// computation.
+func (m *Miner) computeWinningPoSt(ctx context.Context, sectors []proof.ExtendedSectorInfo, rand abi.PoStRandomness, round abi.ChainEpoch, nv network.Version) ([]proof.PoStProof, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		proofs []proof.PoStProof
+		err    error
+	}
+
+	start := build.Clock.Now()
+	done := make(chan result, 1)
+	go func() {
+		proofs, err := m.epp.ComputeProof(ctx, sectors, rand, round, nv)
+		if err == nil {
+			took := build.Clock.Since(start)
+			stats.Record(ctx, metrics.WinningPoStDuration.M(float64(took.Milliseconds())))
+		}
+		done <- result{proofs: proofs, err: err}
+	}()
+
+	var timeout <-chan time.Time
+	if m.winningPoStTimeout > 0 {
+		t := build.Clock.Timer(m.winningPoStTimeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+
+	for {
+		select {
+		case r := <-done:
+			return r.proofs, r.err
+		case <-timeout:
+			timeout = nil
+			stats.Record(ctx, metrics.WinningPoStTimeouts.M(1))
+
+			if m.abandonLateWinningPoSt {
+				log.Warnw("winning PoSt computation took longer than the timeout, abandoning the round", "round", round, "sectors", len(sectors), "timeout", m.winningPoStTimeout)
+				return nil, errWinningPoStAbandoned
+			}
+			log.Warnw("winning PoSt computation is taking longer than the timeout, the block may be produced too late to be accepted", "round", round, "sectors", len(sectors), "timeout", m.winningPoStTimeout)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
 func (m *Miner) computeTicket(ctx context.Context, brand *types.BeaconEntry, round abi.ChainEpoch, chainRand *types.Ticket, mbi *api.MiningBaseInfo) (*types.Ticket, error) {
 	buf := new(bytes.Buffer)
 	if err := m.address.MarshalCBOR(buf); err != nil {
@@ -0,0 +1,182 @@
+package miner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/raulk/clock"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/filecoin-project/go-state-types/proof"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/api/mocks"
+	"github.com/filecoin-project/lotus/build"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+	"github.com/filecoin-project/lotus/metrics"
+)
+
+// blockingProver is a WinningPoStProver whose proofs only complete once
+// release is closed.
+type blockingProver struct {
+	started   chan struct{}
+	release   chan struct{}
+	cancelled chan struct{}
+}
+
+func newBlockingProver() *blockingProver {
+	return &blockingProver{
+		started:   make(chan struct{}),
+		release:   make(chan struct{}),
+		cancelled: make(chan struct{}),
+	}
+}
+
+func (p *blockingProver) GenerateCandidates(context.Context, abi.PoStRandomness, uint64) ([]uint64, error) {
+	return []uint64{0}, nil
+}
+
+func (p *blockingProver) ComputeProof(ctx context.Context, _ []proof.ExtendedSectorInfo, _ abi.PoStRandomness, _ abi.ChainEpoch, _ network.Version) ([]proof.PoStProof, error) {
+	close(p.started)
+	select {
+	case <-p.release:
+		return []proof.PoStProof{{PoStProof: abi.RegisteredPoStProof_StackedDrgWinning2KiBV1, ProofBytes: []byte("proof")}}, nil
+	case <-ctx.Done():
+		close(p.cancelled)
+		return nil, ctx.Err()
+	}
+}
+
+const testWinningPoStTimeout = 10 * time.Second
+
+func setupWinningPoStTest(t *testing.T) *clock.Mock {
+	mockClock := clock.NewMock()
+	oldClock := build.Clock
+	build.Clock = mockClock
+
+	require.NoError(t, view.Register(metrics.WinningPoStTimeoutsView))
+	t.Cleanup(func() {
+		view.Unregister(metrics.WinningPoStTimeoutsView)
+		build.Clock = oldClock
+	})
+
+	return mockClock
+}
+
+func winningPoStTimeouts(t *testing.T) int64 {
+	rows, err := view.RetrieveData(metrics.WinningPoStTimeoutsView.Name)
+	require.NoError(t, err)
+	if len(rows) == 0 {
+		return 0
+	}
+	return rows[0].Data.(*view.CountData).Value
+}
+
+// advanceUntilTimeout moves the mock clock forward until the winning PoSt
+// timeout has been observed; the timer is created concurrently with the
+// proof, so a single step may happen before it exists.
+func advanceUntilTimeout(t *testing.T, mockClock *clock.Mock) {
+	require.Eventually(t, func() bool {
+		mockClock.Add(testWinningPoStTimeout)
+		return winningPoStTimeouts(t) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestComputeWinningPoStLateProof(t *testing.T) {
+	mockClock := setupWinningPoStTest(t)
+
+	prover := newBlockingProver()
+	m := &Miner{
+		epp:                prover,
+		address:            mock.Address(1000),
+		winningPoStTimeout: testWinningPoStTimeout,
+	}
+
+	type result struct {
+		proofs []proof.PoStProof
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		proofs, err := m.computeWinningPoSt(context.Background(), nil, nil, 10, network.Version23)
+		done <- result{proofs: proofs, err: err}
+	}()
+
+	<-prover.started
+	advanceUntilTimeout(t, mockClock)
+
+	// the timeout only warns, the computation must keep going
+	select {
+	case r := <-done:
+		t.Fatalf("computeWinningPoSt returned before the proof was released: %v", r.err)
+	default:
+	}
+
+	close(prover.release)
+	r := <-done
+	require.NoError(t, r.err)
+	require.Len(t, r.proofs, 1)
+	require.Equal(t, int64(1), winningPoStTimeouts(t))
+}
+
+func TestMineOneAbandonsLateWinningPoSt(t *testing.T) {
+	mockClock := setupWinningPoStTest(t)
+
+	ctrl := gomock.NewController(t)
+	fn := mocks.NewMockFullNode(ctrl)
+
+	base := &MiningBase{TipSet: mock.TipSet(mock.MkBlock(nil, 1, 1))}
+	worker := mock.Address(2000)
+
+	fn.EXPECT().MinerGetBaseInfo(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&api.MiningBaseInfo{
+		MinerPower:        big.NewInt(1 << 40),
+		NetworkPower:      big.NewInt(1 << 40),
+		Sectors:           []proof.ExtendedSectorInfo{{SealProof: abi.RegisteredSealProof_StackedDrg2KiBV1_1}},
+		WorkerKey:         worker,
+		SectorSize:        2048,
+		PrevBeaconEntry:   types.BeaconEntry{Round: 1, Data: []byte("beacon")},
+		EligibleForMining: true,
+	}, nil)
+	fn.EXPECT().WalletSign(gomock.Any(), worker, gomock.Any()).Return(&crypto.Signature{
+		Type: crypto.SigTypeBLS,
+		Data: []byte("not a real signature"),
+	}, nil).Times(2)
+	fn.EXPECT().StateNetworkVersion(gomock.Any(), base.TipSet.Key()).Return(network.Version23, nil)
+
+	prover := newBlockingProver()
+	m := &Miner{
+		api:                    fn,
+		epp:                    prover,
+		address:                mock.Address(1000),
+		winningPoStTimeout:     testWinningPoStTimeout,
+		abandonLateWinningPoSt: true,
+	}
+
+	type result struct {
+		blk *types.BlockMsg
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		blk, err := m.mineOne(context.Background(), base)
+		done <- result{blk: blk, err: err}
+	}()
+
+	<-prover.started
+	advanceUntilTimeout(t, mockClock)
+
+	r := <-done
+	require.NoError(t, r.err)
+	require.Nil(t, r.blk)
+
+	// the abandoned computation is cancelled rather than left running
+	<-prover.cancelled
+}
@@ -30,9 +30,11 @@ import (
 	"github.com/filecoin-project/lotus/journal/alerting"
 	"github.com/filecoin-project/lotus/lib/lotuslog"
 	"github.com/filecoin-project/lotus/lib/peermgr"
+	"github.com/filecoin-project/lotus/lib/rpcdrain"
 	_ "github.com/filecoin-project/lotus/lib/sigs/bls"
 	_ "github.com/filecoin-project/lotus/lib/sigs/delegated"
 	_ "github.com/filecoin-project/lotus/lib/sigs/secp"
+	"github.com/filecoin-project/lotus/metrics/rpcstats"
 	"github.com/filecoin-project/lotus/node/config"
 	"github.com/filecoin-project/lotus/node/impl/common"
 	"github.com/filecoin-project/lotus/node/modules"
@@ -90,6 +92,7 @@ const (
 
 	// libp2p
 	PstoreAddSelfKeysKey
+	RegisterSubnetLimiterKey
 	StartListeningKey
 	BootstrapKey
 
@@ -121,10 +124,15 @@ const (
 	HandleRetrievalKey
 	RunSectorServiceKey
 	F3Participation
+	ControlTopUpKey
+	SectorAutoExtendKey
 
 	// daemon
 	ExtractApiKey
 	HeadMetricsKey
+	MonitorReorgsKey
+	AutoSnapshotsKey
+	MpoolFeeBumpKey
 	SettlePaymentChannelsKey
 	RunPeerTaggerKey
 	SetupFallbackBlockstoresKey
@@ -229,12 +237,17 @@ var LibP2P = Options(
 	Override(new(*config.Pubsub), func(bs dtypes.Bootstrapper) *config.Pubsub {
 		return &config.Pubsub{
 			Bootstrapper: bool(bs),
+
+			BlocksTopicMaxMessageSize:   config.DefaultBlocksTopicMaxMessageSize,
+			MessagesTopicMaxMessageSize: config.DefaultMessagesTopicMaxMessageSize,
 		}
 	}),
 
 	// Services (connection management)
 	Override(ConnectionManagerKey, lp2p.ConnectionManager(50, 200, 20*time.Second, nil)),
 	Override(new(*conngater.BasicConnectionGater), lp2p.ConnGater),
+	Override(new(*lp2p.SubnetLimiter), lp2p.SubnetConnLimiter(0, 0, nil)),
+	Override(RegisterSubnetLimiterKey, lp2p.RegisterSubnetLimiter),
 	Override(ConnGaterKey, lp2p.ConnGaterOption),
 
 	// Services (resource management)
@@ -273,6 +286,13 @@ func ConfigCommon(cfg *config.Common, buildVersion build.BuildVersion) Option {
 	// setup logging early
 	lotuslog.SetLevelsFromConfig(cfg.Logging.SubsystemLevels)
 
+	statsMode, err := rpcstats.ParseMode(cfg.API.RPCStats)
+	if err != nil {
+		return Error(xerrors.Errorf("parsing API.RPCStats: %w", err))
+	}
+	rpcstats.SetMode(statsMode)
+	rpcdrain.SetTimeout(time.Duration(cfg.API.ShutdownDrainTimeout))
+
 	return Options(
 		func(s *Settings) error { s.Config = true; return nil },
 		Override(new(build.BuildVersion), buildVersion),
@@ -33,6 +33,7 @@ import (
 	"github.com/filecoin-project/lotus/chain/store"
 	"github.com/filecoin-project/lotus/chain/vm"
 	"github.com/filecoin-project/lotus/chain/wallet"
+	"github.com/filecoin-project/lotus/chain/wallet/extsigner"
 	ledgerwallet "github.com/filecoin-project/lotus/chain/wallet/ledger"
 	"github.com/filecoin-project/lotus/chain/wallet/remotewallet"
 	"github.com/filecoin-project/lotus/lib/peermgr"
@@ -107,6 +108,7 @@ var ChainNode = Options(
 
 	// Service: Message Pool
 	Override(new(dtypes.DefaultMaxFeeFunc), modules.NewDefaultMaxFeeFunc),
+	Override(new(dtypes.GasPremiumMultipliersFunc), modules.NewGasPremiumMultipliersFunc),
 	Override(new(*messagepool.MessagePool), modules.MessagePool),
 	Override(new(*dtypes.MpoolLocker), new(dtypes.MpoolLocker)),
 
@@ -199,8 +201,13 @@ func ConfigFullNode(c interface{}) Option {
 			time.Duration(cfg.Libp2p.ConnMgrGrace),
 			cfg.Libp2p.ProtectedPeers)),
 		Override(new(network.ResourceManager), lp2p.ResourceManager(cfg.Libp2p.ConnMgrHigh)),
+		Override(new(*lp2p.SubnetLimiter), lp2p.SubnetConnLimiter(
+			cfg.Libp2p.MaxConnsPerIPv4Subnet,
+			cfg.Libp2p.MaxConnsPerIPv6Subnet,
+			cfg.Libp2p.SubnetLimitExemptPeers)),
 		Override(new(*pubsub.PubSub), lp2p.GossipSub),
 		Override(new(*config.Pubsub), &cfg.Pubsub),
+		Override(new(*config.SyncConfig), &cfg.Sync),
 
 		ApplyIf(func(s *Settings) bool { return len(cfg.Libp2p.BootstrapPeers) > 0 },
 			Override(new(dtypes.BootstrapPeers), modules.ConfigBootstrap(cfg.Libp2p.BootstrapPeers)),
@@ -221,6 +228,7 @@ func ConfigFullNode(c interface{}) Option {
 				Override(new(dtypes.ColdBlockstore), modules.DiscardColdBlockstore)),
 			If(cfg.Chainstore.Splitstore.HotStoreType == "badger",
 				Override(new(dtypes.HotBlockstore), modules.BadgerHotBlockstore)),
+			Override(new(dtypes.SkipSplitstoreWarmup), dtypes.SkipSplitstoreWarmup(false)),
 			Override(new(dtypes.SplitBlockstore), modules.SplitBlockstore(&cfg.Chainstore)),
 			Override(new(dtypes.BasicChainBlockstore), modules.ChainSplitBlockstore),
 			Override(new(dtypes.BasicStateBlockstore), modules.StateSplitBlockstore),
@@ -236,6 +244,11 @@ func ConfigFullNode(c interface{}) Option {
 			Override(new(dtypes.GCReferenceProtector), modules.NoopGCReferenceProtector),
 		),
 
+		Override(MonitorReorgsKey, modules.MonitorReorgs(cfg.Chainstore.ReorgAlertDepth)),
+		If(cfg.Snapshots.EnableAutoSnapshots,
+			Override(AutoSnapshotsKey, modules.AutoSnapshots(cfg.Snapshots)),
+		),
+
 		Override(new(dtypes.ChainBlockstore), From(new(dtypes.BasicChainBlockstore))),
 		Override(new(dtypes.StateBlockstore), From(new(dtypes.BasicStateBlockstore))),
 
@@ -256,6 +269,9 @@ func ConfigFullNode(c interface{}) Option {
 		If(cfg.Wallet.EnableLedger,
 			Override(new(*ledgerwallet.LedgerWallet), ledgerwallet.NewWallet),
 		),
+		If(len(cfg.Wallet.ExternalSigners) > 0,
+			Override(new(*extsigner.ExternalWallet), extsigner.SetupHTTPSigners(cfg.Wallet.ExternalSigners)),
+		),
 		If(cfg.Wallet.DisableLocal,
 			Unset(new(*wallet.LocalWallet)),
 			Override(new(wallet.Default), wallet.NilDefault),
@@ -287,6 +303,18 @@ func ConfigFullNode(c interface{}) Option {
 			),
 		),
 
+		If(cfg.MpoolAccept.EnableStrictAccept,
+			Override(new(dtypes.MpoolStrictAccept), dtypes.MpoolStrictAccept{
+				MaxGasLimit:          cfg.MpoolAccept.MaxGasLimit,
+				RejectZeroPremium:    cfg.MpoolAccept.RejectZeroPremium,
+				RejectUnknownMethods: cfg.MpoolAccept.RejectUnknownMethods,
+			}),
+		),
+
+		If(cfg.Fees.AutoBump.Enable,
+			Override(MpoolFeeBumpKey, modules.MpoolFeeBump(cfg.Fees.AutoBump)),
+		),
+
 		// enable fault reporter when configured by the user
 		If(cfg.FaultReporter.EnableConsensusFaultReporter,
 			Override(ConsensusReporterKey, modules.RunConsensusFaultReporter(cfg.FaultReporter)),
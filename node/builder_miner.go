@@ -14,6 +14,7 @@ import (
 	"github.com/filecoin-project/lotus/build/buildconstants"
 	"github.com/filecoin-project/lotus/chain/gen"
 	"github.com/filecoin-project/lotus/chain/gen/slashfilter"
+	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/lib/harmony/harmonydb"
 	"github.com/filecoin-project/lotus/miner"
 	"github.com/filecoin-project/lotus/node/config"
@@ -89,7 +90,7 @@ func ConfigStorageMiner(c interface{}) Option {
 			Override(new(*slashfilter.SlashFilter), modules.NewSlashFilter),
 
 			If(!cfg.Subsystems.DisableWinningPoSt,
-				Override(new(*miner.Miner), modules.SetupBlockProducer),
+				Override(new(*miner.Miner), modules.SetupBlockProducer(cfg.Proving)),
 				Override(new(gen.WinningPoStProver), storage.NewWinningPoStProver),
 			),
 
@@ -106,7 +107,6 @@ func ConfigStorageMiner(c interface{}) Option {
 				Override(new(*paths.DBIndex), paths.NewDBIndex),
 				Override(new(paths.SectorIndex), From(new(*paths.DBIndex))),
 
-				// sector index db is the only thing on lotus-miner that will use harmonydb
 				Override(new(*harmonydb.DB), func(cfg config.HarmonyDB) (*harmonydb.DB, error) {
 					return harmonydb.NewFromConfig(cfg)
 				}),
@@ -136,7 +136,15 @@ func ConfigStorageMiner(c interface{}) Option {
 		Override(new(config.SealerConfig), cfg.Storage),
 		Override(new(config.ProvingConfig), cfg.Proving),
 		Override(new(config.HarmonyDB), cfg.HarmonyDB),
+		If(cfg.Subsystems.EnableKeyStoreDB,
+			Override(new(*harmonydb.DB), func(cfg config.HarmonyDB) (*harmonydb.DB, error) {
+				return harmonydb.NewFromConfig(cfg)
+			}),
+			Override(new(types.KeyStore), modules.DBKeyStore),
+		),
 		Override(new(*ctladdr.AddressSelector), modules.AddressSelector(&cfg.Addresses)),
+		If(cfg.Addresses.TopUp.Enable, Override(ControlTopUpKey, modules.ControlTopUp(cfg.Addresses.TopUp))),
+		If(cfg.SectorAutoExtend.Enable, Override(SectorAutoExtendKey, modules.SectorAutoExtend(cfg.SectorAutoExtend))),
 		If(build.IsF3Enabled(), Override(F3Participation, modules.F3Participation)),
 	)
 }
@@ -23,6 +23,9 @@ func defCommon() Common {
 		API: API{
 			ListenAddress: "/ip4/127.0.0.1/tcp/1234/http",
 			Timeout:       Duration(30 * time.Second),
+			RPCStats:      "disabled",
+
+			ShutdownDrainTimeout: Duration(30 * time.Second),
 		},
 		Logging: Logging{
 			SubsystemLevels: map[string]string{
@@ -35,6 +38,13 @@ func defCommon() Common {
 	}
 }
 
+const (
+	// DefaultBlocksTopicMaxMessageSize is the gossipsub default max message size
+	DefaultBlocksTopicMaxMessageSize = 1 << 20
+	// DefaultMessagesTopicMaxMessageSize is the max size of a message
+	DefaultMessagesTopicMaxMessageSize = 64 << 10
+)
+
 func DefaultDefaultMaxFee() types.FIL {
 	return types.MustParseFIL("0.07")
 }
@@ -59,27 +69,46 @@ func DefaultFullNode() *FullNode {
 			ConnMgrLow:   150,
 			ConnMgrHigh:  180,
 			ConnMgrGrace: Duration(20 * time.Second),
+
+			SubnetLimitExemptPeers: []string{},
 		},
 		Pubsub: Pubsub{
 			Bootstrapper: false,
 			DirectPeers:  nil,
+
+			BlocksTopicMaxMessageSize:   DefaultBlocksTopicMaxMessageSize,
+			MessagesTopicMaxMessageSize: DefaultMessagesTopicMaxMessageSize,
 		},
 
+		Wallet: Wallet{
+			ExternalSigners: map[string]string{},
+		},
 		Fees: FeeConfig{
-			DefaultMaxFee: DefaultDefaultMaxFee(),
+			DefaultMaxFee:         DefaultDefaultMaxFee(),
+			GasPremiumMultipliers: map[string]float64{},
+			AutoBump: FeeBumpConfig{
+				Enable:        false,
+				StuckEpochs:   20,
+				MaxFee:        types.MustParseFIL("0.1"),
+				CheckInterval: Duration(time.Minute),
+			},
 		},
 
 		Chainstore: Chainstore{
 			EnableSplitstore: true,
+			ReorgAlertDepth:  5,
 			Splitstore: Splitstore{
 				ColdStoreType: "discard",
 				HotStoreType:  "badger",
 				MarkSetType:   "badger",
+				WarmupMode:    "eager",
 
 				HotStoreFullGCFrequency:      20,
 				HotStoreMaxSpaceTarget:       650_000_000_000,
 				HotStoreMaxSpaceThreshold:    150_000_000_000,
 				HotstoreMaxSpaceSafetyBuffer: 50_000_000_000,
+
+				CompactionWindows: []string{},
 			},
 		},
 		Fevm: FevmConfig{
@@ -100,6 +129,24 @@ func DefaultFullNode() *FullNode {
 			ReconcileEmptyIndex: false,
 			MaxReconcileTipsets: 3 * builtin.EpochsInDay,
 		},
+		MpoolAccept: MpoolAcceptConfig{
+			EnableStrictAccept:   false,
+			MaxGasLimit:          0,
+			RejectZeroPremium:    true,
+			RejectUnknownMethods: true,
+		},
+		Snapshots: SnapshotConfig{
+			EnableAutoSnapshots: false,
+			Path:                "snapshots",
+			IntervalEpochs:      builtin.EpochsInDay,
+			Lookback:            int(policy.ChainFinality),
+			RecentStateRoots:    int(policy.ChainFinality),
+			SkipOldMessages:     true,
+			Retain:              3,
+		},
+		Sync: SyncConfig{
+			ValidationParallelism: 4,
+		},
 	}
 }
 
@@ -117,6 +164,7 @@ func DefaultStorageMiner() *StorageMiner {
 			AlwaysKeepUnsealedCopy:    true,
 			FinalizeEarly:             false,
 			MakeNewSectorForDeals:     true,
+			DealPackingStrategy:       "least-padding",
 
 			CollateralFromMinerBalance: false,
 			AvailableBalanceBuffer:     types.FIL(big.Zero()),
@@ -142,13 +190,16 @@ func DefaultStorageMiner() *StorageMiner {
 			TerminateBatchMax:                      100,
 			TerminateBatchWait:                     Duration(5 * time.Minute),
 			MaxSectorProveCommitsSubmittedPerEpoch: 20,
+			MaxCommitResubmits:                     5,
 			UseSyntheticPoRep:                      false,
+			ReplicaCheckSampleRate:                 0,
 		},
 
 		Proving: ProvingConfig{
 			ParallelCheckLimit:    32,
 			PartitionCheckTimeout: Duration(20 * time.Minute),
 			SingleCheckTimeout:    Duration(10 * time.Minute),
+			WinningPoStTimeout:    Duration(15 * time.Second),
 		},
 
 		Storage: SealerConfig{
@@ -166,6 +217,12 @@ func DefaultStorageMiner() *StorageMiner {
 			// it's the ratio between 10gbit / 1gbit
 			ParallelFetchLimit: 10,
 
+			FetchRetries:      3,
+			FetchRetryBackoff: Duration(10 * time.Second),
+
+			TaskTimeouts:           map[string]Duration{},
+			TaskTimeoutGraceFactor: 2,
+
 			Assigner: "utilization",
 
 			// By default use the hardware resource filtering strategy.
@@ -202,6 +259,10 @@ func DefaultStorageMiner() *StorageMiner {
 			MaxMarketBalanceAddFee: types.MustParseFIL("0.007"),
 
 			MaximizeWindowPoStFeeCap: true,
+
+			MaxPreCommitBaseFee:  types.MustParseFIL("0"),
+			MaxCommitBaseFee:     types.MustParseFIL("0"),
+			MaxWindowPoStBaseFee: types.MustParseFIL("0"),
 		},
 
 		Addresses: MinerAddressConfig{
@@ -209,6 +270,24 @@ func DefaultStorageMiner() *StorageMiner {
 			CommitControl:      []string{},
 			TerminateControl:   []string{},
 			DealPublishControl: []string{},
+
+			TopUp: ControlTopUpConfig{
+				Addresses:        []string{},
+				Threshold:        types.MustParseFIL("1"),
+				TargetBalance:    types.MustParseFIL("5"),
+				MaxTopUp:         types.MustParseFIL("10"),
+				CheckInterval:    Duration(5 * time.Minute),
+				MinTopUpInterval: Duration(time.Hour),
+			},
+		},
+
+		SectorAutoExtend: SectorAutoExtendConfig{
+			ExpiringWithin:   Duration(28 * 24 * time.Hour),
+			TargetLifetime:   Duration(540 * 24 * time.Hour),
+			CheckInterval:    Duration(time.Hour),
+			MaxSectorsPerRun: 10000,
+			MaxGasFeePerDay:  types.MustParseFIL("1"),
+			ExcludeSectors:   []uint64{},
 		},
 
 		HarmonyDB: HarmonyDB{
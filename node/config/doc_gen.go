@@ -28,6 +28,24 @@ var Doc = map[string][]DocField{
 
 			Comment: ``,
 		},
+		{
+			Name: "RPCStats",
+			Type: "string",
+
+			Comment: `RPCStats enables recording the number of calls and cumulative duration of each RPC method
+per client, returned by the RPCStats API method. Set to "connection" to attribute calls to
+the remote address of the connection, "token" to attribute calls to the API token used, or
+"disabled".`,
+		},
+		{
+			Name: "ShutdownDrainTimeout",
+			Type: "Duration",
+
+			Comment: `ShutdownDrainTimeout is the time RPC calls in flight are given to complete when the node
+shuts down. While draining, new connections are refused and new calls on open connections
+fail with a "node shutting down" error; the server is closed once the calls complete or
+the timeout expires. 0 closes the server without waiting.`,
+		},
 	},
 	"ApisConfig": {
 		{
@@ -146,6 +164,15 @@ may increase startup time.`,
 
 			Comment: ``,
 		},
+		{
+			Name: "ReorgAlertDepth",
+			Type: "int",
+
+			Comment: `ReorgAlertDepth is the depth in epochs, between the previous head and the common ancestor,
+above which reorgs observed by the node are logged as warnings and recorded in the journal
+as sync/deep_reorg events. The depth of every reorg is reported in the lotus_reorg_depth
+metric regardless of this setting. 0 disables the warnings.`,
+		},
 	},
 	"Common": {
 		{
@@ -167,6 +194,61 @@ may increase startup time.`,
 			Comment: ``,
 		},
 	},
+	"ControlTopUpConfig": {
+		{
+			Name: "Enable",
+			Type: "bool",
+
+			Comment: `Enable sending funds from FundingAddress to the worker and control
+addresses when their balance drops below Threshold.`,
+		},
+		{
+			Name: "FundingAddress",
+			Type: "string",
+
+			Comment: `FundingAddress is the address top-ups are sent from. It must be in the
+wallet of the node.`,
+		},
+		{
+			Name: "Addresses",
+			Type: "[]string",
+
+			Comment: `Addresses to top up. When empty, the worker and all control addresses
+of the miner are topped up.`,
+		},
+		{
+			Name: "Threshold",
+			Type: "types.FIL",
+
+			Comment: `Threshold is the balance below which an address is topped up.`,
+		},
+		{
+			Name: "TargetBalance",
+			Type: "types.FIL",
+
+			Comment: `TargetBalance is the balance an address is topped up to.`,
+		},
+		{
+			Name: "MaxTopUp",
+			Type: "types.FIL",
+
+			Comment: `MaxTopUp is the maximum amount sent in a single top-up, 0 for no limit.`,
+		},
+		{
+			Name: "CheckInterval",
+			Type: "Duration",
+
+			Comment: `CheckInterval is how often the balances of the addresses are checked.`,
+		},
+		{
+			Name: "MinTopUpInterval",
+			Type: "Duration",
+
+			Comment: `MinTopUpInterval is the minimum time between two top-ups of the same
+address. It should be long enough for a top-up message to land on chain,
+so that the address isn't topped up again before its balance is updated.`,
+		},
+	},
 	"DealmakingConfig": {
 		{
 			Name: "StartEpochSealingBuffer",
@@ -245,6 +327,46 @@ ReportConsensusFault messages. It will pay for gas fees, and receive any
 rewards. This address should have adequate funds to cover gas fees.`,
 		},
 	},
+	"FeeBumpConfig": {
+		{
+			Name: "Enable",
+			Type: "bool",
+
+			Comment: `Enable enables automatic fee bumping of messages which are pending for more than
+StuckEpochs epochs. By default only messages sent automatically by the node or the miners
+using it, e.g. WindowPoSt, PreCommit and ProveCommit messages, are bumped. They are marked
+in memory when they are signed, so those sent before the node was restarted are not bumped
+unless BumpPushedMessages is set. Each bump is logged.`,
+		},
+		{
+			Name: "StuckEpochs",
+			Type: "int",
+
+			Comment: `StuckEpochs is the number of epochs a message has to be pending for before it is bumped.
+Replacements are bumped again when they are pending for as long.`,
+		},
+		{
+			Name: "MaxFee",
+			Type: "types.FIL",
+
+			Comment: `MaxFee is the maximum fee, the gas fee cap times the gas limit, of a bumped message.
+Messages which can't be bumped by the minimum replace-by-fee premium increase within this
+fee are left pending.`,
+		},
+		{
+			Name: "BumpPushedMessages",
+			Type: "bool",
+
+			Comment: `BumpPushedMessages also bumps all other messages whose sender key is in the node wallet,
+e.g. messages sent with lotus send, or signed elsewhere and pushed with MpoolPush.`,
+		},
+		{
+			Name: "CheckInterval",
+			Type: "Duration",
+
+			Comment: `CheckInterval is the interval at which the mpool is checked for stuck messages.`,
+		},
+	},
 	"FeeConfig": {
 		{
 			Name: "DefaultMaxFee",
@@ -252,6 +374,23 @@ rewards. This address should have adequate funds to cover gas fees.`,
 
 			Comment: ``,
 		},
+		{
+			Name: "GasPremiumMultipliers",
+			Type: "map[string]float64",
+
+			Comment: `GasPremiumMultipliers maps sender addresses to a multiplier applied to the gas premium
+estimated for their messages, e.g. {"f3abc..." = 1.5}, so that messages from high-priority
+addresses are given a higher premium automatically. Multipliers must be positive, and
+senders without an entry use a multiplier of 1.
+Only estimated premiums are affected: messages with an explicitly set GasPremium are sent
+with that premium, and the fee cap still respects the MaxFee of the message send spec.`,
+		},
+		{
+			Name: "AutoBump",
+			Type: "FeeBumpConfig",
+
+			Comment: `AutoBump replaces messages stuck in the mpool with copies paying a higher gas premium.`,
+		},
 	},
 	"FevmConfig": {
 		{
@@ -332,6 +471,24 @@ Note: Setting this value to 0 disables the cache.`,
 			Name: "FaultReporter",
 			Type: "FaultReporterConfig",
 
+			Comment: ``,
+		},
+		{
+			Name: "MpoolAccept",
+			Type: "MpoolAcceptConfig",
+
+			Comment: ``,
+		},
+		{
+			Name: "Snapshots",
+			Type: "SnapshotConfig",
+
+			Comment: ``,
+		},
+		{
+			Name: "Sync",
+			Type: "SyncConfig",
+
 			Comment: ``,
 		},
 	},
@@ -442,6 +599,27 @@ count towards this limit.`,
 			Comment: `ConnMgrGrace is a time duration that new connections are immune from being
 closed by the connection manager.`,
 		},
+		{
+			Name: "MaxConnsPerIPv4Subnet",
+			Type: "int",
+
+			Comment: `MaxConnsPerIPv4Subnet is the maximum number of connections from a single /24
+IPv4 subnet; inbound connections over the limit are refused. 0 means no limit.`,
+		},
+		{
+			Name: "MaxConnsPerIPv6Subnet",
+			Type: "int",
+
+			Comment: `MaxConnsPerIPv6Subnet is the maximum number of connections from a single /64
+IPv6 subnet; inbound connections over the limit are refused. 0 means no limit.`,
+		},
+		{
+			Name: "SubnetLimitExemptPeers",
+			Type: "[]string",
+
+			Comment: `SubnetLimitExemptPeers lists the IDs of peers which are never refused because
+of the subnet connection limits; their connections don't count towards the limits.`,
+		},
 	},
 	"Logging": {
 		{
@@ -492,6 +670,12 @@ sent automatically, if control addresses are configured.
 A control address that doesn't have enough funds will still be chosen
 over the worker address if this flag is set.`,
 		},
+		{
+			Name: "TopUp",
+			Type: "ControlTopUpConfig",
+
+			Comment: `TopUp configures automatic top-ups of the worker and control addresses`,
+		},
 	},
 	"MinerFeeConfig": {
 		{
@@ -548,6 +732,30 @@ over the worker address if this flag is set.`,
 
 			Comment: ``,
 		},
+		{
+			Name: "MaxPreCommitBaseFee",
+			Type: "types.FIL",
+
+			Comment: `MaxPreCommitBaseFee is the network base fee above which sending of PreCommit batches is
+delayed, until either the base fee drops or a sector in the batch gets within
+PreCommitBatchSlack of its precommit deadline. Set to 0 to disable.`,
+		},
+		{
+			Name: "MaxCommitBaseFee",
+			Type: "types.FIL",
+
+			Comment: `MaxCommitBaseFee is the network base fee above which sending of ProveCommit batches is
+delayed, until either the base fee drops or a sector in the batch gets within
+CommitBatchSlack of its commit deadline. Set to 0 to disable.`,
+		},
+		{
+			Name: "MaxWindowPoStBaseFee",
+			Type: "types.FIL",
+
+			Comment: `MaxWindowPoStBaseFee is the network base fee above which submission of WindowPoSt proofs
+is delayed, until either the base fee drops or the deadline is about to close. Set to 0 to
+disable.`,
+		},
 	},
 	"MinerSubsystemConfig": {
 		{
@@ -575,6 +783,16 @@ over the worker address if this flag is set.`,
 			Comment: `When enabled, the sector index will reside in an external database
 as opposed to the local KV store in the miner process
 This is useful to allow workers to bypass the lotus miner to access sector information`,
+		},
+		{
+			Name: "EnableKeyStoreDB",
+			Type: "bool",
+
+			Comment: `When enabled, the keys of the miner, like the API secret, are stored in the HarmonyDB
+database instead of the repo keystore, so that all miner nodes using the same database
+share them, and API tokens of one node are accepted by the others. The libp2p host key,
+which identifies the node, stays in the repo keystore.
+Existing keys can be copied between the keystores with 'lotus-shed harmonydb migrate-keystore'.`,
 		},
 		{
 			Name: "SealerApiInfo",
@@ -614,6 +832,39 @@ When disabled and no external block producers are configured, all potential
 block rewards will be missed!`,
 		},
 	},
+	"MpoolAcceptConfig": {
+		{
+			Name: "EnableStrictAccept",
+			Type: "bool",
+
+			Comment: `EnableStrictAccept enables the rules below for messages pushed through the
+MpoolPush, MpoolPushUntrusted and batch push API methods, which rejects
+messages matching any enabled rule before they are added to the mpool.
+Messages signed by the node with MpoolPushMessage, and messages received
+from the network, are not checked.`,
+		},
+		{
+			Name: "MaxGasLimit",
+			Type: "int64",
+
+			Comment: `MaxGasLimit rejects messages with a gas limit above this value, 0 for no
+limit other than the block gas limit.`,
+		},
+		{
+			Name: "RejectZeroPremium",
+			Type: "bool",
+
+			Comment: `RejectZeroPremium rejects messages with a zero gas premium.`,
+		},
+		{
+			Name: "RejectUnknownMethods",
+			Type: "bool",
+
+			Comment: `RejectUnknownMethods rejects messages calling a method which the
+recipient actor doesn't export, for built-in recipient actors. Messages to
+user actors and to addresses without an actor are not checked.`,
+		},
+	},
 	"ProvingConfig": {
 		{
 			Name: "ParallelCheckLimit",
@@ -650,6 +901,47 @@ WARNING: Setting this value too low risks in sectors being skipped even though t
 test challenge took longer than this timeout
 WARNING: Setting this value too high risks missing PoSt deadline in case IO operations related to this partition are
 blocked or slow`,
+		},
+		{
+			Name: "MaxParallelPartitionProofs",
+			Type: "int",
+
+			Comment: `Maximum number of partitions of a single WindowPoSt batch for which proofs are computed
+concurrently on window PoSt workers. (0 = unlimited, all partitions of a batch in parallel)
+
+Lower values reduce peak memory pressure on workers at the cost of a longer proving time,
+which increases the risk of missing the deadline for deadlines with many partitions.
+A warning is logged when proving if the connected window PoSt workers don't have enough
+memory to prove this many partitions at once.
+When no window PoSt workers are connected the limit applies to the lotus-miner process
+only if BuiltinPoStParallelReads is non-zero, and is then checked against the memory of this
+host; otherwise all partitions of a batch are proven in a single call and this setting has
+no effect.`,
+		},
+		{
+			Name: "BuiltinPoStParallelReads",
+			Type: "int",
+
+			Comment: `Maximum number of sectors to read PoSt challenges from in parallel when window PoSt is computed by
+the lotus-miner process. (0 = disabled)
+
+By default the builtin prover only proves sectors in the storage paths of the lotus-miner host, and any
+sector it can't open fails the whole batch until the sector is found by the next retry. When set, the
+builtin prover reads challenges through the storage subsystem instead, in parallel, so sectors in storage
+paths of other hosts, e.g. attached to sealing workers, can be proven by reading just the challenged
+nodes over the network. Sectors whose challenges can't be read are skipped and declared faulty, without
+failing the partition.
+
+Higher values start proof computation sooner, but put more load on the network and disks of the
+storage hosts. This setting has no effect when window PoSt workers are connected, their parallel
+reads are set with the lotus-worker --post-parallel-reads option.`,
+		},
+		{
+			Name: "BuiltinPoStReadTimeout",
+			Type: "Duration",
+
+			Comment: `Maximum amount of time reading the challenges of a single sector can take when BuiltinPoStParallelReads
+is set. Sectors which time out are skipped and declared faulty. (0 = no timeout)`,
 		},
 		{
 			Name: "DisableBuiltinWindowPoSt",
@@ -671,6 +963,27 @@ After changing this option, confirm that the new value works in your setup by in
 
 WARNING: If no WinningPoSt workers are connected, Winning PoSt WILL FAIL resulting in lost block rewards.
 Before enabling this option, make sure your PoSt workers work correctly.`,
+		},
+		{
+			Name: "WinningPoStTimeout",
+			Type: "Duration",
+
+			Comment: `Maximum amount of time Winning PoSt computation is expected to take. When computing the proof takes longer, a
+warning is logged, and the miner/winning_post_timeouts metric is incremented. The duration of each proof is
+reported in the miner/winning_post_ms metric. (0 = no timeout)
+
+Blocks must be produced before the end of the epoch to be included in the chain, so the proof, message selection
+and block creation together have less than the block time to complete.`,
+		},
+		{
+			Name: "AbandonLateWinningPoSt",
+			Type: "bool",
+
+			Comment: `Abandon the mining round when Winning PoSt computation takes longer than WinningPoStTimeout, instead of
+producing a block which is likely to arrive too late to be accepted by the network. When workers compute the
+proof, the computation is cancelled.
+
+Note that an abandoned round means a lost block reward, even if the late block would have been accepted.`,
 		},
 		{
 			Name: "DisableWDPoStPreChecks",
@@ -723,6 +1036,25 @@ there may be too many recoveries to fit in a BlockGasLimit.
 In those cases it may be necessary to set this value to something low (eg 1);
 Note that setting this value lower may result in less efficient gas use - more messages will be sent than needed,
 resulting in more total gas use (but each message will have lower gas limit)`,
+		},
+		{
+			Name: "MaxSectorsPerRecoveryMessage",
+			Type: "int",
+
+			Comment: `Maximum number of sectors to declare in a single DeclareFaultsRecovered message. 0 = no limit.
+
+Like MaxPartitionsPerRecoveryMessage, this keeps recovery messages within the block gas limit when many sectors
+recover at once; recovered sectors of a single partition may be split across multiple messages.`,
+		},
+		{
+			Name: "DisableAutoRecoveryDeclarations",
+			Type: "bool",
+
+			Comment: `Disable automatic recovery declarations.
+
+By default, before each deadline the miner checks sectors which were marked faulty, e.g. after a transient storage
+outage, and declares those which are provable again as recovered. When this option is enabled, recoveries must
+be declared manually with 'lotus-miner proving recover-faults'.`,
 		},
 		{
 			Name: "SingleRecoveringPartitionPerPostMessage",
@@ -737,6 +1069,24 @@ with recovering sectors in the post message
 Note that setting this value lower may result in less efficient gas use - more messages will be sent,
 to prove each deadline, resulting in more total gas use (but each message will have lower gas limit)`,
 		},
+		{
+			Name: "HaltSubmissionFaultySectors",
+			Type: "uint64",
+
+			Comment: `Halt WindowPoSt submission for a deadline when more than this number of its live sectors are faulty, either
+declared faulty or skipped by the proof. 0 = always submit.
+
+When submission is halted, an alert is raised and the proofs are only submitted after the faults are
+acknowledged with 'lotus-miner proving ack-faults <deadline>'. Note that when no proof is submitted before the
+deadline closes, all sectors of the deadline are marked faulty.`,
+		},
+		{
+			Name: "HaltSubmissionFaultyFraction",
+			Type: "float64",
+
+			Comment: `Halt WindowPoSt submission for a deadline when more than this fraction of its live sectors are faulty, for
+example 0.1 for 10%. Works like HaltSubmissionFaultySectors, 0 = always submit.`,
+		},
 	},
 	"Pubsub": {
 		{
@@ -797,6 +1147,22 @@ This property is used only if ElasticSearchTracer propery is set.`,
 
 			Comment: `Auth token that will be passed with logs to elasticsearch - used for weighted peers score.`,
 		},
+		{
+			Name: "BlocksTopicMaxMessageSize",
+			Type: "int",
+
+			Comment: `BlocksTopicMaxMessageSize is the maximum size in bytes of the messages on the blocks topic,
+which carry block headers and the CIDs of their messages. It can be raised on networks with
+larger blocks, and must fit a block with the maximum number of messages (446384 bytes).`,
+		},
+		{
+			Name: "MessagesTopicMaxMessageSize",
+			Type: "int",
+
+			Comment: `MessagesTopicMaxMessageSize is the maximum size in bytes of the messages on the messages
+topic, and of the messages accepted into the message pool. It can't be lower than the
+default maximum size of a message (65536 bytes).`,
+		},
 	},
 	"SealerConfig": {
 		{
@@ -805,6 +1171,39 @@ This property is used only if ElasticSearchTracer propery is set.`,
 
 			Comment: ``,
 		},
+		{
+			Name: "FetchRetries",
+			Type: "int",
+
+			Comment: `FetchRetries is the number of times a sector file fetch from remote storage failing with a network
+error, or an error of a temporarily unavailable remote, is retried before trying other copies of
+the file or failing the task. Missing or corrupt files are not retried. 0 disables retries.`,
+		},
+		{
+			Name: "FetchRetryBackoff",
+			Type: "Duration",
+
+			Comment: `FetchRetryBackoff is the wait before the first retry of a fetch, doubled after every retry.`,
+		},
+		{
+			Name: "TaskTimeouts",
+			Type: "map[string]Duration",
+
+			Comment: `TaskTimeouts sets how long tasks may run on a worker, keyed by the short task type names shown
+by 'lotus-miner sealing jobs', e.g. PC1, PC2 or C2. Tasks running longer than their timeout are
+logged as slow. Tasks running longer than the timeout multiplied by TaskTimeoutGraceFactor are
+considered hung and aborted, failing the sealing step so that the sector is retried. Workers
+can't stop a running task, so the resources of an aborted task stay reserved on its worker
+until its late result arrives or the worker restarts. Task types without a timeout are never
+aborted.`,
+		},
+		{
+			Name: "TaskTimeoutGraceFactor",
+			Type: "float64",
+
+			Comment: `TaskTimeoutGraceFactor is the multiple of a task timeout after which a slow task is aborted.
+Must be at least 1.`,
+		},
 		{
 			Name: "AllowSectorDownload",
 			Type: "bool",
@@ -995,6 +1394,25 @@ This is useful for forcing all deals to be assigned as snap deals to sectors mar
 
 			Comment: `After sealing CC sectors, make them available for upgrading with deals`,
 		},
+		{
+			Name: "DealPackingStrategy",
+			Type: "string",
+
+			Comment: `Strategy used to choose which of the sectors open for deals incoming deal pieces are packed into. One of:
+
+"least-padding" (default): prefer the sectors where the piece needs the least padding to be aligned, which
+wastes little space between pieces, but doesn't take the deal terms into account.
+
+"largest-first": assign the largest pending pieces first, then the smaller ones into the remaining space.
+With pieces of mixed sizes this usually fills sectors more completely, as small pieces can fill gaps which large
+pieces couldn't, at the cost of small pieces waiting longer to be assigned when many large pieces are pending.
+
+"by-expiration": prefer the sectors holding deals which end closest to the end of the deal of the piece, grouping
+deals with similar terms, so that sector lifetimes can be set close to their deals and sectors can be extended or
+expire without outliving most of their deals. This may leave more space for padding in sectors.
+
+The fill ratio of the sectors open for deals can be checked with 'lotus-miner sectors open'.`,
+		},
 		{
 			Name: "CollateralFromMinerBalance",
 			Type: "bool",
@@ -1031,6 +1449,14 @@ This is useful for forcing all deals to be assigned as snap deals to sectors mar
 
 			Comment: `time buffer for forceful batch submission before sectors/deal in batch would start expiring`,
 		},
+		{
+			Name: "PreCommitBatchTicketExpiryEpochs",
+			Type: "uint64",
+
+			Comment: `send the precommit batch as soon as the ticket of a sector in it is within this many epochs
+of expiring, even when the batch is below MaxPreCommitBatch and the base fee is above
+MaxPreCommitBaseFee. Expired tickets require sealing the sector again. Set to 0 to disable.`,
+		},
 		{
 			Name: "AggregateCommits",
 			Type: "bool",
@@ -1085,6 +1511,14 @@ stagger the number of prove commits submitted per epoch
 This is done because gas estimates for ProveCommits are non deterministic and increasing as a large
 number of sectors get committed within the same epoch resulting in occasionally failed msgs.
 Submitting a smaller number of prove commits per epoch would reduce the possibility of failed msgs`,
+		},
+		{
+			Name: "MaxCommitResubmits",
+			Type: "uint64",
+
+			Comment: `Maximum number of times a prove commit message which landed on chain but failed with a transient error (out of
+gas / insufficient funds) will be re-estimated and resubmitted before the sector is moved to CommitFailed and
+requires manual intervention (0 = unlimited)`,
 		},
 		{
 			Name: "TerminateBatchMax",
@@ -1134,6 +1568,117 @@ Submitting a smaller number of prove commits per epoch would reduce the possibil
 
 			Comment: `Whether to abort if any piece activation notification returns a non-zero exit code (updating sectors, only with ProveReplicaUpdates3).`,
 		},
+		{
+			Name: "ReplicaCheckSampleRate",
+			Type: "float64",
+
+			Comment: `ReplicaCheckSampleRate is the share of sectors, between 0 and 1, whose replica is verified after PreCommit 2,
+before the sector is pre-committed. The check generates a vanilla PoSt proof for randomly sampled challenges,
+which reads and verifies parts of the sealed replica against its CommR, to catch replicas corrupted by hardware
+errors before collateral is put down for them. Sectors failing the check are sent back to PreCommit 2.
+The check takes a few seconds to minutes per sector depending on storage, 0 disables it; values outside of 0..1
+are rejected when the sealing config is loaded.`,
+		},
+	},
+	"SectorAutoExtendConfig": {
+		{
+			Name: "Enable",
+			Type: "bool",
+
+			Comment: `Enable periodically extending active sectors which are about to expire. Extensions are sent
+from the worker address, and logged with the extended sectors and message CID.`,
+		},
+		{
+			Name: "ExpiringWithin",
+			Type: "Duration",
+
+			Comment: `ExpiringWithin is how long before their expiration sectors are extended.`,
+		},
+		{
+			Name: "TargetLifetime",
+			Type: "Duration",
+
+			Comment: `TargetLifetime is how long from now sectors are extended to expire in, capped by the maximum
+extension and sector lifetime allowed by the network. Sectors with verified claims ending
+before that are left to be extended manually with 'sectors extend'.`,
+		},
+		{
+			Name: "CheckInterval",
+			Type: "Duration",
+
+			Comment: `CheckInterval is how often the miner checks for expiring sectors.`,
+		},
+		{
+			Name: "MaxSectorsPerRun",
+			Type: "int",
+
+			Comment: `MaxSectorsPerRun is the maximum number of sectors extended in one check, 0 for no limit.
+Sectors expiring first are extended first.`,
+		},
+		{
+			Name: "MaxGasFeePerDay",
+			Type: "types.FIL",
+
+			Comment: `MaxGasFeePerDay is the maximum amount of gas fees spent on extension messages within 24
+hours, 0 for no limit.`,
+		},
+		{
+			Name: "ExcludeSectors",
+			Type: "[]uint64",
+
+			Comment: `ExcludeSectors is a list of sector numbers which are never extended automatically.`,
+		},
+	},
+	"SnapshotConfig": {
+		{
+			Name: "EnableAutoSnapshots",
+			Type: "bool",
+
+			Comment: `EnableAutoSnapshots enables periodic generation of chain snapshots. Snapshots are
+generated in the background, one at a time, and written to a temporary file which is
+renamed once the snapshot is complete.`,
+		},
+		{
+			Name: "Path",
+			Type: "string",
+
+			Comment: `Path is the directory snapshots are written to. Relative paths are relative to the
+repo directory.`,
+		},
+		{
+			Name: "IntervalEpochs",
+			Type: "int",
+
+			Comment: `IntervalEpochs is the number of epochs between snapshots. Snapshots are taken at
+heights which are multiples of this number.`,
+		},
+		{
+			Name: "Lookback",
+			Type: "int",
+
+			Comment: `Lookback is the number of epochs a snapshot height must be below the head before the
+snapshot is generated, so that snapshots are not taken of tipsets which can be reorged.`,
+		},
+		{
+			Name: "RecentStateRoots",
+			Type: "int",
+
+			Comment: `RecentStateRoots is the number of recent state roots included in snapshots, which
+must be at least the chain finality.`,
+		},
+		{
+			Name: "SkipOldMessages",
+			Type: "bool",
+
+			Comment: `SkipOldMessages excludes messages older than the recent state roots from snapshots.`,
+		},
+		{
+			Name: "Retain",
+			Type: "int",
+
+			Comment: `Retain is the number of most recent snapshots kept in Path, older snapshots are
+removed once a new snapshot is complete. 0 keeps all snapshots.`,
+		},
 	},
 	"Splitstore": {
 		{
@@ -1201,6 +1746,31 @@ exceeds HotstoreMaxSpaceTarget - HotstoreMaxSpaceThreshold`,
 is set.  Moving GC will not occur when total moving size exceeds
 HotstoreMaxSpaceTarget - HotstoreMaxSpaceSafetyBuffer`,
 		},
+		{
+			Name: "CompactionWindows",
+			Type: "[]string",
+
+			Comment: `CompactionWindows restricts when splitstore compaction may start, as a list of daily
+windows of local time in the HH:MM-HH:MM format, e.g. ["01:00-05:00"]; windows may wrap
+around midnight. A compaction which becomes due outside of all windows is deferred until
+the next window opens, which keeps heavy I/O out of busy periods such as proving windows.
+Note that a compaction may run past the end of the window it was started in.
+Empty (the default) allows compaction to start at any time.`,
+		},
+		{
+			Name: "WarmupMode",
+			Type: "string",
+
+			Comment: `WarmupMode specifies how the hotstore is warmed up when the splitstore is first enabled on
+top of an existing blockstore. It can be "eager" (default), which copies the state at the
+current head and the chain headers from the coldstore in the background at startup, or
+"lazy", which skips the copy and instead moves objects to the hotstore as they are accessed
+during state computation.
+Lazy warmup avoids a long I/O burst at startup, at the cost of slower reads from the
+coldstore until the objects have been accessed. Block producing nodes should generally use
+eager warmup, so that block validation and mining are not slowed down while the hotstore is
+being primed. The warmup can also be skipped for a single start with the --no-warmup daemon flag.`,
+		},
 	},
 	"StorageMiner": {
 		{
@@ -1249,9 +1819,28 @@ HotstoreMaxSpaceTarget - HotstoreMaxSpaceSafetyBuffer`,
 			Name: "HarmonyDB",
 			Type: "HarmonyDB",
 
+			Comment: ``,
+		},
+		{
+			Name: "SectorAutoExtend",
+			Type: "SectorAutoExtendConfig",
+
 			Comment: ``,
 		},
 	},
+	"SyncConfig": {
+		{
+			Name: "ValidationParallelism",
+			Type: "int",
+
+			Comment: `ValidationParallelism is the number of blocks whose signatures, tickets, election proofs,
+beacon entries and winning PoSts are verified in parallel ahead of their validation while
+the node is catching up with the chain. The state of each tipset depends on the previous
+one, so tipsets are still fully validated in order, skipping the checks already done ahead.
+Tipsets within 20 epochs of the current epoch are only validated in order. 0 or 1 disables
+the parallel verification.`,
+		},
+	},
 	"Wallet": {
 		{
 			Name: "RemoteBackend",
@@ -1271,5 +1860,15 @@ HotstoreMaxSpaceTarget - HotstoreMaxSpaceSafetyBuffer`,
 
 			Comment: ``,
 		},
+		{
+			Name: "ExternalSigners",
+			Type: "map[string]string",
+
+			Comment: `ExternalSigners maps addresses to the URLs of external signers, e.g. a KMS or an HSM
+gateway, which hold their keys, so that the node only needs to know the addresses. Messages,
+including those sent by miners through the node, are signed by POSTing a JSON request with
+the address, the data to sign and its metadata to the URL, which must respond with the JSON
+encoded signature. Credentials can be passed as the userinfo of the URL.`,
+		},
 	},
 }
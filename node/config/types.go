@@ -27,6 +27,9 @@ type FullNode struct {
 	Events        EventsConfig
 	ChainIndexer  ChainIndexerConfig
 	FaultReporter FaultReporterConfig
+	MpoolAccept   MpoolAcceptConfig
+	Snapshots     SnapshotConfig
+	Sync          SyncConfig
 }
 
 // // Common
@@ -58,6 +61,8 @@ type StorageMiner struct {
 	Fees       MinerFeeConfig
 	Addresses  MinerAddressConfig
 	HarmonyDB  HarmonyDB
+
+	SectorAutoExtend SectorAutoExtendConfig
 }
 
 type ApisConfig struct {
@@ -85,6 +90,13 @@ type MinerSubsystemConfig struct {
 	// This is useful to allow workers to bypass the lotus miner to access sector information
 	EnableSectorIndexDB bool
 
+	// When enabled, the keys of the miner, like the API secret, are stored in the HarmonyDB
+	// database instead of the repo keystore, so that all miner nodes using the same database
+	// share them, and API tokens of one node are accepted by the others. The libp2p host key,
+	// which identifies the node, stays in the repo keystore.
+	// Existing keys can be copied between the keystores with 'lotus-shed harmonydb migrate-keystore'.
+	EnableKeyStoreDB bool
+
 	SealerApiInfo      string // if EnableSealing == false
 	SectorIndexApiInfo string // if EnableSectorStorage == false
 
@@ -141,6 +153,38 @@ type ProvingConfig struct {
 	// blocked or slow
 	PartitionCheckTimeout Duration
 
+	// Maximum number of partitions of a single WindowPoSt batch for which proofs are computed
+	// concurrently on window PoSt workers. (0 = unlimited, all partitions of a batch in parallel)
+	//
+	// Lower values reduce peak memory pressure on workers at the cost of a longer proving time,
+	// which increases the risk of missing the deadline for deadlines with many partitions.
+	// A warning is logged when proving if the connected window PoSt workers don't have enough
+	// memory to prove this many partitions at once.
+	// When no window PoSt workers are connected the limit applies to the lotus-miner process
+	// only if BuiltinPoStParallelReads is non-zero, and is then checked against the memory of this
+	// host; otherwise all partitions of a batch are proven in a single call and this setting has
+	// no effect.
+	MaxParallelPartitionProofs int
+
+	// Maximum number of sectors to read PoSt challenges from in parallel when window PoSt is computed by
+	// the lotus-miner process. (0 = disabled)
+	//
+	// By default the builtin prover only proves sectors in the storage paths of the lotus-miner host, and any
+	// sector it can't open fails the whole batch until the sector is found by the next retry. When set, the
+	// builtin prover reads challenges through the storage subsystem instead, in parallel, so sectors in storage
+	// paths of other hosts, e.g. attached to sealing workers, can be proven by reading just the challenged
+	// nodes over the network. Sectors whose challenges can't be read are skipped and declared faulty, without
+	// failing the partition.
+	//
+	// Higher values start proof computation sooner, but put more load on the network and disks of the
+	// storage hosts. This setting has no effect when window PoSt workers are connected, their parallel
+	// reads are set with the lotus-worker --post-parallel-reads option.
+	BuiltinPoStParallelReads int
+
+	// Maximum amount of time reading the challenges of a single sector can take when BuiltinPoStParallelReads
+	// is set. Sectors which time out are skipped and declared faulty. (0 = no timeout)
+	BuiltinPoStReadTimeout Duration
+
 	// Disable Window PoSt computation on the lotus-miner process even if no window PoSt workers are present.
 	//
 	// WARNING: If no windowPoSt workers are connected, window PoSt WILL FAIL resulting in faulty sectors which will need
@@ -156,6 +200,21 @@ type ProvingConfig struct {
 	// Before enabling this option, make sure your PoSt workers work correctly.
 	DisableBuiltinWinningPoSt bool
 
+	// Maximum amount of time Winning PoSt computation is expected to take. When computing the proof takes longer, a
+	// warning is logged, and the miner/winning_post_timeouts metric is incremented. The duration of each proof is
+	// reported in the miner/winning_post_ms metric. (0 = no timeout)
+	//
+	// Blocks must be produced before the end of the epoch to be included in the chain, so the proof, message selection
+	// and block creation together have less than the block time to complete.
+	WinningPoStTimeout Duration
+
+	// Abandon the mining round when Winning PoSt computation takes longer than WinningPoStTimeout, instead of
+	// producing a block which is likely to arrive too late to be accepted by the network. When workers compute the
+	// proof, the computation is cancelled.
+	//
+	// Note that an abandoned round means a lost block reward, even if the late block would have been accepted.
+	AbandonLateWinningPoSt bool
+
 	// Disable WindowPoSt provable sector readability checks.
 	//
 	// In normal operation, when preparing to compute WindowPoSt, lotus-miner will perform a round of reading challenges
@@ -201,6 +260,19 @@ type ProvingConfig struct {
 	// resulting in more total gas use (but each message will have lower gas limit)
 	MaxPartitionsPerRecoveryMessage int
 
+	// Maximum number of sectors to declare in a single DeclareFaultsRecovered message. 0 = no limit.
+	//
+	// Like MaxPartitionsPerRecoveryMessage, this keeps recovery messages within the block gas limit when many sectors
+	// recover at once; recovered sectors of a single partition may be split across multiple messages.
+	MaxSectorsPerRecoveryMessage int
+
+	// Disable automatic recovery declarations.
+	//
+	// By default, before each deadline the miner checks sectors which were marked faulty, e.g. after a transient storage
+	// outage, and declares those which are provable again as recovered. When this option is enabled, recoveries must
+	// be declared manually with 'lotus-miner proving recover-faults'.
+	DisableAutoRecoveryDeclarations bool
+
 	// Enable single partition per PoSt Message for partitions containing recovery sectors
 	//
 	// In cases when submitting PoSt messages which contain recovering sectors, the default network limit may still be
@@ -210,6 +282,18 @@ type ProvingConfig struct {
 	// Note that setting this value lower may result in less efficient gas use - more messages will be sent,
 	// to prove each deadline, resulting in more total gas use (but each message will have lower gas limit)
 	SingleRecoveringPartitionPerPostMessage bool
+
+	// Halt WindowPoSt submission for a deadline when more than this number of its live sectors are faulty, either
+	// declared faulty or skipped by the proof. 0 = always submit.
+	//
+	// When submission is halted, an alert is raised and the proofs are only submitted after the faults are
+	// acknowledged with 'lotus-miner proving ack-faults <deadline>'. Note that when no proof is submitted before the
+	// deadline closes, all sectors of the deadline are marked faulty.
+	HaltSubmissionFaultySectors uint64
+
+	// Halt WindowPoSt submission for a deadline when more than this fraction of its live sectors are faulty, for
+	// example 0.1 for 10%. Works like HaltSubmissionFaultySectors, 0 = always submit.
+	HaltSubmissionFaultyFraction float64
 }
 
 type SealingConfig struct {
@@ -269,6 +353,22 @@ type SealingConfig struct {
 	// After sealing CC sectors, make them available for upgrading with deals
 	MakeCCSectorsAvailable bool
 
+	// Strategy used to choose which of the sectors open for deals incoming deal pieces are packed into. One of:
+	//
+	// "least-padding" (default): prefer the sectors where the piece needs the least padding to be aligned, which
+	// wastes little space between pieces, but doesn't take the deal terms into account.
+	//
+	// "largest-first": assign the largest pending pieces first, then the smaller ones into the remaining space.
+	// With pieces of mixed sizes this usually fills sectors more completely, as small pieces can fill gaps which large
+	// pieces couldn't, at the cost of small pieces waiting longer to be assigned when many large pieces are pending.
+	//
+	// "by-expiration": prefer the sectors holding deals which end closest to the end of the deal of the piece, grouping
+	// deals with similar terms, so that sector lifetimes can be set close to their deals and sectors can be extended or
+	// expire without outliving most of their deals. This may leave more space for padding in sectors.
+	//
+	// The fill ratio of the sectors open for deals can be checked with 'lotus-miner sectors open'.
+	DealPackingStrategy string
+
 	// Whether to use available miner balance for sector collateral instead of sending it with each message
 	CollateralFromMinerBalance bool
 	// Minimum available balance to keep in the miner actor before sending it with messages
@@ -282,6 +382,10 @@ type SealingConfig struct {
 	PreCommitBatchWait Duration
 	// time buffer for forceful batch submission before sectors/deal in batch would start expiring
 	PreCommitBatchSlack Duration
+	// send the precommit batch as soon as the ticket of a sector in it is within this many epochs
+	// of expiring, even when the batch is below MaxPreCommitBatch and the base fee is above
+	// MaxPreCommitBaseFee. Expired tickets require sealing the sector again. Set to 0 to disable.
+	PreCommitBatchTicketExpiryEpochs uint64
 
 	// enable / disable commit aggregation (takes effect after nv13)
 	AggregateCommits bool
@@ -310,6 +414,11 @@ type SealingConfig struct {
 	// Submitting a smaller number of prove commits per epoch would reduce the possibility of failed msgs
 	MaxSectorProveCommitsSubmittedPerEpoch uint64
 
+	// Maximum number of times a prove commit message which landed on chain but failed with a transient error (out of
+	// gas / insufficient funds) will be re-estimated and resubmitted before the sector is moved to CommitFailed and
+	// requires manual intervention (0 = unlimited)
+	MaxCommitResubmits uint64
+
 	TerminateBatchMax  uint64
 	TerminateBatchMin  uint64
 	TerminateBatchWait Duration
@@ -330,11 +439,38 @@ type SealingConfig struct {
 	RequireNotificationSuccess bool
 	// Whether to abort if any piece activation notification returns a non-zero exit code (updating sectors, only with ProveReplicaUpdates3).
 	RequireNotificationSuccessUpdate bool
+
+	// ReplicaCheckSampleRate is the share of sectors, between 0 and 1, whose replica is verified after PreCommit 2,
+	// before the sector is pre-committed. The check generates a vanilla PoSt proof for randomly sampled challenges,
+	// which reads and verifies parts of the sealed replica against its CommR, to catch replicas corrupted by hardware
+	// errors before collateral is put down for them. Sectors failing the check are sent back to PreCommit 2.
+	// The check takes a few seconds to minutes per sector depending on storage, 0 disables it; values outside of 0..1
+	// are rejected when the sealing config is loaded.
+	ReplicaCheckSampleRate float64
 }
 
 type SealerConfig struct {
 	ParallelFetchLimit int
 
+	// FetchRetries is the number of times a sector file fetch from remote storage failing with a network
+	// error, or an error of a temporarily unavailable remote, is retried before trying other copies of
+	// the file or failing the task. Missing or corrupt files are not retried. 0 disables retries.
+	FetchRetries int
+	// FetchRetryBackoff is the wait before the first retry of a fetch, doubled after every retry.
+	FetchRetryBackoff Duration
+
+	// TaskTimeouts sets how long tasks may run on a worker, keyed by the short task type names shown
+	// by 'lotus-miner sealing jobs', e.g. PC1, PC2 or C2. Tasks running longer than their timeout are
+	// logged as slow. Tasks running longer than the timeout multiplied by TaskTimeoutGraceFactor are
+	// considered hung and aborted, failing the sealing step so that the sector is retried. Workers
+	// can't stop a running task, so the resources of an aborted task stay reserved on its worker
+	// until its late result arrives or the worker restarts. Task types without a timeout are never
+	// aborted.
+	TaskTimeouts map[string]Duration
+	// TaskTimeoutGraceFactor is the multiple of a task timeout after which a slow task is aborted.
+	// Must be at least 1.
+	TaskTimeoutGraceFactor float64
+
 	AllowSectorDownload      bool
 	AllowAddPiece            bool
 	AllowPreCommit1          bool
@@ -393,6 +529,19 @@ type MinerFeeConfig struct {
 	MaxMarketBalanceAddFee types.FIL
 
 	MaximizeWindowPoStFeeCap bool
+
+	// MaxPreCommitBaseFee is the network base fee above which sending of PreCommit batches is
+	// delayed, until either the base fee drops or a sector in the batch gets within
+	// PreCommitBatchSlack of its precommit deadline. Set to 0 to disable.
+	MaxPreCommitBaseFee types.FIL
+	// MaxCommitBaseFee is the network base fee above which sending of ProveCommit batches is
+	// delayed, until either the base fee drops or a sector in the batch gets within
+	// CommitBatchSlack of its commit deadline. Set to 0 to disable.
+	MaxCommitBaseFee types.FIL
+	// MaxWindowPoStBaseFee is the network base fee above which submission of WindowPoSt proofs
+	// is delayed, until either the base fee drops or the deadline is about to close. Set to 0 to
+	// disable.
+	MaxWindowPoStBaseFee types.FIL
 }
 
 type MinerAddressConfig struct {
@@ -411,6 +560,55 @@ type MinerAddressConfig struct {
 	// A control address that doesn't have enough funds will still be chosen
 	// over the worker address if this flag is set.
 	DisableWorkerFallback bool
+
+	// TopUp configures automatic top-ups of the worker and control addresses
+	TopUp ControlTopUpConfig
+}
+
+type ControlTopUpConfig struct {
+	// Enable sending funds from FundingAddress to the worker and control
+	// addresses when their balance drops below Threshold.
+	Enable bool
+	// FundingAddress is the address top-ups are sent from. It must be in the
+	// wallet of the node.
+	FundingAddress string
+	// Addresses to top up. When empty, the worker and all control addresses
+	// of the miner are topped up.
+	Addresses []string
+	// Threshold is the balance below which an address is topped up.
+	Threshold types.FIL
+	// TargetBalance is the balance an address is topped up to.
+	TargetBalance types.FIL
+	// MaxTopUp is the maximum amount sent in a single top-up, 0 for no limit.
+	MaxTopUp types.FIL
+	// CheckInterval is how often the balances of the addresses are checked.
+	CheckInterval Duration
+	// MinTopUpInterval is the minimum time between two top-ups of the same
+	// address. It should be long enough for a top-up message to land on chain,
+	// so that the address isn't topped up again before its balance is updated.
+	MinTopUpInterval Duration
+}
+
+type SectorAutoExtendConfig struct {
+	// Enable periodically extending active sectors which are about to expire. Extensions are sent
+	// from the worker address, and logged with the extended sectors and message CID.
+	Enable bool
+	// ExpiringWithin is how long before their expiration sectors are extended.
+	ExpiringWithin Duration
+	// TargetLifetime is how long from now sectors are extended to expire in, capped by the maximum
+	// extension and sector lifetime allowed by the network. Sectors with verified claims ending
+	// before that are left to be extended manually with 'sectors extend'.
+	TargetLifetime Duration
+	// CheckInterval is how often the miner checks for expiring sectors.
+	CheckInterval Duration
+	// MaxSectorsPerRun is the maximum number of sectors extended in one check, 0 for no limit.
+	// Sectors expiring first are extended first.
+	MaxSectorsPerRun int
+	// MaxGasFeePerDay is the maximum amount of gas fees spent on extension messages within 24
+	// hours, 0 for no limit.
+	MaxGasFeePerDay types.FIL
+	// ExcludeSectors is a list of sector numbers which are never extended automatically.
+	ExcludeSectors []uint64
 }
 
 // API contains configs for API endpoint
@@ -419,6 +617,17 @@ type API struct {
 	ListenAddress       string
 	RemoteListenAddress string
 	Timeout             Duration
+
+	// RPCStats enables recording the number of calls and cumulative duration of each RPC method
+	// per client, returned by the RPCStats API method. Set to "connection" to attribute calls to
+	// the remote address of the connection, "token" to attribute calls to the API token used, or
+	// "disabled".
+	RPCStats string
+	// ShutdownDrainTimeout is the time RPC calls in flight are given to complete when the node
+	// shuts down. While draining, new connections are refused and new calls on open connections
+	// fail with a "node shutting down" error; the server is closed once the calls complete or
+	// the timeout expires. 0 closes the server without waiting.
+	ShutdownDrainTimeout Duration
 }
 
 // Libp2p contains configs for libp2p
@@ -452,6 +661,16 @@ type Libp2p struct {
 	// ConnMgrGrace is a time duration that new connections are immune from being
 	// closed by the connection manager.
 	ConnMgrGrace Duration
+
+	// MaxConnsPerIPv4Subnet is the maximum number of connections from a single /24
+	// IPv4 subnet; inbound connections over the limit are refused. 0 means no limit.
+	MaxConnsPerIPv4Subnet int
+	// MaxConnsPerIPv6Subnet is the maximum number of connections from a single /64
+	// IPv6 subnet; inbound connections over the limit are refused. 0 means no limit.
+	MaxConnsPerIPv6Subnet int
+	// SubnetLimitExemptPeers lists the IDs of peers which are never refused because
+	// of the subnet connection limits; their connections don't count towards the limits.
+	SubnetLimitExemptPeers []string
 }
 
 type Pubsub struct {
@@ -479,11 +698,26 @@ type Pubsub struct {
 	ElasticSearchIndex string
 	// Auth token that will be passed with logs to elasticsearch - used for weighted peers score.
 	TracerSourceAuth string
+
+	// BlocksTopicMaxMessageSize is the maximum size in bytes of the messages on the blocks topic,
+	// which carry block headers and the CIDs of their messages. It can be raised on networks with
+	// larger blocks, and must fit a block with the maximum number of messages (446384 bytes).
+	BlocksTopicMaxMessageSize int
+	// MessagesTopicMaxMessageSize is the maximum size in bytes of the messages on the messages
+	// topic, and of the messages accepted into the message pool. It can't be lower than the
+	// default maximum size of a message (65536 bytes).
+	MessagesTopicMaxMessageSize int
 }
 
 type Chainstore struct {
 	EnableSplitstore bool
 	Splitstore       Splitstore
+
+	// ReorgAlertDepth is the depth in epochs, between the previous head and the common ancestor,
+	// above which reorgs observed by the node are logged as warnings and recorded in the journal
+	// as sync/deep_reorg events. The depth of every reorg is reported in the lotus_reorg_depth
+	// metric regardless of this setting. 0 disables the warnings.
+	ReorgAlertDepth int
 }
 
 type Splitstore struct {
@@ -523,6 +757,25 @@ type Splitstore struct {
 	// is set.  Moving GC will not occur when total moving size exceeds
 	// HotstoreMaxSpaceTarget - HotstoreMaxSpaceSafetyBuffer
 	HotstoreMaxSpaceSafetyBuffer uint64
+
+	// CompactionWindows restricts when splitstore compaction may start, as a list of daily
+	// windows of local time in the HH:MM-HH:MM format, e.g. ["01:00-05:00"]; windows may wrap
+	// around midnight. A compaction which becomes due outside of all windows is deferred until
+	// the next window opens, which keeps heavy I/O out of busy periods such as proving windows.
+	// Note that a compaction may run past the end of the window it was started in.
+	// Empty (the default) allows compaction to start at any time.
+	CompactionWindows []string
+
+	// WarmupMode specifies how the hotstore is warmed up when the splitstore is first enabled on
+	// top of an existing blockstore. It can be "eager" (default), which copies the state at the
+	// current head and the chain headers from the coldstore in the background at startup, or
+	// "lazy", which skips the copy and instead moves objects to the hotstore as they are accessed
+	// during state computation.
+	// Lazy warmup avoids a long I/O burst at startup, at the cost of slower reads from the
+	// coldstore until the objects have been accessed. Block producing nodes should generally use
+	// eager warmup, so that block validation and mining are not slowed down while the hotstore is
+	// being primed. The warmup can also be skipped for a single start with the --no-warmup daemon flag.
+	WarmupMode string
 }
 
 // Full Node
@@ -531,10 +784,101 @@ type Wallet struct {
 	RemoteBackend string
 	EnableLedger  bool
 	DisableLocal  bool
+
+	// ExternalSigners maps addresses to the URLs of external signers, e.g. a KMS or an HSM
+	// gateway, which hold their keys, so that the node only needs to know the addresses. Messages,
+	// including those sent by miners through the node, are signed by POSTing a JSON request with
+	// the address, the data to sign and its metadata to the URL, which must respond with the JSON
+	// encoded signature. Credentials can be passed as the userinfo of the URL.
+	ExternalSigners map[string]string
 }
 
 type FeeConfig struct {
 	DefaultMaxFee types.FIL
+
+	// GasPremiumMultipliers maps sender addresses to a multiplier applied to the gas premium
+	// estimated for their messages, e.g. {"f3abc..." = 1.5}, so that messages from high-priority
+	// addresses are given a higher premium automatically. Multipliers must be positive, and
+	// senders without an entry use a multiplier of 1.
+	// Only estimated premiums are affected: messages with an explicitly set GasPremium are sent
+	// with that premium, and the fee cap still respects the MaxFee of the message send spec.
+	GasPremiumMultipliers map[string]float64
+
+	// AutoBump replaces messages stuck in the mpool with copies paying a higher gas premium.
+	AutoBump FeeBumpConfig
+}
+
+type FeeBumpConfig struct {
+	// Enable enables automatic fee bumping of messages which are pending for more than
+	// StuckEpochs epochs. By default only messages sent automatically by the node or the miners
+	// using it, e.g. WindowPoSt, PreCommit and ProveCommit messages, are bumped. They are marked
+	// in memory when they are signed, so those sent before the node was restarted are not bumped
+	// unless BumpPushedMessages is set. Each bump is logged.
+	Enable bool
+	// StuckEpochs is the number of epochs a message has to be pending for before it is bumped.
+	// Replacements are bumped again when they are pending for as long.
+	StuckEpochs int
+	// MaxFee is the maximum fee, the gas fee cap times the gas limit, of a bumped message.
+	// Messages which can't be bumped by the minimum replace-by-fee premium increase within this
+	// fee are left pending.
+	MaxFee types.FIL
+	// BumpPushedMessages also bumps all other messages whose sender key is in the node wallet,
+	// e.g. messages sent with lotus send, or signed elsewhere and pushed with MpoolPush.
+	BumpPushedMessages bool
+	// CheckInterval is the interval at which the mpool is checked for stuck messages.
+	CheckInterval Duration
+}
+
+type MpoolAcceptConfig struct {
+	// EnableStrictAccept enables the rules below for messages pushed through the
+	// MpoolPush, MpoolPushUntrusted and batch push API methods, which rejects
+	// messages matching any enabled rule before they are added to the mpool.
+	// Messages signed by the node with MpoolPushMessage, and messages received
+	// from the network, are not checked.
+	EnableStrictAccept bool
+	// MaxGasLimit rejects messages with a gas limit above this value, 0 for no
+	// limit other than the block gas limit.
+	MaxGasLimit int64
+	// RejectZeroPremium rejects messages with a zero gas premium.
+	RejectZeroPremium bool
+	// RejectUnknownMethods rejects messages calling a method which the
+	// recipient actor doesn't export, for built-in recipient actors. Messages to
+	// user actors and to addresses without an actor are not checked.
+	RejectUnknownMethods bool
+}
+
+type SnapshotConfig struct {
+	// EnableAutoSnapshots enables periodic generation of chain snapshots. Snapshots are
+	// generated in the background, one at a time, and written to a temporary file which is
+	// renamed once the snapshot is complete.
+	EnableAutoSnapshots bool
+	// Path is the directory snapshots are written to. Relative paths are relative to the
+	// repo directory.
+	Path string
+	// IntervalEpochs is the number of epochs between snapshots. Snapshots are taken at
+	// heights which are multiples of this number.
+	IntervalEpochs int
+	// Lookback is the number of epochs a snapshot height must be below the head before the
+	// snapshot is generated, so that snapshots are not taken of tipsets which can be reorged.
+	Lookback int
+	// RecentStateRoots is the number of recent state roots included in snapshots, which
+	// must be at least the chain finality.
+	RecentStateRoots int
+	// SkipOldMessages excludes messages older than the recent state roots from snapshots.
+	SkipOldMessages bool
+	// Retain is the number of most recent snapshots kept in Path, older snapshots are
+	// removed once a new snapshot is complete. 0 keeps all snapshots.
+	Retain int
+}
+
+type SyncConfig struct {
+	// ValidationParallelism is the number of blocks whose signatures, tickets, election proofs,
+	// beacon entries and winning PoSts are verified in parallel ahead of their validation while
+	// the node is catching up with the chain. The state of each tipset depends on the previous
+	// one, so tipsets are still fully validated in order, skipping the checks already done ahead.
+	// Tipsets within 20 epochs of the current epoch are only validated in order. 0 or 1 disables
+	// the parallel verification.
+	ValidationParallelism int
 }
 
 type FevmConfig struct {
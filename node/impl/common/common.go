@@ -2,6 +2,9 @@ package common
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"time"
 
 	"github.com/gbrlsnchs/jwt/v3"
@@ -17,6 +20,7 @@ import (
 	"github.com/filecoin-project/lotus/build"
 	"github.com/filecoin-project/lotus/build/buildconstants"
 	"github.com/filecoin-project/lotus/journal/alerting"
+	"github.com/filecoin-project/lotus/metrics/rpcstats"
 	"github.com/filecoin-project/lotus/node/modules/dtypes"
 )
 
@@ -85,6 +89,105 @@ func (a *CommonAPI) LogAlerts(ctx context.Context) ([]alerting.Alert, error) {
 	return a.Alerting.GetAlerts(), nil
 }
 
+func (a *CommonAPI) LogStream(ctx context.Context, level string, subsystems []string) (<-chan api.LogEntry, error) {
+	lvl, err := logging.LevelFromString(level)
+	if err != nil {
+		return nil, xerrors.Errorf("parsing log level: %w", err)
+	}
+
+	pr := logging.NewPipeReader(logging.PipeFormat(logging.JSONOutput), logging.PipeLevel(lvl))
+	return streamLogs(ctx, pr, subsystems), nil
+}
+
+func (a *CommonAPI) RPCStats(ctx context.Context) ([]api.RPCClientStats, error) {
+	return rpcstats.Snapshot(), nil
+}
+
+// logStreamBuffer is the number of log entries buffered for slow LogStream clients before entries
+// are dropped.
+const logStreamBuffer = 1024
+
+// streamLogs decodes the JSON log entries from the reader, which is closed when the context is
+// done. The reader is drained without blocking, as log entries are written to it synchronously by
+// all loggers. Nothing can be logged here as it would be written back to the reader.
+func streamLogs(ctx context.Context, r io.ReadCloser, subsystems []string) <-chan api.LogEntry {
+	out := make(chan api.LogEntry, logStreamBuffer)
+
+	filter := map[string]struct{}{}
+	for _, s := range subsystems {
+		filter[s] = struct{}{}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		_ = r.Close()
+	}()
+
+	go func() {
+		defer close(out)
+		defer close(done)
+
+		var dropped int
+		dec := json.NewDecoder(r)
+		for {
+			var raw map[string]interface{}
+			if err := dec.Decode(&raw); err != nil {
+				return
+			}
+
+			e := logEntry(raw)
+			if _, ok := filter[e.Logger]; len(filter) > 0 && !ok {
+				continue
+			}
+
+			if dropped > 0 {
+				select {
+				case out <- api.LogEntry{
+					Time:    e.Time,
+					Level:   "warn",
+					Logger:  "logstream",
+					Message: fmt.Sprintf("dropped %d log entries, the client is too slow", dropped),
+				}:
+					dropped = 0
+				default:
+				}
+			}
+
+			select {
+			case out <- e:
+			default:
+				dropped++
+			}
+		}
+	}()
+
+	return out
+}
+
+func logEntry(raw map[string]interface{}) api.LogEntry {
+	str := func(k string) string {
+		s, _ := raw[k].(string)
+		delete(raw, k)
+		return s
+	}
+
+	e := api.LogEntry{
+		Level:   str("level"),
+		Logger:  str("logger"),
+		Caller:  str("caller"),
+		Message: str("msg"),
+	}
+	e.Time, _ = time.Parse("2006-01-02T15:04:05.000Z0700", str("ts"))
+	if len(raw) > 0 {
+		e.Fields = raw
+	}
+	return e
+}
+
 func (a *CommonAPI) Shutdown(ctx context.Context) error {
 	a.ShutdownChan <- struct{}{}
 	return nil
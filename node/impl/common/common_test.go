@@ -0,0 +1,65 @@
+package common
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamLogs(t *testing.T) {
+	r, w := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	entries := streamLogs(ctx, r, []string{"chain"})
+
+	_, err := io.WriteString(w, `{"level":"info","ts":"2024-05-01T10:00:00.000Z","logger":"miner","msg":"skipped"}
+{"level":"warn","ts":"2024-05-01T10:00:01.500Z","logger":"chain","caller":"store/store.go:10","msg":"reorg","depth":3}
+`)
+	require.NoError(t, err)
+
+	e := <-entries
+	require.Equal(t, "warn", e.Level)
+	require.Equal(t, "chain", e.Logger)
+	require.Equal(t, "store/store.go:10", e.Caller)
+	require.Equal(t, "reorg", e.Message)
+	require.Equal(t, map[string]interface{}{"depth": float64(3)}, e.Fields)
+	require.True(t, e.Time.Equal(time.Date(2024, 5, 1, 10, 0, 1, 500_000_000, time.UTC)))
+
+	// the stream detaches and closes when the context is done
+	cancel()
+	_, ok := <-entries
+	require.False(t, ok)
+	_, err = io.WriteString(w, "{}\n")
+	require.ErrorIs(t, err, io.ErrClosedPipe)
+}
+
+func TestLogStream(t *testing.T) {
+	logging.SetupLogging(logging.Config{Level: logging.LevelInfo, Stderr: true})
+	log := logging.Logger("logstream-test")
+	require.NoError(t, logging.SetLogLevel("logstream-test", "debug"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a := &CommonAPI{}
+	entries, err := a.LogStream(ctx, "info", []string{"logstream-test"})
+	require.NoError(t, err)
+
+	log.Debugw("below level")
+	log.Infow("streamed", "key", "value")
+
+	e := <-entries
+	require.Equal(t, "streamed", e.Message)
+	require.Equal(t, "info", e.Level)
+	require.Equal(t, "value", e.Fields["key"])
+
+	cancel()
+	for range entries {
+		// drain until the stream is closed
+	}
+
+	_, err = a.LogStream(ctx, "loud", nil)
+	require.Error(t, err)
+}
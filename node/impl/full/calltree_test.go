@@ -0,0 +1,110 @@
+package full
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/go-state-types/manifest"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/actors"
+	"github.com/filecoin-project/lotus/chain/consensus"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+)
+
+func TestCallTreeNode(t *testing.T) {
+	from, to := mock.Address(100), mock.Address(101)
+
+	accountCode, ok := actors.GetActorCodeID(actorstypes.Version16, manifest.AccountKey)
+	require.True(t, ok)
+
+	gas := func(amounts ...int64) []*types.GasTrace {
+		var out []*types.GasTrace
+		for _, a := range amounts {
+			out = append(out, &types.GasTrace{Name: "charge", TotalGas: a})
+		}
+		return out
+	}
+
+	trace := types.ExecutionTrace{
+		Msg:          types.MessageTrace{From: from, To: to, Value: big.NewInt(10), Method: builtin.MethodSend, GasLimit: 1000},
+		InvokedActor: &types.ActorTrace{Id: 101, State: types.Actor{Code: accountCode}},
+		GasCharges:   gas(100, 20),
+		Subcalls: []types.ExecutionTrace{
+			{
+				Msg:        types.MessageTrace{From: to, To: from, Value: big.Zero(), Method: 2, GasLimit: 500},
+				MsgRct:     types.ReturnTrace{ExitCode: exitcode.ErrForbidden},
+				GasCharges: gas(5),
+				Subcalls: []types.ExecutionTrace{
+					{Msg: types.MessageTrace{From: from, To: to, Value: big.Zero(), Method: abi.MethodNum(3)}, GasCharges: gas(1, 2)},
+				},
+			},
+			{Msg: types.MessageTrace{From: to, To: to, Value: big.Zero()}, GasCharges: gas(7)},
+		},
+	}
+
+	root := callTreeNode(consensus.NewActorRegistry(), trace)
+
+	require.Equal(t, from, root.From)
+	require.Equal(t, to, root.To)
+	require.Equal(t, "fil/16/account", root.Actor)
+	require.Equal(t, "Send", root.MethodName)
+	require.Equal(t, int64(120), root.GasUsed)
+	require.Equal(t, int64(135), root.TotalGasUsed)
+	require.Len(t, root.Subcalls, 2)
+
+	sub := root.Subcalls[0]
+	require.Equal(t, exitcode.ErrForbidden, sub.ExitCode)
+	require.Empty(t, sub.Actor)
+	require.Equal(t, int64(5), sub.GasUsed)
+	require.Equal(t, int64(8), sub.TotalGasUsed)
+	require.Len(t, sub.Subcalls, 1)
+	require.Equal(t, int64(3), sub.Subcalls[0].TotalGasUsed)
+
+	require.Equal(t, int64(7), root.Subcalls[1].TotalGasUsed)
+	require.Empty(t, root.Subcalls[1].Subcalls)
+}
+
+func TestCallTreeGasMismatch(t *testing.T) {
+	from, to := mock.Address(100), mock.Address(101)
+	msg := &types.Message{From: from, To: to, Value: big.Zero()}
+
+	res := func(exit exitcode.ExitCode, gasUsed int64) *api.InvocResult {
+		return &api.InvocResult{
+			MsgCid: msg.Cid(),
+			Msg:    msg,
+			MsgRct: &types.MessageReceipt{ExitCode: exit, GasUsed: gasUsed},
+			ExecutionTrace: types.ExecutionTrace{
+				Msg:        types.MessageTrace{From: from, To: to, Value: big.Zero()},
+				GasCharges: []*types.GasTrace{{Name: "OnChainMessage", TotalGas: 100}, {Name: "OnMethodInvocation", TotalGas: 20}},
+				Subcalls: []types.ExecutionTrace{
+					{Msg: types.MessageTrace{From: to, To: from, Value: big.Zero()}, GasCharges: []*types.GasTrace{{Name: "OnMethodInvocation", TotalGas: 30}}},
+				},
+			},
+		}
+	}
+
+	// the trace accounts for all of the gas charged to the message
+	tree := callTree(consensus.NewActorRegistry(), res(exitcode.Ok, 150))
+	require.Equal(t, msg.Cid(), tree.MsgCid)
+	require.Equal(t, exitcode.Ok, tree.ExitCode)
+	require.Equal(t, int64(150), tree.GasUsed)
+	require.Equal(t, int64(150), tree.TraceGasUsed)
+	require.False(t, tree.GasMismatch)
+
+	// the trace of a message running out of gas misses some of the charges, but the tree is
+	// still returned
+	tree = callTree(consensus.NewActorRegistry(), res(exitcode.SysErrOutOfGas, 1000))
+	require.Equal(t, exitcode.SysErrOutOfGas, tree.ExitCode)
+	require.Equal(t, int64(1000), tree.GasUsed)
+	require.Equal(t, int64(150), tree.TraceGasUsed)
+	require.True(t, tree.GasMismatch)
+	require.Len(t, tree.Root.Subcalls, 1)
+}
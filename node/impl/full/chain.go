@@ -629,17 +629,107 @@ func (a ChainAPI) ChainExportRangeInternal(ctx context.Context, head, tail types
 	return nil
 }
 
+// maxBaseFeeHistorySamples is the maximum number of samples ChainGetBaseFeeHistory returns, a day
+// of tipsets
+const maxBaseFeeHistorySamples = 2880
+
+func (a *ChainAPI) ChainGetBaseFeeHistory(ctx context.Context, fromHeight, toHeight, step abi.ChainEpoch) ([]api.BaseFeeSample, error) {
+	return baseFeeHistory(ctx, a.Chain.GetTipsetByHeight, a.Chain.GetHeaviestTipSet(), fromHeight, toHeight, step)
+}
+
+type tipsetByHeightFunc func(ctx context.Context, h abi.ChainEpoch, ts *types.TipSet, prev bool) (*types.TipSet, error)
+
+func baseFeeHistory(ctx context.Context, byHeight tipsetByHeightFunc, head *types.TipSet, from, to, step abi.ChainEpoch) ([]api.BaseFeeSample, error) {
+	if step <= 0 {
+		return nil, xerrors.Errorf("step must be positive, got %d", step)
+	}
+	if from < 0 || to < from {
+		return nil, xerrors.Errorf("invalid height range %d-%d", from, to)
+	}
+	if from > head.Height() {
+		return nil, xerrors.Errorf("from height %d is above the head at %d", from, head.Height())
+	}
+	if to > head.Height() {
+		to = head.Height()
+	}
+
+	n := int64((to-from)/step) + 1
+	if n > maxBaseFeeHistorySamples {
+		return nil, xerrors.Errorf("range %d-%d with step %d has %d samples, more than the maximum of %d", from, to, step, n, maxBaseFeeHistorySamples)
+	}
+
+	// sample from the top, each tipset lookup starting from the previous sample
+	out := make([]api.BaseFeeSample, 0, n)
+	ts := head
+	for h := from + abi.ChainEpoch(n-1)*step; h >= from; h -= step {
+		if h < ts.Height() {
+			var err error
+			ts, err = byHeight(ctx, h, ts, true)
+			if err != nil {
+				return nil, xerrors.Errorf("loading tipset at height %d: %w", h, err)
+			}
+		}
+		if len(out) > 0 && out[len(out)-1].Height == ts.Height() {
+			// the sampled heights were null rounds after the same tipset
+			continue
+		}
+		out = append(out, api.BaseFeeSample{
+			Height:  ts.Height(),
+			BaseFee: ts.Blocks()[0].ParentBaseFee,
+		})
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out, nil
+}
+
 func (a *ChainAPI) ChainExport(ctx context.Context, nroots abi.ChainEpoch, skipoldmsgs bool, tsk types.TipSetKey) (<-chan []byte, error) {
 	ts, err := a.Chain.GetTipSetFromKey(ctx, tsk)
 	if err != nil {
 		return nil, xerrors.Errorf("loading tipset %s: %w", tsk, err)
 	}
+
+	return exportStream(ctx, func(w io.Writer) error {
+		return a.Chain.Export(ctx, ts, nroots, skipoldmsgs, w)
+	}), nil
+}
+
+func (a *ChainAPI) ChainExportActorState(ctx context.Context, addr address.Address, tsk types.TipSetKey) (<-chan []byte, error) {
+	ts, err := a.Chain.GetTipSetFromKey(ctx, tsk)
+	if err != nil {
+		return nil, xerrors.Errorf("loading tipset %s: %w", tsk, err)
+	}
+
+	return exportStream(ctx, func(w io.Writer) error {
+		return a.Chain.ExportActorState(ctx, ts, addr, w)
+	}), nil
+}
+
+func (a *ChainAPI) ChainExportHeaders(ctx context.Context, tail abi.ChainEpoch, tsk types.TipSetKey) (<-chan []byte, error) {
+	ts, err := a.Chain.GetTipSetFromKey(ctx, tsk)
+	if err != nil {
+		return nil, xerrors.Errorf("loading tipset %s: %w", tsk, err)
+	}
+	if tail < 0 || tail > ts.Height() {
+		return nil, xerrors.Errorf("tail height %d out of range [0, %d]", tail, ts.Height())
+	}
+
+	return exportStream(ctx, func(w io.Writer) error {
+		return a.Chain.ExportHeaders(ctx, ts, tail, w)
+	}), nil
+}
+
+// exportStream streams the output of export in chunks; an empty chunk is sent once the export
+// has completed successfully.
+func exportStream(ctx context.Context, export func(w io.Writer) error) <-chan []byte {
 	r, w := io.Pipe()
 	out := make(chan []byte)
 	go func() {
 		bw := bufio.NewWriterSize(w, 1<<20)
 
-		err := a.Chain.Export(ctx, ts, nroots, skipoldmsgs, bw)
+		err := export(bw)
 		_ = bw.Flush()            // it is a write to a pipe
 		_ = w.CloseWithError(err) // it is a pipe
 	}()
@@ -675,7 +765,7 @@ func (a *ChainAPI) ChainExport(ctx context.Context, nroots abi.ChainEpoch, skipo
 		}
 	}()
 
-	return out, nil
+	return out
 }
 
 func (a *ChainAPI) ChainCheckBlockstore(ctx context.Context) error {
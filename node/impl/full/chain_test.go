@@ -0,0 +1,72 @@
+package full
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+)
+
+func TestBaseFeeHistory(t *testing.T) {
+	ctx := context.Background()
+
+	// tipsets at heights 0-20, with null rounds at 5-7, and a base fee of 100+height
+	tipsets := map[abi.ChainEpoch]*types.TipSet{}
+	var head *types.TipSet
+	for h := abi.ChainEpoch(0); h <= 20; h++ {
+		if h >= 5 && h <= 7 {
+			continue
+		}
+		blk := mock.MkBlock(nil, 0, uint64(h))
+		blk.Height = h
+		blk.ParentBaseFee = types.NewInt(uint64(100 + h))
+		head = mock.TipSet(blk)
+		tipsets[h] = head
+	}
+	byHeight := func(_ context.Context, h abi.ChainEpoch, ts *types.TipSet, prev bool) (*types.TipSet, error) {
+		require.True(t, prev)
+		require.LessOrEqual(t, h, ts.Height())
+		for ; h >= 0; h-- {
+			if ts, ok := tipsets[h]; ok {
+				return ts, nil
+			}
+		}
+		t.Fatal("no tipset")
+		return nil, nil
+	}
+	sample := func(h abi.ChainEpoch) api.BaseFeeSample {
+		return api.BaseFeeSample{Height: h, BaseFee: types.NewInt(uint64(100 + h))}
+	}
+
+	out, err := baseFeeHistory(ctx, byHeight, head, 0, 12, 3)
+	require.NoError(t, err)
+	require.Equal(t, []api.BaseFeeSample{sample(0), sample(3), sample(4), sample(9), sample(12)}, out)
+
+	// null rounds sampled after the same tipset are returned once, and the range is capped at
+	// the head
+	out, err = baseFeeHistory(ctx, byHeight, head, 4, 100, 1)
+	require.NoError(t, err)
+	require.Len(t, out, 14)
+	require.Equal(t, sample(4), out[0])
+	require.Equal(t, sample(8), out[1])
+	require.Equal(t, sample(20), out[13])
+
+	_, err = baseFeeHistory(ctx, byHeight, head, 0, 10, 0)
+	require.Error(t, err)
+	_, err = baseFeeHistory(ctx, byHeight, head, 10, 5, 1)
+	require.Error(t, err)
+	_, err = baseFeeHistory(ctx, byHeight, head, 21, 30, 1)
+	require.Error(t, err)
+
+	// too many samples
+	blk := mock.MkBlock(nil, 0, 0)
+	blk.Height = 10000
+	_, err = baseFeeHistory(ctx, byHeight, mock.TipSet(blk), 0, 10000, 3)
+	require.ErrorContains(t, err, "more than the maximum")
+}
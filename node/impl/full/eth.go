@@ -1088,6 +1088,13 @@ func ethSendRawTransaction(ctx context.Context, mpool MpoolAPI, indexer index.In
 		return ethtypes.EmptyEthHash, err
 	}
 
+	// EIP-1559 fees map onto the fee cap and premium of the message, check them here to
+	// reject invalid transactions with the error Ethereum clients expect
+	if tx, ok := txArgs.(*ethtypes.Eth1559TxArgs); ok && tx.MaxPriorityFeePerGas.GreaterThan(tx.MaxFeePerGas) {
+		return ethtypes.EmptyEthHash, api.NewErrEthTxRejected("max priority fee per gas higher than max fee per gas",
+			xerrors.Errorf("maxPriorityFeePerGas: %s, maxFeePerGas: %s", tx.MaxPriorityFeePerGas, tx.MaxFeePerGas))
+	}
+
 	txHash, err := txArgs.TxHash()
 	if err != nil {
 		return ethtypes.EmptyEthHash, err
@@ -1100,11 +1107,11 @@ func ethSendRawTransaction(ctx context.Context, mpool MpoolAPI, indexer index.In
 
 	if untrusted {
 		if _, err = mpool.MpoolPushUntrusted(ctx, smsg); err != nil {
-			return ethtypes.EmptyEthHash, err
+			return ethtypes.EmptyEthHash, ethTxRejectedError(err)
 		}
 	} else {
 		if _, err = mpool.MpoolPush(ctx, smsg); err != nil {
-			return ethtypes.EmptyEthHash, err
+			return ethtypes.EmptyEthHash, ethTxRejectedError(err)
 		}
 	}
 
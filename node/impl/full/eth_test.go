@@ -2,6 +2,7 @@ package full
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"testing"
@@ -14,6 +15,9 @@ import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/build/buildconstants"
+	"github.com/filecoin-project/lotus/chain/messagepool"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/types/ethtypes"
 )
@@ -293,3 +297,37 @@ func TestDecodePayload(t *testing.T) {
 	_, err = decodePayload(w.Bytes(), 42)
 	require.Error(t, err)
 }
+
+func TestEthSendRawTransactionFees(t *testing.T) {
+	to, err := ethtypes.ParseEthAddress("0x5cbeecf99d3fdb3f25e309cc264f240bb0664031")
+	require.NoError(t, err)
+
+	tx := ethtypes.Eth1559TxArgs{
+		ChainID:              buildconstants.Eip155ChainId,
+		To:                   &to,
+		Value:                big.NewInt(1),
+		MaxFeePerGas:         big.NewInt(100),
+		MaxPriorityFeePerGas: big.NewInt(200),
+		GasLimit:             21000,
+		V:                    big.NewInt(1),
+		R:                    big.NewInt(1),
+		S:                    big.NewInt(1),
+	}
+	raw, err := tx.ToRlpSignedMsg()
+	require.NoError(t, err)
+
+	_, err = ethSendRawTransaction(context.Background(), MpoolAPI{}, nil, raw, false)
+	var rejected *api.ErrEthTxRejected
+	require.ErrorAs(t, err, &rejected)
+	require.Contains(t, rejected.Message, "max priority fee per gas higher than max fee per gas")
+}
+
+func TestEthTxRejectedError(t *testing.T) {
+	err := ethTxRejectedError(fmt.Errorf("minimum expected nonce is 5: %w", messagepool.ErrNonceTooLow))
+	var rejected *api.ErrEthTxRejected
+	require.ErrorAs(t, err, &rejected)
+	require.Equal(t, "nonce too low: minimum expected nonce is 5: message nonce too low", rejected.Message)
+
+	other := fmt.Errorf("something else")
+	require.Equal(t, other, ethTxRejectedError(other))
+}
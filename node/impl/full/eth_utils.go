@@ -24,6 +24,7 @@ import (
 	"github.com/filecoin-project/lotus/chain/actors"
 	"github.com/filecoin-project/lotus/chain/actors/builtin"
 	"github.com/filecoin-project/lotus/chain/actors/policy"
+	"github.com/filecoin-project/lotus/chain/messagepool"
 	"github.com/filecoin-project/lotus/chain/state"
 	"github.com/filecoin-project/lotus/chain/store"
 	"github.com/filecoin-project/lotus/chain/types"
@@ -799,3 +800,26 @@ func encodeAsABIHelper(param1 uint64, param2 uint64, data []byte) []byte {
 
 	return buf
 }
+
+// ethTxRejectedError translates message pool errors for Ethereum transactions into the errors
+// Ethereum clients return for the same rejection. Other errors are returned unchanged.
+func ethTxRejectedError(err error) error {
+	for _, e := range []struct {
+		err    error
+		reason string
+	}{
+		{messagepool.ErrNonceTooLow, "nonce too low"},
+		{messagepool.ErrNonceGap, "nonce too high"},
+		{messagepool.ErrNotEnoughFunds, "insufficient funds for gas * price + value"},
+		{messagepool.ErrGasFeeCapTooLow, "max fee per gas less than block base fee"},
+		{messagepool.ErrRBFTooLowPremium, "replacement transaction underpriced"},
+		{messagepool.ErrExistingNonce, "already known"},
+		{messagepool.ErrTooManyPendingMessages, "txpool is full"},
+		{messagepool.ErrMessageTooBig, "oversized data"},
+	} {
+		if errors.Is(err, e.err) {
+			return api.NewErrEthTxRejected(e.reason, err)
+		}
+	}
+	return err
+}
@@ -43,6 +43,8 @@ type GasModule struct {
 	Mpool     *messagepool.MessagePool
 	GetMaxFee dtypes.DefaultMaxFeeFunc
 
+	GetPremiumMultipliers dtypes.GasPremiumMultipliersFunc `optional:"true"`
+
 	PriceCache *GasPriceCache
 }
 
@@ -400,7 +402,12 @@ func (m *GasModule) GasEstimateMessageGas(ctx context.Context, msg *types.Messag
 		if err != nil {
 			return nil, xerrors.Errorf("estimating gas price: %w", err)
 		}
-		msg.GasPremium = gasPremium
+
+		mul, err := m.premiumMultiplier(ctx, msg.From)
+		if err != nil {
+			return nil, xerrors.Errorf("getting gas premium multiplier: %w", err)
+		}
+		msg.GasPremium = applyPremiumMultiplier(gasPremium, mul)
 	}
 
 	if msg.GasFeeCap == types.EmptyInt || types.BigCmp(msg.GasFeeCap, types.NewInt(0)) == 0 {
@@ -415,3 +422,128 @@ func (m *GasModule) GasEstimateMessageGas(ctx context.Context, msg *types.Messag
 
 	return msg, nil
 }
+
+func applyPremiumMultiplier(premium abi.TokenAmount, mul float64) abi.TokenAmount {
+	if mul == 1 {
+		return premium
+	}
+	const precision = 32
+	premium = types.BigMul(premium, types.NewInt(uint64(mul*(1<<precision))))
+	return types.BigDiv(premium, types.NewInt(1<<precision))
+}
+
+// premiumMultiplier returns the gas premium multiplier configured for the sender, which may be
+// configured by either its ID or its robust address.
+func (m *GasModule) premiumMultiplier(ctx context.Context, from address.Address) (float64, error) {
+	if m.GetPremiumMultipliers == nil {
+		return 1, nil
+	}
+
+	muls, err := m.GetPremiumMultipliers()
+	if err != nil {
+		return 0, err
+	}
+	if len(muls) == 0 {
+		return 1, nil
+	}
+	if mul, ok := muls[from]; ok {
+		return mul, nil
+	}
+
+	ts := m.Chain.GetHeaviestTipSet()
+	var other address.Address
+	if from.Protocol() == address.ID {
+		other, err = m.Stmgr.ResolveToDeterministicAddress(ctx, from, ts)
+	} else {
+		other, err = m.Stmgr.LookupIDAddress(ctx, from, ts)
+	}
+	if err != nil {
+		// the sender may not exist on chain yet; there is no other address to look up
+		return 1, nil
+	}
+	if mul, ok := muls[other]; ok {
+		return mul, nil
+	}
+
+	return 1, nil
+}
+
+// baseFeeForecastLookback is the number of tipsets the block fullness trend is fitted over
+const baseFeeForecastLookback = 120
+
+func (a *GasAPI) GasBaseFeeForecast(ctx context.Context, epochs int) ([]api.BaseFeeForecast, error) {
+	if epochs <= 0 || epochs > builtin.EpochsInDay {
+		return nil, xerrors.Errorf("epochs must be between 1 and %d", builtin.EpochsInDay)
+	}
+
+	head := a.Chain.GetHeaviestTipSet()
+
+	// gas limit of the messages per block in recent tipsets, newest first
+	var fullness []int64
+	for ts := head; len(fullness) < baseFeeForecastLookback; {
+		msgs, err := a.PriceCache.GetTSGasStats(ctx, a.Chain, ts)
+		if err != nil {
+			return nil, xerrors.Errorf("getting gas stats of tipset %s: %w", ts.Key(), err)
+		}
+		var limit int64
+		for _, m := range msgs {
+			limit += m.Limit
+		}
+		fullness = append(fullness, limit/int64(len(ts.Blocks())))
+
+		if ts.Height() == 0 {
+			break
+		}
+		ts, err = a.Chain.LoadTipSet(ctx, ts.Parents())
+		if err != nil {
+			return nil, xerrors.Errorf("loading parent tipset: %w", err)
+		}
+	}
+	for i, j := 0, len(fullness)-1; i < j; i, j = i+1, j-1 {
+		fullness[i], fullness[j] = fullness[j], fullness[i]
+	}
+
+	// the base fee of the next epoch is known from the messages in the head
+	next, err := a.Chain.ComputeBaseFee(ctx, head)
+	if err != nil {
+		return nil, xerrors.Errorf("computing next base fee: %w", err)
+	}
+
+	return forecastBaseFee(head.Height()+1, next, fullness, epochs), nil
+}
+
+// forecastBaseFee projects the base fee from the base fee at the given epoch, with a linear trend
+// fitted to the fullness (gas limit per block) of recent tipsets, ordered oldest first. The trend
+// is extrapolated for as many epochs as it was fitted over, and held flat afterwards, and every
+// future epoch is assumed to have the expected number of blocks.
+func forecastBaseFee(epoch abi.ChainEpoch, baseFee abi.TokenAmount, fullness []int64, epochs int) []api.BaseFeeForecast {
+	// least squares fit of fullness = intercept + slope*i
+	n := float64(len(fullness))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, f := range fullness {
+		x, y := float64(i), float64(f)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	var slope float64
+	if d := n*sumXX - sumX*sumX; d != 0 {
+		slope = (n*sumXY - sumX*sumY) / d
+	}
+	intercept := (sumY - slope*sumX) / n
+
+	blocks := int(buildconstants.BlocksPerEpoch)
+
+	out := make([]api.BaseFeeForecast, 0, epochs)
+	out = append(out, api.BaseFeeForecast{Epoch: epoch, BaseFee: baseFee})
+	for i := 1; i < epochs; i++ {
+		x := n - 1 + math.Min(float64(i), n)
+		f := math.Max(0, math.Min(intercept+slope*x, float64(buildconstants.BlockGasLimit)))
+
+		baseFee = store.ComputeNextBaseFee(baseFee, int64(f)*int64(blocks), blocks, epoch)
+		epoch++
+		out = append(out, api.BaseFeeForecast{Epoch: epoch, BaseFee: baseFee})
+	}
+	return out
+}
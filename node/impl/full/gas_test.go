@@ -1,10 +1,12 @@
 package full
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/big"
 
 	"github.com/filecoin-project/lotus/build/buildconstants"
@@ -38,3 +40,62 @@ func TestMedian(t *testing.T) {
 		{big.NewInt(30), buildconstants.BlockGasTarget / 2},
 	}, 2))
 }
+
+func TestPremiumMultiplier(t *testing.T) {
+	ctx := context.Background()
+
+	addr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+
+	m := &GasModule{}
+	mul, err := m.premiumMultiplier(ctx, addr)
+	require.NoError(t, err)
+	require.Equal(t, 1.0, mul)
+
+	m.GetPremiumMultipliers = func() (map[address.Address]float64, error) {
+		return map[address.Address]float64{addr: 1.5}, nil
+	}
+	mul, err = m.premiumMultiplier(ctx, addr)
+	require.NoError(t, err)
+	require.Equal(t, 1.5, mul)
+
+	require.Equal(t, types.NewInt(150), applyPremiumMultiplier(types.NewInt(100), mul))
+	require.Equal(t, types.NewInt(50), applyPremiumMultiplier(types.NewInt(100), 0.5))
+	require.Equal(t, types.NewInt(100), applyPremiumMultiplier(types.NewInt(100), 1))
+}
+
+func TestForecastBaseFee(t *testing.T) {
+	start := big.NewInt(1000_000)
+	epoch := buildconstants.UpgradeSmokeHeight + 1
+	flat := func(f int64) []int64 {
+		out := make([]int64, 10)
+		for i := range out {
+			out[i] = f
+		}
+		return out
+	}
+
+	// blocks at the gas target keep the base fee stable
+	fc := forecastBaseFee(epoch, start, flat(buildconstants.BlockGasTarget), 20)
+	require.Len(t, fc, 20)
+	require.Equal(t, epoch, fc[0].Epoch)
+	require.Equal(t, epoch+19, fc[19].Epoch)
+	for _, f := range fc {
+		require.Equal(t, start, f.BaseFee)
+	}
+
+	// full blocks increase it by 12.5% per epoch, empty blocks decrease it
+	fc = forecastBaseFee(epoch, start, flat(buildconstants.BlockGasLimit), 3)
+	require.Equal(t, big.NewInt(1265625), fc[2].BaseFee)
+	fc = forecastBaseFee(epoch, start, flat(0), 3)
+	require.Equal(t, big.NewInt(765625), fc[2].BaseFee)
+
+	// the trend of rising fullness is extrapolated, so the base fee keeps rising
+	rising := make([]int64, 10)
+	for i := range rising {
+		rising[i] = buildconstants.BlockGasTarget/2 + int64(i)*buildconstants.BlockGasTarget/10
+	}
+	fc = forecastBaseFee(epoch, start, rising, 20)
+	require.True(t, fc[1].BaseFee.GreaterThan(fc[0].BaseFee))
+	require.True(t, fc[19].BaseFee.GreaterThan(fc[10].BaseFee))
+}
@@ -6,17 +6,23 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ipfs/go-cid"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.uber.org/fx"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
 
 	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/chain/messagepool"
 	"github.com/filecoin-project/lotus/chain/messagesigner"
+	"github.com/filecoin-project/lotus/chain/stmgr"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+	"github.com/filecoin-project/lotus/metrics"
 	"github.com/filecoin-project/lotus/node/modules/dtypes"
 )
 
@@ -48,6 +54,9 @@ type MpoolAPI struct {
 	MessageSigner messagesigner.MsgSigner
 
 	PushLocks *dtypes.MpoolLocker
+
+	TsExec       stmgr.Executor
+	StrictAccept dtypes.MpoolStrictAccept `optional:"true"`
 }
 
 func (a *MpoolAPI) MpoolGetConfig(context.Context) (*types.MpoolConfig, error) {
@@ -58,6 +67,22 @@ func (a *MpoolAPI) MpoolSetConfig(ctx context.Context, cfg *types.MpoolConfig) e
 	return a.Mpool.SetConfig(ctx, cfg)
 }
 
+func (a *MpoolAPI) MpoolPendingTo(ctx context.Context, addr address.Address) ([]*types.SignedMessage, error) {
+	// messages may address the recipient by its ID or robust address, the recipient may not exist yet
+	to := []address.Address{addr}
+	ts := a.Chain.GetHeaviestTipSet()
+	if addr.Protocol() == address.ID {
+		if ra, err := a.Stmgr.ResolveToDeterministicAddress(ctx, addr, ts); err == nil && ra != addr {
+			to = append(to, ra)
+		}
+	} else if id, err := a.Stmgr.LookupIDAddress(ctx, addr, ts); err == nil {
+		to = append(to, id)
+	}
+
+	pending, _ := a.Mpool.PendingTo(ctx, to...)
+	return pending, nil
+}
+
 func (a *MpoolAPI) MpoolSelect(ctx context.Context, tsk types.TipSetKey, ticketQuality float64) ([]*types.SignedMessage, error) {
 	ts, err := a.Chain.GetTipSetFromKey(ctx, tsk)
 	if err != nil {
@@ -138,10 +163,20 @@ func (m *MpoolModule) MpoolPush(ctx context.Context, smsg *types.SignedMessage)
 	return m.Mpool.Push(ctx, smsg, true)
 }
 
+func (a *MpoolAPI) MpoolPush(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error) {
+	if err := a.strictAcceptCheck(ctx, &smsg.Message); err != nil {
+		return cid.Undef, xerrors.Errorf("message %s from %s with nonce %d rejected: %w", smsg.Cid(), smsg.Message.From, smsg.Message.Nonce, err)
+	}
+	return a.MpoolModuleAPI.MpoolPush(ctx, smsg)
+}
+
 func (a *MpoolAPI) MpoolPushUntrusted(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error) {
 	if err := sanityCheckOutgoingMessage(&smsg.Message); err != nil {
 		return cid.Undef, xerrors.Errorf("message %s from %s with nonce %d failed sanity check: %w", smsg.Cid(), smsg.Message.From, smsg.Message.Nonce, err)
 	}
+	if err := a.strictAcceptCheck(ctx, &smsg.Message); err != nil {
+		return cid.Undef, xerrors.Errorf("message %s from %s with nonce %d rejected: %w", smsg.Cid(), smsg.Message.From, smsg.Message.Nonce, err)
+	}
 	return a.Mpool.PushUntrusted(ctx, smsg)
 }
 
@@ -238,6 +273,9 @@ func (a *MpoolAPI) MpoolBatchPush(ctx context.Context, smsgs []*types.SignedMess
 		if err := sanityCheckOutgoingMessage(&msg.Message); err != nil {
 			return nil, xerrors.Errorf("message %s from %s with nonce %d failed sanity check: %w", msg.Cid(), msg.Message.From, msg.Message.Nonce, err)
 		}
+		if err := a.strictAcceptCheck(ctx, &msg.Message); err != nil {
+			return nil, xerrors.Errorf("message %s from %s with nonce %d rejected: %w", msg.Cid(), msg.Message.From, msg.Message.Nonce, err)
+		}
 	}
 	var messageCids []cid.Cid
 	for _, smsg := range smsgs {
@@ -255,6 +293,9 @@ func (a *MpoolAPI) MpoolBatchPushUntrusted(ctx context.Context, smsgs []*types.S
 		if err := sanityCheckOutgoingMessage(&msg.Message); err != nil {
 			return nil, xerrors.Errorf("message %s from %s with nonce %d failed sanity check: %w", msg.Cid(), msg.Message.From, msg.Message.Nonce, err)
 		}
+		if err := a.strictAcceptCheck(ctx, &msg.Message); err != nil {
+			return nil, xerrors.Errorf("message %s from %s with nonce %d rejected: %w", msg.Cid(), msg.Message.From, msg.Message.Nonce, err)
+		}
 	}
 	var messageCids []cid.Cid
 	for _, smsg := range smsgs {
@@ -300,10 +341,67 @@ func (a *MpoolAPI) MpoolGetNonce(ctx context.Context, addr address.Address) (uin
 	return a.Mpool.GetNonce(ctx, addr, types.EmptyTSK)
 }
 
+func (a *MpoolAPI) MpoolGetNonces(ctx context.Context, addrs []address.Address) ([]uint64, error) {
+	return a.Mpool.GetNonces(ctx, addrs)
+}
+
 func (a *MpoolAPI) MpoolSub(ctx context.Context) (<-chan api.MpoolUpdate, error) {
 	return a.Mpool.Updates(ctx)
 }
 
+func (a *MpoolAPI) MpoolWatch(ctx context.Context, filter api.MpoolFilter) (<-chan *types.SignedMessage, error) {
+	// resolve the filter addresses once, so that matching messages is a few map lookups
+	ts := a.Chain.GetHeaviestTipSet()
+	resolve := func(addrs []address.Address) map[address.Address]struct{} {
+		if len(addrs) == 0 {
+			return nil
+		}
+		out := map[address.Address]struct{}{}
+		for _, addr := range addrs {
+			out[addr] = struct{}{}
+			if addr.Protocol() == address.ID {
+				if ra, err := a.Stmgr.ResolveToDeterministicAddress(ctx, addr, ts); err == nil {
+					out[ra] = struct{}{}
+				}
+			} else if id, err := a.Stmgr.LookupIDAddress(ctx, addr, ts); err == nil {
+				out[id] = struct{}{}
+			}
+		}
+		return out
+	}
+
+	return a.Mpool.Watch(ctx, mpoolFilterMatch(resolve(filter.From), resolve(filter.To), filter.Methods)), nil
+}
+
+func mpoolFilterMatch(from, to map[address.Address]struct{}, methods []abi.MethodNum) func(*types.SignedMessage) bool {
+	var methodSet map[abi.MethodNum]struct{}
+	if len(methods) > 0 {
+		methodSet = map[abi.MethodNum]struct{}{}
+		for _, m := range methods {
+			methodSet[m] = struct{}{}
+		}
+	}
+
+	return func(sm *types.SignedMessage) bool {
+		if from != nil {
+			if _, ok := from[sm.Message.From]; !ok {
+				return false
+			}
+		}
+		if to != nil {
+			if _, ok := to[sm.Message.To]; !ok {
+				return false
+			}
+		}
+		if methodSet != nil {
+			if _, ok := methodSet[sm.Message.Method]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+}
+
 func sanityCheckOutgoingMessage(msg *types.Message) error {
 	// Check that the message's TO address is a _valid_ Eth address if it's a delegated address.
 	//
@@ -319,3 +417,49 @@ func sanityCheckOutgoingMessage(msg *types.Message) error {
 
 	return nil
 }
+
+// firstExportedMethodNum is the first method number of the FRC-0042 exported
+// method range, which actors may handle with a fallback rather than a method
+// in their registry.
+const firstExportedMethodNum = abi.MethodNum(1 << 24)
+
+// strictAcceptCheck checks a message pushed through the API against the strict
+// acceptance policy, and records the reason when it is rejected.
+func (a *MpoolAPI) strictAcceptCheck(ctx context.Context, msg *types.Message) error {
+	reason, err := checkStrictAccept(a.StrictAccept, msg, func() bool {
+		act, err := a.Stmgr.LoadActor(ctx, msg.To, a.Chain.GetHeaviestTipSet())
+		if err != nil {
+			// messages can be sent to addresses without an actor, which creates one
+			return true
+		}
+		methods, ok := a.TsExec.NewActorRegistry().Methods[act.Code]
+		if !ok {
+			return true
+		}
+		_, ok = methods[msg.Method]
+		return ok
+	})
+	if err != nil {
+		ctx, _ = tag.New(ctx, tag.Upsert(metrics.FailureType, reason))
+		stats.Record(ctx, metrics.MpoolStrictRejected.M(1))
+	}
+	return err
+}
+
+// checkStrictAccept returns the rejection reason and an error when the message
+// breaks any rule enabled in the policy. methodExists is called for the unknown
+// method rule, and reports whether the recipient exports the message method.
+func checkStrictAccept(p dtypes.MpoolStrictAccept, msg *types.Message, methodExists func() bool) (string, error) {
+	if p.MaxGasLimit > 0 && msg.GasLimit > p.MaxGasLimit {
+		return "gas_limit", xerrors.Errorf("gas limit %d is above the limit %d of this node", msg.GasLimit, p.MaxGasLimit)
+	}
+	if p.RejectZeroPremium && msg.GasPremium.NilOrZero() {
+		return "zero_premium", xerrors.Errorf("gas premium must not be zero on this node")
+	}
+	if p.RejectUnknownMethods && msg.Method != builtin.MethodSend && msg.Method < firstExportedMethodNum {
+		if !methodExists() {
+			return "unknown_method", xerrors.Errorf("recipient actor %s has no method %d", msg.To, msg.Method)
+		}
+	}
+	return "", nil
+}
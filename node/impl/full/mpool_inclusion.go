@@ -0,0 +1,200 @@
+package full
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/build/buildconstants"
+	"github.com/filecoin-project/lotus/chain/types"
+)
+
+// inclusionEstimateEpochs is the number of epochs inclusion probabilities are estimated for.
+const inclusionEstimateEpochs = 5
+
+// inclusionRecentTipsets is the number of recent tipsets the block fullness and the rate of higher
+// premium messages are measured over.
+const inclusionRecentTipsets = 10
+
+func (a *MpoolAPI) MpoolMessageInclusionEstimate(ctx context.Context, msgCid cid.Cid) (*api.MpoolInclusionEstimate, error) {
+	pending, mpts := a.Mpool.Pending(ctx)
+
+	target := pendingMessage(pending, msgCid)
+	if target == nil {
+		return &api.MpoolInclusionEstimate{Status: api.InclusionUnknown, Reason: "message is not in the message pool"}, nil
+	}
+
+	baseFee, err := a.Chain.ComputeBaseFee(ctx, mpts)
+	if err != nil {
+		return nil, xerrors.Errorf("computing base fee: %w", err)
+	}
+
+	act, err := a.Mpool.GetActor(ctx, target.Message.From, mpts.Key())
+	if err != nil {
+		return nil, xerrors.Errorf("getting sender actor: %w", err)
+	}
+
+	est, ownGas := inclusionQueue(pending, target, act.Nonce, baseFee)
+	if est.Reason != "" {
+		return est, nil
+	}
+
+	var tipsets int
+	var recentGas, recentHigherGas int64
+	ts := mpts
+	oldest := mpts.Height()
+	for ; tipsets < inclusionRecentTipsets && ts.Height() > 0; tipsets++ {
+		meta, err := a.PriceCache.GetTSGasStats(ctx, a.Chain, ts)
+		if err != nil {
+			return nil, xerrors.Errorf("getting gas stats of tipset %s: %w", ts.Key(), err)
+		}
+		for _, gm := range meta {
+			recentGas += gm.Limit
+			if gm.Price.GreaterThan(est.EffectivePremium) {
+				recentHigherGas += gm.Limit
+			}
+		}
+
+		oldest = ts.Height()
+		ts, err = a.Chain.LoadTipSet(ctx, ts.Parents())
+		if err != nil {
+			return nil, xerrors.Errorf("loading parent tipset: %w", err)
+		}
+	}
+	if tipsets == 0 {
+		return &api.MpoolInclusionEstimate{Status: api.InclusionUnknown, Reason: "there are no recent blocks to estimate from"}, nil
+	}
+
+	// null rounds lower the rate of higher premium messages per epoch
+	recentEpochs := int64(mpts.Height()-oldest) + 1
+	est.RecentFullness = float64(recentGas) / float64(int64(tipsets)*buildconstants.BlockGasLimit)
+	est.Probability, est.ExpectedEpochs = inclusionProbability(est.GasAhead+ownGas, recentHigherGas/recentEpochs, inclusionEstimateEpochs)
+
+	return est, nil
+}
+
+// pendingMessage returns the pending message with the given CID, signed or not.
+func pendingMessage(pending []*types.SignedMessage, msgCid cid.Cid) *types.SignedMessage {
+	for _, m := range pending {
+		if m.Cid() == msgCid || m.Message.Cid() == msgCid {
+			return m
+		}
+	}
+	return nil
+}
+
+// inclusionQueue returns the estimate with the effective premium of the target message and the gas
+// of the higher premium pending messages ahead of it, along with the gas of the target and the
+// lower nonce messages of its sender. The reason of the estimate is set when the target can't be
+// included.
+func inclusionQueue(pending []*types.SignedMessage, target *types.SignedMessage, senderNonce uint64, baseFee abi.TokenAmount) (*api.MpoolInclusionEstimate, int64) {
+	est := &api.MpoolInclusionEstimate{
+		Status:           api.InclusionEstimated,
+		Epochs:           inclusionEstimateEpochs,
+		ExpectedEpochs:   -1,
+		BaseFee:          baseFee,
+		EffectivePremium: big.Zero(),
+	}
+
+	if target.Message.Nonce < senderNonce {
+		est.Reason = "the nonce of the message was already used"
+		return est, 0
+	}
+
+	// the pending messages of the sender with lower nonces have to be included first, the message
+	// pool selects them together with the message
+	senderMsgs := map[uint64]*types.SignedMessage{}
+	for _, m := range pending {
+		if m.Message.From == target.Message.From {
+			senderMsgs[m.Message.Nonce] = m
+		}
+	}
+
+	chain := map[cid.Cid]struct{}{}
+	weighted := big.Zero()
+	var ownGas int64
+	for n := senderNonce; n <= target.Message.Nonce; n++ {
+		m, ok := senderMsgs[n]
+		if !ok {
+			est.Reason = fmt.Sprintf("nonce %d of the sender is missing from the message pool", n)
+			return est, 0
+		}
+
+		premium := effectivePremium(&m.Message, baseFee)
+		if premium.LessThan(big.Zero()) {
+			est.Reason = fmt.Sprintf("the fee cap of the message with nonce %d is below the base fee", n)
+			return est, 0
+		}
+
+		chain[m.Cid()] = struct{}{}
+		weighted = big.Add(weighted, big.Mul(premium, big.NewInt(m.Message.GasLimit)))
+		ownGas += m.Message.GasLimit
+	}
+	if ownGas > 0 {
+		est.EffectivePremium = big.Div(weighted, big.NewInt(ownGas))
+	}
+
+	for _, m := range pending {
+		if _, ok := chain[m.Cid()]; ok {
+			continue
+		}
+		if effectivePremium(&m.Message, baseFee).GreaterThan(est.EffectivePremium) {
+			est.GasAhead += m.Message.GasLimit
+		}
+	}
+
+	return est, ownGas
+}
+
+// effectivePremium returns the premium a message pays to the block producer at the base fee,
+// negative when its fee cap is below the base fee.
+func effectivePremium(m *types.Message, baseFee abi.TokenAmount) abi.TokenAmount {
+	return big.Min(m.GasPremium, big.Sub(m.GasFeeCap, baseFee))
+}
+
+// inclusionProbability returns the probability of the message being included within the given
+// number of epochs, when the pending messages ahead of it and the message itself use gasNeeded
+// gas, and higher premium messages arrive at arrivalPerEpoch gas per epoch. It also returns the
+// number of non-empty epochs the message is expected to be included within, or -1 when the arrivals
+// fill the blocks.
+//
+// The blocks of an epoch are assumed to include mostly the same messages, so an epoch fits one
+// block gas limit of messages. The number of blocks in an epoch follows a Poisson distribution, an
+// epoch is non-empty with a probability of 1 - e^-BlocksPerEpoch.
+func inclusionProbability(gasNeeded, arrivalPerEpoch int64, epochs int) (float64, int64) {
+	available := buildconstants.BlockGasLimit - arrivalPerEpoch
+	if available <= 0 {
+		return 0, -1
+	}
+
+	needed := (gasNeeded + available - 1) / available
+	if needed < 1 {
+		needed = 1
+	}
+	if needed > int64(epochs) {
+		return 0, needed
+	}
+
+	// probability of at least needed non-empty epochs out of epochs
+	pNonEmpty := 1 - math.Exp(-float64(buildconstants.BlocksPerEpoch))
+	var p float64
+	for k := int(needed); k <= epochs; k++ {
+		p += binomial(epochs, k) * math.Pow(pNonEmpty, float64(k)) * math.Pow(1-pNonEmpty, float64(epochs-k))
+	}
+	return math.Min(p, 1), needed
+}
+
+func binomial(n, k int) float64 {
+	r := 1.0
+	for i := 1; i <= k; i++ {
+		r = r * float64(n-k+i) / float64(i)
+	}
+	return r
+}
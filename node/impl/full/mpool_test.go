@@ -7,9 +7,15 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
 	"github.com/filecoin-project/go-state-types/crypto"
 
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/build/buildconstants"
 	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/node/modules/dtypes"
 )
 
 func TestSanityCheckOutgoingMessage(t *testing.T) {
@@ -65,3 +71,169 @@ func TestMpoolPushInvalidDelegatedAddressFails(t *testing.T) {
 
 	require.Contains(t, err.Error(), "is a delegated address but not a valid Eth Address")
 }
+
+func TestCheckStrictAccept(t *testing.T) {
+	to, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	msg := func(gasLimit int64, premium int64, method abi.MethodNum) *types.Message {
+		return &types.Message{To: to, GasLimit: gasLimit, GasPremium: big.NewInt(premium), Method: method}
+	}
+	exists := func(ok bool) func() bool {
+		return func() bool { return ok }
+	}
+
+	// the zero policy is permissive
+	reason, err := checkStrictAccept(dtypes.MpoolStrictAccept{}, msg(1e10, 0, 99), exists(false))
+	require.NoError(t, err)
+	require.Empty(t, reason)
+
+	p := dtypes.MpoolStrictAccept{MaxGasLimit: 1e9, RejectZeroPremium: true, RejectUnknownMethods: true}
+
+	_, err = checkStrictAccept(p, msg(1e9, 1, 2), exists(true))
+	require.NoError(t, err)
+
+	reason, err = checkStrictAccept(p, msg(1e9+1, 1, 2), exists(true))
+	require.Error(t, err)
+	require.Equal(t, "gas_limit", reason)
+
+	reason, err = checkStrictAccept(p, msg(1e9, 0, 2), exists(true))
+	require.Error(t, err)
+	require.Equal(t, "zero_premium", reason)
+
+	reason, err = checkStrictAccept(p, msg(1e9, 1, 2), exists(false))
+	require.ErrorContains(t, err, "has no method 2")
+	require.Equal(t, "unknown_method", reason)
+
+	// plain sends and exported methods are not checked against the recipient
+	_, err = checkStrictAccept(p, msg(1e9, 1, builtin.MethodSend), exists(false))
+	require.NoError(t, err)
+	_, err = checkStrictAccept(p, msg(1e9, 1, firstExportedMethodNum), exists(false))
+	require.NoError(t, err)
+}
+
+func TestMpoolFilterMatch(t *testing.T) {
+	msg := func(from, to uint64, method abi.MethodNum) *types.SignedMessage {
+		f, _ := address.NewIDAddress(from)
+		tt, _ := address.NewIDAddress(to)
+		return &types.SignedMessage{Message: types.Message{From: f, To: tt, Method: method}}
+	}
+	set := func(ids ...uint64) map[address.Address]struct{} {
+		out := map[address.Address]struct{}{}
+		for _, id := range ids {
+			a, _ := address.NewIDAddress(id)
+			out[a] = struct{}{}
+		}
+		return out
+	}
+
+	require.True(t, mpoolFilterMatch(nil, nil, nil)(msg(1, 2, 0)))
+
+	match := mpoolFilterMatch(set(1, 3), set(2), []abi.MethodNum{5})
+	require.True(t, match(msg(1, 2, 5)))
+	require.True(t, match(msg(3, 2, 5)))
+	require.False(t, match(msg(4, 2, 5)))
+	require.False(t, match(msg(1, 4, 5)))
+	require.False(t, match(msg(1, 2, 0)))
+}
+
+func TestInclusionProbability(t *testing.T) {
+	limit := buildconstants.BlockGasLimit
+
+	// fits in one epoch, only empty epochs prevent the inclusion
+	p, expected := inclusionProbability(limit/10, 0, 5)
+	require.Equal(t, int64(1), expected)
+	require.InDelta(t, 1, p, 1e-6)
+
+	// three epochs worth of gas ahead, with half of each epoch taken by new higher premium messages
+	p, expected = inclusionProbability(limit*3/2, limit/2, 5)
+	require.Equal(t, int64(3), expected)
+	require.Greater(t, p, 0.99)
+	require.Less(t, p, 1.0)
+
+	// not within the estimated epochs
+	p, expected = inclusionProbability(limit*6, 0, 5)
+	require.Equal(t, int64(6), expected)
+	require.Zero(t, p)
+
+	// the blocks are filled by higher premium messages
+	p, expected = inclusionProbability(limit/10, limit, 5)
+	require.Equal(t, int64(-1), expected)
+	require.Zero(t, p)
+}
+
+func TestInclusionQueue(t *testing.T) {
+	from, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	other, err := address.NewIDAddress(1001)
+	require.NoError(t, err)
+
+	baseFee := abi.NewTokenAmount(100)
+	smsg := func(from address.Address, nonce uint64, gasLimit int64, feeCap, premium int64) *types.SignedMessage {
+		return &types.SignedMessage{
+			Message: types.Message{
+				From:       from,
+				To:         builtin.BurntFundsActorAddr,
+				Nonce:      nonce,
+				Value:      big.Zero(),
+				GasLimit:   gasLimit,
+				GasFeeCap:  abi.NewTokenAmount(feeCap),
+				GasPremium: abi.NewTokenAmount(premium),
+			},
+			Signature: crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: []byte{byte(nonce)}},
+		}
+	}
+
+	t.Run("empty mempool", func(t *testing.T) {
+		target := smsg(from, 5, 1000, 200, 10)
+		require.Nil(t, pendingMessage(nil, target.Cid()))
+		require.Nil(t, pendingMessage(nil, target.Message.Cid()))
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		first := smsg(from, 5, 1000, 200, 10)
+		target := smsg(from, 6, 3000, 200, 50)
+		higher := smsg(other, 0, 7000, 200, 45)
+		lower := smsg(other, 1, 9000, 200, 20)
+		pending := []*types.SignedMessage{lower, target, higher, first}
+
+		// found by both the signed and the unsigned CID
+		require.Equal(t, target, pendingMessage(pending, target.Cid()))
+		require.Equal(t, target, pendingMessage(pending, target.Message.Cid()))
+
+		est, ownGas := inclusionQueue(pending, target, 5, baseFee)
+		require.Empty(t, est.Reason)
+		require.Equal(t, api.InclusionEstimated, est.Status)
+		require.Equal(t, baseFee, est.BaseFee)
+		// the premium of the sender messages weighted by their gas
+		require.Equal(t, abi.NewTokenAmount((1000*10+3000*50)/4000), est.EffectivePremium)
+		require.Equal(t, int64(4000), ownGas)
+		require.Equal(t, int64(7000), est.GasAhead)
+	})
+
+	t.Run("already included", func(t *testing.T) {
+		target := smsg(from, 5, 1000, 200, 10)
+		est, ownGas := inclusionQueue([]*types.SignedMessage{target}, target, 6, baseFee)
+		require.Equal(t, "the nonce of the message was already used", est.Reason)
+		require.Zero(t, ownGas)
+		require.Zero(t, est.Probability)
+	})
+
+	t.Run("missing nonce", func(t *testing.T) {
+		target := smsg(from, 7, 1000, 200, 10)
+		est, _ := inclusionQueue([]*types.SignedMessage{smsg(from, 5, 1000, 200, 10), target}, target, 5, baseFee)
+		require.Equal(t, "nonce 6 of the sender is missing from the message pool", est.Reason)
+	})
+
+	t.Run("fee cap below base fee", func(t *testing.T) {
+		target := smsg(from, 5, 1000, 99, 10)
+		est, ownGas := inclusionQueue([]*types.SignedMessage{target}, target, 5, baseFee)
+		require.Equal(t, "the fee cap of the message with nonce 5 is below the base fee", est.Reason)
+		require.Zero(t, ownGas)
+		require.Zero(t, est.Probability)
+
+		// a lower nonce message of the sender below the base fee holds the target back
+		target = smsg(from, 6, 1000, 200, 10)
+		est, _ = inclusionQueue([]*types.SignedMessage{smsg(from, 5, 1000, 50, 10), target}, target, 5, baseFee)
+		require.Equal(t, "the fee cap of the message with nonce 5 is below the base fee", est.Reason)
+	})
+}
@@ -0,0 +1,220 @@
+package full
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/lotus/api"
+)
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// limitActorState applies the path and depth of the options to a decoded actor state.
+func limitActorState(state interface{}, opts api.ReadStateOpts) (interface{}, error) {
+	v := reflect.ValueOf(state)
+
+	if opts.Path != "" {
+		var walked []string
+		for _, name := range strings.Split(opts.Path, ".") {
+			next, err := stateField(v, name)
+			if err != nil {
+				if len(walked) > 0 {
+					return nil, xerrors.Errorf("in %s: %w", strings.Join(walked, "."), err)
+				}
+				return nil, err
+			}
+			walked = append(walked, name)
+			v = next
+		}
+	}
+
+	if opts.Depth <= 0 {
+		return v.Interface(), nil
+	}
+	return limitDepth(v, opts.Depth), nil
+}
+
+// stateField returns the field of a struct with the given JSON name, or the value of a string map
+// key.
+func stateField(v reflect.Value, name string) (reflect.Value, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, xerrors.Errorf("can't select %s of a null value", name)
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, f := range jsonFields(v.Type()) {
+			if f.name != name {
+				continue
+			}
+			fv, err := v.FieldByIndexErr(f.index)
+			if err != nil {
+				return reflect.Value{}, xerrors.Errorf("can't select %s of a null value", name)
+			}
+			return fv, nil
+		}
+		return reflect.Value{}, xerrors.Errorf("no field %s, fields are %v", name, fieldNames(v.Type()))
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if mv := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key())); mv.IsValid() {
+			return mv, nil
+		}
+		return reflect.Value{}, xerrors.Errorf("no key %s", name)
+	}
+	return reflect.Value{}, xerrors.Errorf("can't select %s of a %s value", name, v.Kind())
+}
+
+func fieldNames(t reflect.Type) []string {
+	var out []string
+	for _, f := range jsonFields(t) {
+		out = append(out, f.name)
+	}
+	return out
+}
+
+type jsonField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// jsonFields returns the fields of a struct type the way encoding/json encodes them: named by their
+// json tags, without the ignored ones, and with the fields of embedded structs promoted unless a
+// shallower field has the same name.
+func jsonFields(t reflect.Type) []jsonField {
+	type embedded struct {
+		t     reflect.Type
+		index []int
+	}
+
+	var out []jsonField
+	names := map[string]struct{}{}
+	for next := []embedded{{t: t}}; len(next) > 0; {
+		level := next
+		next = nil
+		for _, e := range level {
+			for i := 0; i < e.t.NumField(); i++ {
+				f := e.t.Field(i)
+				index := append(append([]int{}, e.index...), i)
+
+				tag := f.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+
+				if f.Anonymous && name == "" {
+					ft := f.Type
+					if ft.Kind() == reflect.Ptr {
+						ft = ft.Elem()
+					}
+					if ft.Kind() == reflect.Struct {
+						next = append(next, embedded{t: ft, index: index})
+						continue
+					}
+				}
+				if !f.IsExported() {
+					continue
+				}
+				if name == "" {
+					name = f.Name
+				}
+				if _, ok := names[name]; ok {
+					continue
+				}
+				names[name] = struct{}{}
+				out = append(out, jsonField{
+					name:      name,
+					index:     index,
+					omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+				})
+			}
+		}
+	}
+	return out
+}
+
+// isEmptyJSONValue tells whether encoding/json omits the value of an omitempty field.
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Struct:
+		return false
+	default:
+		return v.IsZero()
+	}
+}
+
+// limitDepth converts the value to plain maps and slices, replacing objects and arrays nested deeper
+// than depth with a short description. Values with their own JSON encoding, like CIDs and big
+// integers, are kept as is.
+func limitDepth(v reflect.Value, depth int) interface{} {
+	for v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Type().Implements(jsonMarshalerType) || (v.CanAddr() && v.Addr().Type().Implements(jsonMarshalerType)) {
+		return v.Interface()
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		return limitDepth(v.Elem(), depth)
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if depth == 0 {
+			return "<object>"
+		}
+		out := map[string]interface{}{}
+		for _, f := range jsonFields(v.Type()) {
+			fv, err := v.FieldByIndexErr(f.index)
+			if err != nil {
+				continue // field of a null embedded struct
+			}
+			if f.omitEmpty && isEmptyJSONValue(fv) {
+				continue
+			}
+			out[f.name] = limitDepth(fv, depth-1)
+		}
+		return out
+	case reflect.Map:
+		if depth == 0 {
+			return fmt.Sprintf("<map of %d>", v.Len())
+		}
+		out := map[string]interface{}{}
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = limitDepth(iter.Value(), depth-1)
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface() // bytes are encoded as base64
+		}
+		if depth == 0 {
+			return fmt.Sprintf("<array of %d>", v.Len())
+		}
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			out[i] = limitDepth(v.Index(i), depth-1)
+		}
+		return out
+	default:
+		return v.Interface()
+	}
+}
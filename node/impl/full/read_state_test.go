@@ -0,0 +1,89 @@
+package full
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/lotus/api"
+)
+
+type testStateInfo struct {
+	Owner    string `json:"owner"`
+	Peers    [][]byte
+	Multiple []abi.ChainEpoch
+	Worker   string `json:",omitempty"`
+}
+
+type testStateMeta struct {
+	Version int
+	// shadowed by testState.Epochs
+	Epochs string
+}
+
+type testState struct {
+	testStateMeta
+	Info     *testStateInfo
+	Balance  big.Int
+	Sectors  cid.Cid
+	Counts   map[string]int `json:"counts,omitempty"`
+	Epochs   []abi.ChainEpoch
+	Cache    map[string]int `json:"-"`
+	unlisted int
+}
+
+func TestLimitActorState(t *testing.T) {
+	st := &testState{
+		Info: &testStateInfo{
+			Owner:    "f01000",
+			Peers:    [][]byte{{1}, {2}},
+			Multiple: []abi.ChainEpoch{1, 2, 3},
+		},
+		Balance:       big.NewInt(10),
+		Sectors:       cid.Undef,
+		Counts:        map[string]int{"a": 1, "b": 2},
+		Epochs:        []abi.ChainEpoch{5, 6},
+		Cache:         map[string]int{"c": 3},
+		testStateMeta: testStateMeta{Version: 2, Epochs: "shadowed"},
+	}
+
+	toJSON := func(opts api.ReadStateOpts) string {
+		out, err := limitActorState(st, opts)
+		require.NoError(t, err)
+		b, err := json.Marshal(out)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	full, err := json.Marshal(st)
+	require.NoError(t, err)
+	require.JSONEq(t, string(full), toJSON(api.ReadStateOpts{}))
+	// a depth deeper than the state is the same as its JSON encoding
+	require.JSONEq(t, string(full), toJSON(api.ReadStateOpts{Depth: 10}))
+
+	require.JSONEq(t, `{"Version":2,"Info":"<object>","Balance":"10","Sectors":null,"counts":"<map of 2>","Epochs":"<array of 2>"}`,
+		toJSON(api.ReadStateOpts{Depth: 1}))
+	require.JSONEq(t, `{"owner":"f01000","Peers":"<array of 2>","Multiple":"<array of 3>"}`,
+		toJSON(api.ReadStateOpts{Path: "Info", Depth: 1}))
+	require.JSONEq(t, `{"owner":"f01000","Peers":["AQ==","Ag=="],"Multiple":[1,2,3]}`,
+		toJSON(api.ReadStateOpts{Path: "Info", Depth: 2}))
+	require.JSONEq(t, `[1,2,3]`, toJSON(api.ReadStateOpts{Path: "Info.Multiple"}))
+	require.JSONEq(t, `"f01000"`, toJSON(api.ReadStateOpts{Path: "Info.owner"}))
+	require.JSONEq(t, `2`, toJSON(api.ReadStateOpts{Path: "counts.b"}))
+	require.JSONEq(t, `2`, toJSON(api.ReadStateOpts{Path: "Version"}))
+	require.JSONEq(t, `[5,6]`, toJSON(api.ReadStateOpts{Path: "Epochs"}))
+
+	_, err = limitActorState(st, api.ReadStateOpts{Path: "Info.Owner"})
+	require.ErrorContains(t, err, "in Info: no field Owner, fields are [owner Peers Multiple Worker]")
+	_, err = limitActorState(st, api.ReadStateOpts{Path: "Cache"})
+	require.ErrorContains(t, err, "no field Cache")
+	_, err = limitActorState(st, api.ReadStateOpts{Path: "unlisted"})
+	require.ErrorContains(t, err, "no field unlisted")
+	_, err = limitActorState(st, api.ReadStateOpts{Path: "Epochs.Foo"})
+	require.ErrorContains(t, err, "can't select Foo of a slice value")
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 
 	"github.com/ipfs/go-cid"
@@ -24,6 +25,7 @@ import (
 	"github.com/filecoin-project/go-state-types/cbor"
 	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/dline"
+	"github.com/filecoin-project/go-state-types/manifest"
 	"github.com/filecoin-project/go-state-types/network"
 	market2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/market"
 	market5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/market"
@@ -472,6 +474,66 @@ func (a *StateAPI) StateReplay(ctx context.Context, tsk types.TipSetKey, mc cid.
 	}, nil
 }
 
+func (a *StateAPI) StateCallTree(ctx context.Context, mc cid.Cid) (*api.CallTree, error) {
+	res, err := a.StateReplay(ctx, types.EmptyTSK, mc)
+	if err != nil {
+		return nil, err
+	}
+
+	return callTree(a.TsExec.NewActorRegistry(), res), nil
+}
+
+func callTree(ar *vm.ActorRegistry, res *api.InvocResult) *api.CallTree {
+	root := callTreeNode(ar, res.ExecutionTrace)
+
+	// all gas charged to the message should be recorded in the trace, but the trace of a message
+	// which ran out of gas may be incomplete; return the tree anyway and let the caller decide
+	// what to make of it
+	mismatch := root.TotalGasUsed != res.MsgRct.GasUsed
+	if mismatch {
+		log.Debugw("call tree gas doesn't match message gas used", "msg", res.MsgCid, "trace", root.TotalGasUsed, "receipt", res.MsgRct.GasUsed, "exit", res.MsgRct.ExitCode)
+	}
+
+	return &api.CallTree{
+		MsgCid:       res.MsgCid,
+		ExitCode:     res.MsgRct.ExitCode,
+		GasUsed:      res.MsgRct.GasUsed,
+		TraceGasUsed: root.TotalGasUsed,
+		GasMismatch:  mismatch,
+		Root:         root,
+	}
+}
+
+func callTreeNode(ar *vm.ActorRegistry, et types.ExecutionTrace) api.CallTreeNode {
+	node := api.CallTreeNode{
+		From:     et.Msg.From,
+		To:       et.Msg.To,
+		Value:    et.Msg.Value,
+		Method:   et.Msg.Method,
+		GasLimit: et.Msg.GasLimit,
+		ReadOnly: et.Msg.ReadOnly,
+		ExitCode: et.MsgRct.ExitCode,
+		GasUsed:  et.SumGas().TotalGas,
+	}
+
+	if et.InvokedActor != nil {
+		code := et.InvokedActor.State.Code
+		node.Actor = builtin.ActorNameByCode(code)
+		if m, ok := ar.Methods[code][et.Msg.Method]; ok {
+			node.MethodName = m.Name
+		}
+	}
+
+	node.TotalGasUsed = node.GasUsed
+	for _, sc := range et.Subcalls {
+		sub := callTreeNode(ar, sc)
+		node.TotalGasUsed += sub.TotalGasUsed
+		node.Subcalls = append(node.Subcalls, sub)
+	}
+
+	return node
+}
+
 func (m *StateModule) StateGetActor(ctx context.Context, actor address.Address, tsk types.TipSetKey) (a *types.Actor, err error) {
 	ts, err := m.Chain.GetTipSetFromKey(ctx, tsk)
 	if err != nil {
@@ -539,6 +601,23 @@ func (a *StateAPI) StateReadState(ctx context.Context, actor address.Address, ts
 	}, nil
 }
 
+func (a *StateAPI) StateReadStateWithOpts(ctx context.Context, actor address.Address, opts api.ReadStateOpts, tsk types.TipSetKey) (*api.ActorState, error) {
+	if opts.Depth < 0 {
+		return nil, xerrors.Errorf("depth must not be negative")
+	}
+
+	as, err := a.StateReadState(ctx, actor, tsk)
+	if err != nil {
+		return nil, err
+	}
+
+	as.State, err = limitActorState(as.State, opts)
+	if err != nil {
+		return nil, xerrors.Errorf("selecting state of %s: %w", actor, err)
+	}
+	return as, nil
+}
+
 func (a *StateAPI) StateDecodeParams(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, tsk types.TipSetKey) (interface{}, error) {
 	act, err := a.StateGetActor(ctx, toAddr, tsk)
 	if err != nil {
@@ -582,6 +661,28 @@ func (a *StateAPI) MinerGetBaseInfo(ctx context.Context, maddr address.Address,
 	return stmgr.MinerGetBaseInfo(ctx, a.StateManager, a.Beacon, tsk, epoch, maddr, a.ProofVerifier)
 }
 
+func (a *StateAPI) MinerWinEligibility(ctx context.Context, maddr address.Address, epoch abi.ChainEpoch) (*api.WinEligibility, error) {
+	base, err := winEligibilityBase(ctx, a.Chain.GetTipsetByHeight, a.Chain.GetHeaviestTipSet(), epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return stmgr.MinerWinEligibility(ctx, a.StateManager, maddr, base, epoch)
+}
+
+// winEligibilityBase returns the tipset blocks at the epoch are mined on: the latest tipset below
+// it, or the head for future epochs.
+func winEligibilityBase(ctx context.Context, byHeight tipsetByHeightFunc, head *types.TipSet, epoch abi.ChainEpoch) (*types.TipSet, error) {
+	if epoch > head.Height() {
+		return head, nil
+	}
+	base, err := byHeight(ctx, epoch-1, head, true)
+	if err != nil {
+		return nil, xerrors.Errorf("loading base tipset for epoch %d: %w", epoch, err)
+	}
+	return base, nil
+}
+
 func (a *StateAPI) MinerCreateBlock(ctx context.Context, bt *api.BlockTemplate) (*types.BlockMsg, error) {
 	fblk, err := a.Consensus.CreateBlock(ctx, a.Wallet, bt)
 	if err != nil {
@@ -667,6 +768,42 @@ func (m *StateModule) StateSearchMsg(ctx context.Context, tsk types.TipSetKey, m
 	return nil, nil
 }
 
+func (a *StateAPI) StateSearchMsgInRange(ctx context.Context, msg cid.Cid, minHeight, maxHeight abi.ChainEpoch, allowReplaced bool) (*api.MsgLookup, error) {
+	if minHeight < 0 || maxHeight < minHeight {
+		return nil, xerrors.Errorf("invalid height range %d-%d", minHeight, maxHeight)
+	}
+
+	fromTs := a.Chain.GetHeaviestTipSet()
+	if minHeight > fromTs.Height() {
+		return nil, nil
+	}
+	if maxHeight < fromTs.Height() {
+		var err error
+		fromTs, err = a.Chain.GetTipsetByHeight(ctx, maxHeight, fromTs, true)
+		if err != nil {
+			return nil, xerrors.Errorf("loading tipset at height %d: %w", maxHeight, err)
+		}
+	}
+
+	// look back far enough to also check the tipset at minHeight
+	ts, recpt, found, err := a.StateManager.SearchForMessage(ctx, fromTs, msg, fromTs.Height()-minHeight+1, allowReplaced)
+	if err != nil {
+		return nil, err
+	}
+
+	// the message index may find the message outside of the requested range
+	if ts == nil || ts.Height() < minHeight || ts.Height() > maxHeight {
+		return nil, nil
+	}
+
+	return &api.MsgLookup{
+		Message: found,
+		Receipt: *recpt,
+		TipSet:  ts.Key(),
+		Height:  ts.Height(),
+	}, nil
+}
+
 func (m *StateModule) StateListMiners(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error) {
 	ts, err := m.Chain.GetTipSetFromKey(ctx, tsk)
 	if err != nil {
@@ -683,6 +820,63 @@ func (a *StateAPI) StateListActors(ctx context.Context, tsk types.TipSetKey) ([]
 	return a.StateManager.ListAllActors(ctx, ts)
 }
 
+var actorTypeAliases = map[string]string{
+	"miner": manifest.MinerKey,
+	"paych": manifest.PaychKey,
+	"msig":  manifest.MultisigKey,
+}
+
+func (a *StateAPI) StateListActorsByType(ctx context.Context, actorType string, tsk types.TipSetKey) (<-chan api.ActorListEntry, error) {
+	if alias, ok := actorTypeAliases[actorType]; ok {
+		actorType = alias
+	}
+	if !slices.Contains(manifest.GetBuiltinActorsKeys(actorstypes.Version(actors.LatestVersion)), actorType) {
+		return nil, xerrors.Errorf("unknown builtin actor type %q", actorType)
+	}
+
+	ts, err := a.Chain.GetTipSetFromKey(ctx, tsk)
+	if err != nil {
+		return nil, xerrors.Errorf("loading tipset %s: %w", tsk, err)
+	}
+	st, err := a.StateManager.ParentState(ts)
+	if err != nil {
+		return nil, xerrors.Errorf("loading state tree: %w", err)
+	}
+
+	out := make(chan api.ActorListEntry, 32)
+	go func() {
+		defer close(out)
+		streamActorsByType(ctx, st.ForEach, actorType, out)
+	}()
+
+	return out, nil
+}
+
+// streamActorsByType sends the addresses of the actors of the type visited by forEach, and an
+// entry holding the error when the walk fails
+func streamActorsByType(ctx context.Context, forEach func(func(address.Address, *types.Actor) error) error, actorType string, out chan<- api.ActorListEntry) {
+	err := forEach(func(addr address.Address, act *types.Actor) error {
+		if actors.CanonicalName(builtin.ActorNameByCode(act.Code)) != actorType {
+			return nil
+		}
+		select {
+		case out <- api.ActorListEntry{Address: addr}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	if err == nil || ctx.Err() != nil {
+		return
+	}
+
+	log.Errorw("listing actors by type", "type", actorType, "error", err)
+	select {
+	case out <- api.ActorListEntry{Error: xerrors.Errorf("walking state tree: %w", err).Error()}:
+	case <-ctx.Done():
+	}
+}
+
 func (m *StateModule) StateMarketBalance(ctx context.Context, addr address.Address, tsk types.TipSetKey) (api.MarketBalance, error) {
 	ts, err := m.Chain.GetTipSetFromKey(ctx, tsk)
 	if err != nil {
@@ -1224,7 +1418,57 @@ func (a *StateAPI) StateSectorPartition(ctx context.Context, maddr address.Addre
 	if err != nil {
 		return nil, err
 	}
-	return mas.FindSector(sectorNumber)
+
+	loc, err := mas.FindSector(sectorNumber)
+	if err != nil {
+		// sectors are only assigned to a deadline once proven, check if it's still pre-committed
+		pci, perr := mas.GetPrecommittedSector(sectorNumber)
+		if perr == nil && pci != nil {
+			return nil, xerrors.Errorf("sector %d is pre-committed: %w", sectorNumber, &api.ErrSectorNotAssigned{})
+		}
+		return nil, err
+	}
+	return loc, nil
+}
+
+func (a *StateAPI) StateMinerSectorDeadlineMap(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]api.SectorDeadlinePartition, error) {
+	act, err := a.StateManager.LoadActorTsk(ctx, maddr, tsk)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load miner actor: %w", err)
+	}
+
+	mas, err := miner.Load(a.StateManager.ChainStore().ActorStore(ctx), act)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load miner actor state: %w", err)
+	}
+
+	return sectorDeadlineMap(mas)
+}
+
+// sectorDeadlineMap returns the live sectors of each partition of the miner, by deadline and
+// partition index.
+func sectorDeadlineMap(mas miner.State) ([]api.SectorDeadlinePartition, error) {
+	var out []api.SectorDeadlinePartition
+	err := mas.ForEachDeadline(func(dlIdx uint64, dl miner.Deadline) error {
+		return dl.ForEachPartition(func(partIdx uint64, part miner.Partition) error {
+			live, err := part.LiveSectors()
+			if err != nil {
+				return xerrors.Errorf("getting live sectors of deadline %d partition %d: %w", dlIdx, partIdx, err)
+			}
+
+			out = append(out, api.SectorDeadlinePartition{
+				Deadline:  dlIdx,
+				Partition: partIdx,
+				Sectors:   live,
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("iterating deadlines: %w", err)
+	}
+
+	return out, nil
 }
 
 func (a *StateAPI) StateListMessages(ctx context.Context, match *api.MessageMatch, tsk types.TipSetKey, toheight abi.ChainEpoch) ([]cid.Cid, error) {
@@ -1710,6 +1954,44 @@ func (a *StateAPI) StateMinerAvailableBalance(ctx context.Context, maddr address
 	return types.BigAdd(abal, vested), nil
 }
 
+func (a *StateAPI) StateMinerVestingSchedule(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*api.MinerVestingSchedule, error) {
+	ts, err := a.Chain.GetTipSetFromKey(ctx, tsk)
+	if err != nil {
+		return nil, xerrors.Errorf("loading tipset %s: %w", tsk, err)
+	}
+
+	act, err := a.StateManager.LoadActor(ctx, maddr, ts)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load miner actor: %w", err)
+	}
+
+	mas, err := miner.Load(a.StateManager.ChainStore().ActorStore(ctx), act)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load miner actor state: %w", err)
+	}
+
+	locked, err := mas.LockedFunds()
+	if err != nil {
+		return nil, xerrors.Errorf("getting locked funds: %w", err)
+	}
+
+	vested, err := mas.VestedFunds(ts.Height())
+	if err != nil {
+		return nil, xerrors.Errorf("getting vested funds: %w", err)
+	}
+
+	schedule, err := mas.VestingSchedule()
+	if err != nil {
+		return nil, xerrors.Errorf("loading vesting schedule: %w", err)
+	}
+
+	return &api.MinerVestingSchedule{
+		Vesting:  locked.VestingFunds,
+		Vested:   vested,
+		Schedule: schedule,
+	}, nil
+}
+
 func (a *StateAPI) StateMinerSectorAllocated(ctx context.Context, maddr address.Address, s abi.SectorNumber, tsk types.TipSetKey) (bool, error) {
 	ts, err := a.Chain.GetTipSetFromKey(ctx, tsk)
 	if err != nil {
@@ -1910,6 +2192,19 @@ func (a *StateAPI) StateCirculatingSupply(ctx context.Context, tsk types.TipSetK
 	return a.StateManager.GetCirculatingSupply(ctx, ts.Height()-1, sTree)
 }
 
+func (a *StateAPI) StateTotalBurned(ctx context.Context, tsk types.TipSetKey) (abi.TokenAmount, error) {
+	ts, err := a.Chain.GetTipSetFromKey(ctx, tsk)
+	if err != nil {
+		return types.EmptyInt, xerrors.Errorf("loading tipset %s: %w", tsk, err)
+	}
+
+	act, err := a.StateManager.LoadActor(ctx, builtin.BurntFundsActorAddr, ts)
+	if err != nil {
+		return types.EmptyInt, xerrors.Errorf("loading burnt funds actor: %w", err)
+	}
+	return act.Balance, nil
+}
+
 func (a *StateAPI) StateVMCirculatingSupplyInternal(ctx context.Context, tsk types.TipSetKey) (api.CirculatingSupply, error) {
 	return stateVMCirculatingSupplyInternal(ctx, tsk, a.Chain, a.StateManager)
 }
@@ -0,0 +1,74 @@
+package full
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/manifest"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/actors"
+	"github.com/filecoin-project/lotus/chain/types"
+)
+
+func TestStreamActorsByType(t *testing.T) {
+	minerCode, ok := actors.GetActorCodeID(actorstypes.Version(actors.LatestVersion), manifest.MinerKey)
+	require.True(t, ok)
+	accountCode, ok := actors.GetActorCodeID(actorstypes.Version(actors.LatestVersion), manifest.AccountKey)
+	require.True(t, ok)
+
+	mkAddr := func(id uint64) address.Address {
+		a, err := address.NewIDAddress(id)
+		require.NoError(t, err)
+		return a
+	}
+	state := []struct {
+		addr address.Address
+		code *types.Actor
+	}{
+		{mkAddr(1000), &types.Actor{Code: minerCode}},
+		{mkAddr(1001), &types.Actor{Code: accountCode}},
+		{mkAddr(1002), &types.Actor{Code: minerCode}},
+	}
+
+	// failAfter is the number of actors visited before the walk fails, -1 to never fail
+	walk := func(failAfter int) func(func(address.Address, *types.Actor) error) error {
+		return func(cb func(address.Address, *types.Actor) error) error {
+			for i, a := range state {
+				if i == failAfter {
+					return xerrors.New("blockstore: block not found")
+				}
+				if err := cb(a.addr, a.code); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	list := func(failAfter int) []api.ActorListEntry {
+		out := make(chan api.ActorListEntry, len(state)+1)
+		streamActorsByType(context.Background(), walk(failAfter), manifest.MinerKey, out)
+		close(out)
+
+		var entries []api.ActorListEntry
+		for e := range out {
+			entries = append(entries, e)
+		}
+		return entries
+	}
+
+	require.Equal(t, []api.ActorListEntry{{Address: mkAddr(1000)}, {Address: mkAddr(1002)}}, list(-1))
+
+	// a failing walk ends with an error entry
+	entries := list(2)
+	require.Len(t, entries, 2)
+	require.Equal(t, mkAddr(1000), entries[0].Address)
+	require.Equal(t, address.Undef, entries[1].Address)
+	require.Contains(t, entries[1].Error, "block not found")
+}
@@ -0,0 +1,98 @@
+package full
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-bitfield"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/miner"
+)
+
+type testMinerState struct {
+	miner.State
+	deadlines [][]testPartition
+}
+
+func (s *testMinerState) ForEachDeadline(cb func(uint64, miner.Deadline) error) error {
+	for i, parts := range s.deadlines {
+		if err := cb(uint64(i), &testDeadline{parts: parts}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type testDeadline struct {
+	miner.Deadline
+	parts []testPartition
+}
+
+func (d *testDeadline) ForEachPartition(cb func(uint64, miner.Partition) error) error {
+	for i := range d.parts {
+		if err := cb(uint64(i), &d.parts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type testPartition struct {
+	miner.Partition
+	live []uint64
+	err  error
+}
+
+func (p *testPartition) LiveSectors() (bitfield.BitField, error) {
+	return bitfield.NewFromSet(p.live), p.err
+}
+
+func TestSectorDeadlineMap(t *testing.T) {
+	mas := &testMinerState{deadlines: [][]testPartition{
+		{{live: []uint64{1, 2, 3}}, {live: []uint64{4}}},
+		// deadlines without partitions aren't in the map
+		nil,
+		// partitions without live sectors, e.g. with only terminated ones, are still listed
+		{{live: []uint64{10, 12}}, {}, {live: []uint64{20}}},
+	}}
+
+	out, err := sectorDeadlineMap(mas)
+	require.NoError(t, err)
+
+	type entry struct {
+		deadline, partition uint64
+		sectors             []uint64
+	}
+	var entries []entry
+	for _, e := range out {
+		sectors, err := e.Sectors.All(1 << 20)
+		require.NoError(t, err)
+		entries = append(entries, entry{e.Deadline, e.Partition, sectors})
+	}
+	require.Equal(t, []entry{
+		{0, 0, []uint64{1, 2, 3}},
+		{0, 1, []uint64{4}},
+		{2, 0, []uint64{10, 12}},
+		{2, 1, []uint64{}},
+		{2, 2, []uint64{20}},
+	}, entries)
+
+	// each sector maps to the deadline and partition it's proven in
+	where := map[uint64]api.SectorDeadlinePartition{}
+	for _, e := range out {
+		require.NoError(t, e.Sectors.ForEach(func(s uint64) error {
+			where[s] = e
+			return nil
+		}))
+	}
+	require.Equal(t, uint64(2), where[12].Deadline)
+	require.Equal(t, uint64(0), where[12].Partition)
+	require.Equal(t, uint64(2), where[20].Partition)
+
+	mas.deadlines[2][1].err = xerrors.New("blockstore: block not found")
+	_, err = sectorDeadlineMap(mas)
+	require.ErrorContains(t, err, "getting live sectors of deadline 2 partition 1")
+}
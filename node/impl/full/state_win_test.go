@@ -0,0 +1,69 @@
+package full
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+)
+
+func TestWinEligibilityBase(t *testing.T) {
+	ctx := context.Background()
+
+	// tipsets at heights 0-10, with null rounds at 5-6
+	tipsets := map[abi.ChainEpoch]*types.TipSet{}
+	var head *types.TipSet
+	for h := abi.ChainEpoch(0); h <= 10; h++ {
+		if h == 5 || h == 6 {
+			continue
+		}
+		blk := mock.MkBlock(nil, 0, uint64(h))
+		blk.Height = h
+		head = mock.TipSet(blk)
+		tipsets[h] = head
+	}
+	byHeight := func(_ context.Context, h abi.ChainEpoch, ts *types.TipSet, prev bool) (*types.TipSet, error) {
+		require.True(t, prev)
+		require.Equal(t, head, ts)
+		if h < 0 {
+			return nil, xerrors.Errorf("looking for tipset with height greater than start point")
+		}
+		for ; h >= 0; h-- {
+			if ts, ok := tipsets[h]; ok {
+				return ts, nil
+			}
+		}
+		t.Fatal("no tipset")
+		return nil, nil
+	}
+
+	for _, tc := range []struct {
+		epoch abi.ChainEpoch
+		base  abi.ChainEpoch
+	}{
+		// mined on the tipset right below
+		{epoch: 4, base: 3},
+		{epoch: 10, base: 9},
+		// after null rounds, on the last tipset before them
+		{epoch: 5, base: 4},
+		{epoch: 7, base: 4},
+		{epoch: 8, base: 7},
+		// the next epoch and future ones are mined on the head
+		{epoch: 11, base: 10},
+		{epoch: 100, base: 10},
+	} {
+		base, err := winEligibilityBase(ctx, byHeight, head, tc.epoch)
+		require.NoError(t, err, "epoch %d", tc.epoch)
+		require.Equal(t, tc.base, base.Height(), "epoch %d", tc.epoch)
+	}
+
+	// there's nothing to mine the genesis on
+	_, err := winEligibilityBase(ctx, byHeight, head, 0)
+	require.ErrorContains(t, err, "loading base tipset for epoch 0")
+}
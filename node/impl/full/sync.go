@@ -114,8 +114,22 @@ func (a *SyncAPI) SyncIncomingBlocks(ctx context.Context) (<-chan *types.BlockHe
 }
 
 func (a *SyncAPI) SyncCheckpoint(ctx context.Context, tsk types.TipSetKey) error {
+	if tsk == types.EmptyTSK {
+		return xerrors.Errorf("called with empty tsk")
+	}
+
+	ts, err := a.Syncer.ChainStore().LoadTipSet(ctx, tsk)
+	if err != nil {
+		return xerrors.Errorf("loading tipset %s: %w", tsk, err)
+	}
+
 	log.Warnf("Marking tipset %s as checkpoint", tsk)
-	return a.Syncer.SyncCheckpoint(ctx, tsk)
+	return a.Syncer.ChainStore().SetCheckpointOnHead(ctx, ts)
+}
+
+func (a *SyncAPI) SyncCheckpointClear(ctx context.Context) error {
+	log.Warn("Removing chain checkpoint")
+	return a.Syncer.ChainStore().RemoveCheckpoint(ctx)
 }
 
 func (a *SyncAPI) SyncMarkBad(ctx context.Context, bcid cid.Cid) error {
@@ -133,3 +133,15 @@ func (a *NetAPI) NetBlockList(ctx context.Context) (result api.NetBlockList, err
 	}
 	return
 }
+
+func (a *NetAPI) NetSubnetConns(ctx context.Context) ([]api.NetSubnetConns, error) {
+	var out []api.NetSubnetConns
+	for subnet, n := range a.SubnetLimiter.Counts() {
+		out = append(out, api.NetSubnetConns{
+			Subnet: subnet,
+			Conns:  n,
+			Limit:  a.SubnetLimiter.Limit(subnet),
+		})
+	}
+	return out, nil
+}
@@ -32,6 +32,7 @@ type NetAPI struct {
 	Host            host.Host
 	Router          lp2p.BaseIpfsRouting
 	ConnGater       *conngater.BasicConnectionGater
+	SubnetLimiter   *lp2p.SubnetLimiter
 	ResourceManager network.ResourceManager
 	Reporter        metrics.Reporter
 	Sk              *dtypes.ScoreKeeper
@@ -176,6 +177,43 @@ func (a *NetAPI) NetAgentVersion(ctx context.Context, p peer.ID) (string, error)
 	return ag.(string), nil
 }
 
+func (a *NetAPI) NetPeerProtocols(ctx context.Context, p peer.ID) ([]protocol.ID, error) {
+	if p == a.Host.ID() {
+		return a.Host.Mux().Protocols(), nil
+	}
+
+	protos, err := a.Host.Peerstore().GetProtocols(p)
+	if err != nil {
+		return nil, xerrors.Errorf("getting peer protocols: %w", err)
+	}
+
+	if len(protos) == 0 && a.Host.Network().Connectedness(p) != network.Connected {
+		// not identified yet, connecting waits for identify to complete
+		ai := peer.AddrInfo{ID: p, Addrs: a.Host.Peerstore().Addrs(p)}
+		if len(ai.Addrs) == 0 {
+			if ai, err = a.Router.FindPeer(ctx, p); err != nil {
+				return nil, xerrors.Errorf("peer wasn't identified yet, finding its addresses: %w", err)
+			}
+		}
+		if err := a.Host.Connect(ctx, ai); err != nil {
+			return nil, xerrors.Errorf("peer wasn't identified yet, connecting: %w", err)
+		}
+
+		if protos, err = a.Host.Peerstore().GetProtocols(p); err != nil {
+			return nil, xerrors.Errorf("getting peer protocols: %w", err)
+		}
+	}
+
+	if len(protos) == 0 {
+		return nil, xerrors.Errorf("protocols of peer %s are not known yet, identify didn't complete", p)
+	}
+
+	sort.Slice(protos, func(i, j int) bool {
+		return protos[i] < protos[j]
+	})
+	return protos, nil
+}
+
 func (a *NetAPI) NetBandwidthStats(ctx context.Context) (metrics.Stats, error) {
 	return a.Reporter.GetBandwidthTotals(), nil
 }
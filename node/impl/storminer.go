@@ -167,6 +167,14 @@ func (sm *StorageMinerAPI) waitSectorStarted(ctx context.Context, si abi.SectorI
 	}
 }
 
+func (sm *StorageMinerAPI) SectorsStatusStream(ctx context.Context, filter *api.SectorNumberRange) (<-chan api.SectorStateChange, error) {
+	if filter != nil && filter.From > filter.To {
+		return nil, xerrors.Errorf("invalid sector number range %d-%d", filter.From, filter.To)
+	}
+
+	return sm.Miner.SubscribeStateChanges(ctx, filter), nil
+}
+
 func (sm *StorageMinerAPI) SectorsStatus(ctx context.Context, sid abi.SectorNumber, showOnChainInfo bool) (api.SectorInfo, error) {
 	sInfo, err := sm.Miner.SectorsStatus(ctx, sid, false)
 	if err != nil {
@@ -269,6 +277,59 @@ func (sm *StorageMinerAPI) SectorsList(context.Context) ([]abi.SectorNumber, err
 	return out, nil
 }
 
+func (sm *StorageMinerAPI) PieceLocations(ctx context.Context, pieceCid cid.Cid) ([]api.PieceLocation, error) {
+	sectors, err := sm.Miner.ListSectors()
+	if err != nil {
+		return nil, err
+	}
+
+	minerID, err := address.IDFromAddress(sm.Miner.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	return pieceLocations(ctx, abi.ActorID(minerID), sectors, pieceCid, sm.RemoteStore.CheckIsUnsealed), nil
+}
+
+type checkUnsealedFunc func(ctx context.Context, s storiface.SectorRef, offset, size abi.PaddedPieceSize) (bool, error)
+
+// pieceLocations returns the locations of the piece in the sectors, checking whether each copy is
+// also available unsealed.
+func pieceLocations(ctx context.Context, minerID abi.ActorID, sectors []sealing.SectorInfo, pieceCid cid.Cid, isUnsealed checkUnsealedFunc) []api.PieceLocation {
+	out := []api.PieceLocation{}
+	for _, sector := range sectors {
+		if sector.State == sealing.UndefinedSectorState || sector.State == sealing.Removed {
+			continue
+		}
+
+		var offset abi.PaddedPieceSize
+		for _, p := range sector.Pieces {
+			pi := p.Piece()
+			if pi.PieceCID.Equals(pieceCid) {
+				sref := storiface.SectorRef{
+					ID:        abi.SectorID{Miner: minerID, Number: sector.SectorNumber},
+					ProofType: sector.SectorType,
+				}
+				unsealed, err := isUnsealed(ctx, sref, offset, pi.Size)
+				if err != nil {
+					log.Warnw("checking if piece is unsealed", "sector", sector.SectorNumber, "piece", pieceCid, "error", err)
+				}
+
+				out = append(out, api.PieceLocation{
+					SectorNumber: sector.SectorNumber,
+					Offset:       offset,
+					Length:       pi.Size,
+					State:        api.SectorState(sector.State),
+					HasUnsealed:  unsealed,
+				})
+			}
+			offset += pi.Size
+		}
+	}
+
+	return out
+}
+
 func (sm *StorageMinerAPI) SectorsListInStates(ctx context.Context, states []api.SectorState) ([]abi.SectorNumber, error) {
 	filterStates := make(map[sealing.SectorState]struct{})
 	for _, state := range states {
@@ -302,6 +363,137 @@ func (sm *StorageMinerAPI) SectorsSummary(ctx context.Context) (map[api.SectorSt
 	return sm.Miner.SectorsSummary(ctx), nil
 }
 
+// sectorHealthSamples is the number of sector numbers returned for each group by
+// SectorsSummaryByHealth.
+const sectorHealthSamples = 10
+
+func (sm *StorageMinerAPI) SectorsSummaryByHealth(ctx context.Context) (*api.SectorHealthSummary, error) {
+	maddr := sm.Miner.Address()
+
+	head, err := sm.Full.ChainHead(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("getting chain head: %w", err)
+	}
+
+	dls, err := sm.Full.StateMinerDeadlines(ctx, maddr, head.Key())
+	if err != nil {
+		return nil, xerrors.Errorf("getting deadlines: %w", err)
+	}
+
+	var parts []api.Partition
+	for dlIdx := range dls {
+		dlParts, err := sm.Full.StateMinerPartitions(ctx, maddr, uint64(dlIdx), head.Key())
+		if err != nil {
+			return nil, xerrors.Errorf("getting partitions of deadline %d: %w", dlIdx, err)
+		}
+		parts = append(parts, dlParts...)
+	}
+
+	sectors, err := sm.Miner.ListSectors()
+	if err != nil {
+		return nil, xerrors.Errorf("listing local sectors: %w", err)
+	}
+
+	return sectorHealthSummary(parts, sectors)
+}
+
+// sectorHealthSummary groups the sectors of the miner's partitions by their on-chain health, and
+// the local sectors not in any partition into terminated and sealing ones.
+func sectorHealthSummary(parts []api.Partition, sectors []sealing.SectorInfo) (*api.SectorHealthSummary, error) {
+	var healthy, faulty, recovering, terminated, onChain []bitfield.BitField
+	for _, p := range parts {
+		h, err := bitfield.SubtractBitField(p.LiveSectors, p.FaultySectors)
+		if err != nil {
+			return nil, err
+		}
+		f, err := bitfield.SubtractBitField(p.FaultySectors, p.RecoveringSectors)
+		if err != nil {
+			return nil, err
+		}
+		t, err := bitfield.SubtractBitField(p.AllSectors, p.LiveSectors)
+		if err != nil {
+			return nil, err
+		}
+
+		healthy = append(healthy, h)
+		faulty = append(faulty, f)
+		recovering = append(recovering, p.RecoveringSectors)
+		terminated = append(terminated, t)
+		onChain = append(onChain, p.AllSectors)
+	}
+
+	inPartitions, err := bitfield.MultiMerge(onChain...)
+	if err != nil {
+		return nil, err
+	}
+
+	var localTerminated, sealingSectors []uint64
+	for _, si := range sectors {
+		found, err := inPartitions.IsSet(uint64(si.SectorNumber))
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			continue
+		}
+
+		switch si.State {
+		case sealing.Removing, sealing.RemoveFailed, sealing.Removed:
+		case sealing.Proving, sealing.Available, sealing.UpdateActivating, sealing.ReleaseSectorKey,
+			sealing.Faulty, sealing.FaultReported, sealing.FaultedFinal,
+			sealing.Terminating, sealing.TerminateWait, sealing.TerminateFinality, sealing.TerminateFailed:
+			localTerminated = append(localTerminated, uint64(si.SectorNumber))
+		default:
+			sealingSectors = append(sealingSectors, uint64(si.SectorNumber))
+		}
+	}
+	terminated = append(terminated, bitfield.NewFromSet(localTerminated))
+
+	group := func(bfs ...bitfield.BitField) (api.SectorHealthGroup, error) {
+		merged, err := bitfield.MultiMerge(bfs...)
+		if err != nil {
+			return api.SectorHealthGroup{}, err
+		}
+		count, err := merged.Count()
+		if err != nil {
+			return api.SectorHealthGroup{}, err
+		}
+
+		g := api.SectorHealthGroup{Count: int(count)}
+		err = merged.ForEach(func(s uint64) error {
+			if len(g.Samples) == sectorHealthSamples {
+				return errStopForEach
+			}
+			g.Samples = append(g.Samples, abi.SectorNumber(s))
+			return nil
+		})
+		if err != nil && err != errStopForEach {
+			return api.SectorHealthGroup{}, err
+		}
+		return g, nil
+	}
+
+	var out api.SectorHealthSummary
+	for _, g := range []struct {
+		dst *api.SectorHealthGroup
+		bfs []bitfield.BitField
+	}{
+		{&out.Healthy, healthy},
+		{&out.Faulty, faulty},
+		{&out.Recovering, recovering},
+		{&out.Terminated, terminated},
+		{&out.Sealing, []bitfield.BitField{bitfield.NewFromSet(sealingSectors)}},
+	} {
+		if *g.dst, err = group(g.bfs...); err != nil {
+			return nil, xerrors.Errorf("grouping sectors: %w", err)
+		}
+	}
+
+	return &out, nil
+}
+
+var errStopForEach = xerrors.New("stop")
+
 func (sm *StorageMinerAPI) StorageLocal(ctx context.Context) (map[storiface.ID]string, error) {
 	l, err := sm.LocalStore.Local(ctx)
 	if err != nil {
@@ -371,6 +563,15 @@ func (sm *StorageMinerAPI) SectorsUpdate(ctx context.Context, id abi.SectorNumbe
 	return sm.Miner.ForceSectorState(ctx, id, sealing.SectorState(state))
 }
 
+func (sm *StorageMinerAPI) SectorRetryStep(ctx context.Context, id abi.SectorNumber, step string) error {
+	return sm.Miner.RetrySectorStep(ctx, id, step)
+}
+
+func (sm *StorageMinerAPI) SectorRecoverFailed(ctx context.Context, id abi.SectorNumber) (api.SectorState, error) {
+	st, err := sm.Miner.RecoverFailedSector(ctx, id)
+	return api.SectorState(st), err
+}
+
 func (sm *StorageMinerAPI) SectorRemove(ctx context.Context, id abi.SectorNumber) error {
 	return sm.Miner.RemoveSector(ctx, id)
 }
@@ -448,6 +649,20 @@ func (sm *StorageMinerAPI) SectorReceive(ctx context.Context, meta api.RemoteSec
 	return err
 }
 
+func (sm *StorageMinerAPI) AckWindowPoStFaults(ctx context.Context, dlIdx uint64) error {
+	if sm.WdPoSt == nil {
+		return xerrors.Errorf("window post scheduler is not running on this node")
+	}
+	return sm.WdPoSt.AckFaults(dlIdx)
+}
+
+func (sm *StorageMinerAPI) ProvingResourceForecast(ctx context.Context) (*api.ProvingForecast, error) {
+	if sm.WdPoSt == nil {
+		return nil, xerrors.Errorf("window post scheduler is not running on this node")
+	}
+	return sm.WdPoSt.ResourceForecast(ctx)
+}
+
 func (sm *StorageMinerAPI) ComputeWindowPoSt(ctx context.Context, dlIdx uint64, tsk types.TipSetKey) ([]lminer.SubmitWindowedPoStParams, error) {
 	var ts *types.TipSet
 	var err error
@@ -490,6 +705,10 @@ func (sm *StorageMinerAPI) SealingRemoveRequest(ctx context.Context, schedId uui
 	return sm.StorageMgr.RemoveSchedRequest(ctx, schedId)
 }
 
+func (sm *StorageMinerAPI) SealingSchedReleaseLeaked(ctx context.Context) ([]storiface.SchedReservation, error) {
+	return sm.StorageMgr.ReleaseLeakedReservations(ctx)
+}
+
 func (sm *StorageMinerAPI) listDeals(ctx context.Context) ([]*api.MarketDeal, error) {
 	ts, err := sm.Full.ChainHead(ctx)
 	if err != nil {
@@ -547,6 +766,15 @@ func (sm *StorageMinerAPI) StorageRedeclareLocal(ctx context.Context, id *storif
 
 	return sm.StorageMgr.RedeclareLocalStorage(ctx, id, dropMissing)
 }
+
+func (sm *StorageMinerAPI) StorageRemoveUnsealed(ctx context.Context, sector abi.SectorID) error {
+	if sm.StorageMgr == nil {
+		return xerrors.Errorf("no storage manager")
+	}
+
+	return sm.StorageMgr.RemoveUnsealed(ctx, sector)
+}
+
 func (sm *StorageMinerAPI) CreateBackup(ctx context.Context, fpath string) error {
 	return backup(ctx, sm.DS, fpath)
 }
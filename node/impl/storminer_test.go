@@ -0,0 +1,186 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-bitfield"
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/api"
+	sealing "github.com/filecoin-project/lotus/storage/pipeline"
+	"github.com/filecoin-project/lotus/storage/sealer/storiface"
+)
+
+func TestSectorHealthSummary(t *testing.T) {
+	bf := func(sectors ...uint64) bitfield.BitField {
+		return bitfield.NewFromSet(sectors)
+	}
+	group := func(sectors ...abi.SectorNumber) api.SectorHealthGroup {
+		return api.SectorHealthGroup{Count: len(sectors), Samples: sectors}
+	}
+	local := func(n abi.SectorNumber, state sealing.SectorState) sealing.SectorInfo {
+		return sealing.SectorInfo{SectorNumber: n, State: state}
+	}
+
+	tests := []struct {
+		name    string
+		parts   []api.Partition
+		sectors []sealing.SectorInfo
+		expect  api.SectorHealthSummary
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "on-chain health",
+			parts: []api.Partition{
+				{
+					// 1, 2 healthy, 3 faulty, 4 recovering, 5 terminated
+					AllSectors:        bf(1, 2, 3, 4, 5),
+					LiveSectors:       bf(1, 2, 3, 4),
+					FaultySectors:     bf(3, 4),
+					RecoveringSectors: bf(4),
+				},
+				{
+					AllSectors:        bf(10, 11),
+					LiveSectors:       bf(10),
+					FaultySectors:     bf(),
+					RecoveringSectors: bf(),
+				},
+			},
+			expect: api.SectorHealthSummary{
+				Healthy:    group(1, 2, 10),
+				Faulty:     group(3),
+				Recovering: group(4),
+				Terminated: group(5, 11),
+			},
+		},
+		{
+			name: "local sectors outside of partitions",
+			parts: []api.Partition{
+				{
+					AllSectors:        bf(1),
+					LiveSectors:       bf(1),
+					FaultySectors:     bf(),
+					RecoveringSectors: bf(),
+				},
+			},
+			sectors: []sealing.SectorInfo{
+				// in a partition, grouped by its on-chain health only
+				local(1, sealing.Proving),
+				// proven once, but gone from the chain
+				local(2, sealing.Proving),
+				local(3, sealing.FaultReported),
+				local(4, sealing.TerminateWait),
+				// being removed, not counted at all
+				local(5, sealing.Removing),
+				local(6, sealing.Removed),
+				// not on chain yet
+				local(7, sealing.PreCommit1),
+				local(8, sealing.WaitDeals),
+				local(9, sealing.CommitFailed),
+			},
+			expect: api.SectorHealthSummary{
+				Healthy:    group(1),
+				Terminated: group(2, 3, 4),
+				Sealing:    group(7, 8, 9),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := sectorHealthSummary(tc.parts, tc.sectors)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, *out)
+		})
+	}
+
+	// only the lowest sector numbers are sampled
+	var all []uint64
+	for s := uint64(100); s > 0; s-- {
+		all = append(all, s)
+	}
+	out, err := sectorHealthSummary([]api.Partition{{
+		AllSectors:        bf(all...),
+		LiveSectors:       bf(all...),
+		FaultySectors:     bf(),
+		RecoveringSectors: bf(),
+	}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 100, out.Healthy.Count)
+	require.Len(t, out.Healthy.Samples, sectorHealthSamples)
+	require.Equal(t, abi.SectorNumber(1), out.Healthy.Samples[0])
+	require.Equal(t, abi.SectorNumber(sectorHealthSamples), out.Healthy.Samples[sectorHealthSamples-1])
+}
+
+func TestPieceLocations(t *testing.T) {
+	ctx := context.Background()
+
+	pieceCid := func(data string) cid.Cid {
+		c, err := commcid.DataCommitmentV1ToCID([]byte(fmt.Sprintf("%-32s", data)))
+		require.NoError(t, err)
+		return c
+	}
+	wanted, other := pieceCid("wanted"), pieceCid("other")
+
+	pieces := func(ps ...abi.PieceInfo) []sealing.SafeSectorPiece {
+		var out []sealing.SafeSectorPiece
+		for _, p := range ps {
+			out = append(out, sealing.SafePiece(api.SectorPiece{Piece: p}))
+		}
+		return out
+	}
+	sectors := []sealing.SectorInfo{
+		// the piece after another one, with an unsealed copy
+		{SectorNumber: 1, State: sealing.Proving, SectorType: abi.RegisteredSealProof_StackedDrg2KiBV1_1,
+			Pieces: pieces(abi.PieceInfo{Size: 256, PieceCID: other}, abi.PieceInfo{Size: 512, PieceCID: wanted})},
+		// only sealed
+		{SectorNumber: 2, State: sealing.Available, SectorType: abi.RegisteredSealProof_StackedDrg2KiBV1_1,
+			Pieces: pieces(abi.PieceInfo{Size: 1024, PieceCID: wanted})},
+		// checking the unsealed copy fails, the location is still returned
+		{SectorNumber: 3, State: sealing.WaitSeed, SectorType: abi.RegisteredSealProof_StackedDrg2KiBV1_1,
+			Pieces: pieces(abi.PieceInfo{Size: 1024, PieceCID: wanted}, abi.PieceInfo{Size: 1024, PieceCID: wanted})},
+		{SectorNumber: 4, State: sealing.Proving, Pieces: pieces(abi.PieceInfo{Size: 2048, PieceCID: other})},
+		// removed sectors have no copies
+		{SectorNumber: 5, State: sealing.Removed, Pieces: pieces(abi.PieceInfo{Size: 2048, PieceCID: wanted})},
+	}
+
+	type check struct {
+		sector abi.SectorNumber
+		offset abi.PaddedPieceSize
+	}
+	var checked []check
+	isUnsealed := func(_ context.Context, s storiface.SectorRef, offset, size abi.PaddedPieceSize) (bool, error) {
+		require.Equal(t, abi.ActorID(1000), s.ID.Miner)
+		require.Equal(t, abi.RegisteredSealProof_StackedDrg2KiBV1_1, s.ProofType)
+		checked = append(checked, check{s.ID.Number, offset})
+
+		switch s.ID.Number {
+		case 1:
+			return true, nil
+		case 3:
+			return false, xerrors.New("storage unreachable")
+		}
+		return false, nil
+	}
+
+	out := pieceLocations(ctx, 1000, sectors, wanted, isUnsealed)
+	require.Equal(t, []api.PieceLocation{
+		{SectorNumber: 1, Offset: 256, Length: 512, State: api.SectorState(sealing.Proving), HasUnsealed: true},
+		{SectorNumber: 2, Offset: 0, Length: 1024, State: api.SectorState(sealing.Available)},
+		{SectorNumber: 3, Offset: 0, Length: 1024, State: api.SectorState(sealing.WaitSeed)},
+		{SectorNumber: 3, Offset: 1024, Length: 1024, State: api.SectorState(sealing.WaitSeed)},
+	}, out)
+	require.Equal(t, []check{{1, 256}, {2, 0}, {3, 0}, {3, 1024}}, checked)
+
+	// pieces not in any sector have no locations
+	require.Empty(t, pieceLocations(ctx, 1000, sectors, pieceCid("missing"), isUnsealed))
+}
@@ -74,13 +74,35 @@ func BadgerHotBlockstore(lc fx.Lifecycle, r repo.LockedRepo) (dtypes.HotBlocksto
 	return bs, nil
 }
 
-func SplitBlockstore(cfg *config.Chainstore) func(lc fx.Lifecycle, r repo.LockedRepo, ds dtypes.MetadataDS, cold dtypes.ColdBlockstore, hot dtypes.HotBlockstore) (dtypes.SplitBlockstore, error) {
-	return func(lc fx.Lifecycle, r repo.LockedRepo, ds dtypes.MetadataDS, cold dtypes.ColdBlockstore, hot dtypes.HotBlockstore) (dtypes.SplitBlockstore, error) {
+func SplitBlockstore(cfg *config.Chainstore) func(lc fx.Lifecycle, r repo.LockedRepo, ds dtypes.MetadataDS, cold dtypes.ColdBlockstore, hot dtypes.HotBlockstore, skipWarmup dtypes.SkipSplitstoreWarmup) (dtypes.SplitBlockstore, error) {
+	return func(lc fx.Lifecycle, r repo.LockedRepo, ds dtypes.MetadataDS, cold dtypes.ColdBlockstore, hot dtypes.HotBlockstore, skipWarmup dtypes.SkipSplitstoreWarmup) (dtypes.SplitBlockstore, error) {
 		path, err := r.SplitstorePath()
 		if err != nil {
 			return nil, err
 		}
 
+		var windows []splitstore.CompactionWindow
+		for _, w := range cfg.Splitstore.CompactionWindows {
+			cw, err := splitstore.ParseCompactionWindow(w)
+			if err != nil {
+				return nil, xerrors.Errorf("parsing Splitstore.CompactionWindows: %w", err)
+			}
+			windows = append(windows, cw)
+		}
+
+		var lazyWarmup bool
+		switch cfg.Splitstore.WarmupMode {
+		case "", "eager":
+		case "lazy":
+			lazyWarmup = true
+		default:
+			return nil, xerrors.Errorf("unknown Splitstore.WarmupMode %q, expected \"eager\" or \"lazy\"", cfg.Splitstore.WarmupMode)
+		}
+		if bool(skipWarmup) && !lazyWarmup {
+			log.Info("skipping eager splitstore warmup as requested")
+			lazyWarmup = true
+		}
+
 		cfg := &splitstore.Config{
 			MarkSetType:                  cfg.Splitstore.MarkSetType,
 			DiscardColdBlocks:            cfg.Splitstore.ColdStoreType == "discard",
@@ -90,6 +112,8 @@ func SplitBlockstore(cfg *config.Chainstore) func(lc fx.Lifecycle, r repo.Locked
 			HotstoreMaxSpaceTarget:       cfg.Splitstore.HotStoreMaxSpaceTarget,
 			HotstoreMaxSpaceThreshold:    cfg.Splitstore.HotStoreMaxSpaceThreshold,
 			HotstoreMaxSpaceSafetyBuffer: cfg.Splitstore.HotstoreMaxSpaceSafetyBuffer,
+			CompactionWindows:            windows,
+			LazyWarmup:                   lazyWarmup,
 		}
 		ss, err := splitstore.Open(path, ds, hot, cold, cfg)
 		if err != nil {
@@ -26,6 +26,7 @@ import (
 	"github.com/filecoin-project/lotus/chain/store"
 	"github.com/filecoin-project/lotus/chain/vm"
 	"github.com/filecoin-project/lotus/journal"
+	"github.com/filecoin-project/lotus/node/config"
 	"github.com/filecoin-project/lotus/node/modules/dtypes"
 	"github.com/filecoin-project/lotus/node/modules/helpers"
 )
@@ -59,8 +60,9 @@ func ChainBlockService(bs dtypes.ExposedBlockstore, rem dtypes.ChainBitswap) dty
 	return blockservice.New(bs, rem)
 }
 
-func MessagePool(lc fx.Lifecycle, mctx helpers.MetricsCtx, us stmgr.UpgradeSchedule, mpp messagepool.Provider, ds dtypes.MetadataDS, nn dtypes.NetworkName, j journal.Journal, protector dtypes.GCReferenceProtector) (*messagepool.MessagePool, error) {
-	mp, err := messagepool.New(helpers.LifecycleCtx(mctx, lc), mpp, ds, us, nn, j)
+func MessagePool(lc fx.Lifecycle, mctx helpers.MetricsCtx, us stmgr.UpgradeSchedule, mpp messagepool.Provider, ds dtypes.MetadataDS, nn dtypes.NetworkName, j journal.Journal, protector dtypes.GCReferenceProtector, pcfg *config.Pubsub) (*messagepool.MessagePool, error) {
+	// accept messages up to the size which can be propagated on the messages topic
+	mp, err := messagepool.NewWithMaxMessageSize(helpers.LifecycleCtx(mctx, lc), mpp, ds, us, nn, j, pcfg.MessagesTopicMaxMessageSize)
 	if err != nil {
 		return nil, xerrors.Errorf("constructing mpool: %w", err)
 	}
@@ -144,6 +146,7 @@ type SyncerParams struct {
 	Beacon       beacon.Schedule
 	Gent         chain.Genesis
 	Consensus    consensus.Consensus
+	SyncConfig   *config.SyncConfig `optional:"true"`
 }
 
 func NewSyncer(params SyncerParams) (*chain.Syncer, error) {
@@ -156,7 +159,12 @@ func NewSyncer(params SyncerParams) (*chain.Syncer, error) {
 		h      = params.Host
 		b      = params.Beacon
 	)
-	syncer, err := chain.NewSyncer(ds, sm, ex, smCtor, h.ConnManager(), h.ID(), b, params.Gent, params.Consensus)
+
+	var validationParallelism int
+	if params.SyncConfig != nil {
+		validationParallelism = params.SyncConfig.ValidationParallelism
+	}
+	syncer, err := chain.NewSyncer(ds, sm, ex, smCtor, h.ConnManager(), h.ID(), b, params.Gent, params.Consensus, validationParallelism)
 	if err != nil {
 		return nil, err
 	}
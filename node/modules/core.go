@@ -18,6 +18,7 @@ import (
 	"go.uber.org/fx"
 	"golang.org/x/xerrors"
 
+	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-jsonrpc/auth"
 	"github.com/filecoin-project/go-state-types/abi"
 
@@ -156,7 +157,15 @@ func APISecret(keystore types.KeyStore, lr repo.LockedRepo) (*dtypes.APIAlg, err
 			PrivateKey: sk,
 		}
 
-		if err := keystore.Put(JWTSecretName, key); err != nil {
+		err = keystore.Put(JWTSecretName, key)
+		if errors.Is(err, types.ErrKeyExists) {
+			// another node sharing the keystore (see repo.DBKeyStore) stored its secret first, use it
+			log.Info("API secret was created concurrently, using the stored secret")
+			key, err = keystore.Get(JWTSecretName)
+			if err != nil {
+				return nil, xerrors.Errorf("reading concurrently created API secret: %w", err)
+			}
+		} else if err != nil {
 			return nil, xerrors.Errorf("writing API secret: %w", err)
 		}
 
@@ -213,6 +222,37 @@ func NewDefaultMaxFeeFunc(r repo.LockedRepo) dtypes.DefaultMaxFeeFunc {
 	}
 }
 
+func NewGasPremiumMultipliersFunc(r repo.LockedRepo) (dtypes.GasPremiumMultipliersFunc, error) {
+	get := func() (out map[address.Address]float64, err error) {
+		var raw map[string]float64
+		if err := readNodeCfg(r, func(cfg *config.FullNode) {
+			raw = cfg.Fees.GasPremiumMultipliers
+		}); err != nil {
+			return nil, err
+		}
+
+		out = make(map[address.Address]float64, len(raw))
+		for a, mul := range raw {
+			addr, err := address.NewFromString(a)
+			if err != nil {
+				return nil, xerrors.Errorf("parsing Fees.GasPremiumMultipliers address %q: %w", a, err)
+			}
+			if !(mul > 0) {
+				return nil, xerrors.Errorf("Fees.GasPremiumMultipliers multiplier for %s must be positive, got %f", a, mul)
+			}
+			out[addr] = mul
+		}
+		return out, nil
+	}
+
+	// fail early on invalid configuration
+	if _, err := get(); err != nil {
+		return nil, err
+	}
+
+	return get, nil
+}
+
 func readNodeCfg(r repo.LockedRepo, accessor func(node *config.FullNode)) error {
 	raw, err := r.Config()
 	if err != nil {
@@ -36,3 +36,14 @@ func (ml *MpoolLocker) TakeLock(ctx context.Context, a address.Address) (func(),
 }
 
 type DefaultMaxFeeFunc func() (abi.TokenAmount, error)
+
+// MpoolStrictAccept is the strict acceptance policy for messages pushed through the API. The zero
+// value accepts all messages.
+type MpoolStrictAccept struct {
+	MaxGasLimit          int64
+	RejectZeroPremium    bool
+	RejectUnknownMethods bool
+}
+
+// GasPremiumMultipliersFunc returns the configured gas premium multipliers by sender address
+type GasPremiumMultipliersFunc func() (map[address.Address]float64, error)
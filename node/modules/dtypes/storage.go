@@ -57,5 +57,9 @@ type (
 	ExposedBlockstore blockstore.Blockstore
 )
 
+// SkipSplitstoreWarmup indicates that the splitstore should skip the eager hotstore warmup,
+// regardless of the configured warmup mode.
+type SkipSplitstoreWarmup bool
+
 type ChainBitswap exchange.Interface
 type ChainBlockService bserv.BlockService
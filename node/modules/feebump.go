@@ -0,0 +1,42 @@
+package modules
+
+import (
+	"time"
+
+	"go.uber.org/fx"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/chain/messagepool/feebump"
+	"github.com/filecoin-project/lotus/chain/messagesigner"
+	"github.com/filecoin-project/lotus/node/config"
+	"github.com/filecoin-project/lotus/node/impl/full"
+)
+
+type feeBumpModules struct {
+	fx.In
+
+	full.ChainModuleAPI
+	full.MpoolAPI
+}
+
+func MpoolFeeBump(cfg config.FeeBumpConfig) func(lc fx.Lifecycle, mod feeBumpModules, ms *messagesigner.MessageSigner) error {
+	return func(lc fx.Lifecycle, mod feeBumpModules, ms *messagesigner.MessageSigner) error {
+		b, err := feebump.NewBumper(&mod, ms.AutoBumpMarked, feebump.Config{
+			StuckEpochs:   abi.ChainEpoch(cfg.StuckEpochs),
+			MaxFee:        abi.TokenAmount(cfg.MaxFee),
+			BumpPushed:    cfg.BumpPushedMessages,
+			CheckInterval: time.Duration(cfg.CheckInterval),
+		})
+		if err != nil {
+			return xerrors.Errorf("creating mpool fee bumper: %w", err)
+		}
+
+		lc.Append(fx.Hook{
+			OnStart: b.Start,
+			OnStop:  b.Stop,
+		})
+		return nil
+	}
+}
@@ -1,9 +1,20 @@
 package lp2p
 
 import (
+	"net"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"golang.org/x/xerrors"
 
+	"github.com/filecoin-project/lotus/build"
 	"github.com/filecoin-project/lotus/node/modules/dtypes"
 )
 
@@ -11,7 +22,219 @@ func ConnGater(ds dtypes.MetadataDS) (*conngater.BasicConnectionGater, error) {
 	return conngater.NewBasicConnectionGater(ds)
 }
 
-func ConnGaterOption(cg *conngater.BasicConnectionGater) (opts Libp2pOpts, err error) {
-	opts.Opts = append(opts.Opts, libp2p.ConnectionGater(cg))
+func ConnGaterOption(cg *conngater.BasicConnectionGater, sl *SubnetLimiter) (opts Libp2pOpts, err error) {
+	opts.Opts = append(opts.Opts, libp2p.ConnectionGater(&subnetGater{BasicConnectionGater: cg, limiter: sl}))
 	return
 }
+
+// subnetReservationTimeout is how long a slot reserved for an inbound connection when it is gated
+// is held for the connection to be opened; it is well above the libp2p upgrade timeout, so a
+// reservation which expires belongs to a connection which failed to open.
+const subnetReservationTimeout = time.Minute
+
+// SubnetLimiter tracks open connections per /24 IPv4 and /64 IPv6 subnet, and limits the number
+// of inbound connections accepted from a single subnet.
+type SubnetLimiter struct {
+	ipv4Limit, ipv6Limit int
+	exempt               map[peer.ID]struct{}
+
+	lk     sync.Mutex
+	counts map[string]int
+
+	// reserved holds the expiry of the slots reserved for inbound connections which were let
+	// through the gater, but are not open yet; reserved slots are included in counts.
+	reserved map[subnetReservation][]time.Time
+}
+
+type subnetReservation struct {
+	peer   peer.ID
+	addr   string
+	subnet string
+}
+
+// SubnetConnLimiter constructs a SubnetLimiter with the given per-subnet limits; 0 means no limit.
+// Connections of the exempt peers are neither counted nor refused.
+func SubnetConnLimiter(ipv4Limit, ipv6Limit int, exempt []string) func() (*SubnetLimiter, error) {
+	return func() (*SubnetLimiter, error) {
+		if ipv4Limit < 0 || ipv6Limit < 0 {
+			return nil, xerrors.Errorf("subnet connection limits must not be negative")
+		}
+
+		sl := &SubnetLimiter{
+			ipv4Limit: ipv4Limit,
+			ipv6Limit: ipv6Limit,
+			exempt:    map[peer.ID]struct{}{},
+			counts:    map[string]int{},
+			reserved:  map[subnetReservation][]time.Time{},
+		}
+		for _, s := range exempt {
+			p, err := peer.Decode(s)
+			if err != nil {
+				return nil, xerrors.Errorf("parsing exempt peer %q: %w", s, err)
+			}
+			sl.exempt[p] = struct{}{}
+		}
+		return sl, nil
+	}
+}
+
+// RegisterSubnetLimiter subscribes the limiter to connection events of the host, so that it can
+// keep track of open connections.
+func RegisterSubnetLimiter(h RawHost, sl *SubnetLimiter) {
+	h.Network().Notify(sl)
+}
+
+// SubnetOf returns the /24 (IPv4) or /64 (IPv6) subnet of the address.
+func SubnetOf(addr ma.Multiaddr) (string, bool) {
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return "", false
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return (&net.IPNet{IP: ip4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}).String(), true
+	}
+	return (&net.IPNet{IP: ip.Mask(net.CIDRMask(64, 128)), Mask: net.CIDRMask(64, 128)}).String(), true
+}
+
+// Limit returns the connection limit which applies to a subnet returned by SubnetOf.
+func (sl *SubnetLimiter) Limit(subnet string) int {
+	if strings.Contains(subnet, ":") {
+		return sl.ipv6Limit
+	}
+	return sl.ipv4Limit
+}
+
+// Counts returns the number of open connections per subnet, including the inbound connections
+// which were accepted, but are still being opened.
+func (sl *SubnetLimiter) Counts() map[string]int {
+	sl.lk.Lock()
+	defer sl.lk.Unlock()
+
+	sl.expireReservations()
+
+	out := make(map[string]int, len(sl.counts))
+	for s, n := range sl.counts {
+		out[s] = n
+	}
+	return out
+}
+
+// reserve checks whether an inbound connection from the address is within the limit of its
+// subnet, and if so reserves a slot for it, so that connections gated concurrently can't exceed
+// the limit before any of them is open. The slot is handed over to the connection once it is
+// open, or released when it doesn't open in time.
+func (sl *SubnetLimiter) reserve(p peer.ID, addr ma.Multiaddr) bool {
+	if _, ok := sl.exempt[p]; ok {
+		return true
+	}
+
+	subnet, ok := SubnetOf(addr)
+	if !ok {
+		return true
+	}
+	limit := sl.Limit(subnet)
+	if limit == 0 {
+		return true
+	}
+
+	sl.lk.Lock()
+	defer sl.lk.Unlock()
+
+	sl.expireReservations()
+	if sl.counts[subnet] >= limit {
+		log.Debugw("refusing inbound connection, subnet connection limit reached", "peer", p, "subnet", subnet, "limit", limit)
+		return false
+	}
+
+	r := subnetReservation{peer: p, addr: addr.String(), subnet: subnet}
+	sl.reserved[r] = append(sl.reserved[r], build.Clock.Now().Add(subnetReservationTimeout))
+	sl.counts[subnet]++
+	return true
+}
+
+// expireReservations releases the reserved slots of connections which failed to open. Must be
+// called with lk held.
+func (sl *SubnetLimiter) expireReservations() {
+	now := build.Clock.Now()
+	for r, expiries := range sl.reserved {
+		live := expiries[:0]
+		for _, exp := range expiries {
+			if now.Before(exp) {
+				live = append(live, exp)
+				continue
+			}
+			sl.release(r.subnet)
+		}
+		if len(live) == 0 {
+			delete(sl.reserved, r)
+		} else {
+			sl.reserved[r] = live
+		}
+	}
+}
+
+// release frees a slot of the subnet. Must be called with lk held.
+func (sl *SubnetLimiter) release(subnet string) {
+	sl.counts[subnet]--
+	if sl.counts[subnet] <= 0 {
+		delete(sl.counts, subnet)
+	}
+}
+
+func (sl *SubnetLimiter) Connected(_ network.Network, c network.Conn) {
+	if _, ok := sl.exempt[c.RemotePeer()]; ok {
+		return
+	}
+	subnet, ok := SubnetOf(c.RemoteMultiaddr())
+	if !ok {
+		return
+	}
+
+	sl.lk.Lock()
+	defer sl.lk.Unlock()
+
+	// an inbound connection takes over the slot reserved for it when it was gated
+	r := subnetReservation{peer: c.RemotePeer(), addr: c.RemoteMultiaddr().String(), subnet: subnet}
+	if expiries := sl.reserved[r]; c.Stat().Direction == network.DirInbound && len(expiries) > 0 {
+		if len(expiries) == 1 {
+			delete(sl.reserved, r)
+		} else {
+			sl.reserved[r] = expiries[1:]
+		}
+		return
+	}
+	sl.counts[subnet]++
+}
+
+func (sl *SubnetLimiter) Disconnected(_ network.Network, c network.Conn) {
+	if _, ok := sl.exempt[c.RemotePeer()]; ok {
+		return
+	}
+	subnet, ok := SubnetOf(c.RemoteMultiaddr())
+	if !ok {
+		return
+	}
+
+	sl.lk.Lock()
+	defer sl.lk.Unlock()
+	sl.release(subnet)
+}
+
+func (sl *SubnetLimiter) Listen(network.Network, ma.Multiaddr)      {}
+func (sl *SubnetLimiter) ListenClose(network.Network, ma.Multiaddr) {}
+
+var _ network.Notifiee = (*SubnetLimiter)(nil)
+
+// subnetGater extends the basic connection gater with per-subnet limits on inbound connections.
+// Limits are checked once the remote peer is known, so that exempt peers can be let through.
+type subnetGater struct {
+	*conngater.BasicConnectionGater
+	limiter *SubnetLimiter
+}
+
+func (g *subnetGater) InterceptSecured(dir network.Direction, p peer.ID, cma network.ConnMultiaddrs) bool {
+	if !g.BasicConnectionGater.InterceptSecured(dir, p, cma) {
+		return false
+	}
+	return dir != network.DirInbound || g.limiter.reserve(p, cma.RemoteMultiaddr())
+}
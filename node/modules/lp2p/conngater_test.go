@@ -0,0 +1,129 @@
+package lp2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/test"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/raulk/clock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/lotus/build"
+)
+
+type testConn struct {
+	network.Conn
+
+	peer peer.ID
+	addr ma.Multiaddr
+	dir  network.Direction
+}
+
+func (c *testConn) RemotePeer() peer.ID           { return c.peer }
+func (c *testConn) RemoteMultiaddr() ma.Multiaddr { return c.addr }
+func (c *testConn) LocalMultiaddr() ma.Multiaddr  { return ma.StringCast("/ip4/127.0.0.1/tcp/1234") }
+func (c *testConn) Stat() network.ConnStats {
+	return network.ConnStats{Stats: network.Stats{Direction: c.dir}}
+}
+
+func setupSubnetGater(t *testing.T, ipv4Limit int, exempt ...peer.ID) (*subnetGater, *clock.Mock) {
+	mockClock := clock.NewMock()
+	oldClock := build.Clock
+	build.Clock = mockClock
+	t.Cleanup(func() {
+		build.Clock = oldClock
+	})
+
+	var exemptIDs []string
+	for _, p := range exempt {
+		exemptIDs = append(exemptIDs, p.String())
+	}
+	sl, err := SubnetConnLimiter(ipv4Limit, 0, exemptIDs)()
+	require.NoError(t, err)
+
+	cg, err := conngater.NewBasicConnectionGater(nil)
+	require.NoError(t, err)
+
+	return &subnetGater{BasicConnectionGater: cg, limiter: sl}, mockClock
+}
+
+func inboundConn(t *testing.T, addr string) *testConn {
+	return &testConn{peer: test.RandPeerIDFatal(t), addr: ma.StringCast(addr), dir: network.DirInbound}
+}
+
+func TestSubnetLimit(t *testing.T) {
+	g, _ := setupSubnetGater(t, 2)
+
+	a := inboundConn(t, "/ip4/10.0.0.1/tcp/1")
+	b := inboundConn(t, "/ip4/10.0.0.2/tcp/1")
+	c := inboundConn(t, "/ip4/10.0.0.3/tcp/1")
+	other := inboundConn(t, "/ip4/10.0.1.1/tcp/1")
+
+	// connections gated concurrently reserve their slot before any of them is open
+	require.True(t, g.InterceptSecured(network.DirInbound, a.peer, a))
+	require.True(t, g.InterceptSecured(network.DirInbound, b.peer, b))
+	require.False(t, g.InterceptSecured(network.DirInbound, c.peer, c))
+	require.True(t, g.InterceptSecured(network.DirInbound, other.peer, other))
+
+	// opening the connections takes over the reserved slots
+	g.limiter.Connected(nil, a)
+	g.limiter.Connected(nil, b)
+	g.limiter.Connected(nil, other)
+	require.Equal(t, map[string]int{"10.0.0.0/24": 2, "10.0.1.0/24": 1}, g.limiter.Counts())
+	require.False(t, g.InterceptSecured(network.DirInbound, c.peer, c))
+
+	// outbound connections are counted, but never refused
+	out := &testConn{peer: test.RandPeerIDFatal(t), addr: ma.StringCast("/ip4/10.0.0.4/tcp/1"), dir: network.DirOutbound}
+	require.True(t, g.InterceptSecured(network.DirOutbound, out.peer, out))
+	g.limiter.Connected(nil, out)
+	require.Equal(t, 3, g.limiter.Counts()["10.0.0.0/24"])
+}
+
+func TestSubnetLimitExempt(t *testing.T) {
+	exempt := test.RandPeerIDFatal(t)
+	g, _ := setupSubnetGater(t, 1, exempt)
+
+	a := inboundConn(t, "/ip4/10.0.0.1/tcp/1")
+	require.True(t, g.InterceptSecured(network.DirInbound, a.peer, a))
+	g.limiter.Connected(nil, a)
+
+	// exempt peers are let through a full subnet, and don't take a slot
+	e := &testConn{peer: exempt, addr: ma.StringCast("/ip4/10.0.0.2/tcp/1"), dir: network.DirInbound}
+	require.True(t, g.InterceptSecured(network.DirInbound, e.peer, e))
+	g.limiter.Connected(nil, e)
+	require.Equal(t, map[string]int{"10.0.0.0/24": 1}, g.limiter.Counts())
+
+	g.limiter.Disconnected(nil, e)
+	require.Equal(t, map[string]int{"10.0.0.0/24": 1}, g.limiter.Counts())
+}
+
+func TestSubnetLimitRelease(t *testing.T) {
+	g, mockClock := setupSubnetGater(t, 1)
+
+	a := inboundConn(t, "/ip4/10.0.0.1/tcp/1")
+	b := inboundConn(t, "/ip4/10.0.0.2/tcp/1")
+
+	// disconnecting frees the slot
+	require.True(t, g.InterceptSecured(network.DirInbound, a.peer, a))
+	g.limiter.Connected(nil, a)
+	require.False(t, g.InterceptSecured(network.DirInbound, b.peer, b))
+	g.limiter.Disconnected(nil, a)
+	require.Empty(t, g.limiter.Counts())
+
+	// a connection which never opens gives its slot back once the reservation expires
+	require.True(t, g.InterceptSecured(network.DirInbound, b.peer, b))
+	require.False(t, g.InterceptSecured(network.DirInbound, a.peer, a))
+	mockClock.Add(subnetReservationTimeout + time.Second)
+	require.Empty(t, g.limiter.Counts())
+	require.True(t, g.InterceptSecured(network.DirInbound, a.peer, a))
+
+	// and a late connection is counted on its own
+	g.limiter.Connected(nil, b)
+	require.Equal(t, map[string]int{"10.0.0.0/24": 2}, g.limiter.Counts())
+	g.limiter.Disconnected(nil, b)
+	require.Equal(t, map[string]int{"10.0.0.0/24": 1}, g.limiter.Counts())
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/filecoin-project/lotus/build"
 	"github.com/filecoin-project/lotus/chain/lf3"
+	"github.com/filecoin-project/lotus/chain/sub"
 	"github.com/filecoin-project/lotus/metrics"
 	"github.com/filecoin-project/lotus/node/config"
 	"github.com/filecoin-project/lotus/node/modules/dtypes"
@@ -50,6 +51,10 @@ const (
 	OpportunisticGraftScoreThreshold = 3.5
 )
 
+// pubsubRPCOverhead is the room left in gossipsub RPCs for the framing and control messages
+// around a message of the maximum size
+const pubsubRPCOverhead = 64 << 10
+
 func ScoreKeeper() *dtypes.ScoreKeeper {
 	return new(dtypes.ScoreKeeper)
 }
@@ -381,6 +386,15 @@ func GossipSub(in GossipIn) (service *pubsub.PubSub, err error) {
 		}
 	}
 
+	if err := sub.CheckTopicMessageSizes(in.Cfg.BlocksTopicMaxMessageSize, in.Cfg.MessagesTopicMaxMessageSize); err != nil {
+		return nil, xerrors.Errorf("invalid pubsub config: %w", err)
+	}
+	// the topic validators enforce the limits of each topic, messages of all topics are limited
+	// by the gossipsub max message size
+	if maxSize := max(in.Cfg.BlocksTopicMaxMessageSize, in.Cfg.MessagesTopicMaxMessageSize); maxSize > pubsub.DefaultMaxMessageSize {
+		options = append(options, pubsub.WithMaxMessageSize(maxSize+pubsubRPCOverhead))
+	}
+
 	options = append(options,
 		pubsub.WithSubscriptionFilter(
 			pubsub.WrapLimitSubscriptionFilter(
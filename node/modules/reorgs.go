@@ -0,0 +1,103 @@
+package modules
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.uber.org/fx"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/chain/store"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/journal"
+	"github.com/filecoin-project/lotus/metrics"
+	"github.com/filecoin-project/lotus/node/modules/helpers"
+)
+
+// DeepReorgEvt is journalled when the node observes a reorg deeper than the configured
+// Chainstore.ReorgAlertDepth.
+type DeepReorgEvt struct {
+	// Depth is the number of epochs between the old head and the common ancestor.
+	Depth          abi.ChainEpoch
+	AncestorHeight abi.ChainEpoch
+	From           types.TipSetKey
+	FromHeight     abi.ChainEpoch
+	To             types.TipSetKey
+	ToHeight       abi.ChainEpoch
+	// DroppedBlocks and AddedBlocks are the number of blocks in the reverted and applied tipsets.
+	DroppedBlocks int
+	AddedBlocks   int
+}
+
+// MonitorReorgs records the depth of every reorg observed by the node, and logs and journals
+// reorgs deeper than alertDepth epochs. An alertDepth of 0 disables the alerts.
+func MonitorReorgs(alertDepth int) func(mctx helpers.MetricsCtx, lc fx.Lifecycle, cs *store.ChainStore, j journal.Journal) {
+	return func(mctx helpers.MetricsCtx, lc fx.Lifecycle, cs *store.ChainStore, j journal.Journal) {
+		ctx := helpers.LifecycleCtx(mctx, lc)
+		evtType := j.RegisterEventType("sync", "deep_reorg")
+
+		cs.SubscribeHeadChanges(func(rev, app []*types.TipSet) error {
+			if len(rev) == 0 {
+				return nil
+			}
+
+			evt := reorgEvt(ctx, cs, rev, app)
+			stats.Record(ctx, metrics.ReorgDepth.M(int64(evt.Depth)))
+
+			if alertDepth <= 0 || evt.Depth <= abi.ChainEpoch(alertDepth) {
+				return nil
+			}
+
+			log.Warnw("observed deep chain reorg",
+				"depth", evt.Depth,
+				"threshold", alertDepth,
+				"ancestorHeight", evt.AncestorHeight,
+				"from", evt.From,
+				"fromHeight", evt.FromHeight,
+				"to", evt.To,
+				"toHeight", evt.ToHeight,
+				"droppedBlocks", evt.DroppedBlocks,
+				"addedBlocks", evt.AddedBlocks)
+			j.RecordEvent(evtType, func() interface{} {
+				return evt
+			})
+			return nil
+		})
+	}
+}
+
+// reorgEvt describes a reorg from the reverted tipsets, ordered from the old head down, and the
+// applied tipsets, ordered up to the new head.
+func reorgEvt(ctx context.Context, cs *store.ChainStore, rev, app []*types.TipSet) DeepReorgEvt {
+	from, to := rev[0], rev[0]
+	if len(app) > 0 {
+		to = app[len(app)-1]
+	}
+
+	evt := DeepReorgEvt{
+		From:       from.Key(),
+		FromHeight: from.Height(),
+		To:         to.Key(),
+		ToHeight:   to.Height(),
+	}
+	for _, ts := range rev {
+		evt.DroppedBlocks += len(ts.Blocks())
+	}
+	for _, ts := range app {
+		evt.AddedBlocks += len(ts.Blocks())
+	}
+
+	oldest := rev[len(rev)-1]
+	ancestor, err := cs.LoadTipSet(ctx, oldest.Parents())
+	if err != nil {
+		// the ancestor is at least one epoch below the oldest reverted tipset, so the depth is a lower bound
+		log.Warnw("loading common ancestor of reorg", "parents", oldest.Parents(), "error", err)
+		evt.AncestorHeight = oldest.Height() - 1
+	} else {
+		evt.AncestorHeight = ancestor.Height()
+	}
+	evt.Depth = from.Height() - evt.AncestorHeight
+
+	return evt
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/filecoin-project/lotus/journal"
 	"github.com/filecoin-project/lotus/journal/fsjournal"
 	"github.com/filecoin-project/lotus/lib/peermgr"
+	"github.com/filecoin-project/lotus/node/config"
 	"github.com/filecoin-project/lotus/node/hello"
 	"github.com/filecoin-project/lotus/node/modules/dtypes"
 	"github.com/filecoin-project/lotus/node/modules/helpers"
@@ -135,11 +136,12 @@ func HandleIncomingBlocks(mctx helpers.MetricsCtx,
 	chain *store.ChainStore,
 	cns consensus.Consensus,
 	h host.Host,
-	nn dtypes.NetworkName) {
+	nn dtypes.NetworkName,
+	pcfg *config.Pubsub) {
 	ctx := helpers.LifecycleCtx(mctx, lc)
 
 	v := sub.NewBlockValidator(
-		h.ID(), chain, cns,
+		h.ID(), chain, cns, pcfg.BlocksTopicMaxMessageSize,
 		func(p peer.ID) {
 			ps.BlacklistPeer(p)
 			h.ConnManager().TagPeer(p, "badblock", -1000)
@@ -159,10 +161,10 @@ func HandleIncomingBlocks(mctx helpers.MetricsCtx,
 	go sub.HandleIncomingBlocks(ctx, blocksub, s, bserv, h.ConnManager())
 }
 
-func HandleIncomingMessages(mctx helpers.MetricsCtx, lc fx.Lifecycle, ps *pubsub.PubSub, stmgr *stmgr.StateManager, mpool *messagepool.MessagePool, h host.Host, nn dtypes.NetworkName, bootstrapper dtypes.Bootstrapper) {
+func HandleIncomingMessages(mctx helpers.MetricsCtx, lc fx.Lifecycle, ps *pubsub.PubSub, stmgr *stmgr.StateManager, mpool *messagepool.MessagePool, h host.Host, nn dtypes.NetworkName, bootstrapper dtypes.Bootstrapper, pcfg *config.Pubsub) {
 	ctx := helpers.LifecycleCtx(mctx, lc)
 
-	v := sub.NewMessageValidator(h.ID(), mpool)
+	v := sub.NewMessageValidator(h.ID(), mpool, pcfg.MessagesTopicMaxMessageSize)
 
 	if err := ps.RegisterTopicValidator(build.MessagesTopic(nn), v.Validate); err != nil {
 		panic(err)
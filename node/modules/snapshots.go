@@ -0,0 +1,279 @@
+package modules
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.uber.org/fx"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/chain/actors/policy"
+	"github.com/filecoin-project/lotus/chain/store"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/journal"
+	"github.com/filecoin-project/lotus/metrics"
+	"github.com/filecoin-project/lotus/node/config"
+	"github.com/filecoin-project/lotus/node/modules/helpers"
+	"github.com/filecoin-project/lotus/node/repo"
+)
+
+const (
+	snapshotPrefix = "snapshot_"
+	snapshotSuffix = ".car"
+)
+
+// SnapshotEvt is journalled when an automatic chain snapshot completes or fails.
+type SnapshotEvt struct {
+	Height abi.ChainEpoch
+	TipSet types.TipSetKey
+	Path   string
+	Size   int64
+	Took   time.Duration
+	Error  string `json:",omitempty"`
+}
+
+// AutoSnapshots generates a chain snapshot every cfg.IntervalEpochs epochs in the background, and
+// prunes snapshots beyond the most recent cfg.Retain ones.
+func AutoSnapshots(cfg config.SnapshotConfig) func(mctx helpers.MetricsCtx, lc fx.Lifecycle, lr repo.LockedRepo, cs *store.ChainStore, j journal.Journal) error {
+	return func(mctx helpers.MetricsCtx, lc fx.Lifecycle, lr repo.LockedRepo, cs *store.ChainStore, j journal.Journal) error {
+		if cfg.IntervalEpochs <= 0 {
+			return xerrors.Errorf("snapshot interval must be positive")
+		}
+		if abi.ChainEpoch(cfg.RecentStateRoots) < policy.ChainFinality {
+			return xerrors.Errorf("snapshot recent state roots must be at least %d", policy.ChainFinality)
+		}
+
+		dir := cfg.Path
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(lr.Path(), dir)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return xerrors.Errorf("creating snapshot directory: %w", err)
+		}
+
+		// remove snapshots left incomplete by a crash
+		stale, err := filepath.Glob(filepath.Join(dir, snapshotPrefix+"*"+snapshotSuffix+".tmp"))
+		if err != nil {
+			return xerrors.Errorf("listing incomplete snapshots: %w", err)
+		}
+		for _, p := range stale {
+			if err := os.Remove(p); err != nil {
+				return xerrors.Errorf("removing incomplete snapshot: %w", err)
+			}
+		}
+
+		existing, err := listSnapshots(dir)
+		if err != nil {
+			return err
+		}
+
+		s := &autoSnapshots{
+			cfg:     cfg,
+			dir:     dir,
+			cs:      cs,
+			j:       j,
+			evtType: j.RegisterEventType("snapshot", "generated"),
+		}
+		if len(existing) > 0 {
+			// don't regenerate snapshots taken before a restart
+			s.last = existing[len(existing)-1].height
+		}
+
+		ctx, cancel := context.WithCancel(helpers.LifecycleCtx(mctx, lc))
+		lc.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				// abort a running snapshot, its temporary file is removed
+				cancel()
+				s.wg.Wait()
+				return nil
+			},
+		})
+
+		cs.SubscribeHeadChanges(func(rev, app []*types.TipSet) error {
+			if len(app) > 0 {
+				s.maybeSnapshot(ctx, app[len(app)-1])
+			}
+			return nil
+		})
+		return nil
+	}
+}
+
+type autoSnapshots struct {
+	cfg     config.SnapshotConfig
+	dir     string
+	cs      *store.ChainStore
+	j       journal.Journal
+	evtType journal.EventType
+
+	lk      sync.Mutex
+	running bool
+	last    abi.ChainEpoch
+
+	wg sync.WaitGroup
+}
+
+func (s *autoSnapshots) maybeSnapshot(ctx context.Context, head *types.TipSet) {
+	target := snapshotTarget(head.Height(), abi.ChainEpoch(s.cfg.Lookback), abi.ChainEpoch(s.cfg.IntervalEpochs))
+
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	if s.running || target <= s.last || ctx.Err() != nil {
+		return
+	}
+	s.running = true
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		err := s.snapshot(ctx, head, target)
+
+		s.lk.Lock()
+		defer s.lk.Unlock()
+		s.running = false
+		if err == nil {
+			// a failed snapshot is retried on the next head change
+			s.last = target
+		}
+	}()
+}
+
+// snapshotTarget returns the latest height which is a multiple of the interval, and at least
+// lookback epochs below the head.
+func snapshotTarget(head, lookback, interval abi.ChainEpoch) abi.ChainEpoch {
+	h := head - lookback
+	if h <= 0 {
+		return 0
+	}
+	return h - h%interval
+}
+
+func (s *autoSnapshots) snapshot(ctx context.Context, head *types.TipSet, height abi.ChainEpoch) error {
+	start := time.Now()
+	evt := SnapshotEvt{Height: height}
+
+	err := func() error {
+		ts, err := s.cs.GetTipsetByHeight(ctx, height, head, true)
+		if err != nil {
+			return xerrors.Errorf("loading snapshot tipset: %w", err)
+		}
+		evt.TipSet = ts.Key()
+		// named after the requested height even if it's a null round, so that it isn't regenerated
+		evt.Path = filepath.Join(s.dir, fmt.Sprintf("%s%d%s", snapshotPrefix, height, snapshotSuffix))
+
+		log.Infow("generating chain snapshot", "height", ts.Height(), "tipset", ts.Key(), "path", evt.Path)
+
+		tmp := evt.Path + ".tmp"
+		f, err := os.Create(tmp)
+		if err != nil {
+			return xerrors.Errorf("creating snapshot file: %w", err)
+		}
+		defer func() {
+			_ = f.Close()
+			_ = os.Remove(tmp) // no-op once renamed
+		}()
+
+		bw := bufio.NewWriterSize(f, 1<<20)
+		if err := s.cs.Export(ctx, ts, abi.ChainEpoch(s.cfg.RecentStateRoots), s.cfg.SkipOldMessages, bw); err != nil {
+			return xerrors.Errorf("exporting snapshot: %w", err)
+		}
+		if err := bw.Flush(); err != nil {
+			return xerrors.Errorf("writing snapshot: %w", err)
+		}
+		if err := f.Sync(); err != nil {
+			return xerrors.Errorf("syncing snapshot: %w", err)
+		}
+		st, err := f.Stat()
+		if err != nil {
+			return xerrors.Errorf("stat snapshot: %w", err)
+		}
+		evt.Size = st.Size()
+		if err := f.Close(); err != nil {
+			return xerrors.Errorf("closing snapshot: %w", err)
+		}
+
+		return os.Rename(tmp, evt.Path)
+	}()
+	evt.Took = time.Since(start)
+
+	if err != nil {
+		evt.Error = err.Error()
+		log.Errorw("generating chain snapshot failed", "height", height, "took", evt.Took, "error", err)
+		stats.Record(ctx, metrics.SnapshotFailures.M(1))
+	} else {
+		log.Infow("generated chain snapshot", "height", height, "path", evt.Path, "size", evt.Size, "took", evt.Took)
+		stats.Record(ctx, metrics.SnapshotsGenerated.M(1), metrics.SnapshotDuration.M(evt.Took.Seconds()), metrics.SnapshotSize.M(evt.Size))
+
+		if err := pruneSnapshots(s.dir, s.cfg.Retain); err != nil {
+			log.Errorw("pruning old snapshots", "error", err)
+		}
+	}
+
+	s.j.RecordEvent(s.evtType, func() interface{} {
+		return evt
+	})
+	return err
+}
+
+type snapshotFile struct {
+	path   string
+	height abi.ChainEpoch
+}
+
+// listSnapshots returns the complete snapshots in the directory ordered by height.
+func listSnapshots(dir string) ([]snapshotFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, xerrors.Errorf("listing snapshots: %w", err)
+	}
+
+	var out []snapshotFile
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, snapshotPrefix) || !strings.HasSuffix(name, snapshotSuffix) {
+			continue
+		}
+		h, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(name, snapshotPrefix), snapshotSuffix), 10, 64)
+		if err != nil {
+			continue // not a snapshot generated by the node
+		}
+		out = append(out, snapshotFile{path: filepath.Join(dir, name), height: abi.ChainEpoch(h)})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].height < out[j].height
+	})
+	return out, nil
+}
+
+// pruneSnapshots removes all but the retain most recent snapshots in the directory.
+func pruneSnapshots(dir string, retain int) error {
+	if retain <= 0 {
+		return nil
+	}
+
+	snaps, err := listSnapshots(dir)
+	if err != nil {
+		return err
+	}
+	for len(snaps) > retain {
+		log.Infow("removing old snapshot", "path", snaps[0].path)
+		if err := os.Remove(snaps[0].path); err != nil {
+			return xerrors.Errorf("removing snapshot: %w", err)
+		}
+		snaps = snaps[1:]
+	}
+	return nil
+}
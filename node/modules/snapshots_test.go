@@ -0,0 +1,86 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestSnapshotTarget(t *testing.T) {
+	for _, tc := range []struct {
+		head, lookback, interval abi.ChainEpoch
+		target                   abi.ChainEpoch
+	}{
+		{head: 1000, lookback: 0, interval: 100, target: 1000},
+		{head: 1099, lookback: 0, interval: 100, target: 1000},
+		{head: 1050, lookback: 60, interval: 100, target: 900},
+		{head: 1060, lookback: 60, interval: 100, target: 1000},
+		{head: 50, lookback: 60, interval: 100, target: 0},
+		{head: 60, lookback: 60, interval: 100, target: 0},
+	} {
+		require.Equal(t, tc.target, snapshotTarget(tc.head, tc.lookback, tc.interval), "head %d, lookback %d, interval %d", tc.head, tc.lookback, tc.interval)
+	}
+}
+
+func writeSnapshotTestFiles(t *testing.T, dir string, names ...string) {
+	for _, name := range names {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0644))
+	}
+}
+
+func TestListSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	writeSnapshotTestFiles(t, dir,
+		"snapshot_2880.car",
+		"snapshot_900.car",
+		"snapshot_5760.car",
+		// not complete snapshots generated by the node
+		"snapshot_8640.car.tmp",
+		"snapshot_latest.car",
+		"snapshot_100.car.zst",
+		"other_100.car",
+		"notes.txt",
+	)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "snapshot_200.car"), 0755))
+
+	snaps, err := listSnapshots(dir)
+	require.NoError(t, err)
+	require.Equal(t, []snapshotFile{
+		{path: filepath.Join(dir, "snapshot_900.car"), height: 900},
+		{path: filepath.Join(dir, "snapshot_2880.car"), height: 2880},
+		{path: filepath.Join(dir, "snapshot_5760.car"), height: 5760},
+	}, snaps)
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	unrelated := []string{"snapshot_1.car.tmp", "snapshot_latest.car", "notes.txt"}
+	writeSnapshotTestFiles(t, dir, "snapshot_900.car", "snapshot_2880.car", "snapshot_5760.car", "snapshot_8640.car")
+	writeSnapshotTestFiles(t, dir, unrelated...)
+
+	remaining := func() []string {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		return names
+	}
+
+	// no retention limit keeps everything
+	require.NoError(t, pruneSnapshots(dir, 0))
+	require.Len(t, remaining(), 7)
+
+	// the oldest snapshots go first, by height rather than by name
+	require.NoError(t, pruneSnapshots(dir, 2))
+	require.ElementsMatch(t, append([]string{"snapshot_5760.car", "snapshot_8640.car"}, unrelated...), remaining())
+
+	// retaining more than there are is a no-op
+	require.NoError(t, pruneSnapshots(dir, 5))
+	require.ElementsMatch(t, append([]string{"snapshot_5760.car", "snapshot_8640.car"}, unrelated...), remaining())
+}
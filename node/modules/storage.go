@@ -9,6 +9,7 @@ import (
 
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/lib/backupds"
+	"github.com/filecoin-project/lotus/lib/harmony/harmonydb"
 	"github.com/filecoin-project/lotus/node/modules/dtypes"
 	"github.com/filecoin-project/lotus/node/modules/helpers"
 	"github.com/filecoin-project/lotus/node/repo"
@@ -30,6 +31,16 @@ func KeyStore(lr repo.LockedRepo) (types.KeyStore, error) {
 	return lr.KeyStore()
 }
 
+// DBKeyStore stores the keys in HarmonyDB, except for the keys identifying the node, which are
+// stored in the repo keystore.
+func DBKeyStore(lr repo.LockedRepo, db *harmonydb.DB) (types.KeyStore, error) {
+	local, err := lr.KeyStore()
+	if err != nil {
+		return nil, err
+	}
+	return repo.NewDBKeyStore(db, local), nil
+}
+
 func Datastore(disableLog bool) func(lc fx.Lifecycle, mctx helpers.MetricsCtx, r repo.LockedRepo) (dtypes.MetadataDS, error) {
 	return func(lc fx.Lifecycle, mctx helpers.MetricsCtx, r repo.LockedRepo) (dtypes.MetadataDS, error) {
 		ctx := helpers.LifecycleCtx(mctx, lc)
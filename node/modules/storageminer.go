@@ -33,11 +33,13 @@ import (
 	"github.com/filecoin-project/lotus/chain/lf3"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/journal"
+	"github.com/filecoin-project/lotus/journal/alerting"
 	lotusminer "github.com/filecoin-project/lotus/miner"
 	"github.com/filecoin-project/lotus/node/config"
 	"github.com/filecoin-project/lotus/node/modules/dtypes"
 	"github.com/filecoin-project/lotus/node/modules/helpers"
 	"github.com/filecoin-project/lotus/node/repo"
+	"github.com/filecoin-project/lotus/storage/autoextend"
 	"github.com/filecoin-project/lotus/storage/ctladdr"
 	"github.com/filecoin-project/lotus/storage/paths"
 	sealing "github.com/filecoin-project/lotus/storage/pipeline"
@@ -183,6 +185,73 @@ func AddressSelector(addrConf *config.MinerAddressConfig) func() (*ctladdr.Addre
 	}
 }
 
+func ControlTopUp(cfg config.ControlTopUpConfig) func(lc fx.Lifecycle, api v1api.FullNode, maddr dtypes.MinerAddress) error {
+	return func(lc fx.Lifecycle, api v1api.FullNode, maddr dtypes.MinerAddress) error {
+		from, err := address.NewFromString(cfg.FundingAddress)
+		if err != nil {
+			return xerrors.Errorf("parsing top-up funding address: %w", err)
+		}
+
+		tcfg := ctladdr.TopUpConfig{
+			From:          from,
+			Threshold:     abi.TokenAmount(cfg.Threshold),
+			Target:        abi.TokenAmount(cfg.TargetBalance),
+			MaxAmount:     abi.TokenAmount(cfg.MaxTopUp),
+			CheckInterval: time.Duration(cfg.CheckInterval),
+			MinInterval:   time.Duration(cfg.MinTopUpInterval),
+		}
+		for _, s := range cfg.Addresses {
+			addr, err := address.NewFromString(s)
+			if err != nil {
+				return xerrors.Errorf("parsing top-up address: %w", err)
+			}
+			tcfg.Addresses = append(tcfg.Addresses, addr)
+		}
+
+		t, err := ctladdr.NewTopUp(api, address.Address(maddr), tcfg)
+		if err != nil {
+			return xerrors.Errorf("creating address top-up: %w", err)
+		}
+
+		lc.Append(fx.Hook{
+			OnStart: t.Start,
+			OnStop:  t.Stop,
+		})
+		return nil
+	}
+}
+
+func SectorAutoExtend(cfg config.SectorAutoExtendConfig) func(lc fx.Lifecycle, api v1api.FullNode, maddr dtypes.MinerAddress) error {
+	return func(lc fx.Lifecycle, api v1api.FullNode, maddr dtypes.MinerAddress) error {
+		epochs := func(d config.Duration) abi.ChainEpoch {
+			return abi.ChainEpoch(uint64(time.Duration(d).Seconds()) / build.BlockDelaySecs)
+		}
+
+		ecfg := autoextend.Config{
+			ExpiringWithin:   epochs(cfg.ExpiringWithin),
+			TargetLifetime:   epochs(cfg.TargetLifetime),
+			MaxSectorsPerRun: cfg.MaxSectorsPerRun,
+			MaxFeePerDay:     abi.TokenAmount(cfg.MaxGasFeePerDay),
+			Exclude:          map[abi.SectorNumber]struct{}{},
+			CheckInterval:    time.Duration(cfg.CheckInterval),
+		}
+		for _, s := range cfg.ExcludeSectors {
+			ecfg.Exclude[abi.SectorNumber(s)] = struct{}{}
+		}
+
+		e, err := autoextend.NewExtender(api, address.Address(maddr), ecfg)
+		if err != nil {
+			return xerrors.Errorf("creating sector auto-extender: %w", err)
+		}
+
+		lc.Append(fx.Hook{
+			OnStart: e.Start,
+			OnStop:  e.Stop,
+		})
+		return nil
+	}
+}
+
 func PreflightChecks(mctx helpers.MetricsCtx, lc fx.Lifecycle, api v1api.FullNode, maddr dtypes.MinerAddress) error {
 	ctx := helpers.LifecycleCtx(mctx, lc)
 
@@ -227,6 +296,7 @@ type SealingPipelineParams struct {
 	Journal            journal.Journal
 	AddrSel            *ctladdr.AddressSelector
 	Maddr              dtypes.MinerAddress
+	Alerting           *alerting.Alerting
 }
 
 func SealingPipeline(fc config.MinerFeeConfig) func(params SealingPipelineParams) (*sealing.Sealing, error) {
@@ -247,6 +317,14 @@ func SealingPipeline(fc config.MinerFeeConfig) func(params SealingPipelineParams
 
 		ctx := helpers.LifecycleCtx(mctx, lc)
 
+		scfg, err := gsd()
+		if err != nil {
+			return nil, xerrors.Errorf("getting sealing config: %w", err)
+		}
+		if err := scfg.DealPackingStrategy.Validate(); err != nil {
+			return nil, xerrors.Errorf("invalid Sealing.DealPackingStrategy: %w", err)
+		}
+
 		evts, err := events.NewEvents(ctx, api)
 		if err != nil {
 			return nil, xerrors.Errorf("failed to subscribe to events: %w", err)
@@ -290,7 +368,7 @@ func WindowPostScheduler(fc config.MinerFeeConfig, pc config.ProvingConfig) func
 
 		ctx := helpers.LifecycleCtx(mctx, lc)
 
-		fps, err := wdpost.NewWindowedPoStScheduler(api, fc, pc, as, sealer, verif, sealer, j, []dtypes.MinerAddress{params.Maddr})
+		fps, err := wdpost.NewWindowedPoStScheduler(api, fc, pc, as, sealer, verif, sealer, j, params.Alerting, []dtypes.MinerAddress{params.Maddr})
 
 		if err != nil {
 			return nil, err
@@ -307,27 +385,30 @@ func WindowPostScheduler(fc config.MinerFeeConfig, pc config.ProvingConfig) func
 	}
 }
 
-func SetupBlockProducer(lc fx.Lifecycle, ds dtypes.MetadataDS, api v1api.FullNode, epp gen.WinningPoStProver, sf *slashfilter.SlashFilter, j journal.Journal) (*lotusminer.Miner, error) {
-	minerAddr, err := minerAddrFromDS(ds)
-	if err != nil {
-		return nil, err
-	}
+func SetupBlockProducer(pc config.ProvingConfig) func(lc fx.Lifecycle, ds dtypes.MetadataDS, api v1api.FullNode, epp gen.WinningPoStProver, sf *slashfilter.SlashFilter, j journal.Journal) (*lotusminer.Miner, error) {
+	return func(lc fx.Lifecycle, ds dtypes.MetadataDS, api v1api.FullNode, epp gen.WinningPoStProver, sf *slashfilter.SlashFilter, j journal.Journal) (*lotusminer.Miner, error) {
+		minerAddr, err := minerAddrFromDS(ds)
+		if err != nil {
+			return nil, err
+		}
 
-	m := lotusminer.NewMiner(api, epp, minerAddr, sf, j)
+		m := lotusminer.NewMiner(api, epp, minerAddr, sf, j)
+		m.SetWinningPoStTimeout(time.Duration(pc.WinningPoStTimeout), pc.AbandonLateWinningPoSt)
 
-	lc.Append(fx.Hook{
-		OnStart: func(ctx context.Context) error {
-			if err := m.Start(ctx); err != nil {
-				return err
-			}
-			return nil
-		},
-		OnStop: func(ctx context.Context) error {
-			return m.Stop(ctx)
-		},
-	})
+		lc.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				if err := m.Start(ctx); err != nil {
+					return err
+				}
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				return m.Stop(ctx)
+			},
+		})
 
-	return m, nil
+		return m, nil
+	}
 }
 
 var WorkerCallsPrefix = datastore.NewKey("/worker/calls")
@@ -339,7 +420,11 @@ func LocalStorage(mctx helpers.MetricsCtx, lc fx.Lifecycle, ls paths.LocalStorag
 }
 
 func RemoteStorage(lstor *paths.Local, si paths.SectorIndex, sa sealer.StorageAuth, sc config.SealerConfig) *paths.Remote {
-	return paths.NewRemote(lstor, si, http.Header(sa), sc.ParallelFetchLimit, &paths.DefaultPartialFileHandler{})
+	return paths.NewRemote(lstor, si, http.Header(sa), sc.ParallelFetchLimit, &paths.DefaultPartialFileHandler{},
+		paths.WithFetchRetry(paths.FetchRetryConfig{
+			MaxRetries: sc.FetchRetries,
+			Backoff:    time.Duration(sc.FetchRetryBackoff),
+		}))
 }
 
 func SectorStorage(mctx helpers.MetricsCtx, lc fx.Lifecycle, lstor *paths.Local, stor paths.Store, ls paths.LocalStorage, si paths.SectorIndex, sc config.SealerConfig, pc config.ProvingConfig, ds dtypes.MetadataDS) (*sealer.Manager, error) {
@@ -425,6 +510,10 @@ func StorageAuthWithURL(apiInfo string) interface{} {
 
 func NewSetSealConfigFunc(r repo.LockedRepo) (dtypes.SetSealingConfigFunc, error) {
 	return func(cfg sealiface.Config) (err error) {
+		if err := validateReplicaCheckSampleRate(cfg.ReplicaCheckSampleRate); err != nil {
+			return err
+		}
+
 		err = mutateSealingCfg(r, func(c config.SealingConfiger) {
 			newCfg := config.SealingConfig{
 				MaxWaitDealsSectors:             cfg.MaxWaitDealsSectors,
@@ -437,6 +526,7 @@ func NewSetSealConfigFunc(r repo.LockedRepo) (dtypes.SetSealingConfigFunc, error
 				MakeNewSectorForDeals:           cfg.MakeNewSectorForDeals,
 				MinUpgradeSectorExpiration:      cfg.MinUpgradeSectorExpiration,
 				MakeCCSectorsAvailable:          cfg.MakeCCSectorsAvailable,
+				DealPackingStrategy:             string(cfg.DealPackingStrategy),
 				AlwaysKeepUnsealedCopy:          cfg.AlwaysKeepUnsealedCopy,
 				FinalizeEarly:                   cfg.FinalizeEarly,
 
@@ -444,9 +534,10 @@ func NewSetSealConfigFunc(r repo.LockedRepo) (dtypes.SetSealingConfigFunc, error
 				AvailableBalanceBuffer:     types.FIL(cfg.AvailableBalanceBuffer),
 				DisableCollateralFallback:  cfg.DisableCollateralFallback,
 
-				MaxPreCommitBatch:   cfg.MaxPreCommitBatch,
-				PreCommitBatchWait:  config.Duration(cfg.PreCommitBatchWait),
-				PreCommitBatchSlack: config.Duration(cfg.PreCommitBatchSlack),
+				MaxPreCommitBatch:                cfg.MaxPreCommitBatch,
+				PreCommitBatchWait:               config.Duration(cfg.PreCommitBatchWait),
+				PreCommitBatchSlack:              config.Duration(cfg.PreCommitBatchSlack),
+				PreCommitBatchTicketExpiryEpochs: cfg.PreCommitBatchTicketExpiryEpochs,
 
 				AggregateCommits:           cfg.AggregateCommits,
 				MinCommitBatch:             cfg.MinCommitBatch,
@@ -460,12 +551,15 @@ func NewSetSealConfigFunc(r repo.LockedRepo) (dtypes.SetSealingConfigFunc, error
 				TerminateBatchMin:                      cfg.TerminateBatchMin,
 				TerminateBatchWait:                     config.Duration(cfg.TerminateBatchWait),
 				MaxSectorProveCommitsSubmittedPerEpoch: cfg.MaxSectorProveCommitsSubmittedPerEpoch,
+				MaxCommitResubmits:                     cfg.MaxCommitResubmits,
 				UseSyntheticPoRep:                      cfg.UseSyntheticPoRep,
 
 				RequireActivationSuccess:         cfg.RequireActivationSuccess,
 				RequireActivationSuccessUpdate:   cfg.RequireActivationSuccessUpdate,
 				RequireNotificationSuccess:       cfg.RequireNotificationSuccess,
 				RequireNotificationSuccessUpdate: cfg.RequireNotificationSuccessUpdate,
+
+				ReplicaCheckSampleRate: cfg.ReplicaCheckSampleRate,
 			}
 			c.SetSealingConfig(newCfg)
 		})
@@ -487,6 +581,7 @@ func ToSealingConfig(dealmakingCfg config.DealmakingConfig, sealingCfg config.Se
 		CommittedCapacitySectorLifetime: time.Duration(sealingCfg.CommittedCapacitySectorLifetime),
 		WaitDealsDelay:                  time.Duration(sealingCfg.WaitDealsDelay),
 		MakeCCSectorsAvailable:          sealingCfg.MakeCCSectorsAvailable,
+		DealPackingStrategy:             sealiface.DealPackingStrategy(sealingCfg.DealPackingStrategy),
 		AlwaysKeepUnsealedCopy:          sealingCfg.AlwaysKeepUnsealedCopy,
 		FinalizeEarly:                   sealingCfg.FinalizeEarly,
 
@@ -494,9 +589,10 @@ func ToSealingConfig(dealmakingCfg config.DealmakingConfig, sealingCfg config.Se
 		AvailableBalanceBuffer:     types.BigInt(sealingCfg.AvailableBalanceBuffer),
 		DisableCollateralFallback:  sealingCfg.DisableCollateralFallback,
 
-		MaxPreCommitBatch:   sealingCfg.MaxPreCommitBatch,
-		PreCommitBatchWait:  time.Duration(sealingCfg.PreCommitBatchWait),
-		PreCommitBatchSlack: time.Duration(sealingCfg.PreCommitBatchSlack),
+		MaxPreCommitBatch:                sealingCfg.MaxPreCommitBatch,
+		PreCommitBatchWait:               time.Duration(sealingCfg.PreCommitBatchWait),
+		PreCommitBatchSlack:              time.Duration(sealingCfg.PreCommitBatchSlack),
+		PreCommitBatchTicketExpiryEpochs: sealingCfg.PreCommitBatchTicketExpiryEpochs,
 
 		AggregateCommits:                       sealingCfg.AggregateCommits,
 		MinCommitBatch:                         sealingCfg.MinCommitBatch,
@@ -506,6 +602,7 @@ func ToSealingConfig(dealmakingCfg config.DealmakingConfig, sealingCfg config.Se
 		AggregateAboveBaseFee:                  types.BigInt(sealingCfg.AggregateAboveBaseFee),
 		BatchPreCommitAboveBaseFee:             types.BigInt(sealingCfg.BatchPreCommitAboveBaseFee),
 		MaxSectorProveCommitsSubmittedPerEpoch: sealingCfg.MaxSectorProveCommitsSubmittedPerEpoch,
+		MaxCommitResubmits:                     sealingCfg.MaxCommitResubmits,
 
 		TerminateBatchMax:  sealingCfg.TerminateBatchMax,
 		TerminateBatchMin:  sealingCfg.TerminateBatchMin,
@@ -516,6 +613,8 @@ func ToSealingConfig(dealmakingCfg config.DealmakingConfig, sealingCfg config.Se
 		RequireActivationSuccessUpdate:   sealingCfg.RequireActivationSuccessUpdate,
 		RequireNotificationSuccess:       sealingCfg.RequireNotificationSuccess,
 		RequireNotificationSuccessUpdate: sealingCfg.RequireNotificationSuccessUpdate,
+
+		ReplicaCheckSampleRate: sealingCfg.ReplicaCheckSampleRate,
 	}
 }
 
@@ -526,10 +625,20 @@ func NewGetSealConfigFunc(r repo.LockedRepo) (dtypes.GetSealingConfigFunc, error
 			dcfg := dc.GetDealmakingConfig()
 			out = ToSealingConfig(dcfg, scfg)
 		})
+		if err == nil {
+			err = validateReplicaCheckSampleRate(out.ReplicaCheckSampleRate)
+		}
 		return
 	}, nil
 }
 
+func validateReplicaCheckSampleRate(rate float64) error {
+	if !(rate >= 0 && rate <= 1) {
+		return xerrors.Errorf("invalid Sealing.ReplicaCheckSampleRate %f, must be between 0 and 1", rate)
+	}
+	return nil
+}
+
 func readSealingCfg(r repo.LockedRepo, accessor func(config.DealmakingConfiger, config.SealingConfiger)) error {
 	raw, err := r.Config()
 	if err != nil {
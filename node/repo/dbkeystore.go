@@ -0,0 +1,130 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/lib/harmony/harmonydb"
+)
+
+// LocalKeys are the keys which identify a node, and are kept in the repo keystore even when the
+// other keys are stored in a database shared by multiple nodes.
+var LocalKeys = map[string]struct{}{
+	"libp2p-host": {},
+}
+
+// DBKeyStore is a keystore backed by HarmonyDB, so that the nodes using the same database share
+// their keys, e.g. the API secret. The LocalKeys are stored in the local keystore.
+type DBKeyStore struct {
+	db    *harmonydb.DB
+	local types.KeyStore
+}
+
+var _ types.KeyStore = (*DBKeyStore)(nil)
+
+func NewDBKeyStore(db *harmonydb.DB, local types.KeyStore) *DBKeyStore {
+	return &DBKeyStore{
+		db:    db,
+		local: local,
+	}
+}
+
+type dbKey struct {
+	Name       string `db:"name"`
+	KeyType    string `db:"key_type"`
+	PrivateKey []byte `db:"private_key"`
+}
+
+// List lists all the keys stored in the KeyStore
+func (ks *DBKeyStore) List() ([]string, error) {
+	var names []string
+	if err := ks.db.Select(context.TODO(), &names, "SELECT name FROM keystore ORDER BY name"); err != nil {
+		return nil, xerrors.Errorf("listing keys: %w", err)
+	}
+
+	local, err := ks.local.List()
+	if err != nil {
+		return nil, xerrors.Errorf("listing local keys: %w", err)
+	}
+	for _, name := range local {
+		if _, ok := LocalKeys[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// Get gets a key out of keystore and returns types.KeyInfo coresponding to named key
+func (ks *DBKeyStore) Get(name string) (types.KeyInfo, error) {
+	if _, ok := LocalKeys[name]; ok {
+		return ks.local.Get(name)
+	}
+
+	var keys []dbKey
+	if err := ks.db.Select(context.TODO(), &keys, "SELECT name, key_type, private_key FROM keystore WHERE name = $1", name); err != nil {
+		return types.KeyInfo{}, xerrors.Errorf("getting key '%s': %w", name, err)
+	}
+	if len(keys) == 0 {
+		return types.KeyInfo{}, xerrors.Errorf("getting key '%s': %w", name, types.ErrKeyInfoNotFound)
+	}
+
+	return types.KeyInfo{
+		Type:       types.KeyType(keys[0].KeyType),
+		PrivateKey: keys[0].PrivateKey,
+	}, nil
+}
+
+// Put saves key info under given name. It fails with types.ErrKeyExists if the key is already
+// stored, possibly concurrently by another node using the same database.
+func (ks *DBKeyStore) Put(name string, info types.KeyInfo) error {
+	if _, ok := LocalKeys[name]; ok {
+		return ks.local.Put(name, info)
+	}
+
+	for retries := 0; ; retries++ {
+		n := dbKeyName(name, retries)
+
+		_, err := ks.db.Exec(context.TODO(), "INSERT INTO keystore (name, key_type, private_key) VALUES ($1, $2, $3)",
+			n, string(info.Type), info.PrivateKey)
+		if harmonydb.IsErrUniqueContraint(err) {
+			if strings.HasPrefix(name, KTrashPrefix) {
+				// retry writing the trash-prefixed key with a number suffix
+				continue
+			}
+			return xerrors.Errorf("checking key before put '%s': %w", name, types.ErrKeyExists)
+		}
+		if err != nil {
+			return xerrors.Errorf("writing key '%s': %w", name, err)
+		}
+		return nil
+	}
+}
+
+// dbKeyName returns the name a key is written under after the given number of retries, which only
+// trash-prefixed keys get, as they are numbered rather than failing when the name is taken.
+func dbKeyName(name string, retries int) string {
+	if retries == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s-%d", name, retries)
+}
+
+// Delete removes a key from keystore
+func (ks *DBKeyStore) Delete(name string) error {
+	if _, ok := LocalKeys[name]; ok {
+		return ks.local.Delete(name)
+	}
+
+	n, err := ks.db.Exec(context.TODO(), "DELETE FROM keystore WHERE name = $1", name)
+	if err != nil {
+		return xerrors.Errorf("deleting key '%s': %w", name, err)
+	}
+	if n == 0 {
+		return xerrors.Errorf("checking key before delete '%s': %w", name, types.ErrKeyInfoNotFound)
+	}
+	return nil
+}
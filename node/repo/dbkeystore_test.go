@@ -0,0 +1,45 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/lotus/chain/types"
+)
+
+// The database backed part of DBKeyStore is covered by itests/dbkeystore_test.go.
+
+func TestDBKeyStoreLocalKeys(t *testing.T) {
+	lr, err := NewMemory(nil).Lock(StorageMiner)
+	require.NoError(t, err)
+	defer lr.Close() //nolint:errcheck
+	local, err := lr.KeyStore()
+	require.NoError(t, err)
+
+	// the local keys never reach the database, so none is needed here
+	ks := NewDBKeyStore(nil, local)
+
+	hostKey := types.KeyInfo{Type: "libp2p-host", PrivateKey: []byte{4, 5, 6}}
+	require.NoError(t, ks.Put("libp2p-host", hostKey))
+
+	got, err := local.Get("libp2p-host")
+	require.NoError(t, err)
+	require.Equal(t, hostKey, got)
+	got, err = ks.Get("libp2p-host")
+	require.NoError(t, err)
+	require.Equal(t, hostKey, got)
+
+	require.ErrorIs(t, ks.Put("libp2p-host", hostKey), types.ErrKeyExists)
+
+	require.NoError(t, ks.Delete("libp2p-host"))
+	_, err = ks.Get("libp2p-host")
+	require.ErrorIs(t, err, types.ErrKeyInfoNotFound)
+	require.ErrorIs(t, ks.Delete("libp2p-host"), types.ErrKeyInfoNotFound)
+}
+
+func TestDBKeyName(t *testing.T) {
+	require.Equal(t, "trash-key", dbKeyName("trash-key", 0))
+	require.Equal(t, "trash-key-1", dbKeyName("trash-key", 1))
+	require.Equal(t, "trash-key-2", dbKeyName("trash-key", 2))
+}
@@ -22,9 +22,11 @@ import (
 	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/api/v0api"
 	"github.com/filecoin-project/lotus/api/v1api"
+	"github.com/filecoin-project/lotus/lib/rpcdrain"
 	"github.com/filecoin-project/lotus/lib/rpcenc"
 	"github.com/filecoin-project/lotus/metrics"
 	"github.com/filecoin-project/lotus/metrics/proxy"
+	"github.com/filecoin-project/lotus/metrics/rpcstats"
 	"github.com/filecoin-project/lotus/node/impl"
 )
 
@@ -36,6 +38,10 @@ var rpclog = logging.Logger("rpc")
 // It returns the stop function to be called to terminate the endpoint.
 //
 // The supplied ID is used in tracing, by inserting a tag in the context.
+//
+// The stop function stops accepting connections and rejects new calls on open connections with
+// rpcdrain.ErrShuttingDown, waits up to rpcdrain.Timeout for the calls in flight to complete, and
+// then closes the server.
 func ServeRPC(h http.Handler, id string, addr multiaddr.Multiaddr) (StopFunc, error) {
 	// Start listening to the addr; if invalid or occupied, we will fail early.
 	lst, err := manet.Listen(addr)
@@ -43,9 +49,11 @@ func ServeRPC(h http.Handler, id string, addr multiaddr.Multiaddr) (StopFunc, er
 		return nil, xerrors.Errorf("could not listen: %w", err)
 	}
 
+	drainer := rpcdrain.New()
+
 	// Instantiate the server and start listening.
 	srv := &http.Server{
-		Handler:           h,
+		Handler:           drainer.Handler(h),
 		ReadHeaderTimeout: 30 * time.Second,
 		BaseContext: func(listener net.Listener) context.Context {
 			ctx, _ := tag.New(context.Background(), tag.Upsert(metrics.APIInterface, id))
@@ -60,7 +68,28 @@ func ServeRPC(h http.Handler, id string, addr multiaddr.Multiaddr) (StopFunc, er
 		}
 	}()
 
-	return srv.Shutdown, err
+	stop := func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, rpcdrain.Timeout())
+		defer cancel()
+
+		// stop accepting connections, requests in flight keep being served
+		shutdownErr := make(chan error, 1)
+		go func() {
+			shutdownErr <- srv.Shutdown(ctx)
+		}()
+
+		// websocket connections aren't tracked by the server, wait for the calls themselves
+		if err := drainer.Drain(ctx); err != nil {
+			rpclog.Warnw("closing rpc server with calls in flight", "server", id, "error", err)
+		}
+
+		if err := <-shutdownErr; err != nil {
+			return srv.Close()
+		}
+		return nil
+	}
+
+	return stop, err
 }
 
 // FullNodeHandler returns a full node handler, to be mounted as-is on the server.
@@ -79,7 +108,7 @@ func FullNodeHandler(a v1api.FullNode, permissioned bool, opts ...jsonrpc.Server
 			handler = &auth.Handler{Verify: a.AuthVerify, Next: rpcServer.ServeHTTP}
 		}
 
-		m.Handle(path, handler)
+		m.Handle(path, rpcstats.Handler(handler))
 	}
 
 	fnapi := proxy.MetricedFullAPI(a)
@@ -137,7 +166,7 @@ func MinerHandler(a api.StorageMiner, permissioned bool) (http.Handler, error) {
 	// local APIs
 	{
 		m := mux.NewRouter()
-		m.Handle("/rpc/v0", rpcServer)
+		m.Handle("/rpc/v0", rpcstats.Handler(rpcServer))
 		m.Handle("/rpc/streams/v0/push/{uuid}", readerHandler)
 		// debugging
 		m.Handle("/debug/metrics", metrics.Exporter())
@@ -0,0 +1,460 @@
+package autoextend
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/network"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/actors"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/miner"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/verifreg"
+	"github.com/filecoin-project/lotus/chain/actors/policy"
+	"github.com/filecoin-project/lotus/chain/types"
+)
+
+var log = logging.Logger("autoextend")
+
+// expirationTolerance is the distance within which new expirations are merged into one
+// declaration, and the minimum extension worth sending a message for.
+const expirationTolerance = 7 * builtin.EpochsInDay
+
+// resendInterval is the time after which a sector sent for extension is considered again, if its
+// extension didn't land on chain.
+const resendInterval = 6 * time.Hour
+
+type ExtendApi interface {
+	ChainHead(context.Context) (*types.TipSet, error)
+	StateNetworkVersion(context.Context, types.TipSetKey) (network.Version, error)
+	StateMinerInfo(context.Context, address.Address, types.TipSetKey) (api.MinerInfo, error)
+	StateMinerActiveSectors(context.Context, address.Address, types.TipSetKey) ([]*miner.SectorOnChainInfo, error)
+	StateSectorPartition(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tok types.TipSetKey) (*miner.SectorLocation, error)
+	StateGetClaims(ctx context.Context, providerAddr address.Address, tsk types.TipSetKey) (map[verifreg.ClaimId]verifreg.Claim, error)
+	GasEstimateMessageGas(context.Context, *types.Message, *api.MessageSendSpec, types.TipSetKey) (*types.Message, error)
+	MpoolPushMessage(ctx context.Context, msg *types.Message, spec *api.MessageSendSpec) (*types.SignedMessage, error)
+}
+
+type Config struct {
+	// ExpiringWithin is the number of epochs before their expiration at which sectors are extended
+	ExpiringWithin abi.ChainEpoch
+	// TargetLifetime is the number of epochs from the current epoch sectors are extended to,
+	// capped by the maximum extension and sector lifetime allowed by the network
+	TargetLifetime abi.ChainEpoch
+
+	// MaxSectorsPerRun is the maximum number of sectors extended in one check, zero for no limit
+	MaxSectorsPerRun int
+	// MaxFeePerDay is the maximum amount of gas fees spent on extensions within 24 hours, zero
+	// for no limit
+	MaxFeePerDay abi.TokenAmount
+	Exclude      map[abi.SectorNumber]struct{}
+
+	CheckInterval time.Duration
+}
+
+type sentFee struct {
+	at  time.Time
+	fee abi.TokenAmount
+}
+
+// Extender periodically extends the sectors of a miner which are about to expire, so that
+// operators don't need to run 'sectors extend' by hand to keep their power.
+type Extender struct {
+	api   ExtendApi
+	maddr address.Address
+	cfg   Config
+
+	lk   sync.Mutex
+	sent map[abi.SectorNumber]time.Time
+	fees []sentFee
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewExtender(a ExtendApi, maddr address.Address, cfg Config) (*Extender, error) {
+	if cfg.ExpiringWithin <= 0 {
+		return nil, xerrors.Errorf("expiring within must be positive")
+	}
+	if cfg.TargetLifetime <= cfg.ExpiringWithin {
+		return nil, xerrors.Errorf("target lifetime %d must be greater than expiring within %d epochs", cfg.TargetLifetime, cfg.ExpiringWithin)
+	}
+	if cfg.MaxSectorsPerRun < 0 {
+		return nil, xerrors.Errorf("max sectors per run can't be negative")
+	}
+	if cfg.MaxFeePerDay.LessThan(big.Zero()) {
+		return nil, xerrors.Errorf("max fee per day can't be negative")
+	}
+	if cfg.CheckInterval <= 0 {
+		return nil, xerrors.Errorf("check interval must be positive")
+	}
+
+	return &Extender{
+		api:   a,
+		maddr: maddr,
+		cfg:   cfg,
+
+		sent: map[abi.SectorNumber]time.Time{},
+
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}, nil
+}
+
+func (e *Extender) Start(context.Context) error {
+	go e.run()
+	return nil
+}
+
+func (e *Extender) Stop(ctx context.Context) error {
+	close(e.stop)
+	select {
+	case <-e.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *Extender) run() {
+	defer close(e.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-e.stop
+		cancel()
+	}()
+
+	ticker := time.NewTicker(e.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.Check(ctx, time.Now()); err != nil {
+			log.Errorw("checking for expiring sectors", "error", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check extends the active sectors expiring within the configured number of epochs, which aren't
+// excluded and weren't sent for extension recently, as long as the daily fee budget allows.
+func (e *Extender) Check(ctx context.Context, now time.Time) error {
+	e.lk.Lock()
+	defer e.lk.Unlock()
+
+	e.expire(now)
+
+	head, err := e.api.ChainHead(ctx)
+	if err != nil {
+		return xerrors.Errorf("getting chain head: %w", err)
+	}
+	tsk := head.Key()
+
+	nv, err := e.api.StateNetworkVersion(ctx, tsk)
+	if err != nil {
+		return xerrors.Errorf("getting network version: %w", err)
+	}
+
+	maxExtension, err := policy.GetMaxSectorExpirationExtension(nv)
+	if err != nil {
+		return xerrors.Errorf("getting max sector extension: %w", err)
+	}
+
+	active, err := e.api.StateMinerActiveSectors(ctx, e.maddr, tsk)
+	if err != nil {
+		return xerrors.Errorf("getting active sectors: %w", err)
+	}
+
+	var expiring []*miner.SectorOnChainInfo
+	for _, si := range active {
+		if si.Expiration > head.Height()+e.cfg.ExpiringWithin {
+			continue
+		}
+		if _, ok := e.cfg.Exclude[si.SectorNumber]; ok {
+			continue
+		}
+		if _, ok := e.sent[si.SectorNumber]; ok {
+			continue
+		}
+		expiring = append(expiring, si)
+	}
+	if len(expiring) == 0 {
+		return nil
+	}
+
+	claims, err := e.api.StateGetClaims(ctx, e.maddr, tsk)
+	if err != nil {
+		return xerrors.Errorf("getting verified claims: %w", err)
+	}
+	claimsBySector := map[abi.SectorNumber][]verifreg.ClaimId{}
+	for id, c := range claims {
+		claimsBySector[c.Sector] = append(claimsBySector[c.Sector], id)
+	}
+
+	// the sectors expiring first are extended first when the run is capped
+	sort.Slice(expiring, func(i, j int) bool {
+		return expiring[i].Expiration < expiring[j].Expiration
+	})
+
+	var exts []extension
+	for _, si := range expiring {
+		if e.cfg.MaxSectorsPerRun > 0 && len(exts) >= e.cfg.MaxSectorsPerRun {
+			break
+		}
+
+		newExp := head.Height() + e.cfg.TargetLifetime
+		if maxExp := head.Height() + maxExtension; newExp > maxExp {
+			newExp = maxExp
+		}
+		if maxExp := si.Activation + policy.GetSectorMaxLifetime(si.SealProof, nv); newExp > maxExp {
+			newExp = maxExp
+		}
+		if newExp-si.Expiration < expirationTolerance {
+			// at the end of its lifetime, the sector has to be resealed to keep its power
+			continue
+		}
+
+		ext := extension{sector: si.SectorNumber, expiration: si.Expiration, newExp: newExp}
+		if ids, ok := claimsBySector[si.SectorNumber]; ok {
+			if !claimsOutlive(claims, ids, newExp) {
+				log.Warnw("not extending sector with verified claims which can't be maintained, extend it with 'sectors extend --drop-claims'", "sector", si.SectorNumber, "expiration", si.Expiration)
+				continue
+			}
+			ext.claims = ids
+		} else if !si.VerifiedDealWeight.IsZero() {
+			log.Warnw("not extending sector with legacy verified deals", "sector", si.SectorNumber, "expiration", si.Expiration)
+			continue
+		}
+
+		loc, err := e.api.StateSectorPartition(ctx, e.maddr, si.SectorNumber, tsk)
+		if err != nil {
+			return xerrors.Errorf("getting location of sector %d: %w", si.SectorNumber, err)
+		}
+		ext.loc = *loc
+
+		exts = append(exts, ext)
+	}
+	if len(exts) == 0 {
+		return nil
+	}
+
+	sectorsMax, err := policy.GetAddressedSectorsMax(nv)
+	if err != nil {
+		return err
+	}
+	declMax, err := policy.GetDeclarationsMax(nv)
+	if err != nil {
+		return err
+	}
+
+	mi, err := e.api.StateMinerInfo(ctx, e.maddr, tsk)
+	if err != nil {
+		return xerrors.Errorf("getting miner info: %w", err)
+	}
+
+	for _, params := range groupExtensions(exts, sectorsMax, declMax) {
+		sent, err := e.send(ctx, now, mi.Worker, params)
+		if err != nil {
+			return err
+		}
+		if !sent {
+			break
+		}
+	}
+
+	return nil
+}
+
+// send sends one extension message, if its estimated fee fits in the daily budget, and returns
+// whether it was sent.
+func (e *Extender) send(ctx context.Context, now time.Time, from address.Address, params miner.ExtendSectorExpiration2Params) (bool, error) {
+	sp, aerr := actors.SerializeParams(&params)
+	if aerr != nil {
+		return false, xerrors.Errorf("serializing params: %w", aerr)
+	}
+
+	msg, err := e.api.GasEstimateMessageGas(ctx, &types.Message{
+		From:   from,
+		To:     e.maddr,
+		Method: builtin.MethodsMiner.ExtendSectorExpiration2,
+		Value:  big.Zero(),
+		Params: sp,
+	}, nil, types.EmptyTSK)
+	if err != nil {
+		return false, xerrors.Errorf("estimating extension message gas: %w", err)
+	}
+
+	fee := msg.RequiredFunds()
+	if !e.cfg.MaxFeePerDay.IsZero() {
+		if spent := e.spent(); big.Add(spent, fee).GreaterThan(e.cfg.MaxFeePerDay) {
+			log.Warnw("daily sector extension fee budget reached, extending more sectors later", "spent", types.FIL(spent), "fee", types.FIL(fee), "budget", types.FIL(e.cfg.MaxFeePerDay))
+			return false, nil
+		}
+	}
+
+	sm, err := e.api.MpoolPushMessage(ctx, msg, &api.MessageSendSpec{AutoBump: true})
+	if err != nil {
+		return false, xerrors.Errorf("sending extension message: %w", err)
+	}
+	e.fees = append(e.fees, sentFee{at: now, fee: fee})
+
+	for _, ext := range params.Extensions {
+		var sectors []uint64
+		if err := ext.Sectors.ForEach(func(s uint64) error {
+			sectors = append(sectors, s)
+			return nil
+		}); err != nil {
+			return false, xerrors.Errorf("listing extended sectors: %w", err)
+		}
+		for _, sc := range ext.SectorsWithClaims {
+			sectors = append(sectors, uint64(sc.SectorNumber))
+		}
+		for _, s := range sectors {
+			e.sent[abi.SectorNumber(s)] = now
+		}
+
+		log.Infow("extending sectors", "deadline", ext.Deadline, "partition", ext.Partition, "sectors", sectors, "newExpiration", ext.NewExpiration, "message", sm.Cid())
+	}
+
+	return true, nil
+}
+
+// expire forgets sectors and fees sent for extension before the resend interval and the fee
+// budget window.
+func (e *Extender) expire(now time.Time) {
+	for s, at := range e.sent {
+		if now.Sub(at) >= resendInterval {
+			delete(e.sent, s)
+		}
+	}
+
+	i := 0
+	for i < len(e.fees) && now.Sub(e.fees[i].at) >= 24*time.Hour {
+		i++
+	}
+	e.fees = e.fees[i:]
+}
+
+func (e *Extender) spent() abi.TokenAmount {
+	total := big.Zero()
+	for _, f := range e.fees {
+		total = big.Add(total, f.fee)
+	}
+	return total
+}
+
+func claimsOutlive(claims map[verifreg.ClaimId]verifreg.Claim, ids []verifreg.ClaimId, newExp abi.ChainEpoch) bool {
+	for _, id := range ids {
+		c := claims[id]
+		if c.TermStart+c.TermMax <= newExp {
+			return false
+		}
+	}
+	return true
+}
+
+type extension struct {
+	sector     abi.SectorNumber
+	loc        miner.SectorLocation
+	expiration abi.ChainEpoch
+	newExp     abi.ChainEpoch
+	claims     []verifreg.ClaimId
+}
+
+// groupExtensions groups the extensions into declarations per partition and new expiration,
+// merging new expirations within the tolerance into earlier ones, and splits the declarations
+// into messages addressing at most sectorsMax sectors with at most declMax declarations each.
+func groupExtensions(exts []extension, sectorsMax, declMax int) []miner.ExtendSectorExpiration2Params {
+	type declKey struct {
+		loc    miner.SectorLocation
+		newExp abi.ChainEpoch
+	}
+
+	sort.SliceStable(exts, func(i, j int) bool {
+		return exts[i].newExp < exts[j].newExp
+	})
+
+	var keys []declKey
+	decls := map[declKey][]extension{}
+	for _, ext := range exts {
+		k := declKey{ext.loc, ext.newExp}
+		for _, prev := range keys {
+			// merging never shortens a sector's life, and at most by the tolerance
+			if prev.loc == ext.loc && ext.newExp-prev.newExp <= expirationTolerance && prev.newExp > ext.expiration {
+				k = prev
+				break
+			}
+		}
+
+		if _, ok := decls[k]; !ok {
+			keys = append(keys, k)
+		}
+		decls[k] = append(decls[k], ext)
+	}
+
+	var out []miner.ExtendSectorExpiration2Params
+	var p miner.ExtendSectorExpiration2Params
+	scount := 0
+	flush := func() {
+		if len(p.Extensions) > 0 {
+			out = append(out, p)
+		}
+		p = miner.ExtendSectorExpiration2Params{}
+		scount = 0
+	}
+
+	for _, k := range keys {
+		exts := decls[k]
+		for len(exts) > 0 {
+			if scount >= sectorsMax || len(p.Extensions) >= declMax {
+				flush()
+			}
+
+			n := len(exts)
+			if n > sectorsMax-scount {
+				n = sectorsMax - scount
+			}
+
+			decl := miner.ExpirationExtension2{
+				Deadline:      k.loc.Deadline,
+				Partition:     k.loc.Partition,
+				Sectors:       bitfield.New(),
+				NewExpiration: k.newExp,
+			}
+			for _, ext := range exts[:n] {
+				if len(ext.claims) == 0 {
+					decl.Sectors.Set(uint64(ext.sector))
+					continue
+				}
+				decl.SectorsWithClaims = append(decl.SectorsWithClaims, miner.SectorClaim{
+					SectorNumber:   ext.sector,
+					MaintainClaims: ext.claims,
+					DropClaims:     []verifreg.ClaimId{},
+				})
+			}
+
+			p.Extensions = append(p.Extensions, decl)
+			scount += n
+			exts = exts[n:]
+		}
+	}
+	flush()
+
+	return out
+}
@@ -0,0 +1,178 @@
+package autoextend
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/network"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/miner"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/verifreg"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+)
+
+type extendApi struct {
+	worker  address.Address
+	sectors []*miner.SectorOnChainInfo
+	claims  map[verifreg.ClaimId]verifreg.Claim
+	sent    []*types.Message
+}
+
+func (a *extendApi) ChainHead(context.Context) (*types.TipSet, error) {
+	return mock.TipSet(mock.MkBlock(nil, 0, 0)), nil
+}
+
+func (a *extendApi) StateNetworkVersion(context.Context, types.TipSetKey) (network.Version, error) {
+	return network.Version23, nil
+}
+
+func (a *extendApi) StateMinerInfo(context.Context, address.Address, types.TipSetKey) (api.MinerInfo, error) {
+	return api.MinerInfo{Worker: a.worker}, nil
+}
+
+func (a *extendApi) StateMinerActiveSectors(context.Context, address.Address, types.TipSetKey) ([]*miner.SectorOnChainInfo, error) {
+	return a.sectors, nil
+}
+
+func (a *extendApi) StateSectorPartition(_ context.Context, _ address.Address, s abi.SectorNumber, _ types.TipSetKey) (*miner.SectorLocation, error) {
+	// two sectors per partition
+	return &miner.SectorLocation{Deadline: uint64(s) / 2}, nil
+}
+
+func (a *extendApi) StateGetClaims(context.Context, address.Address, types.TipSetKey) (map[verifreg.ClaimId]verifreg.Claim, error) {
+	return a.claims, nil
+}
+
+func (a *extendApi) GasEstimateMessageGas(_ context.Context, msg *types.Message, _ *api.MessageSendSpec, _ types.TipSetKey) (*types.Message, error) {
+	msg.GasLimit = 1000
+	msg.GasFeeCap = types.NewInt(1000)
+	return msg, nil
+}
+
+func (a *extendApi) MpoolPushMessage(_ context.Context, msg *types.Message, _ *api.MessageSendSpec) (*types.SignedMessage, error) {
+	a.sent = append(a.sent, msg)
+	return &types.SignedMessage{Message: *msg}, nil
+}
+
+func TestExtenderCheck(t *testing.T) {
+	ctx := context.Background()
+	maddr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	worker, err := address.NewIDAddress(1001)
+	require.NoError(t, err)
+
+	sector := func(n abi.SectorNumber, exp abi.ChainEpoch) *miner.SectorOnChainInfo {
+		return &miner.SectorOnChainInfo{
+			SectorNumber:       n,
+			SealProof:          abi.RegisteredSealProof_StackedDrg32GiBV1_1,
+			Expiration:         exp,
+			VerifiedDealWeight: big.Zero(),
+		}
+	}
+
+	a := &extendApi{
+		worker: worker,
+		sectors: []*miner.SectorOnChainInfo{
+			sector(1, 100),
+			sector(2, 200),
+			sector(3, 300),
+			sector(4, 10*builtin.EpochsInDay), // not expiring soon
+			sector(5, 150),                    // excluded
+			sector(6, 150),                    // claim ending before the target lifetime
+			sector(7, 150),                    // claim outliving the target lifetime
+		},
+		claims: map[verifreg.ClaimId]verifreg.Claim{
+			1: {Sector: 6, TermMax: 100 * builtin.EpochsInDay},
+			2: {Sector: 7, TermMax: 1000 * builtin.EpochsInDay},
+		},
+	}
+
+	e, err := NewExtender(a, maddr, Config{
+		ExpiringWithin:   builtin.EpochsInDay,
+		TargetLifetime:   180 * builtin.EpochsInDay,
+		MaxSectorsPerRun: 3,
+		MaxFeePerDay:     types.NewInt(2000000),
+		Exclude:          map[abi.SectorNumber]struct{}{5: {}},
+		CheckInterval:    time.Minute,
+	})
+	require.NoError(t, err)
+
+	// the three sectors expiring first, without unmaintainable claims, are extended in one message
+	now := time.Now()
+	require.NoError(t, e.Check(ctx, now))
+	require.Len(t, a.sent, 1)
+	require.Equal(t, worker, a.sent[0].From)
+	require.Equal(t, builtin.MethodsMiner.ExtendSectorExpiration2, a.sent[0].Method)
+
+	var params miner.ExtendSectorExpiration2Params
+	require.NoError(t, params.UnmarshalCBOR(bytes.NewReader(a.sent[0].Params)))
+	require.Len(t, params.Extensions, 3)
+
+	var extended []uint64
+	for _, ext := range params.Extensions {
+		require.Equal(t, abi.ChainEpoch(180*builtin.EpochsInDay), ext.NewExpiration)
+		require.NoError(t, ext.Sectors.ForEach(func(s uint64) error {
+			extended = append(extended, s)
+			return nil
+		}))
+		for _, sc := range ext.SectorsWithClaims {
+			require.Equal(t, []verifreg.ClaimId{2}, sc.MaintainClaims)
+			extended = append(extended, uint64(sc.SectorNumber))
+		}
+	}
+	require.ElementsMatch(t, []uint64{1, 2, 7}, extended)
+
+	// sectors just sent aren't extended again, the fee budget allows one more message
+	require.NoError(t, e.Check(ctx, now.Add(time.Minute)))
+	require.Len(t, a.sent, 2)
+	require.NoError(t, e.Check(ctx, now.Add(2*time.Minute)))
+	require.Len(t, a.sent, 2)
+
+	// the budget frees up after a day, sectors whose extensions did not land are sent again
+	require.NoError(t, e.Check(ctx, now.Add(25*time.Hour)))
+	require.Len(t, a.sent, 3)
+
+	_, err = NewExtender(a, maddr, Config{ExpiringWithin: 10, TargetLifetime: 10, CheckInterval: time.Minute})
+	require.Error(t, err)
+}
+
+func TestGroupExtensions(t *testing.T) {
+	loc0, loc1 := miner.SectorLocation{Deadline: 0}, miner.SectorLocation{Deadline: 1}
+	exts := []extension{
+		{sector: 1, loc: loc0, expiration: 10, newExp: 1000},
+		{sector: 2, loc: loc0, expiration: 10, newExp: 1000 + expirationTolerance}, // merged
+		{sector: 3, loc: loc0, expiration: 10, newExp: 2000 + expirationTolerance},
+		{sector: 4, loc: loc1, expiration: 10, newExp: 1000},
+		{sector: 5, loc: loc1, expiration: 1000, newExp: 1100}, // can't be merged without shortening its life
+	}
+
+	params := groupExtensions(exts, 3, 2)
+	require.Len(t, params, 2)
+	require.Len(t, params[0].Extensions, 2)
+	require.Len(t, params[1].Extensions, 2)
+
+	count := func(p miner.ExtendSectorExpiration2Params) uint64 {
+		var n uint64
+		for _, ext := range p.Extensions {
+			c, err := ext.Sectors.Count()
+			require.NoError(t, err)
+			n += c
+		}
+		return n
+	}
+	require.Equal(t, uint64(3), count(params[0]))
+	require.Equal(t, uint64(2), count(params[1]))
+
+	require.Equal(t, abi.ChainEpoch(1000), params[0].Extensions[0].NewExpiration)
+	require.Equal(t, loc0, miner.SectorLocation{Deadline: params[0].Extensions[0].Deadline})
+}
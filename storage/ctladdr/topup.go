@@ -0,0 +1,216 @@
+package ctladdr
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types"
+)
+
+type TopUpApi interface {
+	ChainHead(context.Context) (*types.TipSet, error)
+	StateMinerInfo(context.Context, address.Address, types.TipSetKey) (api.MinerInfo, error)
+	StateLookupID(context.Context, address.Address, types.TipSetKey) (address.Address, error)
+	WalletBalance(context.Context, address.Address) (types.BigInt, error)
+	MpoolPushMessage(ctx context.Context, msg *types.Message, spec *api.MessageSendSpec) (*types.SignedMessage, error)
+}
+
+type TopUpConfig struct {
+	// From is the address top-ups are sent from
+	From address.Address
+	// Addresses to top up, the worker and all control addresses of the miner when empty
+	Addresses []address.Address
+
+	// Threshold is the balance below which an address is topped up to Target
+	Threshold abi.TokenAmount
+	Target    abi.TokenAmount
+	// MaxAmount is the maximum amount of a single top-up, zero for no limit
+	MaxAmount abi.TokenAmount
+
+	CheckInterval time.Duration
+	// MinInterval is the minimum time between top-ups of the same address
+	MinInterval time.Duration
+}
+
+// TopUp periodically checks the balances of the worker and control addresses of a miner, and sends
+// funds to the ones which run low, so that automatically sent messages don't stall.
+type TopUp struct {
+	api   TopUpApi
+	maddr address.Address
+	cfg   TopUpConfig
+
+	lk        sync.Mutex
+	lastTopUp map[address.Address]time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewTopUp(a TopUpApi, maddr address.Address, cfg TopUpConfig) (*TopUp, error) {
+	if cfg.From == address.Undef {
+		return nil, xerrors.Errorf("funding address not set")
+	}
+	if cfg.Target.LessThanEqual(cfg.Threshold) {
+		return nil, xerrors.Errorf("target balance %s must be greater than the threshold %s", types.FIL(cfg.Target), types.FIL(cfg.Threshold))
+	}
+	if cfg.MaxAmount.LessThan(big.Zero()) {
+		return nil, xerrors.Errorf("max top-up amount can't be negative")
+	}
+	if cfg.CheckInterval <= 0 {
+		return nil, xerrors.Errorf("check interval must be positive")
+	}
+
+	return &TopUp{
+		api:   a,
+		maddr: maddr,
+		cfg:   cfg,
+
+		lastTopUp: map[address.Address]time.Time{},
+
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}, nil
+}
+
+func (t *TopUp) Start(context.Context) error {
+	go t.run()
+	return nil
+}
+
+func (t *TopUp) Stop(ctx context.Context) error {
+	close(t.stop)
+	select {
+	case <-t.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *TopUp) run() {
+	defer close(t.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-t.stop
+		cancel()
+	}()
+
+	ticker := time.NewTicker(t.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := t.Check(ctx, time.Now()); err != nil {
+			log.Errorw("checking address balances for top-ups", "error", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check tops up all addresses with balances below the threshold, which weren't topped up within the
+// minimum top-up interval before now.
+func (t *TopUp) Check(ctx context.Context, now time.Time) error {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	head, err := t.api.ChainHead(ctx)
+	if err != nil {
+		return xerrors.Errorf("getting chain head: %w", err)
+	}
+
+	addrs, err := t.addresses(ctx, head.Key())
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if last, ok := t.lastTopUp[addr]; ok && now.Sub(last) < t.cfg.MinInterval {
+			continue
+		}
+
+		bal, err := t.api.WalletBalance(ctx, addr)
+		if err != nil {
+			log.Errorw("getting address balance", "address", addr, "error", err)
+			continue
+		}
+		if bal.GreaterThanEqual(t.cfg.Threshold) {
+			continue
+		}
+
+		amt := big.Sub(t.cfg.Target, bal)
+		if !t.cfg.MaxAmount.IsZero() && amt.GreaterThan(t.cfg.MaxAmount) {
+			amt = t.cfg.MaxAmount
+		}
+
+		fromBal, err := t.api.WalletBalance(ctx, t.cfg.From)
+		if err != nil {
+			return xerrors.Errorf("getting funding address balance: %w", err)
+		}
+		if fromBal.LessThan(amt) {
+			log.Errorw("funding address balance too low for top-up", "from", t.cfg.From, "fromBalance", types.FIL(fromBal), "address", addr, "balance", types.FIL(bal), "amount", types.FIL(amt))
+			continue
+		}
+
+		sm, err := t.api.MpoolPushMessage(ctx, &types.Message{
+			From:  t.cfg.From,
+			To:    addr,
+			Value: amt,
+		}, &api.MessageSendSpec{AutoBump: true})
+		if err != nil {
+			log.Errorw("sending top-up", "from", t.cfg.From, "address", addr, "amount", types.FIL(amt), "error", err)
+			continue
+		}
+		t.lastTopUp[addr] = now
+
+		log.Infow("topped up address", "from", t.cfg.From, "address", addr, "balance", types.FIL(bal), "amount", types.FIL(amt), "message", sm.Cid())
+	}
+
+	return nil
+}
+
+// addresses returns the ID addresses to top up, without the funding address.
+func (t *TopUp) addresses(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error) {
+	addrs := t.cfg.Addresses
+	if len(addrs) == 0 {
+		mi, err := t.api.StateMinerInfo(ctx, t.maddr, tsk)
+		if err != nil {
+			return nil, xerrors.Errorf("getting miner info: %w", err)
+		}
+		addrs = append([]address.Address{mi.Worker}, mi.ControlAddresses...)
+	}
+
+	from, err := t.api.StateLookupID(ctx, t.cfg.From, tsk)
+	if err != nil {
+		// the funding address may not exist on chain yet, it will fail to send then
+		from = t.cfg.From
+	}
+
+	seen := map[address.Address]struct{}{from: {}}
+	out := make([]address.Address, 0, len(addrs))
+	for _, a := range addrs {
+		id, err := t.api.StateLookupID(ctx, a, tsk)
+		if err != nil {
+			return nil, xerrors.Errorf("looking up id of %s: %w", a, err)
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out, nil
+}
@@ -0,0 +1,105 @@
+package ctladdr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+)
+
+type topUpApi struct {
+	mi       api.MinerInfo
+	balances map[address.Address]types.BigInt
+	sent     []*types.Message
+}
+
+func (a *topUpApi) ChainHead(context.Context) (*types.TipSet, error) {
+	return mock.TipSet(mock.MkBlock(nil, 0, 0)), nil
+}
+
+func (a *topUpApi) StateMinerInfo(context.Context, address.Address, types.TipSetKey) (api.MinerInfo, error) {
+	return a.mi, nil
+}
+
+func (a *topUpApi) StateLookupID(_ context.Context, addr address.Address, _ types.TipSetKey) (address.Address, error) {
+	return addr, nil
+}
+
+func (a *topUpApi) WalletBalance(_ context.Context, addr address.Address) (types.BigInt, error) {
+	if b, ok := a.balances[addr]; ok {
+		return b, nil
+	}
+	return big.Zero(), nil
+}
+
+func (a *topUpApi) MpoolPushMessage(_ context.Context, msg *types.Message, _ *api.MessageSendSpec) (*types.SignedMessage, error) {
+	a.sent = append(a.sent, msg)
+	a.balances[msg.From] = big.Sub(a.balances[msg.From], msg.Value)
+	return &types.SignedMessage{Message: *msg}, nil
+}
+
+func TestTopUp(t *testing.T) {
+	ctx := context.Background()
+	id := func(i uint64) address.Address {
+		a, err := address.NewIDAddress(i)
+		require.NoError(t, err)
+		return a
+	}
+	maddr, worker, ctl1, ctl2, from := id(1000), id(1001), id(1002), id(1003), id(1004)
+
+	a := &topUpApi{
+		mi: api.MinerInfo{Worker: worker, ControlAddresses: []address.Address{ctl1, ctl2, from}},
+		balances: map[address.Address]types.BigInt{
+			worker: types.FromFil(2),
+			ctl1:   types.FromFil(0),
+			ctl2:   big.Div(types.FromFil(3), big.NewInt(4)),
+			from:   types.FromFil(100),
+		},
+	}
+
+	tu, err := NewTopUp(a, maddr, TopUpConfig{
+		From:          from,
+		Threshold:     types.FromFil(1),
+		Target:        types.FromFil(5),
+		MaxAmount:     big.Div(types.FromFil(9), big.NewInt(2)),
+		CheckInterval: time.Minute,
+		MinInterval:   time.Hour,
+	})
+	require.NoError(t, err)
+
+	// the worker is above the threshold, the control addresses are topped up, the funding address
+	// is never topped up
+	now := time.Now()
+	require.NoError(t, tu.Check(ctx, now))
+	require.Len(t, a.sent, 2)
+	require.Equal(t, ctl1, a.sent[0].To)
+	require.Equal(t, big.Div(types.FromFil(9), big.NewInt(2)), a.sent[0].Value) // capped
+	require.Equal(t, ctl2, a.sent[1].To)
+	require.Equal(t, big.Div(types.FromFil(17), big.NewInt(4)), a.sent[1].Value)
+	for _, m := range a.sent {
+		require.Equal(t, from, m.From)
+	}
+
+	// top-ups are rate limited even if the balance didn't update yet
+	require.NoError(t, tu.Check(ctx, now.Add(time.Minute)))
+	require.Len(t, a.sent, 2)
+
+	require.NoError(t, tu.Check(ctx, now.Add(time.Hour)))
+	require.Len(t, a.sent, 4)
+
+	// nothing is sent when the funding address can't cover the top-up
+	a.balances[from] = types.FromFil(1)
+	require.NoError(t, tu.Check(ctx, now.Add(2*time.Hour)))
+	require.Len(t, a.sent, 4)
+
+	_, err = NewTopUp(a, maddr, TopUpConfig{From: from, Threshold: types.FromFil(5), Target: types.FromFil(5), CheckInterval: time.Minute})
+	require.Error(t, err)
+}
@@ -2,6 +2,7 @@ package paths
 
 import (
 	"context"
+	"errors"
 	"io"
 	"mime"
 	"net/http"
@@ -14,6 +15,36 @@ import (
 	"github.com/filecoin-project/lotus/storage/sealer/tarutil"
 )
 
+// retryableFetchError marks fetch errors caused by the network or a temporarily unavailable remote,
+// after which the fetch can be retried. Other errors, such as missing or corrupt files on the remote
+// or local disk errors, are terminal.
+type retryableFetchError struct {
+	err error
+}
+
+func (e *retryableFetchError) Error() string { return e.err.Error() }
+func (e *retryableFetchError) Unwrap() error { return e.err }
+
+func isRetryableFetchError(err error) bool {
+	var rerr *retryableFetchError
+	return errors.As(err, &rerr)
+}
+
+// bodyReader records errors reading the response body, to tell network errors from local errors and
+// corrupt data when copying the body fails.
+type bodyReader struct {
+	r   io.Reader
+	err error
+}
+
+func (b *bodyReader) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if err != nil && err != io.EOF {
+		b.err = err
+	}
+	return n, err
+}
+
 func fetch(ctx context.Context, url, outname string, header http.Header) (rerr error) {
 	log.Infof("Fetch %s -> %s", url, outname)
 
@@ -26,14 +57,28 @@ func fetch(ctx context.Context, url, outname string, header http.Header) (rerr e
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return xerrors.Errorf("do request: %w", err)
+		if ctx.Err() != nil {
+			return xerrors.Errorf("do request: %w", err)
+		}
+		return &retryableFetchError{xerrors.Errorf("do request: %w", err)}
 	}
 	defer resp.Body.Close() // nolint
 
 	if resp.StatusCode != 200 {
-		return xerrors.Errorf("non-200 code: %d", resp.StatusCode)
+		err := xerrors.Errorf("non-200 code: %d", resp.StatusCode)
+		if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout {
+			return &retryableFetchError{err}
+		}
+		return err
 	}
 
+	body := &bodyReader{r: resp.Body}
+	defer func() {
+		if rerr != nil && body.err != nil && ctx.Err() == nil {
+			rerr = &retryableFetchError{rerr}
+		}
+	}()
+
 	start := time.Now()
 	var bytes int64
 	defer func() {
@@ -53,14 +98,14 @@ func fetch(ctx context.Context, url, outname string, header http.Header) (rerr e
 
 	switch mediatype {
 	case "application/x-tar":
-		bytes, err = tarutil.ExtractTar(resp.Body, outname, make([]byte, CopyBuf))
+		bytes, err = tarutil.ExtractTar(body, outname, make([]byte, CopyBuf))
 		return err
 	case "application/octet-stream":
 		f, err := os.Create(outname)
 		if err != nil {
 			return err
 		}
-		bytes, err = io.CopyBuffer(f, resp.Body, make([]byte, CopyBuf))
+		bytes, err = io.CopyBuffer(f, body, make([]byte, CopyBuf))
 		if err != nil {
 			f.Close() // nolint
 			return err
@@ -0,0 +1,68 @@
+package paths
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchRetry(t *testing.T) {
+	var calls, failures atomic.Int64
+	status := http.StatusServiceUnavailable
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if failures.Add(-1) >= 0 {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte("sector data"))
+	}))
+	defer srv.Close()
+
+	r := NewRemote(nil, nil, http.Header{}, 1, nil, WithFetchRetry(FetchRetryConfig{
+		MaxRetries: 2,
+		Backoff:    time.Millisecond,
+	}))
+	out := filepath.Join(t.TempDir(), "sealed")
+	ctx := context.Background()
+
+	// transient errors are retried
+	failures.Store(2)
+	require.NoError(t, r.fetchRetrying(ctx, srv.URL, out))
+	require.EqualValues(t, 3, calls.Load())
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "sector data", string(data))
+
+	// until retries run out
+	calls.Store(0)
+	failures.Store(3)
+	err = r.fetchRetrying(ctx, srv.URL, out)
+	require.Error(t, err)
+	require.True(t, isRetryableFetchError(err))
+	require.EqualValues(t, 3, calls.Load())
+
+	// missing files aren't retried
+	calls.Store(0)
+	failures.Store(1)
+	status = http.StatusNotFound
+	err = r.fetchRetrying(ctx, srv.URL, out)
+	require.Error(t, err)
+	require.False(t, isRetryableFetchError(err))
+	require.EqualValues(t, 1, calls.Load())
+
+	// network errors are retried
+	srv.Close()
+	err = r.fetchRetrying(ctx, srv.URL, out)
+	require.Error(t, err)
+	require.True(t, isRetryableFetchError(err))
+}
@@ -18,10 +18,12 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/ipfs/go-cid"
+	"go.opencensus.io/stats"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-state-types/abi"
 
+	"github.com/filecoin-project/lotus/metrics"
 	"github.com/filecoin-project/lotus/storage/sealer/fsutil"
 	"github.com/filecoin-project/lotus/storage/sealer/partialfile"
 	"github.com/filecoin-project/lotus/storage/sealer/storiface"
@@ -46,6 +48,27 @@ type Remote struct {
 	fetching map[abi.SectorID]chan struct{}
 
 	pfHandler PartialFileHandler
+
+	fetchRetry FetchRetryConfig
+}
+
+// FetchRetryConfig configures the retries of sector file fetches failing with network errors, or
+// errors of temporarily unavailable remotes.
+type FetchRetryConfig struct {
+	// MaxRetries is the number of retries of a fetch from each URL, 0 disables retries
+	MaxRetries int
+	// Backoff is the wait before the first retry, doubled after every retry up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+type RemoteOption func(*Remote)
+
+// WithFetchRetry sets the retry configuration of sector file fetches.
+func WithFetchRetry(cfg FetchRetryConfig) RemoteOption {
+	return func(r *Remote) {
+		r.fetchRetry = cfg
+	}
 }
 
 func (r *Remote) RemoveCopies(ctx context.Context, s abi.SectorID, typ storiface.SectorFileType) error {
@@ -80,8 +103,8 @@ func (r *Remote) RemoveCopies(ctx context.Context, s abi.SectorID, typ storiface
 	return r.Remove(ctx, s, typ, true, keep)
 }
 
-func NewRemote(local Store, index SectorIndex, auth http.Header, fetchLimit int, pfHandler PartialFileHandler) *Remote {
-	return &Remote{
+func NewRemote(local Store, index SectorIndex, auth http.Header, fetchLimit int, pfHandler PartialFileHandler, opts ...RemoteOption) *Remote {
+	r := &Remote{
 		local: local,
 		index: index,
 		auth:  auth,
@@ -91,6 +114,10 @@ func NewRemote(local Store, index SectorIndex, auth http.Header, fetchLimit int,
 		fetching:  map[abi.SectorID]chan struct{}{},
 		pfHandler: pfHandler,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (r *Remote) AcquireSector(ctx context.Context, s storiface.SectorRef, existing storiface.SectorFileType, allocate storiface.SectorFileType, pathType storiface.PathType, op storiface.AcquireMode, opts ...storiface.AcquireOption) (storiface.SectorPaths, storiface.SectorPaths, error) {
@@ -257,7 +284,7 @@ func (r *Remote) acquireFromRemote(ctx context.Context, s abi.SectorID, fileType
 				return "", xerrors.Errorf("removing dest: %w", err)
 			}
 
-			err = r.fetchThrottled(ctx, url, tempDest)
+			err = r.fetchRetrying(ctx, url, tempDest)
 			if err != nil {
 				merr = multierror.Append(merr, xerrors.Errorf("fetch error %s (storage %s) -> %s: %w", url, info.ID, tempDest, err))
 				// fetching failed, remove temp file
@@ -281,6 +308,31 @@ func (r *Remote) acquireFromRemote(ctx context.Context, s abi.SectorID, fileType
 	return "", xerrors.Errorf("failed to acquire sector %v from remote (tried %v): %w", s, si, merr)
 }
 
+// fetchRetrying fetches from the url, retrying with backoff on retryable errors.
+func (r *Remote) fetchRetrying(ctx context.Context, url, outname string) error {
+	backoff := r.fetchRetry.Backoff
+	for attempt := 0; ; attempt++ {
+		err := r.fetchThrottled(ctx, url, outname)
+		if err == nil || attempt >= r.fetchRetry.MaxRetries || !isRetryableFetchError(err) {
+			return err
+		}
+
+		log.Warnw("fetch failed, retrying", "url", url, "attempt", attempt+1, "maxRetries", r.fetchRetry.MaxRetries, "backoff", backoff, "error", err)
+		stats.Record(ctx, metrics.StorageFetchRetries.M(1))
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return xerrors.Errorf("context error while waiting to retry fetch: %w (fetch error: %s)", ctx.Err(), err)
+		}
+
+		backoff *= 2
+		if r.fetchRetry.MaxBackoff > 0 && backoff > r.fetchRetry.MaxBackoff {
+			backoff = r.fetchRetry.MaxBackoff
+		}
+	}
+}
+
 func (r *Remote) fetchThrottled(ctx context.Context, url, outname string) (rerr error) {
 	if len(r.limit) >= cap(r.limit) {
 		log.Infof("Throttling fetch, %d already running", len(r.limit))
@@ -30,7 +30,7 @@ func (t *SectorInfo) MarshalCBOR(w io.Writer) error {
 
 	cw := cbg.NewCborWriter(w)
 
-	if _, err := cw.Write([]byte{184, 39}); err != nil {
+	if _, err := cw.Write([]byte{184, 40}); err != nil {
 		return err
 	}
 
@@ -567,6 +567,22 @@ func (t *SectorInfo) MarshalCBOR(w io.Writer) error {
 		}
 	}
 
+	// t.CommitResubmits (uint64) (uint64)
+	if len("CommitResubmits") > 8192 {
+		return xerrors.Errorf("Value in field \"CommitResubmits\" was too long")
+	}
+
+	if err := cw.WriteMajorTypeHeader(cbg.MajTextString, uint64(len("CommitResubmits"))); err != nil {
+		return err
+	}
+	if _, err := cw.WriteString(string("CommitResubmits")); err != nil {
+		return err
+	}
+
+	if err := cw.WriteMajorTypeHeader(cbg.MajUnsignedInt, uint64(t.CommitResubmits)); err != nil {
+		return err
+	}
+
 	// t.PreCommit1Fails (uint64) (uint64)
 	if len("PreCommit1Fails") > 8192 {
 		return xerrors.Errorf("Value in field \"PreCommit1Fails\" was too long")
@@ -1451,6 +1467,21 @@ func (t *SectorInfo) UnmarshalCBOR(r io.Reader) (err error) {
 					t.UpdateUnsealed = &c
 				}
 
+			}
+			// t.CommitResubmits (uint64) (uint64)
+		case "CommitResubmits":
+
+			{
+
+				maj, extra, err = cr.ReadHeader()
+				if err != nil {
+					return err
+				}
+				if maj != cbg.MajUnsignedInt {
+					return fmt.Errorf("wrong type for uint64 field")
+				}
+				t.CommitResubmits = uint64(extra)
+
 			}
 			// t.PreCommit1Fails (uint64) (uint64)
 		case "PreCommit1Fails":
@@ -77,6 +77,8 @@ type CommitBatcher struct {
 	notify, stop, stopped chan struct{}
 	force                 chan chan []sealiface.CommitBatchRes
 	lk                    sync.Mutex
+
+	feeCapDelayed bool // sending was delayed because the base fee is above the configured cap
 }
 
 func NewCommitBatcher(mctx context.Context, maddr address.Address, api CommitBatcherApi, addrSel AddressSelector, feeCfg config.MinerFeeConfig, getConfig dtypes.GetSealingConfigFunc, prov storiface.Prover) (*CommitBatcher, error) {
@@ -136,7 +138,7 @@ func (b *CommitBatcher) run(cfg sealiface.Config) {
 		}
 
 		var err error
-		lastMsg, err = b.maybeStartBatch(sendAboveMax)
+		lastMsg, err = b.maybeStartBatch(sendAboveMax, forceRes != nil)
 		if err != nil {
 			log.Warnw("CommitBatcher processBatch error", "error", err)
 		}
@@ -176,6 +178,10 @@ func (b *CommitBatcher) batchWait(maxWait, slack time.Duration) time.Duration {
 		}
 	}
 
+	if b.feeCapDelayed && maxWait > baseFeeCapRecheck {
+		maxWait = baseFeeCapRecheck
+	}
+
 	if cutoff.IsZero() {
 		return maxWait
 	}
@@ -193,7 +199,7 @@ func (b *CommitBatcher) batchWait(maxWait, slack time.Duration) time.Duration {
 	return wait
 }
 
-func (b *CommitBatcher) maybeStartBatch(notif bool) ([]sealiface.CommitBatchRes, error) {
+func (b *CommitBatcher) maybeStartBatch(notif, force bool) ([]sealiface.CommitBatchRes, error) {
 	b.lk.Lock()
 	defer b.lk.Unlock()
 
@@ -218,6 +224,15 @@ func (b *CommitBatcher) maybeStartBatch(notif bool) ([]sealiface.CommitBatchRes,
 		return nil, err
 	}
 
+	// unless forced by the user, or some sectors are close to their cutoff, wait for the basefee
+	// to drop below the configured cap
+	b.feeCapDelayed = false
+	if !force && aboveBaseFeeCap(ts, b.feeCfg.MaxCommitBaseFee) && !cutoffWithin(b.cutoffs, cfg.CommitBatchSlack) {
+		log.Infow("delaying Commit batch, basefee above MaxCommitBaseFee", "basefee", ts.MinTicketBlock().ParentBaseFee, "cap", b.feeCfg.MaxCommitBaseFee, "sectors", total)
+		b.feeCapDelayed = true
+		return nil, nil
+	}
+
 	nv, err := b.api.StateNetworkVersion(b.mctx, ts.Key())
 	if err != nil {
 		return nil, xerrors.Errorf("getting network version: %s", err)
@@ -500,6 +515,15 @@ func (b *CommitBatcher) Flush(ctx context.Context) ([]sealiface.CommitBatchRes,
 	}
 }
 
+// isPending returns whether the sector waits to be sent in a batch
+func (b *CommitBatcher) isPending(sn abi.SectorNumber) bool {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+
+	_, ok := b.todo[sn]
+	return ok
+}
+
 func (b *CommitBatcher) Pending(ctx context.Context) ([]abi.SectorID, error) {
 	b.lk.Lock()
 	defer b.lk.Unlock()
@@ -163,11 +163,15 @@ var fsmPlanners = map[SectorState]func(events []statemachine.Event, state *Secto
 		on(SectorProving{}, FinalizeSector),
 		on(SectorCommitFailed{}, CommitFailed),
 		on(SectorRetrySubmitCommit{}, SubmitCommit),
+		on(SectorCommitResubmit{}, SubmitCommit),
+		on(SectorPreCommitExpired{}, PreCommitExpired),
 	),
 	CommitAggregateWait: planOne(
 		on(SectorProving{}, FinalizeSector),
 		on(SectorCommitFailed{}, CommitFailed),
 		on(SectorRetrySubmitCommit{}, SubmitCommit),
+		on(SectorCommitResubmit{}, SubmitCommit),
+		on(SectorPreCommitExpired{}, PreCommitExpired),
 	),
 
 	FinalizeSector: planOne(
@@ -275,10 +279,15 @@ var fsmPlanners = map[SectorState]func(events []statemachine.Event, state *Secto
 		on(SectorRetryPreCommit{}, PreCommitting),
 		on(SectorRetryCommitWait{}, CommitWait),
 		on(SectorRetrySubmitCommit{}, SubmitCommit),
+		on(SectorCommitResubmit{}, SubmitCommit),
+		on(SectorPreCommitExpired{}, PreCommitExpired),
 		onWithCB(SectorDealsExpired{}, DealsExpired, maybeNotifyRemoteDone(false, "DealsExpired")),
 		on(SectorInvalidDealIDs{}, RecoverDealIDs),
 		onWithCB(SectorTicketExpired{}, Removing, maybeNotifyRemoteDone(false, "Removing")),
 	),
+	PreCommitExpired: planOne(
+	// SectorRemove (global)
+	),
 	FinalizeFailed: planOne(
 		on(SectorRetryFinalize{}, FinalizeSector),
 	),
@@ -582,6 +591,8 @@ func (m *Sealing) plan(events []statemachine.Event, state *SectorInfo) (func(sta
 		return m.handleRemoteCommitFailed, processed, nil
 	case CommitFailed:
 		return m.handleCommitFailed, processed, nil
+	case PreCommitExpired:
+		return m.handlePreCommitExpired, processed, nil
 	case CommitFinalizeFailed:
 		fallthrough
 	case FinalizeFailed:
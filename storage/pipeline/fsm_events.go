@@ -9,6 +9,7 @@ import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/builtin/v9/miner"
+	"github.com/filecoin-project/go-state-types/exitcode"
 
 	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/chain/types"
@@ -57,6 +58,23 @@ func (evt SectorForceState) applyGlobal(state *SectorInfo) bool {
 	return true
 }
 
+// SectorRetryStep moves the sector back to a state to manually re-run a sealing step. It's
+// ignored when the sector left the state it was validated in.
+type SectorRetryStep struct {
+	Step  string
+	From  SectorState
+	State SectorState
+}
+
+func (evt SectorRetryStep) applyGlobal(state *SectorInfo) bool {
+	if state.State != evt.From {
+		log.Warnw("not retrying sealing step, sector state changed", "sector", state.SectorNumber, "step", evt.Step, "expected", evt.From, "state", state.State)
+		return true
+	}
+	state.State = evt.State
+	return true
+}
+
 // Normal path
 
 type SectorStart struct {
@@ -246,6 +264,21 @@ type SectorRetrySubmitCommit struct{}
 
 func (evt SectorRetrySubmitCommit) apply(*SectorInfo) {}
 
+// SectorCommitResubmit is sent when the commit message landed on chain, but
+// failed with a transient error, and will be re-estimated and resubmitted
+type SectorCommitResubmit struct {
+	ExitCode exitcode.ExitCode
+}
+
+func (evt SectorCommitResubmit) apply(state *SectorInfo) {
+	state.CommitResubmits++
+}
+
+type SectorPreCommitExpired struct{ error }
+
+func (evt SectorPreCommitExpired) FormatError(xerrors.Printer) (next error) { return evt.error }
+func (evt SectorPreCommitExpired) apply(*SectorInfo)                        {}
+
 type SectorDealsExpired struct{ error }
 
 func (evt SectorDealsExpired) FormatError(xerrors.Printer) (next error) { return evt.error }
@@ -4,14 +4,17 @@ import (
 	"context"
 	"testing"
 
+	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/filecoin-project/go-statemachine"
 
+	"github.com/filecoin-project/lotus/storage/pipeline/sealiface"
 	"github.com/filecoin-project/lotus/storage/sealer/storiface"
 )
 
@@ -453,6 +456,56 @@ func TestCreationTimeCleared(t *testing.T) {
 	require.NotEqual(t, int64(0), m.state.CreationTime)
 }
 
+func TestCommitResubmit(t *testing.T) {
+	ma, _ := address.NewIDAddress(55151)
+	m := test{
+		s: &Sealing{
+			maddr: ma,
+			stats: SectorStats{
+				bySector: map[abi.SectorID]SectorState{},
+				byState:  map[SectorState]int64{},
+			},
+		},
+		t:     t,
+		state: &SectorInfo{State: CommitAggregateWait},
+	}
+
+	m.planSingle(SectorCommitResubmit{ExitCode: exitcode.SysErrOutOfGas})
+	require.Equal(m.t, SubmitCommit, m.state.State)
+	require.Equal(m.t, uint64(1), m.state.CommitResubmits)
+
+	m.planSingle(SectorCommitSubmitted{})
+	require.Equal(m.t, CommitWait, m.state.State)
+
+	m.planSingle(SectorCommitFailed{xerrors.New("out of gas")})
+	require.Equal(m.t, CommitFailed, m.state.State)
+
+	m.planSingle(SectorCommitResubmit{ExitCode: exitcode.SysErrOutOfGas})
+	require.Equal(m.t, SubmitCommit, m.state.State)
+	require.Equal(m.t, uint64(2), m.state.CommitResubmits)
+}
+
+func TestPreCommitExpired(t *testing.T) {
+	ma, _ := address.NewIDAddress(55151)
+	m := test{
+		s: &Sealing{
+			maddr: ma,
+			stats: SectorStats{
+				bySector: map[abi.SectorID]SectorState{},
+				byState:  map[SectorState]int64{},
+			},
+		},
+		t:     t,
+		state: &SectorInfo{State: CommitAggregateWait},
+	}
+
+	m.planSingle(SectorPreCommitExpired{xerrors.New("precommit expired")})
+	require.Equal(m.t, PreCommitExpired, m.state.State)
+
+	m.planSingle(SectorRemove{})
+	require.Equal(m.t, Removing, m.state.State)
+}
+
 func TestRetrySoftErr(t *testing.T) {
 	i := 0
 
@@ -473,3 +526,77 @@ func TestRetrySoftErr(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 2, i)
 }
+
+func TestRetryStep(t *testing.T) {
+	ma, _ := address.NewIDAddress(55151)
+	m := test{
+		s: &Sealing{
+			maddr: ma,
+			stats: SectorStats{
+				bySector: map[abi.SectorID]SectorState{},
+				byState:  map[SectorState]int64{},
+			},
+		},
+		t:     t,
+		state: &SectorInfo{State: ComputeProofFailed},
+	}
+
+	m.planSingle(SectorRetryStep{Step: "commit", From: ComputeProofFailed, State: Committing})
+	require.Equal(m.t, Committing, m.state.State)
+	require.Equal(m.t, "event;sealing.SectorRetryStep", m.state.Log[len(m.state.Log)-1].Kind)
+
+	// stale retries are ignored
+	m.planSingle(SectorRetryStep{Step: "wait-seed", From: ComputeProofFailed, State: WaitSeed})
+	require.Equal(m.t, Committing, m.state.State)
+
+	for name, rs := range retrySteps {
+		_, ok := fsmPlanners[rs.state]
+		require.True(t, ok, "no planner for the state of step %s", name)
+		for _, st := range rs.from {
+			_, ok := fsmPlanners[st]
+			require.True(t, ok, "no planner for state %s of step %s", st, name)
+		}
+	}
+}
+
+func TestRetryTarget(t *testing.T) {
+	cfg := sealiface.Config{AggregateCommits: true}
+	m := &Sealing{
+		precommiter: &PreCommitBatcher{todo: map[abi.SectorNumber]*preCommitEntry{}},
+		commiter:    &CommitBatcher{todo: map[abi.SectorNumber]AggregateInput{}},
+		getConfig: func() (sealiface.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	failed := SectorInfo{SectorNumber: 5, State: PreCommitFailed, CommR: &cid.Undef}
+	next, err := m.retryTarget("precommit", retrySteps["precommit"], failed)
+	require.NoError(t, err)
+	require.Equal(t, PreCommitting, next)
+
+	// sectors waiting in a batch can't be moved out of the batch
+	_, err = m.retryTarget("precommit", retrySteps["precommit"], SectorInfo{SectorNumber: 5, State: SubmitPreCommitBatch, CommR: &cid.Undef})
+	require.Error(t, err)
+
+	// including sectors pending in the batcher after failing
+	m.precommiter.todo[5] = &preCommitEntry{}
+	_, err = m.retryTarget("precommit", retrySteps["precommit"], failed)
+	require.ErrorContains(t, err, "PreCommit batcher")
+	delete(m.precommiter.todo, 5)
+
+	commitFailed := SectorInfo{SectorNumber: 6, State: CommitFailed, Proof: []byte{1}}
+	m.commiter.todo[6] = AggregateInput{}
+	_, err = m.retryTarget("submit-commit", retrySteps["submit-commit"], commitFailed)
+	require.ErrorContains(t, err, "Commit batcher")
+	delete(m.commiter.todo, 6)
+
+	// the commit submission state depends on aggregation
+	next, err = m.retryTarget("submit-commit", retrySteps["submit-commit"], commitFailed)
+	require.NoError(t, err)
+	require.Equal(t, SubmitCommitAggregate, next)
+
+	cfg.AggregateCommits = false
+	next, err = m.retryTarget("submit-commit", retrySteps["submit-commit"], commitFailed)
+	require.NoError(t, err)
+	require.Equal(t, SubmitCommit, next)
+}
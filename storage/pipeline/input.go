@@ -536,18 +536,17 @@ func (m *Sealing) updateInput(ctx context.Context, sp abi.RegisteredSealProof) e
 		return err
 	}
 
-	type match struct {
-		sector abi.SectorID
-		deal   piece.PieceKey
-
-		dealEnd      abi.ChainEpoch
-		claimTermEnd abi.ChainEpoch
-
-		size    abi.UnpaddedPieceSize
-		padding abi.UnpaddedPieceSize
+	cfg, err := m.getConfig()
+	if err != nil {
+		return xerrors.Errorf("getting config: %w", err)
+	}
+	strategy := cfg.DealPackingStrategy
+	if err := strategy.Validate(); err != nil {
+		log.Errorw("invalid deal packing strategy, using the default", "error", err)
+		strategy = sealiface.DealPackingLeastPadding
 	}
 
-	var matches []match
+	var matches []pieceMatch
 	toAssign := map[piece.PieceKey]struct{}{} // used to maybe create new sectors
 
 	// todo: this is distinctly O(n^2), may need to be optimized for tiny deals and large scale miners
@@ -579,12 +578,13 @@ func (m *Sealing) updateInput(ctx context.Context, sp abi.RegisteredSealProof) e
 			}
 
 			if piece.size <= avail { // (note: if we have enough space for the piece, we also have enough space for inter-piece padding)
-				matches = append(matches, match{
+				matches = append(matches, pieceMatch{
 					sector: id,
 					deal:   proposalCid,
 
-					dealEnd:      endEpoch,
-					claimTermEnd: piece.claimTerms.claimTermEnd,
+					dealEnd:       endEpoch,
+					claimTermEnd:  piece.claimTerms.claimTermEnd,
+					sectorDealEnd: sector.lastDealEnd,
 
 					size:    piece.size,
 					padding: avail % piece.size,
@@ -592,21 +592,9 @@ func (m *Sealing) updateInput(ctx context.Context, sp abi.RegisteredSealProof) e
 			}
 		}
 	}
-	sort.Slice(matches, func(i, j int) bool {
-		// todo maybe sort by expiration
+	sortPieceMatches(matches, strategy)
 
-		if matches[i].padding != matches[j].padding { // less padding is better
-			return matches[i].padding < matches[j].padding
-		}
-
-		if matches[i].size != matches[j].size { // larger pieces are better
-			return matches[i].size < matches[j].size
-		}
-
-		return matches[i].sector.Number < matches[j].sector.Number // prefer older sectors
-	})
-
-	log.Debugw("updateInput matching", "matches", len(matches), "toAssign", len(toAssign), "openSectors", len(m.openSectors), "pieces", len(m.pendingPieces))
+	log.Debugw("updateInput matching", "strategy", strategy, "matches", len(matches), "toAssign", len(toAssign), "openSectors", len(m.openSectors), "pieces", len(m.pendingPieces))
 
 	var assigned int
 	for _, mt := range matches {
@@ -663,6 +651,80 @@ func (m *Sealing) updateInput(ctx context.Context, sp abi.RegisteredSealProof) e
 	return nil
 }
 
+// pieceMatch is a pending piece which fits in an open sector
+type pieceMatch struct {
+	sector abi.SectorID
+	deal   piece.PieceKey
+
+	dealEnd      abi.ChainEpoch
+	claimTermEnd abi.ChainEpoch
+	// sectorDealEnd is the end of the last ending deal already in the sector, 0 for sectors without deals
+	sectorDealEnd abi.ChainEpoch
+
+	size    abi.UnpaddedPieceSize
+	padding abi.UnpaddedPieceSize
+}
+
+// sortPieceMatches orders the matches in the order pieces are assigned to sectors with the strategy,
+// earlier matches are preferred
+func sortPieceMatches(matches []pieceMatch, strategy sealiface.DealPackingStrategy) {
+	leastPadding := func(a, b pieceMatch) (bool, bool) {
+		if a.padding != b.padding { // less padding is better
+			return a.padding < b.padding, true
+		}
+		return false, false
+	}
+	olderSector := func(a, b pieceMatch) bool {
+		return a.sector.Number < b.sector.Number // prefer older sectors
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+
+		switch strategy {
+		case sealiface.DealPackingLargestFirst:
+			if a.size != b.size {
+				return a.size > b.size
+			}
+			if less, ok := leastPadding(a, b); ok {
+				return less
+			}
+			return olderSector(a, b)
+
+		case sealiface.DealPackingByExpiration:
+			// sectors without deals are least preferred, so that new deal groups start there
+			if (a.sectorDealEnd == 0) != (b.sectorDealEnd == 0) {
+				return b.sectorDealEnd == 0
+			}
+			if a.sectorDealEnd != 0 {
+				if da, db := epochDistance(a.dealEnd, a.sectorDealEnd), epochDistance(b.dealEnd, b.sectorDealEnd); da != db {
+					return da < db
+				}
+			}
+			if less, ok := leastPadding(a, b); ok {
+				return less
+			}
+			return olderSector(a, b)
+
+		default:
+			if less, ok := leastPadding(a, b); ok {
+				return less
+			}
+			if a.size != b.size { // larger pieces are better
+				return a.size < b.size
+			}
+			return olderSector(a, b)
+		}
+	})
+}
+
+func epochDistance(a, b abi.ChainEpoch) abi.ChainEpoch {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
 // pendingPieceIndex is an index in the Sealing.pendingPieces map
 type pendingPieceIndex piece.PieceKey
 
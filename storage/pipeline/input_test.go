@@ -0,0 +1,54 @@
+package sealing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/storage/pipeline/sealiface"
+)
+
+func TestSortPieceMatches(t *testing.T) {
+	mk := func(sector abi.SectorNumber, size, padding abi.UnpaddedPieceSize, dealEnd, sectorDealEnd abi.ChainEpoch) pieceMatch {
+		return pieceMatch{
+			sector:        abi.SectorID{Miner: 1000, Number: sector},
+			size:          size,
+			padding:       padding,
+			dealEnd:       dealEnd,
+			sectorDealEnd: sectorDealEnd,
+		}
+	}
+
+	small := mk(1, 127, 0, 5000, 0)
+	large := mk(2, 1016, 254, 1000, 1100)
+	aligned := mk(3, 508, 0, 1000, 4000)
+	empty := mk(4, 508, 0, 1000, 0)
+
+	order := func(strategy sealiface.DealPackingStrategy) []abi.SectorNumber {
+		matches := []pieceMatch{small, large, aligned, empty}
+		sortPieceMatches(matches, strategy)
+
+		var out []abi.SectorNumber
+		for _, m := range matches {
+			out = append(out, m.sector.Number)
+		}
+		return out
+	}
+
+	// least padding, then smaller pieces, then older sectors
+	require.Equal(t, []abi.SectorNumber{1, 3, 4, 2}, order(""))
+	require.Equal(t, []abi.SectorNumber{1, 3, 4, 2}, order(sealiface.DealPackingLeastPadding))
+	// larger pieces, then least padding
+	require.Equal(t, []abi.SectorNumber{2, 3, 4, 1}, order(sealiface.DealPackingLargestFirst))
+	// closest deal ends, sectors without deals last
+	require.Equal(t, []abi.SectorNumber{2, 3, 1, 4}, order(sealiface.DealPackingByExpiration))
+}
+
+func TestDealPackingStrategyValidate(t *testing.T) {
+	for _, s := range []sealiface.DealPackingStrategy{"", sealiface.DealPackingLeastPadding, sealiface.DealPackingLargestFirst, sealiface.DealPackingByExpiration} {
+		require.NoError(t, s.Validate())
+	}
+	require.Error(t, sealiface.DealPackingStrategy("smallest-first").Validate())
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"go.opencensus.io/stats"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-address"
@@ -21,6 +22,7 @@ import (
 	"github.com/filecoin-project/lotus/chain/actors/builtin/miner"
 	"github.com/filecoin-project/lotus/chain/actors/policy"
 	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/metrics"
 	"github.com/filecoin-project/lotus/node/config"
 	"github.com/filecoin-project/lotus/node/modules/dtypes"
 	"github.com/filecoin-project/lotus/storage/pipeline/sealiface"
@@ -56,13 +58,16 @@ type PreCommitBatcher struct {
 	feeCfg    config.MinerFeeConfig
 	getConfig dtypes.GetSealingConfigFunc
 
-	cutoffs map[abi.SectorNumber]time.Time
-	todo    map[abi.SectorNumber]*preCommitEntry
-	waiting map[abi.SectorNumber][]chan sealiface.PreCommitBatchRes
+	cutoffs        map[abi.SectorNumber]time.Time
+	ticketExpiries map[abi.SectorNumber]time.Time
+	todo           map[abi.SectorNumber]*preCommitEntry
+	waiting        map[abi.SectorNumber][]chan sealiface.PreCommitBatchRes
 
 	notify, stop, stopped chan struct{}
 	force                 chan chan []sealiface.PreCommitBatchRes
 	lk                    sync.Mutex
+
+	feeCapDelayed bool // sending was delayed because the base fee is above the configured cap
 }
 
 func NewPreCommitBatcher(mctx context.Context, maddr address.Address, api PreCommitBatcherApi, addrSel AddressSelector, feeCfg config.MinerFeeConfig, getConfig dtypes.GetSealingConfigFunc) (*PreCommitBatcher, error) {
@@ -74,9 +79,10 @@ func NewPreCommitBatcher(mctx context.Context, maddr address.Address, api PreCom
 		feeCfg:    feeCfg,
 		getConfig: getConfig,
 
-		cutoffs: map[abi.SectorNumber]time.Time{},
-		todo:    map[abi.SectorNumber]*preCommitEntry{},
-		waiting: map[abi.SectorNumber][]chan sealiface.PreCommitBatchRes{},
+		cutoffs:        map[abi.SectorNumber]time.Time{},
+		ticketExpiries: map[abi.SectorNumber]time.Time{},
+		todo:           map[abi.SectorNumber]*preCommitEntry{},
+		waiting:        map[abi.SectorNumber][]chan sealiface.PreCommitBatchRes{},
 
 		notify:  make(chan struct{}, 1),
 		force:   make(chan chan []sealiface.PreCommitBatchRes),
@@ -98,7 +104,7 @@ func (b *PreCommitBatcher) run(cfg sealiface.Config) {
 	var forceRes chan []sealiface.PreCommitBatchRes
 	var lastRes []sealiface.PreCommitBatchRes
 
-	timer := time.NewTimer(b.batchWait(cfg.PreCommitBatchWait, cfg.PreCommitBatchSlack))
+	timer := time.NewTimer(b.batchWait(cfg.PreCommitBatchWait, cfg.PreCommitBatchSlack, epochsDuration(cfg.PreCommitBatchTicketExpiryEpochs)))
 	for {
 		if forceRes != nil {
 			forceRes <- lastRes
@@ -120,7 +126,7 @@ func (b *PreCommitBatcher) run(cfg sealiface.Config) {
 		}
 
 		var err error
-		lastRes, err = b.maybeStartBatch(sendAboveMax)
+		lastRes, err = b.maybeStartBatch(sendAboveMax, forceRes != nil)
 		if err != nil {
 			log.Warnw("PreCommitBatcher processBatch error", "error", err)
 		}
@@ -132,11 +138,11 @@ func (b *PreCommitBatcher) run(cfg sealiface.Config) {
 			}
 		}
 
-		timer.Reset(b.batchWait(cfg.PreCommitBatchWait, cfg.PreCommitBatchSlack))
+		timer.Reset(b.batchWait(cfg.PreCommitBatchWait, cfg.PreCommitBatchSlack, epochsDuration(cfg.PreCommitBatchTicketExpiryEpochs)))
 	}
 }
 
-func (b *PreCommitBatcher) batchWait(maxWait, slack time.Duration) time.Duration {
+func (b *PreCommitBatcher) batchWait(maxWait, slack, ticketWindow time.Duration) time.Duration {
 	now := time.Now()
 
 	b.lk.Lock()
@@ -160,16 +166,33 @@ func (b *PreCommitBatcher) batchWait(maxWait, slack time.Duration) time.Duration
 		}
 	}
 
-	if cutoff.IsZero() {
+	if b.feeCapDelayed && maxWait > baseFeeCapRecheck {
+		maxWait = baseFeeCapRecheck
+	}
+
+	var deadline time.Time
+	if !cutoff.IsZero() {
+		deadline = cutoff.Add(-slack)
+	}
+
+	if ticketWindow > 0 {
+		for sn := range b.todo {
+			expiry, ok := b.ticketExpiries[sn]
+			if ok && (deadline.IsZero() || expiry.Add(-ticketWindow).Before(deadline)) {
+				deadline = expiry.Add(-ticketWindow)
+			}
+		}
+	}
+
+	if deadline.IsZero() {
 		return maxWait
 	}
 
-	cutoff = cutoff.Add(-slack)
-	if cutoff.Before(now) {
+	if deadline.Before(now) {
 		return time.Nanosecond // can't return 0
 	}
 
-	wait := cutoff.Sub(now)
+	wait := deadline.Sub(now)
 	if wait > maxWait {
 		wait = maxWait
 	}
@@ -177,7 +200,7 @@ func (b *PreCommitBatcher) batchWait(maxWait, slack time.Duration) time.Duration
 	return wait
 }
 
-func (b *PreCommitBatcher) maybeStartBatch(notif bool) ([]sealiface.PreCommitBatchRes, error) {
+func (b *PreCommitBatcher) maybeStartBatch(notif, force bool) ([]sealiface.PreCommitBatchRes, error) {
 	b.lk.Lock()
 	defer b.lk.Unlock()
 
@@ -201,12 +224,29 @@ func (b *PreCommitBatcher) maybeStartBatch(notif bool) ([]sealiface.PreCommitBat
 		curBasefeeLow = true
 	}
 
+	// sectors with tickets about to expire would have to be sealed again, send them right away
+	ticketExpiring := cfg.PreCommitBatchTicketExpiryEpochs > 0 && cutoffWithin(b.ticketExpiries, epochsDuration(cfg.PreCommitBatchTicketExpiryEpochs))
+
 	// if this wasn't an user-forced batch, and we're not at/above the max batch size,
 	// and we're not above the basefee threshold, don't batch yet
-	if notif && total < cfg.MaxPreCommitBatch && !curBasefeeLow {
+	if notif && total < cfg.MaxPreCommitBatch && !curBasefeeLow && !ticketExpiring {
+		return nil, nil
+	}
+
+	// unless forced by the user, or some sectors are close to their cutoff, wait for the basefee
+	// to drop below the configured cap
+	b.feeCapDelayed = false
+	if !force && aboveBaseFeeCap(ts, b.feeCfg.MaxPreCommitBaseFee) && !cutoffWithin(b.cutoffs, cfg.PreCommitBatchSlack) && !ticketExpiring {
+		log.Infow("delaying PreCommit batch, basefee above MaxPreCommitBaseFee", "basefee", ts.MinTicketBlock().ParentBaseFee, "cap", b.feeCfg.MaxPreCommitBaseFee, "sectors", total)
+		b.feeCapDelayed = true
 		return nil, nil
 	}
 
+	if ticketExpiring && !force && total < cfg.MaxPreCommitBatch {
+		log.Infow("sending PreCommit batch early, a sector ticket is about to expire", "sectors", total, "expiryEpochs", cfg.PreCommitBatchTicketExpiryEpochs)
+		stats.Record(b.mctx, metrics.SealPreCommitTicketFlushes.M(1))
+	}
+
 	nv, err := b.api.StateNetworkVersion(b.mctx, ts.Key())
 	if err != nil {
 		return nil, xerrors.Errorf("couldn't get network version: %w", err)
@@ -232,6 +272,7 @@ func (b *PreCommitBatcher) maybeStartBatch(notif bool) ([]sealiface.PreCommitBat
 			delete(b.waiting, sn)
 			delete(b.todo, sn)
 			delete(b.cutoffs, sn)
+			delete(b.ticketExpiries, sn)
 		}
 	}
 
@@ -348,6 +389,7 @@ func (b *PreCommitBatcher) AddPreCommit(ctx context.Context, s SectorInfo, depos
 
 	b.lk.Lock()
 	b.cutoffs[sn] = time.Now().Add(time.Duration(cutoffEpoch-ts.Height()) * time.Duration(buildconstants.BlockDelaySecs) * time.Second)
+	b.ticketExpiries[sn] = time.Now().Add(time.Duration(s.TicketEpoch+policy.MaxPreCommitRandomnessLookback-ts.Height()) * time.Duration(buildconstants.BlockDelaySecs) * time.Second)
 	b.todo[sn] = &preCommitEntry{
 		deposit: deposit,
 		pci:     in,
@@ -385,6 +427,15 @@ func (b *PreCommitBatcher) Flush(ctx context.Context) ([]sealiface.PreCommitBatc
 	}
 }
 
+// isPending returns whether the sector waits to be sent in a batch
+func (b *PreCommitBatcher) isPending(sn abi.SectorNumber) bool {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+
+	_, ok := b.todo[sn]
+	return ok
+}
+
 func (b *PreCommitBatcher) Pending(ctx context.Context) ([]abi.SectorID, error) {
 	b.lk.Lock()
 	defer b.lk.Unlock()
@@ -469,3 +520,8 @@ func (b *PreCommitBatcher) getAllocationCutoff(si SectorInfo) abi.ChainEpoch {
 	}
 	return cutoff
 }
+
+// epochsDuration returns the expected wall clock duration of the given number of epochs.
+func epochsDuration(epochs uint64) time.Duration {
+	return time.Duration(epochs) * time.Duration(buildconstants.BlockDelaySecs) * time.Second
+}
@@ -0,0 +1,115 @@
+package sealing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+	"github.com/filecoin-project/lotus/node/config"
+	"github.com/filecoin-project/lotus/storage/pipeline/mocks"
+	"github.com/filecoin-project/lotus/storage/pipeline/sealiface"
+)
+
+func TestPreCommitBatchWaitTicketExpiry(t *testing.T) {
+	now := time.Now()
+	b := &PreCommitBatcher{
+		cutoffs:        map[abi.SectorNumber]time.Time{1: now.Add(10 * time.Hour), 2: now.Add(20 * time.Hour)},
+		ticketExpiries: map[abi.SectorNumber]time.Time{1: now.Add(12 * time.Hour), 2: now.Add(5 * time.Hour)},
+		todo:           map[abi.SectorNumber]*preCommitEntry{1: {}, 2: {}},
+	}
+
+	within := func(d, expected time.Duration) {
+		require.InDelta(t, expected.Seconds(), d.Seconds(), 1)
+	}
+
+	// without a ticket expiry window, the batch waits for the earliest cutoff minus slack
+	within(b.batchWait(24*time.Hour, 3*time.Hour, 0), 7*time.Hour)
+
+	// sector 2 ticket expires in 5h, flush 1h before that
+	within(b.batchWait(24*time.Hour, 3*time.Hour, time.Hour), 4*time.Hour)
+
+	// already within the window
+	require.Equal(t, time.Nanosecond, b.batchWait(24*time.Hour, 3*time.Hour, 6*time.Hour))
+
+	require.True(t, cutoffWithin(b.ticketExpiries, 6*time.Hour))
+	require.False(t, cutoffWithin(b.ticketExpiries, time.Hour))
+}
+
+func TestPreCommitBatchTicketExpiryBaseFeeCap(t *testing.T) {
+	ctx := context.Background()
+
+	head := mock.MkBlock(nil, 0, 0)
+	head.Height = 1000
+	head.ParentBaseFee = abi.NewTokenAmount(200)
+	ts := mock.TipSet(head)
+
+	errSent := xerrors.New("batch sent")
+
+	cfg := sealiface.Config{
+		MaxPreCommitBatch:                10,
+		PreCommitBatchSlack:              time.Hour,
+		PreCommitBatchTicketExpiryEpochs: 100,
+		BatchPreCommitAboveBaseFee:       big.Zero(),
+	}
+
+	for _, tc := range []struct {
+		name         string
+		ticketExpiry time.Duration
+		expiryEpochs uint64
+		notif        bool
+		sent         bool
+	}{
+		{name: "ticket far from expiry", ticketExpiry: 24 * time.Hour, expiryEpochs: 100},
+		// the base fee cap is ignored for sectors which would have to be sealed again
+		{name: "ticket about to expire", ticketExpiry: epochsDuration(50), expiryEpochs: 100, sent: true},
+		// and so is the batch size on notifications of new sectors
+		{name: "ticket about to expire, notified", ticketExpiry: epochsDuration(50), expiryEpochs: 100, notif: true, sent: true},
+		{name: "ticket expiry flushes disabled", ticketExpiry: epochsDuration(50), expiryEpochs: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mapi := mocks.NewMockPreCommitBatcherApi(ctrl)
+
+			mapi.EXPECT().ChainHead(gomock.Any()).Return(ts, nil)
+			if tc.sent {
+				// fail right after deciding to send the batch
+				mapi.EXPECT().StateNetworkVersion(gomock.Any(), ts.Key()).Return(network.Version0, errSent)
+			}
+
+			cfg := cfg
+			cfg.PreCommitBatchTicketExpiryEpochs = tc.expiryEpochs
+			b := &PreCommitBatcher{
+				api:       mapi,
+				mctx:      ctx,
+				feeCfg:    config.MinerFeeConfig{MaxPreCommitBaseFee: types.FIL(abi.NewTokenAmount(100))},
+				getConfig: func() (sealiface.Config, error) { return cfg, nil },
+
+				cutoffs:        map[abi.SectorNumber]time.Time{1: time.Now().Add(48 * time.Hour)},
+				ticketExpiries: map[abi.SectorNumber]time.Time{1: time.Now().Add(tc.ticketExpiry)},
+				todo:           map[abi.SectorNumber]*preCommitEntry{1: {}},
+				waiting:        map[abi.SectorNumber][]chan sealiface.PreCommitBatchRes{},
+			}
+
+			res, err := b.maybeStartBatch(tc.notif, false)
+			require.Nil(t, res)
+			if tc.sent {
+				require.ErrorIs(t, err, errSent)
+				require.False(t, b.feeCapDelayed)
+			} else {
+				require.NoError(t, err)
+				require.True(t, b.feeCapDelayed)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package sealing
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// unrecoverableStates are terminal states sectors can't be brought back from, with the reason why.
+var unrecoverableStates = map[SectorState]string{
+	FaultedFinal:      "the sector was declared faulty on chain",
+	PreCommitExpired:  "the precommit expired, the sector number can't be precommitted again",
+	DealsExpired:      "the deals in the sector expired before it was committed",
+	TerminateFinality: "the sector was terminated on chain",
+	Removed:           "the sector was removed from storage",
+}
+
+// RecoverFailedSector moves a sector in the FailedUnrecoverable state back into the sealing
+// pipeline, at the state which resumes sealing from its last completed step. The sector must not
+// be committed on chain, its deals must still be able to start, and when it was already sealed its
+// sealed replica must be readable from storage.
+func (m *Sealing) RecoverFailedSector(ctx context.Context, sid abi.SectorNumber) (SectorState, error) {
+	m.startupWait.Wait()
+
+	si, err := m.GetSectorInfo(sid)
+	if err != nil {
+		return "", xerrors.Errorf("getting sector info: %w", err)
+	}
+
+	status, err := m.onChainStatus(ctx, sid)
+	if err != nil {
+		return "", err
+	}
+
+	head, err := m.Api.ChainHead(ctx)
+	if err != nil {
+		return "", xerrors.Errorf("getting chain head: %w", err)
+	}
+
+	next, err := recoverFailedState(si, status, head.Height())
+	if err != nil {
+		return "", xerrors.Errorf("can't recover sector %d: %w", sid, err)
+	}
+
+	if si.CommR != nil {
+		if err := m.checkReplica(ctx, si, *si.CommR); err != nil {
+			return "", xerrors.Errorf("can't recover sector %d, sealed replica check failed, restore its storage first: %w", sid, err)
+		}
+	}
+
+	log.Warnw("recovering failed sector", "sector", sid, "from", si.State, "to", next, "onChain", status)
+
+	return next, m.sectors.Send(uint64(sid), SectorRetryStep{Step: "recover-failed", From: si.State, State: next})
+}
+
+// recoverFailedState returns the state a failed sector resumes sealing from, or why it can't be
+// recovered.
+func recoverFailedState(si SectorInfo, status onChainStatus, height abi.ChainEpoch) (SectorState, error) {
+	if reason, ok := unrecoverableStates[si.State]; ok {
+		return "", xerrors.Errorf("sector is in %s, %s", si.State, reason)
+	}
+	if si.State != FailedUnrecoverable {
+		return "", xerrors.Errorf("sector is in %s, not %s; use 'sectors retry' to re-run sealing steps of stuck sectors", si.State, FailedUnrecoverable)
+	}
+
+	if status == committed {
+		return "", xerrors.Errorf("sector is committed on chain, check its files with 'proving check' instead")
+	}
+
+	for _, p := range si.Pieces {
+		if !p.HasDealInfo() {
+			continue
+		}
+		start, err := p.StartEpoch()
+		if err != nil {
+			return "", xerrors.Errorf("getting start epoch of piece %s: %w", p.Piece().PieceCID, err)
+		}
+		if start <= height {
+			return "", xerrors.Errorf("piece %s can't be sealed anymore, its deal start epoch %d passed", p.Piece().PieceCID, start)
+		}
+	}
+
+	if status == preCommitted {
+		if len(si.Proof) > 0 {
+			// the commit failure handler checks whether the proof is still valid
+			return CommitFailed, nil
+		}
+		return WaitSeed, nil
+	}
+
+	switch {
+	case si.CommR != nil:
+		// the precommit failure handler checks the ticket and resubmits
+		return PreCommitFailed, nil
+	case len(si.PreCommit1Out) > 0:
+		return PreCommit2, nil
+	case len(si.TicketValue) > 0:
+		return PreCommit1, nil
+	default:
+		return GetTicket, nil
+	}
+}
@@ -0,0 +1,100 @@
+package sealing
+
+import (
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/market"
+	"github.com/filecoin-project/lotus/storage/pipeline/piece"
+)
+
+func TestRecoverFailedState(t *testing.T) {
+	commR, publishCid := cid.Undef, cid.Undef
+	dealPiece := SafePiece(api.SectorPiece{
+		Piece: abi.PieceInfo{Size: 2048},
+		DealInfo: &piece.PieceDealInfo{
+			PublishCid:   &publishCid,
+			DealID:       1,
+			DealProposal: &market.DealProposal{PieceCID: cid.Undef, PieceSize: 2048},
+			DealSchedule: piece.DealSchedule{StartEpoch: 1000, EndEpoch: 5000},
+		},
+	})
+
+	for name, tc := range map[string]struct {
+		si     SectorInfo
+		status onChainStatus
+		height abi.ChainEpoch
+		next   SectorState
+		err    string
+	}{
+		"not-failed": {
+			si:  SectorInfo{State: PreCommit1},
+			err: "sectors retry",
+		},
+		"precommit-expired": {
+			si:  SectorInfo{State: PreCommitExpired},
+			err: "can't be precommitted again",
+		},
+		"committed": {
+			si:     SectorInfo{State: FailedUnrecoverable},
+			status: committed,
+			err:    "committed on chain",
+		},
+		"deal-start-passed": {
+			si:     SectorInfo{State: FailedUnrecoverable, Pieces: []SafeSectorPiece{dealPiece}},
+			height: 1000,
+			err:    "start epoch 1000 passed",
+		},
+		"no-ticket": {
+			si:     SectorInfo{State: FailedUnrecoverable, Pieces: []SafeSectorPiece{dealPiece}},
+			height: 999,
+			next:   GetTicket,
+		},
+		"ticket": {
+			si:   SectorInfo{State: FailedUnrecoverable, TicketValue: abi.SealRandomness{1}},
+			next: PreCommit1,
+		},
+		"pc1-done": {
+			si:   SectorInfo{State: FailedUnrecoverable, TicketValue: abi.SealRandomness{1}, PreCommit1Out: []byte{1}},
+			next: PreCommit2,
+		},
+		"sealed": {
+			si:   SectorInfo{State: FailedUnrecoverable, PreCommit1Out: []byte{1}, CommR: &commR},
+			next: PreCommitFailed,
+		},
+		"precommitted": {
+			si:     SectorInfo{State: FailedUnrecoverable, CommR: &commR, Pieces: []SafeSectorPiece{dealPiece}},
+			status: preCommitted,
+			height: 999,
+			next:   WaitSeed,
+		},
+		"precommitted-deal-start-passed": {
+			si:     SectorInfo{State: FailedUnrecoverable, CommR: &commR, Pieces: []SafeSectorPiece{dealPiece}},
+			status: preCommitted,
+			height: 2000,
+			err:    "start epoch 1000 passed",
+		},
+		"proven": {
+			si:     SectorInfo{State: FailedUnrecoverable, CommR: &commR, Proof: []byte{1}},
+			status: preCommitted,
+			next:   CommitFailed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			next, err := recoverFailedState(tc.si, tc.status, tc.height)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.next, next)
+			_, ok := fsmPlanners[next]
+			require.True(t, ok)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package sealing
+
+import (
+	"context"
+	"sort"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/storage/pipeline/sealiface"
+)
+
+type onChainStatus int
+
+const (
+	notOnChain   onChainStatus = iota // not precommitted or committed
+	preCommitted                      // precommitted, not committed yet
+	committed                         // committed
+)
+
+func (s onChainStatus) String() string {
+	switch s {
+	case notOnChain:
+		return "not on chain"
+	case preCommitted:
+		return "precommitted"
+	default:
+		return "committed"
+	}
+}
+
+// retryStep describes a sealing step which can be manually re-run, with the states a sector can be
+// moved back from to re-run it, and the on-chain status the sector must have for the step to be safe.
+//
+// Sectors waiting in the PreCommit or Commit batchers (SubmitPreCommitBatch and SubmitCommitAggregate)
+// can't be moved, as the batchers would still send them in the next batch.
+type retryStep struct {
+	state SectorState
+	// stateFor, when set, selects the state depending on the sealing config
+	stateFor func(sealiface.Config) SectorState
+	from     []SectorState
+	onChain  onChainStatus
+	check    func(SectorInfo) error
+}
+
+var retrySteps = map[string]retryStep{
+	"precommit1": {
+		state:   PreCommit1,
+		from:    []SectorState{PreCommit1, PreCommit2, PreCommitting, SealPreCommit1Failed, SealPreCommit2Failed, PreCommitFailed},
+		onChain: notOnChain,
+		check: func(si SectorInfo) error {
+			if len(si.TicketValue) == 0 {
+				return xerrors.Errorf("sector has no ticket")
+			}
+			return nil
+		},
+	},
+	"precommit2": {
+		state:   PreCommit2,
+		from:    []SectorState{PreCommit2, PreCommitting, SealPreCommit2Failed, PreCommitFailed},
+		onChain: notOnChain,
+		check: func(si SectorInfo) error {
+			if len(si.PreCommit1Out) == 0 {
+				return xerrors.Errorf("sector has no precommit1 output, retry precommit1 instead")
+			}
+			return nil
+		},
+	},
+	"precommit": {
+		state:   PreCommitting,
+		from:    []SectorState{PreCommitting, PreCommitFailed},
+		onChain: notOnChain,
+		check: func(si SectorInfo) error {
+			if si.CommR == nil {
+				return xerrors.Errorf("sector has no CommR, retry precommit2 instead")
+			}
+			return nil
+		},
+	},
+	"wait-seed": {
+		state:   WaitSeed,
+		from:    []SectorState{PreCommitWait, PreCommitBatchWait, PreCommitFailed, WaitSeed, Committing, ComputeProofFailed},
+		onChain: preCommitted,
+	},
+	"commit": {
+		state:   Committing,
+		from:    []SectorState{WaitSeed, Committing, CommitFinalize, CommitFinalizeFailed, SubmitCommit, ComputeProofFailed, RemoteCommitFailed, CommitFailed},
+		onChain: preCommitted,
+		check: func(si SectorInfo) error {
+			if len(si.SeedValue) == 0 {
+				return xerrors.Errorf("sector has no seed, retry wait-seed instead")
+			}
+			return nil
+		},
+	},
+	"submit-commit": {
+		state: SubmitCommitAggregate,
+		stateFor: func(cfg sealiface.Config) SectorState {
+			if !cfg.AggregateCommits {
+				return SubmitCommit
+			}
+			return SubmitCommitAggregate
+		},
+		from:    []SectorState{SubmitCommit, CommitFinalize, CommitFinalizeFailed, CommitFailed},
+		onChain: preCommitted,
+		check: func(si SectorInfo) error {
+			if len(si.Proof) == 0 {
+				return xerrors.Errorf("sector has no proof, retry commit instead")
+			}
+			return nil
+		},
+	},
+	"finalize": {
+		state:   FinalizeSector,
+		from:    []SectorState{CommitWait, CommitAggregateWait, FinalizeSector, FinalizeFailed},
+		onChain: committed,
+	},
+}
+
+// RetrySteps returns the names of the sealing steps which can be re-run with RetrySectorStep.
+func RetrySteps() []string {
+	var out []string
+	for name := range retrySteps {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// RetrySectorStep moves the sector back to the state re-running the named sealing step. It refuses
+// transitions from states the step can't be re-run from, and transitions which are unsafe given the
+// on-chain status of the sector, e.g. re-precommitting an already precommitted sector.
+func (m *Sealing) RetrySectorStep(ctx context.Context, sid abi.SectorNumber, step string) error {
+	m.startupWait.Wait()
+
+	rs, ok := retrySteps[step]
+	if !ok {
+		return xerrors.Errorf("unknown step %q, possible steps are %v", step, RetrySteps())
+	}
+
+	si, err := m.GetSectorInfo(sid)
+	if err != nil {
+		return xerrors.Errorf("getting sector info: %w", err)
+	}
+
+	next, err := m.retryTarget(step, rs, si)
+	if err != nil {
+		return err
+	}
+
+	status, err := m.onChainStatus(ctx, sid)
+	if err != nil {
+		return err
+	}
+	if status != rs.onChain {
+		return xerrors.Errorf("can't retry %s: sector is %s, must be %s", step, status, rs.onChain)
+	}
+
+	log.Warnw("manually retrying sealing step", "sector", sid, "step", step, "from", si.State, "to", next)
+
+	return m.sectors.Send(uint64(sid), SectorRetryStep{Step: step, From: si.State, State: next})
+}
+
+// retryTarget checks that the step can be re-run for the sector, not taking the on-chain status of the
+// sector into account, and returns the state re-running it.
+func (m *Sealing) retryTarget(step string, rs retryStep, si SectorInfo) (SectorState, error) {
+	if !rs.allowedFrom(si.State) {
+		return "", xerrors.Errorf("can't retry %s from state %s, allowed from %v", step, si.State, rs.from)
+	}
+
+	if rs.check != nil {
+		if err := rs.check(si); err != nil {
+			return "", xerrors.Errorf("can't retry %s: %w", step, err)
+		}
+	}
+
+	// the sector may have been added to a batch after failing, e.g. when a batch failure was retried
+	if m.precommiter != nil && m.precommiter.isPending(si.SectorNumber) {
+		return "", xerrors.Errorf("can't retry %s: sector is pending in the PreCommit batcher, wait for the batch to be sent", step)
+	}
+	if m.commiter != nil && m.commiter.isPending(si.SectorNumber) {
+		return "", xerrors.Errorf("can't retry %s: sector is pending in the Commit batcher, wait for the batch to be sent", step)
+	}
+
+	if rs.stateFor == nil {
+		return rs.state, nil
+	}
+	cfg, err := m.getConfig()
+	if err != nil {
+		return "", xerrors.Errorf("getting sealing config: %w", err)
+	}
+	return rs.stateFor(cfg), nil
+}
+
+func (rs retryStep) allowedFrom(st SectorState) bool {
+	for _, f := range rs.from {
+		if f == st {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Sealing) onChainStatus(ctx context.Context, sid abi.SectorNumber) (onChainStatus, error) {
+	si, err := m.Api.StateSectorGetInfo(ctx, m.maddr, sid, types.EmptyTSK)
+	if err != nil {
+		return 0, xerrors.Errorf("getting sector info: %w", err)
+	}
+	if si != nil {
+		return committed, nil
+	}
+
+	pci, err := m.Api.StateSectorPreCommitInfo(ctx, m.maddr, sid, types.EmptyTSK)
+	if err != nil {
+		return 0, xerrors.Errorf("getting precommit info: %w", err)
+	}
+	if pci != nil {
+		return preCommitted, nil
+	}
+	return notOnChain, nil
+}
@@ -3,11 +3,35 @@ package sealiface
 import (
 	"time"
 
+	"golang.org/x/xerrors"
+
 	"github.com/filecoin-project/go-state-types/abi"
 )
 
 // this has to be in a separate package to not make lotus API depend on filecoin-ffi
 
+// DealPackingStrategy selects the open sectors deal pieces are assigned to
+type DealPackingStrategy string
+
+const (
+	// DealPackingLeastPadding prefers the sectors where pieces need the least padding
+	DealPackingLeastPadding DealPackingStrategy = "least-padding"
+	// DealPackingLargestFirst assigns the largest pieces first
+	DealPackingLargestFirst DealPackingStrategy = "largest-first"
+	// DealPackingByExpiration prefers the sectors with deals ending closest to the deal of the piece
+	DealPackingByExpiration DealPackingStrategy = "by-expiration"
+)
+
+// Validate checks that the strategy is known, an empty strategy is DealPackingLeastPadding
+func (s DealPackingStrategy) Validate() error {
+	switch s {
+	case "", DealPackingLeastPadding, DealPackingLargestFirst, DealPackingByExpiration:
+		return nil
+	default:
+		return xerrors.Errorf("unknown deal packing strategy %q, must be one of %q, %q or %q", s, DealPackingLeastPadding, DealPackingLargestFirst, DealPackingByExpiration)
+	}
+}
+
 type Config struct {
 	// 0 = no limit
 	MaxWaitDealsSectors uint64
@@ -28,6 +52,8 @@ type Config struct {
 
 	MakeCCSectorsAvailable bool
 
+	DealPackingStrategy DealPackingStrategy
+
 	WaitDealsDelay time.Duration
 
 	CommittedCapacitySectorLifetime time.Duration
@@ -42,9 +68,10 @@ type Config struct {
 	AvailableBalanceBuffer     abi.TokenAmount
 	DisableCollateralFallback  bool
 
-	MaxPreCommitBatch   int
-	PreCommitBatchWait  time.Duration
-	PreCommitBatchSlack time.Duration
+	MaxPreCommitBatch                int
+	PreCommitBatchWait               time.Duration
+	PreCommitBatchSlack              time.Duration
+	PreCommitBatchTicketExpiryEpochs uint64
 
 	AggregateCommits bool
 	MinCommitBatch   int
@@ -57,6 +84,9 @@ type Config struct {
 
 	MaxSectorProveCommitsSubmittedPerEpoch uint64
 
+	// 0 = unlimited
+	MaxCommitResubmits uint64
+
 	TerminateBatchMax  uint64
 	TerminateBatchMin  uint64
 	TerminateBatchWait time.Duration
@@ -67,4 +97,7 @@ type Config struct {
 	RequireActivationSuccessUpdate   bool
 	RequireNotificationSuccess       bool
 	RequireNotificationSuccessUpdate bool
+
+	// share of sectors whose replica is checked after PreCommit2, 0 = never
+	ReplicaCheckSampleRate float64
 }
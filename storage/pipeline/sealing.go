@@ -126,6 +126,7 @@ type Sealing struct {
 	journal        journal.Journal
 	sealingEvtType journal.EventType
 	notifee        SectorStateNotifee
+	stateSubs      stateSubscribers
 	addrSel        AddressSelector
 
 	stats SectorStats
@@ -263,6 +264,10 @@ func New(mctx context.Context, sapi SealingAPI, fc config.MinerFeeConfig, events
 			bySector: map[abi.SectorID]SectorState{},
 			byState:  map[SectorState]int64{},
 		},
+
+		stateSubs: stateSubscribers{
+			subs: map[uint64]*stateSubscriber{},
+		},
 	}
 	pc, err := NewPreCommitBatcher(mctx, maddr, sapi, addrSel, fc, gc)
 	if err != nil {
@@ -286,6 +291,7 @@ func New(mctx context.Context, sapi SealingAPI, fc config.MinerFeeConfig, events
 				Error:        after.LastErr,
 			}
 		})
+		s.stateSubs.notify(before, after)
 	}
 
 	s.startupWait.Add(1)
@@ -33,6 +33,7 @@ var ExistSectorStateList = map[SectorState]struct{}{
 	ComputeProofFailed:          {},
 	RemoteCommitFailed:          {},
 	CommitFailed:                {},
+	PreCommitExpired:            {},
 	PackingFailed:               {},
 	FinalizeFailed:              {},
 	DealsExpired:                {},
@@ -129,7 +130,8 @@ const (
 	ComputeProofFailed   SectorState = "ComputeProofFailed"
 	RemoteCommitFailed   SectorState = "RemoteCommitFailed"
 	CommitFailed         SectorState = "CommitFailed"
-	PackingFailed        SectorState = "PackingFailed" // TODO: deprecated, remove
+	PreCommitExpired     SectorState = "PreCommitExpired" // precommit expired before the commit landed on chain
+	PackingFailed        SectorState = "PackingFailed"    // TODO: deprecated, remove
 	FinalizeFailed       SectorState = "FinalizeFailed"
 	DealsExpired         SectorState = "DealsExpired"
 	RecoverDealIDs       SectorState = "RecoverDealIDs"
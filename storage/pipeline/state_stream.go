@@ -0,0 +1,80 @@
+package sealing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/lotus/api"
+)
+
+// stateStreamBuffer is the number of state change events buffered for each subscriber; events
+// which don't fit in the buffer of a slow subscriber are dropped
+const stateStreamBuffer = 256
+
+type stateSubscriber struct {
+	filter *api.SectorNumberRange
+	ch     chan api.SectorStateChange
+}
+
+type stateSubscribers struct {
+	lk   sync.Mutex
+	next uint64
+	subs map[uint64]*stateSubscriber
+}
+
+// SubscribeStateChanges returns a channel receiving an event each time a sector, optionally
+// limited to the given number range, transitions between states. The channel is closed when ctx
+// is cancelled.
+func (m *Sealing) SubscribeStateChanges(ctx context.Context, filter *api.SectorNumberRange) <-chan api.SectorStateChange {
+	sub := &stateSubscriber{
+		filter: filter,
+		ch:     make(chan api.SectorStateChange, stateStreamBuffer),
+	}
+
+	m.stateSubs.lk.Lock()
+	id := m.stateSubs.next
+	m.stateSubs.next++
+	m.stateSubs.subs[id] = sub
+	m.stateSubs.lk.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		m.stateSubs.lk.Lock()
+		delete(m.stateSubs.subs, id)
+		close(sub.ch)
+		m.stateSubs.lk.Unlock()
+	}()
+
+	return sub.ch
+}
+
+// notify sends the state change to all interested subscribers, never blocking the caller
+func (ss *stateSubscribers) notify(before, after SectorInfo) {
+	if before.State == after.State {
+		return
+	}
+
+	evt := api.SectorStateChange{
+		Number: after.SectorNumber,
+		From:   api.SectorState(before.State),
+		To:     api.SectorState(after.State),
+		Time:   time.Now(),
+	}
+
+	ss.lk.Lock()
+	defer ss.lk.Unlock()
+
+	for _, sub := range ss.subs {
+		if sub.filter != nil && !sub.filter.Contains(evt.Number) {
+			continue
+		}
+
+		select {
+		case sub.ch <- evt:
+		default:
+			log.Warnw("sector state stream subscriber is too slow, dropping event", "sector", evt.Number, "from", evt.From, "to", evt.To)
+		}
+	}
+}
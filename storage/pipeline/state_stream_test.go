@@ -0,0 +1,45 @@
+package sealing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/lotus/api"
+)
+
+func TestStateStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m := &Sealing{stateSubs: stateSubscribers{subs: map[uint64]*stateSubscriber{}}}
+
+	all := m.SubscribeStateChanges(ctx, nil)
+	ranged := m.SubscribeStateChanges(ctx, &api.SectorNumberRange{From: 10, To: 20})
+
+	m.stateSubs.notify(SectorInfo{SectorNumber: 5, State: Packing}, SectorInfo{SectorNumber: 5, State: GetTicket})
+	m.stateSubs.notify(SectorInfo{SectorNumber: 15, State: GetTicket}, SectorInfo{SectorNumber: 15, State: GetTicket}) // no transition
+	m.stateSubs.notify(SectorInfo{SectorNumber: 15, State: GetTicket}, SectorInfo{SectorNumber: 15, State: PreCommit1})
+
+	evt := <-all
+	require.Equal(t, api.SectorStateChange{Number: 5, From: api.SectorState(Packing), To: api.SectorState(GetTicket), Time: evt.Time}, evt)
+	evt = <-all
+	require.Equal(t, api.SectorStateChange{Number: 15, From: api.SectorState(GetTicket), To: api.SectorState(PreCommit1), Time: evt.Time}, evt)
+
+	evt = <-ranged
+	require.EqualValues(t, 15, evt.Number)
+	require.Len(t, ranged, 0)
+
+	// a subscriber which doesn't read must not block notifications
+	for i := 0; i < 2*stateStreamBuffer; i++ {
+		m.stateSubs.notify(SectorInfo{SectorNumber: 15, State: PreCommit1}, SectorInfo{SectorNumber: 15, State: PreCommit2})
+	}
+	require.Len(t, ranged, stateStreamBuffer)
+
+	cancel()
+	for range all {
+	}
+	for range ranged {
+	}
+	require.Empty(t, m.stateSubs.subs)
+}
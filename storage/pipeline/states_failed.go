@@ -324,9 +324,26 @@ func (m *Sealing) handleCommitFailed(ctx statemachine.Context, sector SectorInfo
 			// find out in checkCommit
 		case exitcode.SysErrOutOfGas:
 			// API error in CommitWait AND gas estimator guessed a wrong number in SubmitCommit
-			return ctx.Send(SectorRetrySubmitCommit{})
+			resubmit, err := m.canResubmitCommit(sector)
+			if err != nil {
+				return err
+			}
+			if !resubmit {
+				log.Errorw("commit message failed on chain too many times, not resubmitting; needs manual intervention", "sector", sector.SectorNumber, "exit", mw.Receipt.ExitCode, "resubmits", sector.CommitResubmits)
+				return nil // pause the fsm, needs manual user action
+			}
+
+			return ctx.Send(SectorCommitResubmit{ExitCode: mw.Receipt.ExitCode})
 		default:
 			// something else went wrong
+			expired, err := m.preCommitExpired(ctx.Context(), sector, ts.Key())
+			if err != nil {
+				log.Errorf("handleCommitFailed: api error, not proceeding: %+v", err)
+				return nil
+			}
+			if expired {
+				return ctx.Send(SectorPreCommitExpired{xerrors.Errorf("precommit expired before the sector proof landed on chain (exit=%d)", mw.Receipt.ExitCode)})
+			}
 		}
 	}
 
@@ -378,6 +395,44 @@ func (m *Sealing) handleCommitFailed(ctx statemachine.Context, sector SectorInfo
 	return ctx.Send(SectorRetryComputeProof{})
 }
 
+// canResubmitCommit checks whether a commit message which failed on chain with
+// a transient error can be resubmitted without exceeding MaxCommitResubmits
+func (m *Sealing) canResubmitCommit(sector SectorInfo) (bool, error) {
+	cfg, err := m.getConfig()
+	if err != nil {
+		return false, xerrors.Errorf("getting sealing config: %w", err)
+	}
+
+	return cfg.MaxCommitResubmits == 0 || sector.CommitResubmits < cfg.MaxCommitResubmits, nil
+}
+
+// preCommitExpired returns true when the sector precommit is no longer on chain
+// and the sector wasn't committed, which means that the precommit expired before
+// the sector proof could land
+func (m *Sealing) preCommitExpired(ctx context.Context, sector SectorInfo, tsk types.TipSetKey) (bool, error) {
+	pci, err := m.Api.StateSectorPreCommitInfo(ctx, m.maddr, sector.SectorNumber, tsk)
+	if err != nil {
+		return false, xerrors.Errorf("getting precommit info: %w", err)
+	}
+	if pci != nil {
+		return false, nil
+	}
+
+	si, err := m.Api.StateSectorGetInfo(ctx, m.maddr, sector.SectorNumber, tsk)
+	if err != nil {
+		return false, xerrors.Errorf("getting sector info: %w", err)
+	}
+
+	return si == nil, nil
+}
+
+func (m *Sealing) handlePreCommitExpired(ctx statemachine.Context, sector SectorInfo) error {
+	// The sector number is allocated on chain, so the sector can't be precommitted
+	// again. Keep the data around until the operator decides what to do with it.
+	log.Errorw("sector precommit expired before the sector proof landed on chain; the sector must be removed manually", "sector", sector.SectorNumber)
+	return nil // pause the fsm, needs manual user action
+}
+
 func (m *Sealing) handleFinalizeFailed(ctx statemachine.Context, sector SectorInfo) error {
 	// TODO: Check sector files
 
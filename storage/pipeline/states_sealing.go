@@ -6,10 +6,12 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"math/rand"
 	"net/http"
 	"time"
 
 	"github.com/ipfs/go-cid"
+	"go.opencensus.io/stats"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-address"
@@ -34,6 +36,7 @@ import (
 	"github.com/filecoin-project/lotus/chain/actors/policy"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/lib/filler"
+	"github.com/filecoin-project/lotus/metrics"
 	"github.com/filecoin-project/lotus/storage/pipeline/lib/nullreader"
 	"github.com/filecoin-project/lotus/storage/sealer/storiface"
 )
@@ -344,12 +347,63 @@ func (m *Sealing) handlePreCommit2(ctx statemachine.Context, sector SectorInfo)
 		return ctx.Send(SectorSealPreCommit1Failed{xerrors.Errorf("seal pre commit(2) returned undefined CommD")})
 	}
 
+	cfg, err := m.getConfig()
+	if err != nil {
+		return xerrors.Errorf("getting config: %w", err)
+	}
+	if err := m.sampleReplicaCheck(ctx.Context(), cfg.ReplicaCheckSampleRate, sector, cids.Sealed); err != nil {
+		return ctx.Send(SectorSealPreCommit2Failed{xerrors.Errorf("replica check failed: %w", err)})
+	}
+
 	return ctx.Send(SectorPreCommit2{
 		Unsealed: cids.Unsealed,
 		Sealed:   cids.Sealed,
 	})
 }
 
+// sampleReplicaCheck runs checkReplica for the given share of sectors, between 0 and 1, recording
+// the checks in the sealing metrics.
+func (m *Sealing) sampleReplicaCheck(ctx context.Context, rate float64, sector SectorInfo, sealed cid.Cid) error {
+	if rate <= 0 || rand.Float64() >= rate {
+		return nil
+	}
+
+	stats.Record(ctx, metrics.SealReplicaChecks.M(1))
+	done := metrics.Timer(ctx, metrics.SealReplicaCheckDuration)
+
+	err := m.checkReplica(ctx, sector, sealed)
+	took := done()
+	if err != nil {
+		stats.Record(ctx, metrics.SealReplicaCheckFailures.M(1))
+		log.Warnw("sampled replica check failed", "sector", sector.SectorNumber, "took", took, "error", err)
+		return err
+	}
+
+	log.Infow("sampled replica check passed", "sector", sector.SectorNumber, "took", took)
+	return nil
+}
+
+// checkReplica verifies the sealed replica of a sector by generating a vanilla PoSt proof for
+// randomly sampled challenges, which checks the challenged nodes of the replica against its CommR.
+func (m *Sealing) checkReplica(ctx context.Context, sector SectorInfo, sealed cid.Cid) error {
+	pp, err := sector.SectorType.RegisteredWindowPoStProof()
+	if err != nil {
+		return xerrors.Errorf("getting window post proof type: %w", err)
+	}
+
+	sref := m.minerSector(sector.SectorType, sector.SectorNumber)
+	bad, err := m.sealer.CheckProvable(ctx, pp, []storiface.SectorRef{sref}, func(ctx context.Context, id abi.SectorID) (cid.Cid, bool, error) {
+		return sealed, false, nil
+	})
+	if err != nil {
+		return err
+	}
+	if reason, ok := bad[sref.ID]; ok {
+		return xerrors.New(reason)
+	}
+	return nil
+}
+
 func (m *Sealing) preCommitInfo(ctx statemachine.Context, sector SectorInfo) (*miner.SectorPreCommitInfo, big.Int, types.TipSetKey, error) {
 	ts, err := m.Api.ChainHead(ctx.Context())
 	if err != nil {
@@ -840,8 +894,25 @@ func (m *Sealing) handleCommitWait(ctx statemachine.Context, sector SectorInfo)
 		fallthrough
 	case exitcode.SysErrOutOfGas:
 		// gas estimator guessed a wrong number / out of funds
-		return ctx.Send(SectorRetrySubmitCommit{})
+		resubmit, err := m.canResubmitCommit(sector)
+		if err != nil {
+			return err
+		}
+		if !resubmit {
+			return ctx.Send(SectorCommitFailed{xerrors.Errorf("submitting sector proof failed (exit=%d, msg=%s), giving up after %d resubmissions", mw.Receipt.ExitCode, sector.CommitMessage, sector.CommitResubmits)})
+		}
+
+		log.Warnw("commit message failed on chain, resubmitting", "sector", sector.SectorNumber, "exit", mw.Receipt.ExitCode, "msg", sector.CommitMessage, "resubmits", sector.CommitResubmits)
+		return ctx.Send(SectorCommitResubmit{ExitCode: mw.Receipt.ExitCode})
 	default:
+		expired, err := m.preCommitExpired(ctx.Context(), sector, mw.TipSet)
+		if err != nil {
+			log.Errorw("checking for expired precommit", "sector", sector.SectorNumber, "error", err)
+		}
+		if expired {
+			return ctx.Send(SectorPreCommitExpired{xerrors.Errorf("precommit expired before the sector proof landed on chain (exit=%d, msg=%s)", mw.Receipt.ExitCode, sector.CommitMessage)})
+		}
+
 		return ctx.Send(SectorCommitFailed{xerrors.Errorf("submitting sector proof failed (exit=%d, msg=%s) (t:%x; s:%x(%d); p:%x)", mw.Receipt.ExitCode, sector.CommitMessage, sector.TicketValue, sector.SeedValue, sector.SeedEpoch, sector.Proof)})
 	}
 
@@ -0,0 +1,98 @@
+package sealing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/metrics"
+	"github.com/filecoin-project/lotus/storage/sealer"
+	"github.com/filecoin-project/lotus/storage/sealer/storiface"
+)
+
+type fakeReplicaChecker struct {
+	sealer.SectorManager
+
+	calls int
+	bad   string
+}
+
+func (f *fakeReplicaChecker) CheckProvable(ctx context.Context, pp abi.RegisteredPoStProof, sectors []storiface.SectorRef, rg storiface.RGetter) (map[abi.SectorID]string, error) {
+	f.calls++
+	if f.bad != "" {
+		return map[abi.SectorID]string{sectors[0].ID: f.bad}, nil
+	}
+	return nil, nil
+}
+
+func TestSampleReplicaCheck(t *testing.T) {
+	require.NoError(t, view.Register(metrics.SealReplicaChecksView, metrics.SealReplicaCheckFailuresView))
+	t.Cleanup(func() {
+		view.Unregister(metrics.SealReplicaChecksView, metrics.SealReplicaCheckFailuresView)
+	})
+
+	count := func(v *view.View) int64 {
+		rows, err := view.RetrieveData(v.Name)
+		require.NoError(t, err)
+		if len(rows) == 0 {
+			return 0
+		}
+		return rows[0].Data.(*view.CountData).Value
+	}
+
+	ctx := context.Background()
+	maddr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+
+	sector := SectorInfo{SectorNumber: 1, SectorType: abi.RegisteredSealProof_StackedDrg2KiBV1_1}
+	sealed := cid.Undef
+
+	checker := &fakeReplicaChecker{}
+	m := &Sealing{
+		maddr:  maddr,
+		sealer: checker,
+		stats: SectorStats{
+			bySector: map[abi.SectorID]SectorState{},
+			byState:  map[SectorState]int64{},
+		},
+	}
+
+	// rate 0 never checks
+	for i := 0; i < 10; i++ {
+		require.NoError(t, m.sampleReplicaCheck(ctx, 0, sector, sealed))
+	}
+	require.Equal(t, 0, checker.calls)
+	require.Equal(t, int64(0), count(metrics.SealReplicaChecksView))
+
+	// rate 1 checks every sector
+	for i := 0; i < 10; i++ {
+		require.NoError(t, m.sampleReplicaCheck(ctx, 1, sector, sealed))
+	}
+	require.Equal(t, 10, checker.calls)
+	require.Equal(t, int64(10), count(metrics.SealReplicaChecksView))
+	require.Equal(t, int64(0), count(metrics.SealReplicaCheckFailuresView))
+
+	// a failing check is reported, and sends the sector back to PreCommit2 through SealPreCommit2Failed
+	checker.bad = "challenged node doesn't match CommR"
+	err = m.sampleReplicaCheck(ctx, 1, sector, sealed)
+	require.ErrorContains(t, err, checker.bad)
+	require.Equal(t, 11, checker.calls)
+	require.Equal(t, int64(11), count(metrics.SealReplicaChecksView))
+	require.Equal(t, int64(1), count(metrics.SealReplicaCheckFailuresView))
+
+	tst := test{s: m, t: t, state: &SectorInfo{SectorNumber: 1, State: PreCommit2}}
+	tst.planSingle(SectorSealPreCommit2Failed{xerrors.Errorf("replica check failed: %w", err)})
+	require.Equal(t, SealPreCommit2Failed, tst.state.State)
+	// unsampled checks, e.g. when recovering failed sectors, aren't recorded
+	require.ErrorContains(t, m.checkReplica(ctx, sector, sealed), checker.bad)
+	require.Equal(t, 12, checker.calls)
+	require.Equal(t, int64(11), count(metrics.SealReplicaChecksView))
+	require.Equal(t, int64(1), count(metrics.SealReplicaCheckFailuresView))
+}
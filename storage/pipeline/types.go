@@ -95,6 +95,8 @@ type SectorInfo struct {
 	CommitMessage *cid.Cid
 	InvalidProofs uint64 // failed proof computations (doesn't validate with proof inputs; can't compute)
 
+	CommitResubmits uint64 // commit messages resubmitted after failing on chain with a transient error
+
 	// CCUpdate
 	CCUpdate             bool
 	CCPieces             []SafeSectorPiece
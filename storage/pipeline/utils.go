@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	"golang.org/x/xerrors"
@@ -17,6 +18,31 @@ import (
 	"github.com/filecoin-project/lotus/storage/pipeline/sealiface"
 )
 
+// baseFeeCapRecheck is how often message batchers re-check the network base fee while sending is
+// delayed because it's above the configured cap
+const baseFeeCapRecheck = time.Minute
+
+// aboveBaseFeeCap returns true if the base fee cap is set, and the parent base fee of the given
+// tipset exceeds it
+func aboveBaseFeeCap(ts *types.TipSet, feeCap types.FIL) bool {
+	c := abi.TokenAmount(feeCap)
+	if c.Int == nil || c.IsZero() {
+		return false
+	}
+	return ts.MinTicketBlock().ParentBaseFee.GreaterThan(c)
+}
+
+// cutoffWithin returns true if any of the cutoffs is less than slack from now
+func cutoffWithin(cutoffs map[abi.SectorNumber]time.Time, slack time.Duration) bool {
+	deadline := time.Now().Add(slack)
+	for _, cutoff := range cutoffs {
+		if !cutoff.IsZero() && cutoff.Before(deadline) {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Sealing) ListSectors() ([]SectorInfo, error) {
 	var sectors []SectorInfo
 	if err := m.sectors.List(&sectors); err != nil {
@@ -94,7 +120,7 @@ func sendMsg(ctx context.Context, sa interface {
 		Params: params,
 	}
 
-	smsg, err := sa.MpoolPushMessage(ctx, &msg, &api.MessageSendSpec{MaxFee: maxFee})
+	smsg, err := sa.MpoolPushMessage(ctx, &msg, &api.MessageSendSpec{MaxFee: maxFee, AutoBump: true})
 	if err != nil {
 		return cid.Undef, err
 	}
@@ -75,6 +75,10 @@ type Manager struct {
 	parallelCheckLimit        int
 	singleCheckTimeout        time.Duration
 	partitionCheckTimeout     time.Duration
+	partitionProofLimit       int
+	postReadThrottle          chan struct{} // nil when the builtin prover reads from local storage only
+	postReadTimeout           time.Duration
+	postReadsInFlight         int64
 	disableBuiltinWindowPoSt  bool
 	disableBuiltinWinningPoSt bool
 	disallowRemoteFinalize    bool
@@ -105,6 +109,10 @@ func New(ctx context.Context, lstor *paths.Local, stor paths.Store, ls paths.Loc
 		return nil, xerrors.Errorf("creating prover instance: %w", err)
 	}
 
+	if err := checkPartitionProofLimit(pc.MaxParallelPartitionProofs); err != nil {
+		return nil, err
+	}
+
 	sh, err := newScheduler(ctx, sc.Assigner)
 	if err != nil {
 		return nil, err
@@ -126,6 +134,8 @@ func New(ctx context.Context, lstor *paths.Local, stor paths.Store, ls paths.Loc
 		parallelCheckLimit:        pc.ParallelCheckLimit,
 		singleCheckTimeout:        time.Duration(pc.SingleCheckTimeout),
 		partitionCheckTimeout:     time.Duration(pc.PartitionCheckTimeout),
+		partitionProofLimit:       pc.MaxParallelPartitionProofs,
+		postReadTimeout:           time.Duration(pc.BuiltinPoStReadTimeout),
 		disableBuiltinWindowPoSt:  pc.DisableBuiltinWindowPoSt,
 		disableBuiltinWinningPoSt: pc.DisableBuiltinWinningPoSt,
 		disallowRemoteFinalize:    sc.DisallowRemoteFinalize,
@@ -137,10 +147,23 @@ func New(ctx context.Context, lstor *paths.Local, stor paths.Store, ls paths.Loc
 		waitRes:    map[WorkID]chan struct{}{},
 	}
 
+	if pc.BuiltinPoStParallelReads > 0 {
+		m.postReadThrottle = make(chan struct{}, pc.BuiltinPoStParallelReads)
+	}
+
 	m.setupWorkTracker()
 
+	timeouts, err := parseTaskTimeouts(sc.TaskTimeouts, sc.TaskTimeoutGraceFactor)
+	if err != nil {
+		return nil, err
+	}
+
 	go m.sched.runSched()
 
+	if timeouts != nil {
+		go m.watchTaskTimeouts(timeouts)
+	}
+
 	localTasks := []sealtasks.TaskType{
 		sealtasks.TTCommit1, sealtasks.TTProveReplicaUpdate1, sealtasks.TTFinalize, sealtasks.TTFetch, sealtasks.TTFinalizeUnsealed, sealtasks.TTFinalizeReplicaUpdate,
 	}
@@ -924,6 +947,19 @@ func (m *Manager) GenerateSectorKeyFromData(ctx context.Context, sector storifac
 	return waitErr
 }
 
+// RemoveUnsealed removes the unsealed copy of the sector from all storage paths; other sector
+// files are left in place.
+func (m *Manager) RemoveUnsealed(ctx context.Context, sector abi.SectorID) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if err := m.index.StorageLock(ctx, sector, storiface.FTNone, storiface.FTUnsealed); err != nil {
+		return xerrors.Errorf("acquiring sector lock: %w", err)
+	}
+
+	return m.storage.Remove(ctx, sector, storiface.FTUnsealed, true, nil)
+}
+
 func (m *Manager) Remove(ctx context.Context, sector storiface.SectorRef) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -1319,6 +1355,10 @@ func (m *Manager) RemoveSchedRequest(ctx context.Context, schedId uuid.UUID) err
 	return m.sched.RemoveRequest(ctx, schedId)
 }
 
+func (m *Manager) ReleaseLeakedReservations(ctx context.Context) ([]storiface.SchedReservation, error) {
+	return m.sched.ReleaseLeakedReservations(time.Now()), nil
+}
+
 func (m *Manager) Close(ctx context.Context) error {
 	m.windowPoStSched.schedClose()
 	m.winningPoStSched.schedClose()
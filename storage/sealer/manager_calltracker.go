@@ -350,6 +350,16 @@ func (m *Manager) waitCall(ctx context.Context, callID storiface.CallID) (interf
 }
 
 func (m *Manager) returnResult(ctx context.Context, callID storiface.CallID, r interface{}, cerr *storiface.CallError) error {
+	if m.sched.releaseHungTask(callID) {
+		// the task was aborted after timing out, and the caller has already received an error
+		log.Warnw("dropping late result of a timed out task", "call", callID, "failed", cerr != nil)
+		return nil
+	}
+
+	return m.deliverResult(ctx, callID, r, cerr)
+}
+
+func (m *Manager) deliverResult(ctx context.Context, callID storiface.CallID, r interface{}, cerr *storiface.CallError) error {
 	res := result{
 		r: r,
 	}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/elastic/go-sysinfo"
+	"go.opencensus.io/stats"
 	"go.uber.org/multierr"
 	"golang.org/x/xerrors"
 
@@ -14,9 +17,61 @@ import (
 	"github.com/filecoin-project/go-state-types/builtin"
 	"github.com/filecoin-project/specs-actors/v7/actors/runtime/proof"
 
+	"github.com/filecoin-project/lotus/metrics"
+	"github.com/filecoin-project/lotus/storage/sealer/sealtasks"
 	"github.com/filecoin-project/lotus/storage/sealer/storiface"
 )
 
+// checkPartitionProofLimit validates the configured partition proof concurrency, and logs the
+// effective value
+func checkPartitionProofLimit(limit int) error {
+	if limit < 0 {
+		return xerrors.Errorf("Proving.MaxParallelPartitionProofs must not be negative, got %d", limit)
+	}
+	if limit == 0 {
+		log.Infow("window PoSt partition proof concurrency", "limit", "unlimited")
+		return nil
+	}
+	log.Infow("window PoSt partition proof concurrency", "limit", limit)
+	return nil
+}
+
+// checkPartitionProofMemory logs a warning when the configured partition proof concurrency is
+// unlikely to fit in the memory of the hosts computing the proofs; those are the connected window
+// PoSt workers, or this host when local is set
+func (m *Manager) checkPartitionProofMemory(sectorInfo []proof.ExtendedSectorInfo, local bool) {
+	if m.partitionProofLimit == 0 || len(sectorInfo) == 0 {
+		return
+	}
+	spt := sectorInfo[0].SealProof
+
+	if !local {
+		workers, capacity := m.windowPoStSched.partitionProofCapacity(spt)
+		if uint64(m.partitionProofLimit) > capacity {
+			log.Warnw("window PoSt workers don't have enough memory to prove the configured number of partitions in parallel",
+				"limit", m.partitionProofLimit, "workers", workers, "capacity", capacity)
+		}
+		return
+	}
+
+	h, err := sysinfo.Host()
+	if err != nil {
+		log.Warnw("failed to get host memory, not checking partition proof concurrency against it", "error", err)
+		return
+	}
+	mem, err := h.Memory()
+	if err != nil {
+		log.Warnw("failed to get host memory, not checking partition proof concurrency against it", "error", err)
+		return
+	}
+
+	res := storiface.ResourceTable[sealtasks.TTGenerateWindowPoSt][spt]
+	if capacity := proofsInMemory(mem.Total, res); uint64(m.partitionProofLimit) > capacity {
+		log.Warnw("this host doesn't have enough memory to prove the configured number of partitions in parallel",
+			"limit", m.partitionProofLimit, "physicalMemory", mem.Total, "capacity", capacity)
+	}
+}
+
 func (m *Manager) GenerateWinningPoSt(ctx context.Context, minerID abi.ActorID, sectorInfo []proof.ExtendedSectorInfo, randomness abi.PoStRandomness) ([]proof.PoStProof, error) {
 	if !m.disableBuiltinWinningPoSt && !m.winningPoStSched.CanSched(ctx) {
 		// if builtin PoSt isn't disabled, and there are no workers, compute the PoSt locally
@@ -82,6 +137,12 @@ func (m *Manager) GenerateWindowPoSt(ctx context.Context, minerID abi.ActorID, p
 	if !m.disableBuiltinWindowPoSt && !m.windowPoStSched.CanSched(ctx) {
 		// if builtin PoSt isn't disabled, and there are no workers, compute the PoSt locally
 
+		if m.postReadThrottle != nil {
+			log.Info("GenerateWindowPoSt run at lotus-miner, reading challenges through storage")
+			m.checkPartitionProofMemory(sectorInfo, true)
+			return m.generateWindowPoSt(ctx, minerID, postProofType, sectorInfo, randomness, m.generateLocalPartitionWindowPost)
+		}
+
 		log.Info("GenerateWindowPoSt run at lotus-miner")
 		p, s, err := m.localProver.GenerateWindowPoSt(ctx, minerID, postProofType, sectorInfo, randomness)
 		if err != nil {
@@ -91,7 +152,8 @@ func (m *Manager) GenerateWindowPoSt(ctx context.Context, minerID abi.ActorID, p
 		return p, s, nil
 	}
 
-	return m.generateWindowPoSt(ctx, minerID, postProofType, sectorInfo, randomness)
+	m.checkPartitionProofMemory(sectorInfo, false)
+	return m.generateWindowPoSt(ctx, minerID, postProofType, sectorInfo, randomness, m.generatePartitionWindowPost)
 }
 
 func dedupeSectorInfo(sectorInfo []proof.ExtendedSectorInfo) []proof.ExtendedSectorInfo {
@@ -107,7 +169,10 @@ func dedupeSectorInfo(sectorInfo []proof.ExtendedSectorInfo) []proof.ExtendedSec
 	return out
 }
 
-func (m *Manager) generateWindowPoSt(ctx context.Context, minerID abi.ActorID, ppt abi.RegisteredPoStProof, sectorInfo []proof.ExtendedSectorInfo, randomness abi.PoStRandomness) ([]proof.PoStProof, []abi.SectorID, error) {
+// partitionProver computes the proof of a single partition, returning the sectors which had to be skipped
+type partitionProver func(ctx context.Context, spt abi.RegisteredSealProof, ppt abi.RegisteredPoStProof, minerID abi.ActorID, partIndex int, sc []storiface.PostSectorChallenge, randomness abi.PoStRandomness) (proof.PoStProof, []abi.SectorID, error)
+
+func (m *Manager) generateWindowPoSt(ctx context.Context, minerID abi.ActorID, ppt abi.RegisteredPoStProof, sectorInfo []proof.ExtendedSectorInfo, randomness abi.PoStRandomness, provePartition partitionProver) ([]proof.PoStProof, []abi.SectorID, error) {
 	var retErr error
 	randomness[31] &= 0x3f
 
@@ -161,10 +226,27 @@ func (m *Manager) generateWindowPoSt(ctx context.Context, minerID abi.ActorID, p
 	var wg sync.WaitGroup
 	wg.Add(int(partitionCount))
 
+	var throttle chan struct{}
+	if m.partitionProofLimit > 0 {
+		throttle = make(chan struct{}, m.partitionProofLimit)
+	}
+
 	for partIdx := uint64(0); partIdx < partitionCount; partIdx++ {
 		go func(partIdx uint64) {
 			defer wg.Done()
 
+			if throttle != nil {
+				select {
+				case throttle <- struct{}{}:
+					defer func() { <-throttle }()
+				case <-cctx.Done():
+					flk.Lock()
+					retErr = multierr.Append(retErr, xerrors.Errorf("partitionIndex:%d err:%w", partIdx, cctx.Err()))
+					flk.Unlock()
+					return
+				}
+			}
+
 			sectors := make([]storiface.PostSectorChallenge, 0)
 			for i := uint64(0); i < maxPartitionSize; i++ {
 				si := i + partIdx*maxPartitionSize
@@ -184,7 +266,7 @@ func (m *Manager) generateWindowPoSt(ctx context.Context, minerID abi.ActorID, p
 				})
 			}
 
-			p, sk, err := m.generatePartitionWindowPost(cctx, spt, ppt, minerID, int(partIdx), sectors, randomness)
+			p, sk, err := provePartition(cctx, spt, ppt, minerID, int(partIdx), sectors, randomness)
 			if err != nil || len(sk) > 0 {
 				log.Errorf("generateWindowPost part:%d, skipped:%d, sectors: %d, err: %+v", partIdx, len(sk), len(sectors), err)
 				flk.Lock()
@@ -231,11 +313,100 @@ func (m *Manager) generatePartitionWindowPost(ctx context.Context, spt abi.Regis
 		return nil
 	})
 
-	log.Warnw("generateWindowPost done", "index", partIndex, "skipped", len(result.Skipped), "took", time.Since(start).String(), "err", err)
+	took := time.Since(start)
+	log.Warnw("generateWindowPost done", "index", partIndex, "skipped", len(result.Skipped), "took", took.String(), "err", err)
+	if err == nil {
+		stats.Record(ctx, metrics.WdPoStPartitionProofDuration.M(float64(took.Milliseconds())))
+	}
 
 	return result.PoStProofs, result.Skipped, err
 }
 
+// generateLocalPartitionWindowPost computes the proof of a partition on the lotus-miner process, reading the
+// challenges of its sectors in parallel through the storage subsystem, so that sectors in storage paths of
+// other hosts can be proven.
+func (m *Manager) generateLocalPartitionWindowPost(ctx context.Context, spt abi.RegisteredSealProof, ppt abi.RegisteredPoStProof, minerID abi.ActorID, partIndex int, sc []storiface.PostSectorChallenge, randomness abi.PoStRandomness) (proof.PoStProof, []abi.SectorID, error) {
+	log.Infow("generateWindowPost locally", "index", partIndex)
+
+	start := time.Now()
+
+	vproofs, skipped := m.readVanillaProofs(ctx, ppt, minerID, sc)
+	if len(skipped) > 0 {
+		// the window PoSt runner retries without the skipped sectors, declaring them faulty
+		log.Warnw("generateWindowPost locally skipped sectors", "index", partIndex, "skipped", len(skipped), "took", time.Since(start).String())
+		return proof.PoStProof{}, skipped, nil
+	}
+
+	p, err := m.localProver.GenerateWindowPoStWithVanilla(ctx, ppt, minerID, randomness, vproofs, partIndex)
+	took := time.Since(start)
+	log.Infow("generateWindowPost locally done", "index", partIndex, "took", took.String(), "err", err)
+	if err != nil {
+		return proof.PoStProof{}, nil, xerrors.Errorf("generate window PoSt with vanilla proofs: %w", err)
+	}
+	stats.Record(ctx, metrics.WdPoStPartitionProofDuration.M(float64(took.Milliseconds())))
+
+	return p, nil, nil
+}
+
+// readVanillaProofs reads the challenges of the sectors, at most BuiltinPoStParallelReads at a time across
+// all partitions being proven. Sectors whose challenges can't be read are returned as skipped.
+func (m *Manager) readVanillaProofs(ctx context.Context, ppt abi.RegisteredPoStProof, minerID abi.ActorID, sc []storiface.PostSectorChallenge) ([][]byte, []abi.SectorID) {
+	vproofs := make([][]byte, len(sc))
+
+	var lk sync.Mutex
+	var skipped []abi.SectorID
+	skip := func(s storiface.PostSectorChallenge) {
+		lk.Lock()
+		defer lk.Unlock()
+		skipped = append(skipped, abi.SectorID{Miner: minerID, Number: s.SectorNumber})
+	}
+
+	var wg sync.WaitGroup
+	for i, s := range sc {
+		select {
+		case m.postReadThrottle <- struct{}{}:
+		case <-ctx.Done():
+			log.Errorw("reading PoSt challenges aborted", "error", ctx.Err())
+			for _, s := range sc[i:] {
+				skip(s)
+			}
+			wg.Wait()
+			return vproofs, skipped
+		}
+
+		wg.Add(1)
+		go func(i int, s storiface.PostSectorChallenge) {
+			defer wg.Done()
+			defer func() { <-m.postReadThrottle }()
+
+			rctx := ctx
+			if m.postReadTimeout > 0 {
+				var cancel context.CancelFunc
+				rctx, cancel = context.WithTimeout(ctx, m.postReadTimeout)
+				defer cancel()
+			}
+
+			stats.Record(ctx, metrics.WdPoStSectorReadsInFlight.M(atomic.AddInt64(&m.postReadsInFlight, 1)))
+			start := time.Now()
+			vanilla, err := m.storage.GenerateSingleVanillaProof(rctx, minerID, s, ppt)
+			stats.Record(ctx, metrics.WdPoStSectorReadsInFlight.M(atomic.AddInt64(&m.postReadsInFlight, -1)),
+				metrics.WdPoStSectorReadDuration.M(float64(time.Since(start).Milliseconds())))
+
+			if err != nil || len(vanilla) == 0 {
+				log.Errorw("reading PoSt challenges failed, skipping sector", "sector", s.SectorNumber, "took", time.Since(start), "error", err)
+				stats.Record(ctx, metrics.WdPoStSectorReadFailures.M(1))
+				skip(s)
+				return
+			}
+
+			vproofs[i] = vanilla
+		}(i, s)
+	}
+	wg.Wait()
+
+	return vproofs, skipped
+}
+
 func (m *Manager) GenerateWinningPoStWithVanilla(ctx context.Context, proofType abi.RegisteredPoStProof, minerID abi.ActorID, randomness abi.PoStRandomness, proofs [][]byte) ([]proof.PoStProof, error) {
 	panic("worker-level api shouldn't be called at this level")
 }
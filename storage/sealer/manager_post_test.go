@@ -0,0 +1,100 @@
+package sealer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/storage/paths"
+	"github.com/filecoin-project/lotus/storage/sealer/sealtasks"
+	"github.com/filecoin-project/lotus/storage/sealer/storiface"
+)
+
+type vanillaStore struct {
+	paths.Store
+
+	lk       sync.Mutex
+	running  int
+	maxSeen  int
+	failures map[abi.SectorNumber]struct{}
+}
+
+func (s *vanillaStore) GenerateSingleVanillaProof(ctx context.Context, minerID abi.ActorID, si storiface.PostSectorChallenge, ppt abi.RegisteredPoStProof) ([]byte, error) {
+	s.lk.Lock()
+	s.running++
+	if s.running > s.maxSeen {
+		s.maxSeen = s.running
+	}
+	s.lk.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.running--
+
+	if _, fail := s.failures[si.SectorNumber]; fail {
+		return nil, xerrors.Errorf("sector unreadable")
+	}
+	return []byte{byte(si.SectorNumber)}, nil
+}
+
+func TestReadVanillaProofs(t *testing.T) {
+	store := &vanillaStore{failures: map[abi.SectorNumber]struct{}{3: {}}}
+	m := &Manager{
+		storage:          store,
+		postReadThrottle: make(chan struct{}, 2),
+	}
+
+	var sc []storiface.PostSectorChallenge
+	for i := 1; i <= 6; i++ {
+		sc = append(sc, storiface.PostSectorChallenge{SectorNumber: abi.SectorNumber(i)})
+	}
+
+	vproofs, skipped := m.readVanillaProofs(context.Background(), abi.RegisteredPoStProof_StackedDrgWindow2KiBV1_1, 1000, sc)
+	require.Equal(t, []abi.SectorID{{Miner: 1000, Number: 3}}, skipped)
+	require.Equal(t, [][]byte{{1}, {2}, nil, {4}, {5}, {6}}, vproofs)
+	require.LessOrEqual(t, store.maxSeen, 2)
+	require.Zero(t, m.postReadsInFlight)
+
+	// sectors which didn't get to be read before the context is done are skipped
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	m.postReadThrottle <- struct{}{}
+	m.postReadThrottle <- struct{}{}
+	_, skipped = m.readVanillaProofs(ctx, abi.RegisteredPoStProof_StackedDrgWindow2KiBV1_1, 1000, sc)
+	require.Len(t, skipped, len(sc))
+}
+
+func TestPartitionProofCapacity(t *testing.T) {
+	ps := newPoStScheduler(sealtasks.TTGenerateWindowPoSt)
+	addWorker := func(res storiface.WorkerResources, enabled bool) {
+		ps.workers[storiface.WorkerID(uuid.New())] = &WorkerHandle{
+			Info:    storiface.WorkerInfo{Resources: res},
+			Enabled: enabled,
+		}
+	}
+
+	spt := abi.RegisteredSealProof_StackedDrg32GiBV1_1
+
+	workers, capacity := ps.partitionProofCapacity(spt)
+	require.Zero(t, workers)
+	require.Zero(t, capacity)
+
+	// (128GiB - 32GiB of params) / 30GiB per partition
+	addWorker(decentWorkerResources, true)
+	addWorker(decentWorkerResources, true)
+	addWorker(constrainedWorkerResources, true)
+	addWorker(decentWorkerResources, false)
+
+	workers, capacity = ps.partitionProofCapacity(spt)
+	require.Equal(t, 3, workers)
+	require.Equal(t, uint64(6), capacity)
+}
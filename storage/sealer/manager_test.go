@@ -891,3 +891,9 @@ l:
 		require.Equal(t, uint64(99999), w.MemUsedMax)
 	}
 }
+
+func TestCheckPartitionProofLimit(t *testing.T) {
+	require.Error(t, checkPartitionProofLimit(-1))
+	require.NoError(t, checkPartitionProofLimit(0))
+	require.NoError(t, checkPartitionProofLimit(2))
+}
@@ -0,0 +1,201 @@
+package sealer
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/lotus/metrics"
+	"github.com/filecoin-project/lotus/node/config"
+	"github.com/filecoin-project/lotus/storage/sealer/sealtasks"
+	"github.com/filecoin-project/lotus/storage/sealer/storiface"
+)
+
+var taskTimeoutCheckInterval = 30 * time.Second
+
+type taskTimeouts struct {
+	timeouts map[sealtasks.TaskType]time.Duration
+	grace    float64
+
+	// calls already reported as slow
+	slow map[storiface.CallID]struct{}
+}
+
+// parseTaskTimeouts returns the configured task timeouts, or nil when no timeouts are set.
+func parseTaskTimeouts(cfg map[string]config.Duration, grace float64) (*taskTimeouts, error) {
+	if len(cfg) == 0 {
+		return nil, nil
+	}
+	if grace < 1 {
+		return nil, xerrors.Errorf("task timeout grace factor must be at least 1, got %f", grace)
+	}
+
+	tt := &taskTimeouts{
+		timeouts: map[sealtasks.TaskType]time.Duration{},
+		grace:    grace,
+		slow:     map[storiface.CallID]struct{}{},
+	}
+	for name, d := range cfg {
+		task, ok := sealtasks.TaskTypeFromShort(name)
+		if !ok {
+			return nil, xerrors.Errorf("unknown task type %q in task timeouts", name)
+		}
+		if d <= 0 {
+			return nil, xerrors.Errorf("timeout of %s tasks must be positive", name)
+		}
+		tt.timeouts[task] = time.Duration(d)
+	}
+	return tt, nil
+}
+
+func (m *Manager) watchTaskTimeouts(tt *taskTimeouts) {
+	ticker := time.NewTicker(taskTimeoutCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.checkTaskTimeouts(context.TODO(), tt, time.Now())
+		case <-m.sched.closing:
+			return
+		}
+	}
+}
+
+// checkTaskTimeouts warns about running tasks which exceeded their timeout, and aborts tasks which
+// exceeded the timeout multiplied by the grace factor.
+func (m *Manager) checkTaskTimeouts(ctx context.Context, tt *taskTimeouts, now time.Time) {
+	running, _ := m.sched.workTracker.Running()
+
+	stillRunning := map[storiface.CallID]struct{}{}
+	for _, work := range running {
+		timeout, ok := tt.timeouts[work.job.Task]
+		if !ok {
+			continue
+		}
+		stillRunning[work.job.ID] = struct{}{}
+
+		took := now.Sub(work.job.Start)
+		if took <= timeout {
+			continue
+		}
+
+		tctx, _ := tag.New(ctx,
+			tag.Upsert(metrics.TaskType, string(work.job.Task)),
+			tag.Upsert(metrics.WorkerHostname, work.workerHostname),
+		)
+
+		if took > time.Duration(float64(timeout)*tt.grace) {
+			log.Errorw("aborting hung worker task", "task", work.job.Task.Short(), "sector", work.job.Sector, "worker", work.workerHostname, "call", work.job.ID, "took", took, "timeout", timeout)
+			stats.Record(tctx, metrics.WorkerCallsTimedOut.M(1))
+
+			// the worker can't be told to stop, so keep the resources of the task reserved until its
+			// late result arrives, or the worker restarts
+			m.sched.holdHungTask(work)
+
+			cerr := storiface.Err(storiface.ErrTempUnknown, xerrors.Errorf("task timed out after %s (timeout %s)", took.Truncate(time.Second), timeout))
+			if err := m.deliverResult(ctx, work.job.ID, nil, cerr); err != nil {
+				log.Errorw("aborting hung worker task", "call", work.job.ID, "error", err)
+			}
+			delete(tt.slow, work.job.ID)
+			continue
+		}
+
+		if _, warned := tt.slow[work.job.ID]; !warned {
+			log.Warnw("worker task running longer than its timeout", "task", work.job.Task.Short(), "sector", work.job.Sector, "worker", work.workerHostname, "call", work.job.ID, "took", took, "timeout", timeout, "abort-after", time.Duration(float64(timeout)*tt.grace))
+			stats.Record(tctx, metrics.WorkerCallsSlow.M(1))
+			tt.slow[work.job.ID] = struct{}{}
+		}
+	}
+
+	for call := range tt.slow {
+		if _, ok := stillRunning[call]; !ok {
+			delete(tt.slow, call)
+		}
+	}
+
+	m.sched.pruneHungTasks()
+}
+
+// hungTask is a resource reservation kept for an aborted task which may still run on its worker.
+type hungTask struct {
+	wid    storiface.WorkerID
+	worker *WorkerHandle
+	holdID uuid.UUID
+	tt     sealtasks.SealTaskType
+	res    storiface.Resources
+}
+
+// holdHungTask reserves the resources of an aborted task on its worker, so that no other tasks
+// are scheduled in their place while the task still runs.
+func (sh *Scheduler) holdHungTask(work trackedWork) {
+	sh.workersLk.RLock()
+	defer sh.workersLk.RUnlock()
+
+	w, ok := sh.Workers[work.worker]
+	if !ok {
+		return
+	}
+
+	h := hungTask{
+		wid:    work.worker,
+		worker: w,
+		holdID: uuid.New(),
+		tt:     work.job.Task.SealTask(work.proofType),
+	}
+
+	w.lk.Lock()
+	h.res = w.Info.Resources.ResourceSpec(work.proofType, work.job.Task)
+	w.active.Add(h.holdID, h.tt, w.Info.Resources, h.res)
+	w.lk.Unlock()
+
+	sh.hungLk.Lock()
+	sh.hung[work.job.ID] = h
+	sh.hungLk.Unlock()
+}
+
+// releaseHungTask frees the resources held for an aborted task, returning whether the call was
+// aborted.
+func (sh *Scheduler) releaseHungTask(call storiface.CallID) bool {
+	sh.hungLk.Lock()
+	h, ok := sh.hung[call]
+	delete(sh.hung, call)
+	sh.hungLk.Unlock()
+	if !ok {
+		return false
+	}
+
+	sh.workersLk.RLock()
+	if sh.Workers[h.wid] == h.worker {
+		h.worker.lk.Lock()
+		h.worker.active.Free(h.holdID, h.tt, h.worker.Info.Resources, h.res)
+		h.worker.lk.Unlock()
+	}
+	sh.workersLk.RUnlock()
+
+	select {
+	case sh.workerChange <- struct{}{}:
+	default: // workerChange is buffered, and scheduling is global, so it's ok if we don't send here
+	}
+	return true
+}
+
+// pruneHungTasks forgets aborted tasks of workers which were dropped by the scheduler, e.g.
+// because they restarted, which also dropped their reservations.
+func (sh *Scheduler) pruneHungTasks() {
+	sh.workersLk.RLock()
+	defer sh.workersLk.RUnlock()
+	sh.hungLk.Lock()
+	defer sh.hungLk.Unlock()
+
+	for call, h := range sh.hung {
+		if sh.Workers[h.wid] != h.worker {
+			log.Infow("worker of an aborted task is gone, forgetting the task", "task", h.tt.TaskType.Short(), "worker", h.wid, "call", call)
+			delete(sh.hung, call)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package sealer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/node/config"
+	"github.com/filecoin-project/lotus/storage/sealer/sealtasks"
+	"github.com/filecoin-project/lotus/storage/sealer/storiface"
+)
+
+func TestTaskTimeouts(t *testing.T) {
+	_, err := parseTaskTimeouts(map[string]config.Duration{"XX": config.Duration(time.Hour)}, 2)
+	require.Error(t, err)
+	_, err = parseTaskTimeouts(map[string]config.Duration{"PC1": config.Duration(time.Hour)}, 0.5)
+	require.Error(t, err)
+
+	tt, err := parseTaskTimeouts(nil, 2)
+	require.NoError(t, err)
+	require.Nil(t, tt)
+
+	tt, err = parseTaskTimeouts(map[string]config.Duration{"PC1": config.Duration(time.Hour)}, 2)
+	require.NoError(t, err)
+
+	sh, err := newScheduler(context.Background(), "")
+	require.NoError(t, err)
+	m := &Manager{
+		sched:      sh,
+		callToWork: map[storiface.CallID]WorkID{},
+		callRes:    map[storiface.CallID]chan result{},
+	}
+
+	wid := storiface.WorkerID(uuid.New())
+	w := &WorkerHandle{
+		Info:      storiface.WorkerInfo{Hostname: "w", Resources: decentWorkerResources},
+		Enabled:   true,
+		preparing: NewActiveResources(newTaskCounter()),
+		active:    NewActiveResources(newTaskCounter()),
+	}
+	sh.Workers[wid] = w
+	spt := abi.RegisteredSealProof_StackedDrg32GiBV1_1
+
+	start := time.Now()
+	call := func(task sealtasks.TaskType) storiface.CallID {
+		id := storiface.CallID{ID: uuid.New()}
+		sh.workTracker.running[id] = trackedWork{job: storiface.WorkerJob{ID: id, Task: task, Start: start}, proofType: spt, worker: wid}
+		return id
+	}
+	pc1, pc2 := call(sealtasks.TTPreCommit1), call(sealtasks.TTPreCommit2)
+
+	// slow tasks are reported once, and keep running
+	m.checkTaskTimeouts(context.Background(), tt, start.Add(90*time.Minute))
+	m.checkTaskTimeouts(context.Background(), tt, start.Add(100*time.Minute))
+	require.Contains(t, tt.slow, pc1)
+	require.Len(t, sh.workTracker.running, 2)
+
+	// hung tasks are aborted with an error result
+	m.checkTaskTimeouts(context.Background(), tt, start.Add(3*time.Hour))
+	require.NotContains(t, sh.workTracker.running, pc1)
+	require.Contains(t, sh.workTracker.running, pc2) // no timeout
+	require.Empty(t, tt.slow)
+
+	res := <-m.callRes[pc1]
+	require.ErrorContains(t, res.err, "task timed out")
+
+	// the aborted task still runs on the worker, so its resources stay reserved, and aren't
+	// reported as leaked
+	pc1Res := decentWorkerResources.ResourceSpec(spt, sealtasks.TTPreCommit1)
+	require.Equal(t, pc1Res.MinMemory, w.active.memUsedMin)
+	require.Equal(t, 1, w.active.taskCount(&sealtasks.SealTaskType{TaskType: sealtasks.TTPreCommit1, RegisteredSealProof: spt}))
+	for _, r := range sh.Reservations(start.Add(3 * time.Hour).Add(ReservationLeakGrace)) {
+		require.False(t, r.Leaked)
+	}
+
+	// the late result frees the resources, and is dropped
+	require.NoError(t, m.returnResult(context.Background(), pc1, nil, nil))
+	require.Zero(t, w.active.memUsedMin)
+	require.Empty(t, sh.hung)
+	require.Empty(t, m.callRes[pc1])
+
+	// when the worker of a hung task goes away, the task is forgotten
+	pc1 = call(sealtasks.TTPreCommit1)
+	m.checkTaskTimeouts(context.Background(), tt, start.Add(3*time.Hour))
+	require.Contains(t, sh.hung, pc1)
+	delete(sh.Workers, wid)
+	m.checkTaskTimeouts(context.Background(), tt, start.Add(3*time.Hour))
+	require.Empty(t, sh.hung)
+}
@@ -81,6 +81,10 @@ type Scheduler struct {
 
 	workTracker *workTracker
 
+	// hung holds the resources kept reserved for aborted tasks which may still run on their workers
+	hungLk sync.Mutex
+	hung   map[storiface.CallID]hungTask
+
 	info      chan func(interface{})
 	rmRequest chan *rmRequest
 
@@ -192,6 +196,7 @@ func newScheduler(ctx context.Context, assigner string) (*Scheduler, error) {
 			running:  map[storiface.CallID]trackedWork{},
 			prepared: map[uuid.UUID]trackedWork{},
 		},
+		hung: map[storiface.CallID]hungTask{},
 
 		info:      make(chan func(interface{})),
 		rmRequest: make(chan *rmRequest),
@@ -266,8 +271,9 @@ type SchedDiagRequestInfo struct {
 }
 
 type SchedDiagInfo struct {
-	Requests    []SchedDiagRequestInfo
-	OpenWindows []string
+	Requests     []SchedDiagRequestInfo
+	OpenWindows  []string
+	Reservations []storiface.SchedReservation
 }
 
 func (sh *Scheduler) runSched() {
@@ -373,6 +379,8 @@ func (sh *Scheduler) diag() SchedDiagInfo {
 		})
 	}
 
+	out.Reservations = sh.Reservations(time.Now())
+
 	sh.workersLk.RLock()
 	defer sh.workersLk.RUnlock()
 
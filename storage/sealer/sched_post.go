@@ -3,6 +3,7 @@ package sealer
 import (
 	"context"
 	"errors"
+	"math"
 	"math/rand"
 	"sync"
 	"time"
@@ -256,3 +257,37 @@ func (ps *poStScheduler) WorkerStats(ctx context.Context, cb func(ctx context.Co
 		cb(ctx, id, w)
 	}
 }
+
+// partitionProofCapacity returns the number of enabled workers, and how many proofs of the given
+// seal proof type they can compute at the same time given their physical memory
+func (ps *poStScheduler) partitionProofCapacity(spt abi.RegisteredSealProof) (workers int, capacity uint64) {
+	ps.lk.RLock()
+	defer ps.lk.RUnlock()
+
+	for _, wr := range ps.workers {
+		if !wr.Enabled {
+			continue
+		}
+		workers++
+
+		n := proofsInMemory(wr.Info.Resources.MemPhysical, wr.Info.Resources.ResourceSpec(spt, ps.postType))
+		if capacity > math.MaxUint64-n {
+			capacity = math.MaxUint64
+		} else {
+			capacity += n
+		}
+	}
+
+	return workers, capacity
+}
+
+// proofsInMemory returns how many tasks with the given resource requirements fit in mem
+func proofsInMemory(mem uint64, res storiface.Resources) uint64 {
+	if mem < res.BaseMinMemory {
+		return 0
+	}
+	if res.MinMemory == 0 {
+		return math.MaxUint64
+	}
+	return (mem - res.BaseMinMemory) / res.MinMemory
+}
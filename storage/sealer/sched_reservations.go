@@ -0,0 +1,116 @@
+package sealer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/filecoin-project/lotus/storage/sealer/storiface"
+)
+
+// ReservationLeakGrace is the time a resource reservation has to exist for without tracked work of
+// the request owning it before it is considered leaked. The work of a request is tracked from right
+// after its resources are reserved until it returns, apart from the prepare step of requests which
+// don't need one.
+var ReservationLeakGrace = 5 * time.Minute
+
+// Reservations returns the resources reserved on the workers for the tasks they run. Reservations
+// of requests without any tracked work, preparing, waiting for resources or running, are flagged as
+// leaked.
+func (sh *Scheduler) Reservations(now time.Time) []storiface.SchedReservation {
+	return sh.reservations(now, false)
+}
+
+// ReleaseLeakedReservations frees the resources of the leaked reservations, and returns the
+// released reservations. If the tasks owning them return later, their resources aren't freed
+// again.
+func (sh *Scheduler) ReleaseLeakedReservations(now time.Time) []storiface.SchedReservation {
+	released := sh.reservations(now, true)
+
+	if len(released) > 0 {
+		select {
+		case sh.workerChange <- struct{}{}:
+		default: // workerChange is buffered, and scheduling is global, so it's ok if we don't send here
+		}
+	}
+
+	return released
+}
+
+func (sh *Scheduler) reservations(now time.Time, release bool) []storiface.SchedReservation {
+	// reservations are owned by scheduler requests, while a request is preparing or running, the
+	// work it runs is tracked with its schedID
+	live := map[uuid.UUID]struct{}{}
+	running, preparing := sh.workTracker.Running()
+	for _, t := range append(running, preparing...) {
+		live[t.schedID] = struct{}{}
+	}
+	sh.hungLk.Lock()
+	for _, h := range sh.hung {
+		live[h.holdID] = struct{}{}
+	}
+	sh.hungLk.Unlock()
+
+	type entry struct {
+		res *ActiveResources
+		key reservationKey
+		out storiface.SchedReservation
+	}
+
+	sh.workersLk.RLock()
+	defer sh.workersLk.RUnlock()
+
+	var out []storiface.SchedReservation
+	for wid, w := range sh.Workers {
+		w.lk.Lock()
+
+		var entries []entry
+		collect := func(res *ActiveResources, prep bool) {
+			for k, rv := range res.reservations {
+				n := uint64(rv.count)
+				entries = append(entries, entry{res: res, key: k, out: storiface.SchedReservation{
+					Worker:    uuid.UUID(wid),
+					Hostname:  w.Info.Hostname,
+					SchedId:   k.schedID,
+					Task:      k.tt.TaskType,
+					Preparing: prep,
+					Since:     rv.since,
+					MinMemory: rv.res.MinMemory * n,
+					MaxMemory: rv.res.MaxMemory * n,
+					GPU:       rv.res.GPUUtilization * float64(n),
+					Threads:   rv.res.Threads(rv.wr.CPUs, len(rv.wr.GPUs)) * n,
+				}})
+			}
+		}
+		collect(w.preparing, true)
+		collect(w.active, false)
+
+		for _, e := range entries {
+			if _, ok := live[e.key.schedID]; !ok && now.Sub(e.out.Since) >= ReservationLeakGrace {
+				e.out.Leaked = true
+			}
+
+			if !release {
+				out = append(out, e.out)
+				continue
+			}
+			if e.out.Leaked && e.res.release(e.key) {
+				log.Warnw("released leaked resource reservation", "worker", wid, "hostname", w.Info.Hostname,
+					"schedId", e.out.SchedId, "task", e.out.Task, "preparing", e.out.Preparing, "since", e.out.Since,
+					"minMemory", e.out.MinMemory, "maxMemory", e.out.MaxMemory, "gpu", e.out.GPU, "threads", e.out.Threads)
+				out = append(out, e.out)
+			}
+		}
+
+		w.lk.Unlock()
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Worker != out[j].Worker {
+			return out[i].Worker.String() < out[j].Worker.String()
+		}
+		return out[i].Since.Before(out[j].Since)
+	})
+	return out
+}
@@ -2,6 +2,7 @@ package sealer
 
 import (
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -17,10 +18,28 @@ type ActiveResources struct {
 
 	taskCounters *taskCounter
 
+	// reservations holds the resources reserved for scheduled requests, so that reservations
+	// which are never freed can be found and released
+	reservations map[reservationKey]*reservation
+	// released counts the released reservations which are still to be freed by their owner
+	released map[reservationKey]int
+
 	cond    *sync.Cond
 	waiting int
 }
 
+type reservationKey struct {
+	schedID uuid.UUID
+	tt      sealtasks.SealTaskType
+}
+
+type reservation struct {
+	wr    storiface.WorkerResources
+	res   storiface.Resources
+	count int
+	since time.Time
+}
+
 type taskCounter struct {
 	taskCounters map[sealtasks.SealTaskType]map[uuid.UUID]int
 
@@ -127,10 +146,46 @@ func (a *ActiveResources) Add(schedID uuid.UUID, tt sealtasks.SealTaskType, wr s
 	a.memUsedMax += r.MaxMemory
 	a.taskCounters.Add(tt, schedID)
 
+	if schedID != (uuid.UUID{}) {
+		if a.reservations == nil {
+			a.reservations = map[reservationKey]*reservation{}
+		}
+		k := reservationKey{schedID: schedID, tt: tt}
+		rv, ok := a.reservations[k]
+		if !ok {
+			rv = &reservation{wr: wr, res: r, since: time.Now()}
+			a.reservations[k] = rv
+		}
+		rv.count++
+	}
+
 	return a.utilization(wr) - startUtil
 }
 
 func (a *ActiveResources) Free(schedID uuid.UUID, tt sealtasks.SealTaskType, wr storiface.WorkerResources, r storiface.Resources) {
+	if schedID != (uuid.UUID{}) {
+		k := reservationKey{schedID: schedID, tt: tt}
+		if n := a.released[k]; n > 0 {
+			// the resources were already freed when the reservation was released
+			if n == 1 {
+				delete(a.released, k)
+			} else {
+				a.released[k] = n - 1
+			}
+			return
+		}
+		if rv, ok := a.reservations[k]; ok {
+			rv.count--
+			if rv.count <= 0 {
+				delete(a.reservations, k)
+			}
+		}
+	}
+
+	a.free(tt, schedID, wr, r)
+}
+
+func (a *ActiveResources) free(tt sealtasks.SealTaskType, schedID uuid.UUID, wr storiface.WorkerResources, r storiface.Resources) {
 	if r.GPUUtilization > 0 {
 		a.gpuUsed -= r.GPUUtilization
 	}
@@ -144,6 +199,25 @@ func (a *ActiveResources) Free(schedID uuid.UUID, tt sealtasks.SealTaskType, wr
 	}
 }
 
+// release frees the resources of a reservation which its owner failed to free. When the owner
+// frees the reservation later, its resources aren't freed again.
+func (a *ActiveResources) release(k reservationKey) bool {
+	rv, ok := a.reservations[k]
+	if !ok {
+		return false
+	}
+	delete(a.reservations, k)
+
+	if a.released == nil {
+		a.released = map[reservationKey]int{}
+	}
+	for i := 0; i < rv.count; i++ {
+		a.free(k.tt, k.schedID, rv.wr, rv.res)
+		a.released[k]++
+	}
+	return true
+}
+
 // CanHandleRequest evaluates if the worker has enough available resources to
 // handle the request.
 func (a *ActiveResources) CanHandleRequest(schedID uuid.UUID, tt sealtasks.SealTaskType, needRes storiface.Resources, wid storiface.WorkerID, caller string, info storiface.WorkerInfo) bool {
@@ -14,6 +14,7 @@ import (
 	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-state-types/abi"
 	prooftypes "github.com/filecoin-project/go-state-types/proof"
@@ -752,3 +753,137 @@ func TestWindowCompact(t *testing.T) {
 		[][]sealtasks.TaskType{{sealtasks.TTPreCommit1, sealtasks.TTPreCommit1, sealtasks.TTAddPiece}, {sealtasks.TTPreCommit1, sealtasks.TTPreCommit2}}),
 	)
 }
+
+func TestLeakedReservations(t *testing.T) {
+	sched, err := newScheduler(context.Background(), "")
+	require.NoError(t, err)
+
+	wid := storiface.WorkerID(uuid.New())
+	tc := newTaskCounter()
+	w := &WorkerHandle{
+		Info:      storiface.WorkerInfo{Hostname: "w", Resources: decentWorkerResources},
+		Enabled:   true,
+		preparing: NewActiveResources(tc),
+		active:    NewActiveResources(tc),
+	}
+	sched.Workers[wid] = w
+
+	res := storiface.Resources{MinMemory: 1 << 30, MaxMemory: 2 << 30, MaxParallelism: 1}
+	pc1 := sealtasks.TTPreCommit1.SealTask(abi.RegisteredSealProof_StackedDrg32GiBV1_1)
+	running, leaked := uuid.New(), uuid.New()
+	w.active.Add(running, pc1, decentWorkerResources, res)
+	w.active.Add(leaked, pc1, decentWorkerResources, res)
+	w.active.reservations[reservationKey{schedID: leaked, tt: pc1}].since = time.Now().Add(-2 * ReservationLeakGrace)
+
+	// the PC1 task of one of the requests is tracked on the worker
+	sched.workTracker.running[storiface.CallID{ID: uuid.New()}] = trackedWork{
+		job:     storiface.WorkerJob{Task: sealtasks.TTPreCommit1},
+		worker:  wid,
+		schedID: running,
+	}
+
+	rs := sched.Reservations(time.Now())
+	require.Len(t, rs, 2)
+	require.True(t, rs[0].Leaked)
+	require.Equal(t, leaked, rs[0].SchedId)
+	require.False(t, rs[1].Leaked)
+
+	released := sched.ReleaseLeakedReservations(time.Now())
+	require.Len(t, released, 1)
+	require.Equal(t, leaked, released[0].SchedId)
+	require.Equal(t, res.MinMemory, w.active.memUsedMin)
+	require.Len(t, tc.Get(pc1), 1)
+
+	// the resources of released reservations aren't freed again when their task returns
+	w.active.Free(leaked, pc1, decentWorkerResources, res)
+	require.Equal(t, res.MinMemory, w.active.memUsedMin)
+	w.active.Free(running, pc1, decentWorkerResources, res)
+	require.Zero(t, w.active.memUsedMin)
+	require.Empty(t, w.active.reservations)
+	require.Empty(t, w.active.released)
+	require.Empty(t, sched.Reservations(time.Now()))
+}
+
+func TestPreparingReservations(t *testing.T) {
+	sched, err := newScheduler(context.Background(), "")
+	require.NoError(t, err)
+
+	wid := storiface.WorkerID(uuid.New())
+	tc := newTaskCounter()
+	w := &WorkerHandle{
+		Info:      storiface.WorkerInfo{Hostname: "w", Resources: decentWorkerResources},
+		Enabled:   true,
+		preparing: NewActiveResources(tc),
+		active:    NewActiveResources(tc),
+	}
+	sched.Workers[wid] = w
+
+	spt := abi.RegisteredSealProof_StackedDrg32GiBV1_1
+	sector := storiface.SectorRef{ID: abi.SectorID{Miner: 1000, Number: 1}, ProofType: spt}
+	res := storiface.Resources{MinMemory: 1 << 30, MaxMemory: 2 << 30, MaxParallelism: 1}
+
+	// reserve the preparing resources of a request in the past, so that they would be leaked if
+	// its work wasn't tracked
+	prepare := func(prep sealtasks.TaskType) uuid.UUID {
+		schedID := uuid.New()
+		tt := prep.SealTask(spt)
+		w.preparing.Add(schedID, tt, decentWorkerResources, res)
+		w.preparing.reservations[reservationKey{schedID: schedID, tt: tt}].since = time.Now().Add(-2 * ReservationLeakGrace)
+		return schedID
+	}
+
+	// track work of the request which waits for its active resources, like the work of requests
+	// does after their prepare step
+	waitForResources := func(ctx context.Context, schedID uuid.UUID, task sealtasks.TaskType) {
+		go func() {
+			_, _ = sched.workTracker.track(ctx, make(chan struct{}), wid, w.Info, schedID, sector, task, func() (storiface.CallID, error) {
+				return storiface.UndefCall, xerrors.Errorf("not started")
+			})
+		}()
+		require.Eventually(t, func() bool {
+			_, prepared := sched.workTracker.Running()
+			for _, p := range prepared {
+				if p.schedID == schedID {
+					return true
+				}
+			}
+			return false
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	leaked := func() map[uuid.UUID]bool {
+		out := map[uuid.UUID]bool{}
+		for _, r := range sched.Reservations(time.Now()) {
+			require.True(t, r.Preparing)
+			out[r.SchedId] = r.Leaked
+		}
+		return out
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// no-op prepare steps aren't tracked, but the work of the request waiting for resources is
+	nop := prepare(sealtasks.TTNoop)
+	waitForResources(ctx, nop, sealtasks.TTPreCommit1)
+
+	// a running fetch is tracked with the request, and after the fetch is done the work of the
+	// request waiting for resources is
+	fetching, fetched := prepare(sealtasks.TTFetch), prepare(sealtasks.TTFetch)
+	sched.workTracker.running[storiface.CallID{ID: uuid.New()}] = trackedWork{
+		job:     storiface.WorkerJob{Task: sealtasks.TTFetch},
+		worker:  wid,
+		schedID: fetching,
+	}
+	waitForResources(ctx, fetched, sealtasks.TTCommit2)
+
+	// the work of this request is gone
+	gone := prepare(sealtasks.TTFetch)
+
+	require.Equal(t, map[uuid.UUID]bool{nop: false, fetching: false, fetched: false, gone: true}, leaked())
+
+	released := sched.ReleaseLeakedReservations(time.Now())
+	require.Len(t, released, 1)
+	require.Equal(t, gone, released[0].SchedId)
+	require.Equal(t, 3*res.MinMemory, w.preparing.memUsedMin)
+}
@@ -462,7 +462,7 @@ func (sw *schedWorker) startProcessingTask(req *WorkerRequest) error {
 
 	go func() {
 		// first run the prepare step (e.g. fetching sector data from other worker)
-		tw := sh.workTracker.worker(sw.wid, w.Info, w.workerRpc)
+		tw := sh.workTracker.worker(sw.wid, w.Info, req.SchedId, w.workerRpc)
 		tw.start()
 		err := req.prepare.Action(req.Ctx, tw)
 		w.lk.Lock()
@@ -488,7 +488,7 @@ func (sw *schedWorker) startProcessingTask(req *WorkerRequest) error {
 			return
 		}
 
-		tw = sh.workTracker.worker(sw.wid, w.Info, w.workerRpc)
+		tw = sh.workTracker.worker(sw.wid, w.Info, req.SchedId, w.workerRpc)
 
 		// start tracking work first early in case we need to wait for resources
 		werr := make(chan error, 1)
@@ -550,7 +550,7 @@ func (sw *schedWorker) startProcessingReadyTask(req *WorkerRequest) error {
 
 	go func() {
 		// Do the work!
-		tw := sh.workTracker.worker(sw.wid, w.Info, w.workerRpc)
+		tw := sh.workTracker.worker(sw.wid, w.Info, req.SchedId, w.workerRpc)
 		tw.start()
 		err := req.work(req.Ctx, tw)
 
@@ -130,6 +130,16 @@ func (a TaskType) Short() string {
 	return n
 }
 
+// TaskTypeFromShort returns the task type with the given short name.
+func TaskTypeFromShort(s string) (TaskType, bool) {
+	for tt, n := range shortNames {
+		if n == s {
+			return tt, true
+		}
+	}
+	return TTNoop, false
+}
+
 type SealTaskType struct {
 	TaskType
 	abi.RegisteredSealProof
@@ -117,6 +117,26 @@ type WorkerJob struct {
 	Hostname string `json:",omitempty"` // optional, set for ret-wait jobs
 }
 
+// SchedReservation is a reservation of worker resources made by the scheduler for a task
+type SchedReservation struct {
+	Worker   uuid.UUID
+	Hostname string
+
+	SchedId uuid.UUID
+	Task    sealtasks.TaskType
+	// Preparing is set for reservations of the prepare step of a task, e.g. fetching its data
+	Preparing bool
+	Since     time.Time
+
+	MinMemory uint64
+	MaxMemory uint64
+	GPU       float64
+	Threads   uint64
+
+	// Leaked is set when the worker has no task of the type tracked for the reservation
+	Leaked bool
+}
+
 type CallID struct {
 	Sector abi.SectorID
 	ID     uuid.UUID
@@ -15,6 +15,10 @@ import (
 const (
 	SubmitConfidence    = 4
 	ChallengeConfidence = 1
+
+	// SubmitBaseFeeCapOverride is the number of epochs before the deadline closes from which
+	// proofs are submitted regardless of the configured base fee cap
+	SubmitBaseFeeCapOverride = 20
 )
 
 type CompleteGeneratePoSTCb func(posts []miner.SubmitWindowedPoStParams, err error)
@@ -27,6 +31,7 @@ type WdPoStCommands interface {
 
 	startGeneratePoST(ctx context.Context, ts *types.TipSet, deadline *dline.Info, onComplete CompleteGeneratePoSTCb) context.CancelFunc
 	startSubmitPoST(ctx context.Context, ts *types.TipSet, deadline *dline.Info, posts []miner.SubmitWindowedPoStParams, onComplete CompleteSubmitPoSTCb) context.CancelFunc
+	delaySubmitPoST(ts *types.TipSet, deadline *dline.Info) bool
 	onAbort(ts *types.TipSet, deadline *dline.Info)
 	recordPoStFailure(err error, ts *types.TipSet, deadline *dline.Info)
 }
@@ -479,6 +484,12 @@ func (s *submitHandler) submitIfReady(ctx context.Context, advance *types.TipSet
 		return
 	}
 
+	// Wait for the basefee to drop if it's above the configured cap, we'll
+	// check again on the next head change
+	if s.api.delaySubmitPoST(advance, pw.di) {
+		return
+	}
+
 	// Start submitting post
 	pw.submitState = SubmitStateSubmitting
 	pw.abort = s.api.startSubmitPoST(ctx, advance, pw.di, posts, func(err error) {
@@ -193,6 +193,10 @@ func (m *mockAPI) wasAbortCalled() bool {
 func (m *mockAPI) recordPoStFailure(err error, ts *types.TipSet, deadline *dline.Info) {
 }
 
+func (m *mockAPI) delaySubmitPoST(ts *types.TipSet, deadline *dline.Info) bool {
+	return false
+}
+
 func (m *mockAPI) setChangeHandler(ch *changeHandler) {
 	m.ch = ch
 }
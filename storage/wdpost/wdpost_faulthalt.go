@@ -0,0 +1,203 @@
+package wdpost
+
+import (
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/dline"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/miner"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/journal/alerting"
+)
+
+// faultHalts tracks the deadlines whose proof submission is halted because too many of their
+// sectors are faulty, until the operator acknowledges the faults.
+type faultHalts struct {
+	maxSectors  uint64
+	maxFraction float64
+
+	alerting *alerting.Alerting
+	alert    alerting.AlertType
+
+	lk     sync.Mutex
+	halted map[abi.ChainEpoch]*faultHalt // by deadline open epoch
+}
+
+type faultHalt struct {
+	di           dline.Info
+	faulty, live uint64
+	acked        bool
+}
+
+func newFaultHalts(maxSectors uint64, maxFraction float64, al *alerting.Alerting) *faultHalts {
+	fh := &faultHalts{
+		maxSectors:  maxSectors,
+		maxFraction: maxFraction,
+		alerting:    al,
+		halted:      map[abi.ChainEpoch]*faultHalt{},
+	}
+	if al != nil && fh.enabled() {
+		fh.alert = al.AddAlertType("wdpost", "faulty-deadline")
+	}
+	return fh
+}
+
+func (fh *faultHalts) enabled() bool {
+	return fh.maxSectors > 0 || fh.maxFraction > 0
+}
+
+// check halts the submission of the proofs generated for the deadline when its faulty sectors
+// exceed the configured thresholds.
+func (fh *faultHalts) check(di dline.Info, partitions []api.Partition, posts []miner.SubmitWindowedPoStParams) error {
+	if !fh.enabled() {
+		return nil
+	}
+
+	faulty, live, err := deadlineFaults(partitions, posts)
+	if err != nil {
+		return err
+	}
+
+	exceeded := fh.maxSectors > 0 && faulty > fh.maxSectors
+	exceeded = exceeded || (fh.maxFraction > 0 && live > 0 && float64(faulty)/float64(live) > fh.maxFraction)
+	if !exceeded {
+		return nil
+	}
+
+	fh.lk.Lock()
+	defer fh.lk.Unlock()
+
+	if h, ok := fh.halted[di.Open]; ok && h.acked {
+		// the proof was regenerated after the faults were acknowledged
+		return nil
+	}
+	fh.halted[di.Open] = &faultHalt{di: di, faulty: faulty, live: live}
+
+	log.Errorw("halting WindowPoSt submission, too many faulty sectors in the deadline; acknowledge with 'lotus-miner proving ack-faults' to submit",
+		"deadline", di.Index, "open", di.Open, "close", di.Close, "faulty", faulty, "live", live,
+		"maxFaultySectors", fh.maxSectors, "maxFaultyFraction", fh.maxFraction)
+	if fh.alerting != nil {
+		fh.alerting.Raise(fh.alert, map[string]interface{}{
+			"message":  "WindowPoSt submission halted, too many faulty sectors in the deadline",
+			"deadline": di.Index,
+			"open":     di.Open,
+			"close":    di.Close,
+			"faulty":   faulty,
+			"live":     live,
+		})
+	}
+
+	return nil
+}
+
+// isHalted returns whether the submission of proofs for the deadline is halted. Halts of closed
+// deadlines are dropped.
+func (fh *faultHalts) isHalted(ts *types.TipSet, di *dline.Info) bool {
+	fh.lk.Lock()
+	defer fh.lk.Unlock()
+
+	for open, h := range fh.halted {
+		if ts.Height() >= h.di.Close {
+			if !h.acked {
+				log.Warnw("deadline closed without submitting WindowPoSt, the faults were not acknowledged", "deadline", h.di.Index, "open", open)
+			}
+			delete(fh.halted, open)
+		}
+	}
+	fh.resolveLocked()
+
+	h, ok := fh.halted[di.Open]
+	if !ok || h.acked {
+		return false
+	}
+
+	log.Warnw("WindowPoSt submission halted, waiting for the faults to be acknowledged", "deadline", di.Index, "faulty", h.faulty, "live", h.live, "height", ts.Height(), "close", di.Close)
+	return true
+}
+
+// ack acknowledges the faults of the halted deadline with the given index, so that its proofs are
+// submitted.
+func (fh *faultHalts) ack(dlIdx uint64) error {
+	fh.lk.Lock()
+	defer fh.lk.Unlock()
+
+	var found bool
+	for _, h := range fh.halted {
+		if h.di.Index == dlIdx && !h.acked {
+			h.acked = true
+			found = true
+			log.Warnw("WindowPoSt faults acknowledged, resuming submission", "deadline", h.di.Index, "faulty", h.faulty, "live", h.live)
+		}
+	}
+	if !found {
+		return xerrors.Errorf("WindowPoSt submission for deadline %d is not halted", dlIdx)
+	}
+
+	fh.resolveLocked()
+	return nil
+}
+
+func (fh *faultHalts) resolveLocked() {
+	if fh.alerting == nil || !fh.alerting.IsRaised(fh.alert) {
+		return
+	}
+	for _, h := range fh.halted {
+		if !h.acked {
+			return
+		}
+	}
+	fh.alerting.Resolve(fh.alert, map[string]string{
+		"message": "no WindowPoSt submissions are halted",
+	})
+}
+
+// deadlineFaults returns the number of live sectors of the deadline which aren't proven by the
+// posts, because they are declared faulty or were skipped, and the number of live sectors.
+func deadlineFaults(partitions []api.Partition, posts []miner.SubmitWindowedPoStParams) (uint64, uint64, error) {
+	var live, proven uint64
+	for _, p := range partitions {
+		n, err := p.LiveSectors.Count()
+		if err != nil {
+			return 0, 0, xerrors.Errorf("counting live sectors: %w", err)
+		}
+		live += n
+	}
+
+	for _, post := range posts {
+		for _, pp := range post.Partitions {
+			if pp.Index >= uint64(len(partitions)) {
+				return 0, 0, xerrors.Errorf("proof for partition %d out of %d", pp.Index, len(partitions))
+			}
+			p := partitions[pp.Index]
+
+			toProve, err := bitfield.SubtractBitField(p.LiveSectors, p.FaultySectors)
+			if err != nil {
+				return 0, 0, xerrors.Errorf("removing faults from set of sectors to prove: %w", err)
+			}
+			toProve, err = bitfield.MergeBitFields(toProve, p.RecoveringSectors)
+			if err != nil {
+				return 0, 0, xerrors.Errorf("adding recoveries to set of sectors to prove: %w", err)
+			}
+			good, err := bitfield.SubtractBitField(toProve, pp.Skipped)
+			if err != nil {
+				return 0, 0, xerrors.Errorf("removing skipped sectors: %w", err)
+			}
+
+			n, err := good.Count()
+			if err != nil {
+				return 0, 0, xerrors.Errorf("counting proven sectors: %w", err)
+			}
+			proven += n
+		}
+	}
+
+	if proven > live {
+		return 0, live, nil
+	}
+	return live - proven, live, nil
+}
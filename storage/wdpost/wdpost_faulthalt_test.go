@@ -0,0 +1,80 @@
+package wdpost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/dline"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/miner"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+	"github.com/filecoin-project/lotus/journal"
+	"github.com/filecoin-project/lotus/journal/alerting"
+)
+
+func TestFaultHalts(t *testing.T) {
+	bf := bitfield.NewFromSet
+	partitions := []api.Partition{
+		// sector 3 is faulty, 4 recovering
+		{LiveSectors: bf([]uint64{1, 2, 3, 4}), FaultySectors: bf([]uint64{3, 4}), RecoveringSectors: bf([]uint64{4})},
+		// all faulty, not in the proofs
+		{LiveSectors: bf([]uint64{5, 6}), FaultySectors: bf([]uint64{5, 6}), RecoveringSectors: bf(nil)},
+		{LiveSectors: bf([]uint64{7, 8, 9, 10}), FaultySectors: bf(nil), RecoveringSectors: bf(nil)},
+	}
+	posts := []miner.SubmitWindowedPoStParams{{
+		Partitions: []miner.PoStPartition{
+			{Index: 0, Skipped: bf([]uint64{2})},
+			{Index: 2, Skipped: bf(nil)},
+		},
+	}}
+
+	// 3, 2, 5 and 6 aren't proven
+	faulty, live, err := deadlineFaults(partitions, posts)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), faulty)
+	require.Equal(t, uint64(10), live)
+
+	al := alerting.NewAlertingSystem(journal.NilJournal())
+	di := dline.Info{Index: 3, Open: 100, Close: 160}
+	tsAt := func(h abi.ChainEpoch) *types.TipSet {
+		blk := mock.MkBlock(nil, 0, 0)
+		blk.Height = h
+		return mock.TipSet(blk)
+	}
+	at := tsAt(110)
+
+	// within the thresholds
+	fh := newFaultHalts(4, 0.5, al)
+	require.NoError(t, fh.check(di, partitions, posts))
+	require.False(t, fh.isHalted(at, &di))
+
+	fh = newFaultHalts(0, 0.3, al)
+	require.NoError(t, fh.check(di, partitions, posts))
+	require.True(t, fh.isHalted(at, &di))
+	require.True(t, al.IsRaised(fh.alert))
+
+	require.Error(t, fh.ack(2))
+	require.NoError(t, fh.ack(3))
+	require.False(t, fh.isHalted(at, &di))
+	require.False(t, al.IsRaised(fh.alert))
+
+	// a regenerated proof for the acknowledged deadline isn't halted again
+	require.NoError(t, fh.check(di, partitions, posts))
+	require.False(t, fh.isHalted(at, &di))
+
+	// halts are dropped when the deadline closes
+	fh = newFaultHalts(0, 0.3, al)
+	require.NoError(t, fh.check(di, partitions, posts))
+	require.False(t, fh.isHalted(tsAt(di.Close), &di))
+	require.Empty(t, fh.halted)
+
+	// disabled by default
+	fh = newFaultHalts(0, 0, al)
+	require.NoError(t, fh.check(di, partitions, posts))
+	require.False(t, fh.isHalted(at, &di))
+}
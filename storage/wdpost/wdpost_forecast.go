@@ -0,0 +1,178 @@
+package wdpost
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/dline"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/build"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/miner"
+	"github.com/filecoin-project/lotus/storage/sealer/sealtasks"
+	"github.com/filecoin-project/lotus/storage/sealer/storiface"
+)
+
+// proofSamples is the number of recently computed proofs the compute time forecasts are based on
+const proofSamples = 16
+
+type proofSample struct {
+	partitions int
+	took       time.Duration
+}
+
+// proofTimes keeps the durations of the recently computed WindowPoSt proofs
+type proofTimes struct {
+	lk      sync.Mutex
+	samples []proofSample
+}
+
+func (pt *proofTimes) record(partitions int, took time.Duration) {
+	if partitions == 0 {
+		return
+	}
+
+	pt.lk.Lock()
+	defer pt.lk.Unlock()
+
+	pt.samples = append(pt.samples, proofSample{partitions: partitions, took: took})
+	if len(pt.samples) > proofSamples {
+		pt.samples = pt.samples[len(pt.samples)-proofSamples:]
+	}
+}
+
+// partitionTime returns the mean time it took to prove a partition, and the number of proofs it
+// was computed from
+func (pt *proofTimes) partitionTime() (time.Duration, int) {
+	pt.lk.Lock()
+	defer pt.lk.Unlock()
+
+	var took time.Duration
+	var partitions int
+	for _, s := range pt.samples {
+		took += s.took
+		partitions += s.partitions
+	}
+	if partitions == 0 {
+		return 0, 0
+	}
+	return took / time.Duration(partitions), len(pt.samples)
+}
+
+// ResourceForecast estimates the resources needed to prove each deadline of the next proving
+// period which has sectors to prove, starting with the current deadline.
+func (s *WindowPoStScheduler) ResourceForecast(ctx context.Context) (*api.ProvingForecast, error) {
+	head, err := s.api.ChainHead(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("getting chain head: %w", err)
+	}
+	di, err := s.api.StateMinerProvingDeadline(ctx, s.actor, head.Key())
+	if err != nil {
+		return nil, xerrors.Errorf("getting proving deadline: %w", err)
+	}
+	nv, err := s.api.StateNetworkVersion(ctx, head.Key())
+	if err != nil {
+		return nil, xerrors.Errorf("getting network version: %w", err)
+	}
+
+	ssize, err := s.proofType.SectorSize()
+	if err != nil {
+		return nil, xerrors.Errorf("getting sector size: %w", err)
+	}
+	spt, err := miner.SealProofTypeFromSectorSize(ssize, nv, miner.SealProofVariant_Standard)
+	if err != nil {
+		return nil, xerrors.Errorf("getting seal proof type: %w", err)
+	}
+	peakMemory := storiface.ResourceTable[sealtasks.TTGenerateWindowPoSt][spt].MaxMemory
+
+	partitionTime, samples := s.proofTimes.partitionTime()
+	out := &api.ProvingForecast{
+		Samples:       samples,
+		PartitionTime: partitionTime,
+	}
+
+	for i := uint64(0); i < di.WPoStPeriodDeadlines; i++ {
+		dlIdx := (di.Index + i) % di.WPoStPeriodDeadlines
+		partitions, err := s.api.StateMinerPartitions(ctx, s.actor, dlIdx, head.Key())
+		if err != nil {
+			return nil, xerrors.Errorf("getting partitions of deadline %d: %w", dlIdx, err)
+		}
+		batches, err := s.BatchPartitions(partitions, nv)
+		if err != nil {
+			return nil, xerrors.Errorf("batching partitions of deadline %d: %w", dlIdx, err)
+		}
+
+		open := di.Open + abi.ChainEpoch(i)*di.WPoStChallengeWindow
+		dl, err := forecastDeadline(dline.Info{
+			Index: dlIdx,
+			Open:  open,
+			Close: open + di.WPoStChallengeWindow,
+		}, batches, peakMemory, partitionTime)
+		if err != nil {
+			return nil, xerrors.Errorf("forecasting deadline %d: %w", dlIdx, err)
+		}
+		if dl.Sectors == 0 {
+			continue
+		}
+		out.Deadlines = append(out.Deadlines, dl)
+	}
+
+	return out, nil
+}
+
+// forecastDeadline estimates the resources needed to prove the batches of partitions of a
+// deadline, from the mean time it takes to prove a partition. Like in runPoStCycle, partitions
+// without sectors to prove aren't proven, and the proofs of the batches are computed one after
+// another.
+func forecastDeadline(di dline.Info, batches [][]api.Partition, peakMemory uint64, partitionTime time.Duration) (api.DeadlineForecast, error) {
+	out := api.DeadlineForecast{
+		Index: di.Index,
+		Open:  di.Open,
+		Close: di.Close,
+	}
+
+	var proven int
+	for _, batch := range batches {
+		var batchSectors uint64
+		for _, partition := range batch {
+			toProve, err := bitfield.SubtractBitField(partition.LiveSectors, partition.FaultySectors)
+			if err != nil {
+				return api.DeadlineForecast{}, xerrors.Errorf("removing faults from set of sectors to prove: %w", err)
+			}
+			toProve, err = bitfield.MergeBitFields(toProve, partition.RecoveringSectors)
+			if err != nil {
+				return api.DeadlineForecast{}, xerrors.Errorf("adding recoveries to set of sectors to prove: %w", err)
+			}
+			n, err := toProve.Count()
+			if err != nil {
+				return api.DeadlineForecast{}, xerrors.Errorf("counting sectors to prove: %w", err)
+			}
+
+			out.Partitions++
+			if n > 0 {
+				proven++
+			}
+			batchSectors += n
+		}
+
+		if batchSectors > 0 {
+			out.Proofs++
+			out.Sectors += batchSectors
+		}
+	}
+
+	if out.Proofs > 0 {
+		out.PeakMemory = peakMemory
+	}
+	out.ComputeTime = partitionTime * time.Duration(proven)
+
+	window := time.Duration(di.Close-di.Open) * time.Duration(build.BlockDelaySecs) * time.Second
+	out.AtRisk = out.ComputeTime > window
+
+	return out, nil
+}
@@ -0,0 +1,84 @@
+package wdpost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/dline"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/build"
+)
+
+func TestProofTimes(t *testing.T) {
+	var pt proofTimes
+
+	took, samples := pt.partitionTime()
+	require.Zero(t, took)
+	require.Zero(t, samples)
+
+	pt.record(0, time.Minute) // nothing proven
+	pt.record(2, 4*time.Minute)
+	pt.record(1, time.Minute)
+	took, samples = pt.partitionTime()
+	require.Equal(t, 5*time.Minute/3, took)
+	require.Equal(t, 2, samples)
+
+	// only the recent samples are kept
+	for i := 0; i < proofSamples; i++ {
+		pt.record(1, time.Minute)
+	}
+	took, samples = pt.partitionTime()
+	require.Equal(t, time.Minute, took)
+	require.Equal(t, proofSamples, samples)
+}
+
+func TestForecastDeadline(t *testing.T) {
+	partition := func(live, faulty, recovering []uint64) api.Partition {
+		return api.Partition{
+			AllSectors:        bitfield.NewFromSet(live),
+			LiveSectors:       bitfield.NewFromSet(live),
+			FaultySectors:     bitfield.NewFromSet(faulty),
+			RecoveringSectors: bitfield.NewFromSet(recovering),
+			ActiveSectors:     bitfield.NewFromSet(live),
+		}
+	}
+
+	di := dline.Info{Index: 3, Open: 100, Close: 160}
+	window := 60 * time.Duration(build.BlockDelaySecs) * time.Second
+
+	batches := [][]api.Partition{
+		{
+			partition([]uint64{1, 2, 3}, []uint64{3}, nil),
+			partition([]uint64{4, 5}, []uint64{4, 5}, []uint64{5}),
+		},
+		{
+			// all faulty, not proven
+			partition([]uint64{6}, []uint64{6}, nil),
+		},
+	}
+
+	dl, err := forecastDeadline(di, batches, 64<<30, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), dl.Index)
+	require.Equal(t, 3, dl.Partitions)
+	require.Equal(t, uint64(3), dl.Sectors)
+	require.Equal(t, 1, dl.Proofs)
+	require.Equal(t, uint64(64<<30), dl.PeakMemory)
+	require.Equal(t, 2*time.Minute, dl.ComputeTime)
+	require.False(t, dl.AtRisk)
+
+	// the proofs take longer than the deadline is open for
+	dl, err = forecastDeadline(di, batches, 64<<30, window)
+	require.NoError(t, err)
+	require.True(t, dl.AtRisk)
+
+	// no proofs measured yet
+	dl, err = forecastDeadline(di, batches, 64<<30, 0)
+	require.NoError(t, err)
+	require.Zero(t, dl.ComputeTime)
+	require.False(t, dl.AtRisk)
+}
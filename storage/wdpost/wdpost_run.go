@@ -137,6 +137,33 @@ func (s *WindowPoStScheduler) startSubmitPoST(
 	return abort
 }
 
+// delaySubmitPoST returns true if the PoST submission should wait because it is
+// halted until too many faulty sectors are acknowledged, or because the basefee
+// is above MaxWindowPoStBaseFee, and the deadline isn't about to close
+func (s *WindowPoStScheduler) delaySubmitPoST(ts *types.TipSet, deadline *dline.Info) bool {
+	if s.faultHalts != nil && s.faultHalts.isHalted(ts, deadline) {
+		return true
+	}
+
+	feeCap := abi.TokenAmount(s.feeCfg.MaxWindowPoStBaseFee)
+	if feeCap.Int == nil || feeCap.IsZero() {
+		return false
+	}
+
+	baseFee := ts.MinTicketBlock().ParentBaseFee
+	if !baseFee.GreaterThan(feeCap) {
+		return false
+	}
+
+	if ts.Height() >= deadline.Close-SubmitBaseFeeCapOverride {
+		log.Warnw("basefee above MaxWindowPoStBaseFee, submitting anyway as the deadline is about to close", "basefee", baseFee, "cap", s.feeCfg.MaxWindowPoStBaseFee, "deadline", deadline.Index, "close", deadline.Close)
+		return false
+	}
+
+	log.Infow("delaying WindowPoSt submission, basefee above MaxWindowPoStBaseFee", "basefee", baseFee, "cap", s.feeCfg.MaxWindowPoStBaseFee, "deadline", deadline.Index, "height", ts.Height())
+	return true
+}
+
 // runSubmitPoST submits PoST
 func (s *WindowPoStScheduler) runSubmitPoST(
 	ctx context.Context,
@@ -279,7 +306,7 @@ func (s *WindowPoStScheduler) runPoStCycle(ctx context.Context, manual bool, di
 		log.Infow("post cycle done", "took", time.Now().Sub(start))
 	}()
 
-	if !manual {
+	if !manual && !s.disableAutoRecoveries {
 		// TODO: extract from runPoStCycle, run on fault cutoff boundaries
 		s.asyncFaultRecover(di, ts)
 	}
@@ -310,9 +337,16 @@ func (s *WindowPoStScheduler) runPoStCycle(ctx context.Context, manual bool, di
 		return nil, xerrors.Errorf("getting network version: %w", err)
 	}
 
+	// Leave out partitions without sectors to prove, so that they don't take up
+	// space in messages
+	provable, partitionIdxs, err := provablePartitions(partitions, manual)
+	if err != nil {
+		return nil, err
+	}
+
 	// Split partitions into batches, so as not to exceed the number of sectors
 	// allowed in a single message
-	partitionBatches, err := s.BatchPartitions(partitions, nv)
+	partitionBatches, err := s.BatchPartitions(provable, nv)
 	if err != nil {
 		log.Errorf("batch partitions failed: %+v", err)
 		return nil, err
@@ -401,7 +435,7 @@ func (s *WindowPoStScheduler) runPoStCycle(ctx context.Context, manual bool, di
 
 				xsinfos = append(xsinfos, ssi...)
 				partitions = append(partitions, miner.PoStPartition{
-					Index:   uint64(batchPartitionStartIdx + partIdx),
+					Index:   partitionIdxs[batchPartitionStartIdx+partIdx],
 					Skipped: skipped,
 				})
 			}
@@ -484,6 +518,7 @@ func (s *WindowPoStScheduler) runPoStCycle(ctx context.Context, manual bool, di
 				}
 
 				// Proof generation successful, stop retrying
+				s.proofTimes.record(len(partitions), elapsed)
 				somethingToProve = true
 				params.Partitions = partitions
 				params.Proofs = postOut
@@ -521,9 +556,63 @@ func (s *WindowPoStScheduler) runPoStCycle(ctx context.Context, manual bool, di
 		}
 		posts = append(posts, params)
 	}
+
+	if !manual && len(posts) > 0 && s.faultHalts != nil {
+		if err := s.faultHalts.check(di, partitions, posts); err != nil {
+			return nil, xerrors.Errorf("checking deadline faults: %w", err)
+		}
+	}
+
+	if len(posts) > 0 {
+		var proven int
+		for _, post := range posts {
+			proven += len(post.Partitions)
+		}
+		log.Infow("window post messages compacted",
+			"deadline", di.Index,
+			"partitions", len(partitions),
+			"provable", len(provable),
+			"proven", proven,
+			"messages", len(posts),
+			"ratio", float64(proven)/float64(len(posts)))
+	}
+
 	return posts, nil
 }
 
+// provablePartitions returns the partitions with sectors to prove, with their indexes in the
+// deadline. When manual is set, faulty sectors are proven as well.
+func provablePartitions(partitions []api.Partition, manual bool) ([]api.Partition, []uint64, error) {
+	var out []api.Partition
+	var idxs []uint64
+	for i, partition := range partitions {
+		toProve := partition.LiveSectors
+		if !manual {
+			var err error
+			toProve, err = bitfield.SubtractBitField(partition.LiveSectors, partition.FaultySectors)
+			if err != nil {
+				return nil, nil, xerrors.Errorf("removing faults from set of sectors to prove: %w", err)
+			}
+		}
+		n, err := toProve.Count()
+		if err != nil {
+			return nil, nil, xerrors.Errorf("counting sectors to prove: %w", err)
+		}
+		if n == 0 {
+			r, err := partition.RecoveringSectors.Count()
+			if err != nil {
+				return nil, nil, xerrors.Errorf("counting recovering sectors: %w", err)
+			}
+			if r == 0 {
+				continue
+			}
+		}
+		out = append(out, partition)
+		idxs = append(idxs, uint64(i))
+	}
+	return out, idxs, nil
+}
+
 // BatchPartitions splits partitions into batches of partitions, so as not to exceed the number of
 // sectors allowed in a single message.
 //
@@ -655,7 +744,7 @@ func (s *WindowPoStScheduler) submitPoStMessage(ctx context.Context, proof *mine
 		Params: enc,
 		Value:  types.NewInt(0),
 	}
-	spec := &api.MessageSendSpec{MaxFee: abi.TokenAmount(s.feeCfg.MaxWindowPoStGasFee), MaximizeFeeCap: s.feeCfg.MaximizeWindowPoStFeeCap}
+	spec := &api.MessageSendSpec{MaxFee: abi.TokenAmount(s.feeCfg.MaxWindowPoStGasFee), MaximizeFeeCap: s.feeCfg.MaximizeWindowPoStFeeCap, AutoBump: true}
 	if err := s.prepareMessage(ctx, msg, spec); err != nil {
 		return nil, err
 	}
@@ -770,3 +859,12 @@ func (s *WindowPoStScheduler) ComputePoSt(ctx context.Context, dlIdx uint64, ts
 func (s *WindowPoStScheduler) ManualFaultRecovery(ctx context.Context, maddr address.Address, sectors []abi.SectorNumber) ([]cid.Cid, error) {
 	return s.declareManualRecoveries(ctx, maddr, sectors, types.TipSetKey{})
 }
+
+// AckFaults acknowledges the faulty sectors of a deadline whose proof submission was halted
+// because they exceeded the configured thresholds, so that the proofs are submitted.
+func (s *WindowPoStScheduler) AckFaults(dlIdx uint64) error {
+	if !s.faultHalts.enabled() {
+		return xerrors.Errorf("WindowPoSt submission halting on faults is not enabled")
+	}
+	return s.faultHalts.ack(dlIdx)
+}
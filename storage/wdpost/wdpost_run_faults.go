@@ -62,6 +62,7 @@ func (s *WindowPoStScheduler) declareRecoveries(ctx context.Context, dlIdx uint6
 	var batchedRecoveryDecls [][]miner.RecoveryDeclaration
 	batchedRecoveryDecls = append(batchedRecoveryDecls, []miner.RecoveryDeclaration{})
 	totalSectorsToRecover := uint64(0)
+	batchSectors := uint64(0) // sectors in the last batch
 
 	for partIdx, partition := range partitions {
 		unrecovered, err := bitfield.SubtractBitField(partition.FaultySectors, partition.RecoveringSectors)
@@ -120,17 +121,34 @@ func (s *WindowPoStScheduler) declareRecoveries(ctx context.Context, dlIdx uint6
 			}
 		}
 
-		// respect user config if set
-		if s.maxPartitionsPerRecoveryMessage > 0 &&
-			len(batchedRecoveryDecls[len(batchedRecoveryDecls)-1]) >= s.maxPartitionsPerRecoveryMessage {
-			batchedRecoveryDecls = append(batchedRecoveryDecls, []miner.RecoveryDeclaration{})
+		log.Infow("declaring recovery of faulty sectors", "deadline", dlIdx, "partition", partIdx, "sectors", recoveredCount, "faulty", uc)
+
+		chunks, err := splitRecoveredSectors(recovered, uint64(s.maxSectorsPerRecoveryMessage), batchSectors)
+		if err != nil {
+			return nil, nil, xerrors.Errorf("splitting recovered sectors: %w", err)
 		}
 
-		batchedRecoveryDecls[len(batchedRecoveryDecls)-1] = append(batchedRecoveryDecls[len(batchedRecoveryDecls)-1], miner.RecoveryDeclaration{
-			Deadline:  dlIdx,
-			Partition: uint64(partIdx),
-			Sectors:   recovered,
-		})
+		for _, chunk := range chunks {
+			chunkCount, err := chunk.Count()
+			if err != nil {
+				return nil, nil, xerrors.Errorf("counting recovered sectors: %w", err)
+			}
+
+			// respect user config if set
+			last := batchedRecoveryDecls[len(batchedRecoveryDecls)-1]
+			if len(last) > 0 && ((s.maxPartitionsPerRecoveryMessage > 0 && len(last) >= s.maxPartitionsPerRecoveryMessage) ||
+				(s.maxSectorsPerRecoveryMessage > 0 && batchSectors+chunkCount > uint64(s.maxSectorsPerRecoveryMessage))) {
+				batchedRecoveryDecls = append(batchedRecoveryDecls, []miner.RecoveryDeclaration{})
+				batchSectors = 0
+			}
+
+			batchedRecoveryDecls[len(batchedRecoveryDecls)-1] = append(batchedRecoveryDecls[len(batchedRecoveryDecls)-1], miner.RecoveryDeclaration{
+				Deadline:  dlIdx,
+				Partition: uint64(partIdx),
+				Sectors:   chunk,
+			})
+			batchSectors += chunkCount
+		}
 
 		totalSectorsToRecover += recoveredCount
 
@@ -168,7 +186,7 @@ func (s *WindowPoStScheduler) declareRecoveries(ctx context.Context, dlIdx uint6
 			Params: enc,
 			Value:  types.NewInt(0),
 		}
-		spec := &api.MessageSendSpec{MaxFee: abi.TokenAmount(s.feeCfg.MaxWindowPoStGasFee), MaximizeFeeCap: s.feeCfg.MaximizeWindowPoStFeeCap}
+		spec := &api.MessageSendSpec{MaxFee: abi.TokenAmount(s.feeCfg.MaxWindowPoStGasFee), MaximizeFeeCap: s.feeCfg.MaximizeWindowPoStFeeCap, AutoBump: true}
 		if err := s.prepareMessage(ctx, msg, spec); err != nil {
 			return nil, nil, err
 		}
@@ -195,6 +213,34 @@ func (s *WindowPoStScheduler) declareRecoveries(ctx context.Context, dlIdx uint6
 	return batchedRecoveryDecls, msgs, nil
 }
 
+// splitRecoveredSectors splits the recovered sectors of a partition into chunks which fit in
+// DeclareFaultsRecovered messages of at most limit sectors, given that the current message already
+// holds used sectors. With no limit the sectors are returned as a single chunk.
+func splitRecoveredSectors(recovered bitfield.BitField, limit, used uint64) ([]bitfield.BitField, error) {
+	if limit == 0 {
+		return []bitfield.BitField{recovered}, nil
+	}
+
+	sectors, err := recovered.All(math.MaxUint64)
+	if err != nil {
+		return nil, err
+	}
+
+	var chunks []bitfield.BitField
+	space := limit - min(used, limit)
+	if space == 0 {
+		space = limit
+	}
+	for len(sectors) > 0 {
+		n := min(space, uint64(len(sectors)))
+		chunks = append(chunks, bitfield.NewFromSet(sectors[:n]))
+		sectors = sectors[n:]
+		space = limit
+	}
+
+	return chunks, nil
+}
+
 func (s *WindowPoStScheduler) asyncFaultRecover(di dline.Info, ts *types.TipSet) {
 	go func() {
 		// check faults / recoveries for the *next* deadline. It's already too
@@ -343,11 +389,13 @@ func (s *WindowPoStScheduler) manualRecoveryMsg(ctx context.Context, Recovery []
 		Params: enc,
 		Value:  types.NewInt(0),
 	}
+	// recoveries declared with the recover-faults command are up to the user, like other messages
+	// they send, so they aren't marked for the automatic fee bumper
 	spec := &api.MessageSendSpec{MaxFee: abi.TokenAmount(s.feeCfg.MaxWindowPoStGasFee)}
 	if err := s.prepareMessage(ctx, msg, spec); err != nil {
 		return cid.Undef, err
 	}
-	sm, err := s.api.MpoolPushMessage(ctx, msg, &api.MessageSendSpec{MaxFee: abi.TokenAmount(s.feeCfg.MaxWindowPoStGasFee)})
+	sm, err := s.api.MpoolPushMessage(ctx, msg, spec)
 	if err != nil {
 		return cid.Undef, xerrors.Errorf("pushing message to mpool: %w", err)
 	}
@@ -3,6 +3,7 @@ package wdpost
 import (
 	"bytes"
 	"context"
+	"sync"
 	"testing"
 
 	"github.com/ipfs/go-cid"
@@ -38,6 +39,9 @@ type mockStorageMinerAPI struct {
 	partitions     []api.Partition
 	pushedMessages chan *types.Message
 	NodeAPI
+
+	lk          sync.Mutex
+	pushedSpecs []*api.MessageSendSpec
 }
 
 func newMockStorageMinerAPI() *mockStorageMinerAPI {
@@ -88,6 +92,10 @@ func (m *mockStorageMinerAPI) StateMinerSectors(ctx context.Context, address add
 }
 
 func (m *mockStorageMinerAPI) MpoolPushMessage(ctx context.Context, message *types.Message, spec *api.MessageSendSpec) (*types.SignedMessage, error) {
+	m.lk.Lock()
+	m.pushedSpecs = append(m.pushedSpecs, spec)
+	m.lk.Unlock()
+
 	m.pushedMessages <- message
 	return &types.SignedMessage{
 		Message: *message,
@@ -428,6 +436,35 @@ func TestBatchPartitionsRecoverySectors(t *testing.T) {
 	}
 }
 
+// TestProvablePartitions tests that partitions without sectors to prove are left out of
+// batching, keeping their indexes in the deadline
+func TestProvablePartitions(t *testing.T) {
+	faulty := generatePartition(100, 0)
+	faulty.FaultySectors = faulty.LiveSectors
+
+	recovering := generatePartition(100, 10)
+	recovering.FaultySectors = recovering.LiveSectors
+
+	partitions := []api.Partition{
+		generatePartition(100, 0),
+		generatePartition(0, 0),
+		faulty,
+		recovering,
+		generatePartition(100, 0),
+	}
+
+	provable, idxs, err := provablePartitions(partitions, false)
+	require.NoError(t, err)
+	require.Len(t, provable, 3)
+	require.Equal(t, []uint64{0, 3, 4}, idxs)
+
+	// manual checks prove faulty sectors too
+	provable, idxs, err = provablePartitions(partitions, true)
+	require.NoError(t, err)
+	require.Len(t, provable, 4)
+	require.Equal(t, []uint64{0, 2, 3, 4}, idxs)
+}
+
 // TestWDPostDeclareRecoveriesPartLimitConfig verifies that declareRecoveries will send the correct number of
 // DeclareFaultsRecovered messages for a given number of partitions based on user config
 func TestWDPostDeclareRecoveriesPartLimitConfig(t *testing.T) {
@@ -517,6 +554,91 @@ func TestWDPostDeclareRecoveriesPartLimitConfig(t *testing.T) {
 	}
 }
 
+func TestWDPostManualRecoveryNotAutoBumped(t *testing.T) {
+	ctx := context.Background()
+
+	mockStgMinerAPI := newMockStorageMinerAPI()
+	scheduler := &WindowPoStScheduler{
+		api:     mockStgMinerAPI,
+		actor:   tutils.NewIDAddr(t, 100),
+		journal: journal.NilJournal(),
+		addrSel: &ctladdr.AddressSelector{},
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := scheduler.manualRecoveryMsg(ctx, []miner.RecoveryDeclaration{{Deadline: 1, Partition: 0, Sectors: bitfield.NewFromSet([]uint64{1})}})
+		errs <- err
+	}()
+
+	msg := <-mockStgMinerAPI.pushedMessages
+	require.NoError(t, <-errs)
+	require.Equal(t, builtin.MethodsMiner.DeclareFaultsRecovered, msg.Method)
+
+	// recoveries declared by the user aren't replaced by the automatic fee bumper
+	require.Len(t, mockStgMinerAPI.pushedSpecs, 1)
+	require.False(t, mockStgMinerAPI.pushedSpecs[0].AutoBump)
+}
+
+func TestSplitRecoveredSectors(t *testing.T) {
+	recovered := bitfield.NewFromSet([]uint64{1, 2, 3, 5, 8, 13, 21})
+
+	counts := func(chunks []bitfield.BitField) []uint64 {
+		var out []uint64
+		for _, c := range chunks {
+			n, err := c.Count()
+			require.NoError(t, err)
+			out = append(out, n)
+		}
+		return out
+	}
+
+	chunks, err := splitRecoveredSectors(recovered, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{7}, counts(chunks))
+
+	chunks, err = splitRecoveredSectors(recovered, 3, 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 3, 1}, counts(chunks))
+
+	// the first chunk fills up the current message
+	chunks, err = splitRecoveredSectors(recovered, 3, 2)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 3, 3}, counts(chunks))
+
+	// the current message is full
+	chunks, err = splitRecoveredSectors(recovered, 3, 3)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 3, 1}, counts(chunks))
+
+	first, err := chunks[0].All(10)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2, 3}, first)
+}
+
+// TestWDPostDelaySubmitBaseFeeCap verifies that PoST submission waits while the
+// basefee is above the configured cap, unless the deadline is about to close
+func TestWDPostDelaySubmitBaseFeeCap(t *testing.T) {
+	ts := mockTipSet(t)
+	ts.Blocks()[0].ParentBaseFee = abi.NewTokenAmount(200)
+
+	scheduler := &WindowPoStScheduler{}
+	di := &dline.Info{Close: ts.Height() + 2*SubmitBaseFeeCapOverride}
+
+	// no cap configured
+	require.False(t, scheduler.delaySubmitPoST(ts, di))
+
+	scheduler.feeCfg.MaxWindowPoStBaseFee = types.FIL(abi.NewTokenAmount(300))
+	require.False(t, scheduler.delaySubmitPoST(ts, di))
+
+	scheduler.feeCfg.MaxWindowPoStBaseFee = types.FIL(abi.NewTokenAmount(100))
+	require.True(t, scheduler.delaySubmitPoST(ts, di))
+
+	// close to the end of the deadline the cap is ignored
+	di.Close = ts.Height() + SubmitBaseFeeCapOverride/2
+	require.False(t, scheduler.delaySubmitPoST(ts, di))
+}
+
 func mockTipSet(t *testing.T) *types.TipSet {
 	minerAct := tutils.NewActorAddr(t, "miner")
 	c, err := cid.Decode("QmbFMke1KXqnYyBBWxB74N4c5SBnJMVAiMNRcGu6x1AwQH")
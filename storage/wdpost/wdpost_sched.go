@@ -22,6 +22,7 @@ import (
 	"github.com/filecoin-project/lotus/chain/store"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/journal"
+	"github.com/filecoin-project/lotus/journal/alerting"
 	"github.com/filecoin-project/lotus/node/config"
 	"github.com/filecoin-project/lotus/node/modules/dtypes"
 	"github.com/filecoin-project/lotus/storage/ctladdr"
@@ -76,7 +77,11 @@ type WindowPoStScheduler struct {
 	disablePreChecks                        bool
 	maxPartitionsPerPostMessage             int
 	maxPartitionsPerRecoveryMessage         int
+	maxSectorsPerRecoveryMessage            int
 	singleRecoveringPartitionPerPostMessage bool
+	disableAutoRecoveries                   bool
+	faultHalts                              *faultHalts
+	proofTimes                              proofTimes
 	ch                                      *changeHandler
 
 	actor address.Address
@@ -102,6 +107,7 @@ func NewWindowedPoStScheduler(api NodeAPI,
 	verif storiface.Verifier,
 	ft sealer.FaultTracker,
 	j journal.Journal,
+	al *alerting.Alerting,
 	actors []dtypes.MinerAddress) (*WindowPoStScheduler, error) {
 	var actorInfos []ActorInfo
 
@@ -113,6 +119,10 @@ func NewWindowedPoStScheduler(api NodeAPI,
 		actorInfos = append(actorInfos, ActorInfo{address.Address(actor), mi})
 	}
 
+	if pcfg.DisableAutoRecoveryDeclarations {
+		log.Warn("automatic recovery declarations are disabled, faulty sectors must be recovered manually")
+	}
+
 	// TODO I punted here knowing that actorInfos will be consumed differently later.
 	return &WindowPoStScheduler{
 		api:                                     api,
@@ -127,7 +137,10 @@ func NewWindowedPoStScheduler(api NodeAPI,
 		disablePreChecks:                        pcfg.DisableWDPoStPreChecks,
 		maxPartitionsPerPostMessage:             pcfg.MaxPartitionsPerPoStMessage,
 		maxPartitionsPerRecoveryMessage:         pcfg.MaxPartitionsPerRecoveryMessage,
+		maxSectorsPerRecoveryMessage:            pcfg.MaxSectorsPerRecoveryMessage,
 		singleRecoveringPartitionPerPostMessage: pcfg.SingleRecoveringPartitionPerPostMessage,
+		disableAutoRecoveries:                   pcfg.DisableAutoRecoveryDeclarations,
+		faultHalts:                              newFaultHalts(pcfg.HaltSubmissionFaultySectors, pcfg.HaltSubmissionFaultyFraction, al),
 		evtTypes: [...]journal.EventType{
 			evtTypeWdPoStScheduler:  j.RegisterEventType("wdpost", "scheduler"),
 			evtTypeWdPoStProofs:     j.RegisterEventType("wdpost", "proofs_processed"),